@@ -2,9 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"backend/internal/app"
 	"backend/internal/config"
 )
 
@@ -13,33 +19,100 @@ import (
  */
 func main() {
 	config.LoadDotEnv()
+	if err := app.SetupLogger(os.Stdout); err != nil {
+		fatalf("ログ設定失敗: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	if err := runFunc(context.Background()); err != nil {
+	if err := runFunc(ctx); err != nil {
 		fatalf("API起動失敗: %v", err)
 	}
 }
 
 /**
- * 依存を初期化し、HTTP サーバーを起動する
+ * 依存を初期化し、停止指示が来るまで HTTP サーバーを動かす。
+ * 停止時は処理中のリクエストを待ってから依存を閉じる。
  */
 func run(ctx context.Context) error {
+	serverCfg, err := config.LoadHTTPServerConfigFromEnv()
+	if err != nil {
+		return fmt.Errorf("サーバー設定失敗: %w", err)
+	}
+
+	// 依存より先にトレースの送り先を決め、終了時に未送信のスパンを送り切る
+	shutdownTracing, err := app.SetupTracing(ctx, app.APIServiceName)
+	if err != nil {
+		return fmt.Errorf("トレース設定失敗: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("failed to flush traces", slog.Any("error", err))
+		}
+	}()
+
 	// 依存関係をまとめて初期化
 	container, err := newContainer(ctx)
 	if err != nil {
 		return fmt.Errorf("依存初期化失敗: %w", err)
 	}
 
-	// 関数の終了時に依存リソースを閉じる
+	// 関数の終了時に依存リソースを閉じる（サーバーの停止を待ってから実行される）
 	defer func() {
 		if closeErr := closeContainer(container); closeErr != nil {
-			log.Printf("依存終了失敗: %v", closeErr)
+			slog.Error("failed to close dependencies", slog.Any("error", closeErr))
 		}
 	}()
 
-	// ルーティングを組み立てて、起動
-	router := newRouter(container.DrawHandler, container.PostHandler)
-	if err := router.Run(); err != nil {
-		return fmt.Errorf("サーバー起動失敗: %w", err)
+	// ルーティングを組み立てて、タイムアウト付きのサーバーで起動
+	router := newRouter(container.DrawHandler, container.PostHandler, container.RouterOptions...)
+	srv := &http.Server{
+		Addr:              serverCfg.Addr(),
+		Handler:           router,
+		ReadHeaderTimeout: serverCfg.ReadTimeout,
+		ReadTimeout:       serverCfg.ReadTimeout,
+		WriteTimeout:      serverCfg.WriteTimeout,
+		IdleTimeout:       serverCfg.IdleTimeout,
+	}
+
+	// /metrics は公開ルーターに載せず、内部ポートの別サーバーで配信する
+	servers := []*http.Server{srv}
+	if container.Metrics != nil {
+		servers = append(servers, &http.Server{
+			Addr:              serverCfg.MetricsAddr(),
+			Handler:           container.Metrics.Handler(),
+			ReadHeaderTimeout: serverCfg.ReadTimeout,
+		})
+	}
+
+	serveErr := make(chan error, len(servers))
+	for _, s := range servers {
+		go func() {
+			serveErr <- serve(s)
+		}()
+	}
+	slog.Info("api server started", slog.String("addr", srv.Addr))
+
+	var runErr error
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		// 片方が起動できなければ、もう片方も止めてから終了する
+		runErr = fmt.Errorf("サーバー起動失敗: %w", err)
+	case <-ctx.Done():
+	}
+
+	// 新しい接続の受け付けを止め、処理中のリクエストが終わるのを待つ
+	slog.Info("api server shutting down", slog.Duration("timeout", serverCfg.ShutdownTimeout))
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serverCfg.ShutdownTimeout)
+	defer cancel()
+	for _, s := range servers {
+		if err := s.Shutdown(shutdownCtx); err != nil && runErr == nil {
+			runErr = fmt.Errorf("サーバー停止失敗: %w", err)
+		}
 	}
-	return nil
+	return runErr
 }
@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
 
 	drawhandler "backend/internal/adapter/http/handler"
 	"backend/internal/app"
@@ -12,22 +15,30 @@ import (
 
 type containerFactory func(ctx context.Context) (*app.Container, error)
 
-type routerFactory func(drawHandler *drawhandler.DrawHandler, postHandler *drawhandler.PostHandler) routerRunner
+type routerFactory func(drawHandler *drawhandler.DrawHandler, postHandler *drawhandler.PostHandler, opts ...drawhandler.RouterOption) routerRunner
 
+// routerRunner は http.Server に載せて動かすルーター。
 type routerRunner interface {
-	Run(...string) error
+	http.Handler
 }
 
 type containerCloser func(container *app.Container) error
 
 var (
 	newContainer containerFactory = app.NewContainer
-	newRouter    routerFactory    = func(drawHandler *drawhandler.DrawHandler, postHandler *drawhandler.PostHandler) routerRunner {
-		return drawhandler.NewRouter(drawHandler, postHandler)
+	newRouter    routerFactory    = func(drawHandler *drawhandler.DrawHandler, postHandler *drawhandler.PostHandler, opts ...drawhandler.RouterOption) routerRunner {
+		return drawhandler.NewRouter(drawHandler, postHandler, opts...)
 	}
 	closeContainer containerCloser = func(container *app.Container) error {
 		return container.Close()
 	}
+	// サーバーの待ち受けを始める（テストでは任意のリスナーに差し替える）
+	serve = func(srv *http.Server) error {
+		return srv.ListenAndServe()
+	}
 	runFunc = run
-	fatalf  = log.Fatalf
+	fatalf  = func(format string, args ...any) {
+		slog.Error(fmt.Sprintf(format, args...))
+		os.Exit(1)
+	}
 )
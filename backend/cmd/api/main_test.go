@@ -4,26 +4,41 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	drawhandler "backend/internal/adapter/http/handler"
+	"backend/internal/adapter/metrics"
 	"backend/internal/app"
 )
 
 type stubRouter struct {
-	runErr error
-	called bool
-	args   []string
+	handler http.HandlerFunc
 }
 
 /**
- * 呼び出し状況を記録し、指定した結果を返す。
+ * 指定したハンドラーへ委ねる。未指定なら 200 を返す。
  */
-func (s *stubRouter) Run(args ...string) error {
-	s.called = true
-	s.args = args
-	return s.runErr
+func (s *stubRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.handler != nil {
+		s.handler(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+/**
+ * サーバーの待ち受けを差し替え、テスト終了時に元へ戻す。
+ */
+func stubServe(t *testing.T, fn func(srv *http.Server) error) {
+	t.Helper()
+	origServe := serve
+	serve = fn
+	t.Cleanup(func() { serve = origServe })
 }
 
 /**
@@ -47,9 +62,14 @@ func TestRun_Success(t *testing.T) {
 	}
 
 	routerStub := &stubRouter{}
+	var served http.Handler
+	stubServe(t, func(srv *http.Server) error {
+		served = srv.Handler
+		return http.ErrServerClosed
+	})
 	var gotDraw *drawhandler.DrawHandler
 	var gotPost *drawhandler.PostHandler
-	newRouter = func(draw *drawhandler.DrawHandler, post *drawhandler.PostHandler) routerRunner {
+	newRouter = func(draw *drawhandler.DrawHandler, post *drawhandler.PostHandler, _ ...drawhandler.RouterOption) routerRunner {
 		gotDraw = draw
 		gotPost = post
 		return routerStub
@@ -58,8 +78,8 @@ func TestRun_Success(t *testing.T) {
 	if err := run(context.Background()); err != nil {
 		t.Fatalf("エラーなしを想定しましたが取得しました: %v", err)
 	}
-	if !routerStub.called {
-		t.Fatalf("router.Run の呼び出しを想定しましたが未実行です")
+	if served != routerStub {
+		t.Fatalf("ルーターをサーバーに載せて起動することを想定しましたが未実行です")
 	}
 	if gotDraw != expectedDraw {
 		t.Fatalf("draw handler の引き渡しが想定どおりではありません")
@@ -86,7 +106,7 @@ func TestRun_NewContainerError(t *testing.T) {
 	newContainer = func(ctx context.Context) (*app.Container, error) {
 		return nil, expectedErr
 	}
-	newRouter = func(*drawhandler.DrawHandler, *drawhandler.PostHandler) routerRunner {
+	newRouter = func(*drawhandler.DrawHandler, *drawhandler.PostHandler, ...drawhandler.RouterOption) routerRunner {
 		t.Fatalf("router の生成は想定外です")
 		return nil
 	}
@@ -125,9 +145,10 @@ func TestRun_RouterRunError(t *testing.T) {
 	}
 
 	expectedErr := errors.New("起動失敗")
-	newRouter = func(*drawhandler.DrawHandler, *drawhandler.PostHandler) routerRunner {
-		return &stubRouter{runErr: expectedErr}
+	newRouter = func(*drawhandler.DrawHandler, *drawhandler.PostHandler, ...drawhandler.RouterOption) routerRunner {
+		return &stubRouter{}
 	}
+	stubServe(t, func(*http.Server) error { return expectedErr })
 
 	err := run(context.Background())
 	if err == nil {
@@ -161,9 +182,10 @@ func TestRun_CloseErrorIsLogged(t *testing.T) {
 	newContainer = func(ctx context.Context) (*app.Container, error) {
 		return container, nil
 	}
-	newRouter = func(*drawhandler.DrawHandler, *drawhandler.PostHandler) routerRunner {
+	newRouter = func(*drawhandler.DrawHandler, *drawhandler.PostHandler, ...drawhandler.RouterOption) routerRunner {
 		return &stubRouter{}
 	}
+	stubServe(t, func(*http.Server) error { return http.ErrServerClosed })
 
 	closeCalled := false
 	closeContainer = func(*app.Container) error {
@@ -179,6 +201,144 @@ func TestRun_CloseErrorIsLogged(t *testing.T) {
 	}
 }
 
+/**
+ * 停止指示を受けたら処理中のリクエストを終えてから依存を閉じることを確認する。
+ */
+func TestRun_GracefulShutdownDrainsInFlightRequests(t *testing.T) {
+	origContainer := newContainer
+	origRouter := newRouter
+	origClose := closeContainer
+	t.Cleanup(func() {
+		newContainer = origContainer
+		newRouter = origRouter
+		closeContainer = origClose
+	})
+
+	newContainer = func(ctx context.Context) (*app.Container, error) {
+		return &app.Container{}, nil
+	}
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var handled atomic.Bool
+	newRouter = func(*drawhandler.DrawHandler, *drawhandler.PostHandler, ...drawhandler.RouterOption) routerRunner {
+		return &stubRouter{handler: func(w http.ResponseWriter, r *http.Request) {
+			close(entered)
+			<-release
+			handled.Store(true)
+			w.WriteHeader(http.StatusAccepted)
+		}}
+	}
+	var closedAfterHandled atomic.Bool
+	closeContainer = func(*app.Container) error {
+		closedAfterHandled.Store(handled.Load())
+		return nil
+	}
+	addr := make(chan string, 1)
+	stubServe(t, func(srv *http.Server) error {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return err
+		}
+		addr <- ln.Addr().String()
+		return srv.Serve(ln)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runErr := make(chan error, 1)
+	go func() { runErr <- run(ctx) }()
+
+	status := make(chan int, 1)
+	go func() {
+		resp, err := http.Post("http://"+<-addr+"/posts", "application/json", nil)
+		if err != nil {
+			status <- 0
+			return
+		}
+		defer resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+
+	<-entered
+	cancel()
+	// 停止指示の後も処理中のリクエストは打ち切られない
+	select {
+	case err := <-runErr:
+		t.Fatalf("処理中のリクエストを待たずに停止しました: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+
+	if got := <-status; got != http.StatusAccepted {
+		t.Fatalf("処理中のリクエストの完了を想定しましたが status=%d でした", got)
+	}
+	if err := <-runErr; err != nil {
+		t.Fatalf("エラーなしを想定しましたが取得しました: %v", err)
+	}
+	if !closedAfterHandled.Load() {
+		t.Fatalf("リクエストの完了後に依存を閉じることを想定しましたが順序が異なります")
+	}
+}
+
+/**
+ * /metrics を公開ポートではなく内部ポートの別サーバーで配信し、停止時にはそちらも止めることを確認する。
+ */
+func TestRun_ServesMetricsOnInternalPort(t *testing.T) {
+	origContainer := newContainer
+	origRouter := newRouter
+	origClose := closeContainer
+	t.Cleanup(func() {
+		newContainer = origContainer
+		newRouter = origRouter
+		closeContainer = origClose
+	})
+	t.Setenv("PORT", "8080")
+	t.Setenv("METRICS_PORT", "9090")
+
+	newContainer = func(ctx context.Context) (*app.Container, error) {
+		return &app.Container{Metrics: metrics.New()}, nil
+	}
+	newRouter = func(*drawhandler.DrawHandler, *drawhandler.PostHandler, ...drawhandler.RouterOption) routerRunner {
+		return &stubRouter{handler: func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNotFound) }}
+	}
+	closeContainer = func(*app.Container) error { return nil }
+	addrs := make(chan [2]string, 2)
+	stubServe(t, func(srv *http.Server) error {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return err
+		}
+		addrs <- [2]string{srv.Addr, ln.Addr().String()}
+		return srv.Serve(ln)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runErr := make(chan error, 1)
+	go func() { runErr <- run(ctx) }()
+
+	listening := map[string]string{}
+	for range 2 {
+		a := <-addrs
+		listening[a[0]] = a[1]
+	}
+	for addr, want := range map[string]int{":8080": http.StatusNotFound, ":9090": http.StatusOK} {
+		resp, err := http.Get("http://" + listening[addr] + "/metrics")
+		if err != nil {
+			t.Fatalf("%s: %v", addr, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Fatalf("%s: expected %d, got %d", addr, want, resp.StatusCode)
+		}
+	}
+
+	cancel()
+	if err := <-runErr; err != nil {
+		t.Fatalf("エラーなしを想定しましたが取得しました: %v", err)
+	}
+}
+
 /**
  * 起動成功時に致命的ログが呼ばれないことを確認する。
  */
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	adminusecase "backend/internal/usecase/admin"
+	opsusecase "backend/internal/usecase/ops"
+	"backend/internal/usecase/worker"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+
+	defaultQueueLimit = 10
+)
+
+var errUsage = errors.New("kirakujictl: 引数が不正です")
+
+// opsService は確認・書き出し用のユースケース。
+type opsService interface {
+	QueueStatus(ctx context.Context, limit int) (*opsusecase.QueueStatus, error)
+	ExportPosts(ctx context.Context, fn func(*post.Post) error) error
+	ExportDraws(ctx context.Context, fn func(*drawdomain.Draw) error) error
+}
+
+// adminService は監査ログに残す操作のユースケース。
+type adminService interface {
+	RequeuePost(ctx context.Context, actor string, id post.DarkPostID) error
+	RequeuePending(ctx context.Context, actor string) (*adminusecase.RequeueResult, error)
+	HideDraw(ctx context.Context, actor string, id post.DarkPostID) (*drawdomain.Draw, error)
+	ExpireDraw(ctx context.Context, actor string, id post.DarkPostID) (*drawdomain.Draw, error)
+	ApproveDraw(ctx context.Context, actor string, id post.DarkPostID) (*drawdomain.Draw, error)
+}
+
+// previewer は保存せずに整形・検証を試すユースケース。
+type previewer interface {
+	Preview(ctx context.Context, postID string) (*worker.Preview, error)
+}
+
+/**
+ * サブコマンドの実行に使う依存と出力先
+ * newPreviewer: LLM の設定が要るため reformat のときだけ組み立てる
+ * newPromptStats: 反応の設定が要るため prompt-stats のときだけ組み立てる
+ * now: ジョブの待ち時間の計算に使う（nil なら time.Now）
+ */
+type commands struct {
+	ops            opsService
+	admin          adminService
+	newPreviewer   func(ctx context.Context) (previewer, func() error, error)
+	newPromptStats func(ctx context.Context) (promptStatsService, error)
+	out            io.Writer
+	format         string
+	actor          string
+	now            func() time.Time
+}
+
+/**
+ * 先頭の引数でサブコマンドを選んで実行する。
+ */
+func (c *commands) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch name, rest := args[0], args[1:]; name {
+	case "queue":
+		return c.queue(ctx, rest)
+	case "requeue":
+		return c.requeue(ctx, rest)
+	case "reformat":
+		return c.reformat(ctx, rest)
+	case "hide":
+		return c.markDraw(ctx, rest, c.admin.HideDraw)
+	case "verify":
+		return c.markDraw(ctx, rest, c.admin.ApproveDraw)
+	case "expire":
+		return c.markDraw(ctx, rest, c.admin.ExpireDraw)
+	case "export":
+		return c.export(ctx, rest)
+	case "prompt-stats":
+		return c.promptStats(ctx, rest)
+	default:
+		return fmt.Errorf("%w: 不明なコマンド %q", errUsage, name)
+	}
+}
+
+type queuedJobView struct {
+	PostID    string    `json:"post_id"`
+	RequestID string    `json:"request_id,omitempty"`
+	QueuedAt  time.Time `json:"queued_at"`
+}
+
+type queueView struct {
+	Depth  int64           `json:"depth"`
+	Oldest []queuedJobView `json:"oldest"`
+}
+
+/**
+ * 整形キューの件数と、登録の古いジョブを表示する。
+ */
+func (c *commands) queue(ctx context.Context, args []string) error {
+	fs := newFlagSet("queue")
+	limit := fs.Int("limit", defaultQueueLimit, "表示するジョブの件数")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	status, err := c.ops.QueueStatus(ctx, *limit)
+	if err != nil {
+		return err
+	}
+	view := queueView{Depth: status.Depth, Oldest: make([]queuedJobView, 0, len(status.Oldest))}
+	for _, job := range status.Oldest {
+		view.Oldest = append(view.Oldest, queuedJobView{PostID: string(job.PostID), RequestID: job.RequestID, QueuedAt: job.QueuedAt})
+	}
+	if c.format == formatJSON {
+		return writeJSON(c.out, view)
+	}
+	rows := make([][]string, 0, len(view.Oldest))
+	for _, job := range view.Oldest {
+		rows = append(rows, []string{job.PostID, job.QueuedAt.Format(time.RFC3339), c.since(job.QueuedAt).Round(time.Second).String(), orDash(job.RequestID)})
+	}
+	fmt.Fprintf(c.out, "depth: %d\n", view.Depth)
+	return writeTable(c.out, []string{"POST ID", "QUEUED AT", "WAITING", "REQUEST ID"}, rows)
+}
+
+type requeueView struct {
+	Enqueued         []string `json:"enqueued"`
+	AlreadyScheduled []string `json:"already_scheduled"`
+}
+
+/**
+ * 整形待ちの投稿を積み直す。-all ならすべて、それ以外は指定した投稿だけ。ジョブが残っている投稿は飛ばす。
+ */
+func (c *commands) requeue(ctx context.Context, args []string) error {
+	fs := newFlagSet("requeue")
+	all := fs.Bool("all", false, "整形待ちの投稿をすべて積み直す")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if *all == (fs.NArg() > 0) {
+		return fmt.Errorf("%w: -all か投稿 ID のどちらかを指定してください", errUsage)
+	}
+
+	result := &adminusecase.RequeueResult{}
+	if *all {
+		var err error
+		if result, err = c.admin.RequeuePending(ctx, c.actor); err != nil {
+			// 途中までに積んだ分も表示してから失敗を伝える
+			_ = c.writeRequeue(result)
+			return err
+		}
+	} else {
+		for _, arg := range fs.Args() {
+			id := post.DarkPostID(arg)
+			err := c.admin.RequeuePost(ctx, c.actor, id)
+			switch {
+			case err == nil:
+				result.Enqueued = append(result.Enqueued, id)
+			case errors.Is(err, adminusecase.ErrJobAlreadyScheduled):
+				result.AlreadyScheduled = append(result.AlreadyScheduled, id)
+			default:
+				_ = c.writeRequeue(result)
+				return fmt.Errorf("requeue %s: %w", id, err)
+			}
+		}
+	}
+	return c.writeRequeue(result)
+}
+
+func (c *commands) writeRequeue(result *adminusecase.RequeueResult) error {
+	if result == nil {
+		return nil
+	}
+	view := requeueView{Enqueued: postIDs(result.Enqueued), AlreadyScheduled: postIDs(result.AlreadyScheduled)}
+	if c.format == formatJSON {
+		return writeJSON(c.out, view)
+	}
+	rows := make([][]string, 0, len(view.Enqueued)+len(view.AlreadyScheduled))
+	for _, id := range view.Enqueued {
+		rows = append(rows, []string{id, "enqueued"})
+	}
+	for _, id := range view.AlreadyScheduled {
+		rows = append(rows, []string{id, "already_scheduled"})
+	}
+	return writeTable(c.out, []string{"POST ID", "RESULT"}, rows)
+}
+
+type previewView struct {
+	PostID           string         `json:"post_id"`
+	PostStatus       string         `json:"post_status"`
+	PromptVersion    string         `json:"prompt_version"`
+	Redactions       map[string]int `json:"redactions,omitempty"`
+	Formatted        string         `json:"formatted"`
+	Status           string         `json:"status"`
+	ValidationReason string         `json:"validation_reason,omitempty"`
+	ValidationError  string         `json:"validation_error,omitempty"`
+	Result           string         `json:"result"`
+}
+
+/**
+ * 投稿を保存せずに整形・検証し、LLM の出力と検証結果を表示する。投稿やおみくじは変えない。
+ */
+func (c *commands) reformat(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: 投稿 ID を 1 つ指定してください", errUsage)
+	}
+	usecase, closeFn, err := c.newPreviewer(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	preview, err := usecase.Preview(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	view := previewView{
+		PostID:     string(preview.PostID),
+		PostStatus: string(preview.PostStatus),
+		Redactions: preview.Redactions,
+	}
+	if preview.Formatted != nil {
+		view.Formatted = string(preview.Formatted.FormattedContent)
+		view.PromptVersion = preview.Formatted.PromptVersion
+	}
+	if v := preview.Validated; v != nil {
+		view.Status = string(v.Status)
+		view.ValidationReason = v.ValidationReason
+		view.Result = string(v.FormattedContent)
+		if v.PromptVersion != "" {
+			view.PromptVersion = v.PromptVersion
+		}
+	}
+	if preview.ValidationErr != nil {
+		view.ValidationError = preview.ValidationErr.Error()
+	}
+	if c.format == formatJSON {
+		return writeJSON(c.out, view)
+	}
+	return writeTable(c.out, []string{"FIELD", "VALUE"}, [][]string{
+		{"post id", view.PostID},
+		{"post status", view.PostStatus},
+		{"prompt version", orDash(view.PromptVersion)},
+		{"redactions", formatCounts(view.Redactions)},
+		{"formatted", orDash(view.Formatted)},
+		{"status", orDash(view.Status)},
+		{"reason", orDash(view.ValidationReason)},
+		{"validation error", orDash(view.ValidationError)},
+		{"result", orDash(view.Result)},
+	})
+}
+
+type drawView struct {
+	PostID        string `json:"post_id"`
+	Result        string `json:"result"`
+	Status        string `json:"status"`
+	PromptVersion string `json:"prompt_version,omitempty"`
+	ShareID       string `json:"share_id,omitempty"`
+}
+
+func newDrawView(d *drawdomain.Draw) drawView {
+	return drawView{
+		PostID:        string(d.PostID()),
+		Result:        string(d.Result()),
+		Status:        string(d.Status()),
+		PromptVersion: d.PromptVersion(),
+		ShareID:       string(d.ShareID()),
+	}
+}
+
+/**
+ * おみくじの状態を change で変え、変更後の状態を表示する。
+ */
+func (c *commands) markDraw(ctx context.Context, args []string, change func(context.Context, string, post.DarkPostID) (*drawdomain.Draw, error)) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: 投稿 ID を 1 つ指定してください", errUsage)
+	}
+	d, err := change(ctx, c.actor, post.DarkPostID(args[0]))
+	if err != nil {
+		return err
+	}
+	view := newDrawView(d)
+	if c.format == formatJSON {
+		return writeJSON(c.out, view)
+	}
+	return writeTable(c.out, []string{"POST ID", "STATUS", "SHARE ID"}, [][]string{{view.PostID, view.Status, orDash(view.ShareID)}})
+}
+
+type postView struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+	Status  string `json:"status"`
+}
+
+/**
+ * 投稿またはおみくじを 1 行 1 件の JSON で書き出す。-out が無ければ標準出力へ。
+ */
+func (c *commands) export(ctx context.Context, args []string) (retErr error) {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: posts か draws を指定してください", errUsage)
+	}
+	kind := args[0]
+	fs := newFlagSet("export")
+	outPath := fs.String("out", "", "書き出し先のファイル（省略時は標準出力）")
+	if err := fs.Parse(args[1:]); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if kind != "posts" && kind != "draws" {
+		return fmt.Errorf("%w: 不明な書き出し対象 %q", errUsage, kind)
+	}
+
+	w := c.out
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", *outPath, err)
+		}
+		defer func() {
+			if err := f.Close(); err != nil && retErr == nil {
+				retErr = fmt.Errorf("close %s: %w", *outPath, err)
+			}
+		}()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	var count int
+	var err error
+	if kind == "posts" {
+		err = c.ops.ExportPosts(ctx, func(p *post.Post) error {
+			count++
+			return enc.Encode(postView{ID: string(p.ID()), Content: string(p.Content()), Status: string(p.Status())})
+		})
+	} else {
+		err = c.ops.ExportDraws(ctx, func(d *drawdomain.Draw) error {
+			count++
+			return enc.Encode(newDrawView(d))
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("export %s: %w", kind, err)
+	}
+	if *outPath != "" {
+		fmt.Fprintf(c.out, "exported %d %s to %s\n", count, kind, *outPath)
+	}
+	return nil
+}
+
+func (c *commands) since(t time.Time) time.Duration {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	return now().Sub(t)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func postIDs(ids []post.DarkPostID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, string(id))
+	}
+	return out
+}
+
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "-"
+	}
+	data, _ := json.Marshal(counts)
+	return string(data)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
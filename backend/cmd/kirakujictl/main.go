@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"backend/internal/app"
+	"backend/internal/config"
+	experimentusecase "backend/internal/usecase/experiment"
+	"backend/internal/usecase/worker"
+)
+
+const usage = `使い方: kirakujictl [-format table|json] [-actor 名前] <コマンド> [引数]
+
+コマンド:
+  queue [-limit N]                整形キューの件数と、登録の古いジョブ（既定 10 件）を表示する
+  requeue -all | <post_id>...     整形待ちの投稿を整形キューへ積み直す
+  reformat <post_id>              保存せずに整形・検証を試し、LLM の出力と検証結果を表示する
+  hide <post_id>                  おみくじを非公開にする
+  verify <post_id>                おみくじを公開する（審査待ち・非公開から）
+  expire <post_id>                公開中のおみくじの公開期間を終える（公開には戻せない）
+  export posts|draws [-out パス]  投稿・おみくじを JSONL で書き出す（-format に関係なく JSONL）
+  prompt-stats                    プロンプトのバリアントごとに通過率・平均文字数・反応数・却下理由を集計する
+
+FIRESTORE_EMULATOR_HOST を設定するとエミュレーターを操作します。
+`
+
+/**
+ * 整形キューや投稿・おみくじを直接確認・操作し、プロンプト実験の結果を集計する運用コマンド。
+ * おみくじの状態変更と積み直しは管理 API と同じく監査ログに残す。
+ */
+func main() {
+	log.SetFlags(0)
+	format := flag.String("format", formatTable, "出力形式（table / json）")
+	actor := flag.String("actor", defaultActor(), "監査ログに残す操作者")
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format != formatTable && *format != formatJSON {
+		log.Fatalf("unknown format: %s", *format)
+	}
+
+	config.LoadDotEnv()
+
+	ctx := context.Background()
+	container, err := app.NewCtlContainer(ctx)
+	if err != nil {
+		log.Fatalf("failed to initialize: %v", err)
+	}
+	defer func() {
+		if cerr := container.Close(); cerr != nil {
+			log.Printf("close error: %v", cerr)
+		}
+	}()
+
+	c := &commands{
+		ops:   container.Ops,
+		admin: container.Admin,
+		newPreviewer: func(ctx context.Context) (previewer, func() error, error) {
+			usecase, closeFn, err := container.NewFormatPreviewUsecase(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return usecase, closeFn, nil
+		},
+		newPromptStats: func(ctx context.Context) (promptStatsService, error) {
+			usecase, err := container.NewPromptStatsUsecase(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return usecase, nil
+		},
+		out:    os.Stdout,
+		format: *format,
+		actor:  *actor,
+	}
+	if err := c.run(ctx, flag.Args()); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(os.Stderr, usage)
+		}
+		log.Print(err)
+		// os.Exit は defer を実行しないため、先に接続を閉じる
+		if cerr := container.Close(); cerr != nil {
+			log.Printf("close error: %v", cerr)
+		}
+		os.Exit(1)
+	}
+}
+
+/**
+ * 監査ログの操作者の既定値。誰が実行したかを追えるよう OS のユーザー名を添える。
+ */
+func defaultActor() string {
+	if user := strings.TrimSpace(os.Getenv("USER")); user != "" {
+		return "kirakujictl:" + user
+	}
+	return "kirakujictl"
+}
+
+var (
+	_ previewer          = (*worker.FormatPendingUsecase)(nil)
+	_ promptStatsService = (*experimentusecase.SummarizeUsecase)(nil)
+)
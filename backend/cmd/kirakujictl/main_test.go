@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/port/llm"
+	"backend/internal/port/queue"
+	adminusecase "backend/internal/usecase/admin"
+	experimentusecase "backend/internal/usecase/experiment"
+	opsusecase "backend/internal/usecase/ops"
+	"backend/internal/usecase/worker"
+)
+
+var testNow = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestCommands(format string) (*commands, *stubOps, *stubAdmin, *bytes.Buffer) {
+	ops := &stubOps{}
+	admin := &stubAdmin{scheduled: map[post.DarkPostID]bool{}}
+	var out bytes.Buffer
+	c := &commands{
+		ops:   ops,
+		admin: admin,
+		newPreviewer: func(ctx context.Context) (previewer, func() error, error) {
+			return stubPreviewer{}, func() error { return nil }, nil
+		},
+		newPromptStats: func(ctx context.Context) (promptStatsService, error) {
+			return stubPromptStats{}, nil
+		},
+		out:    &out,
+		format: format,
+		actor:  "tester",
+		now:    func() time.Time { return testNow },
+	}
+	return c, ops, admin, &out
+}
+
+func TestQueueTable(t *testing.T) {
+	c, ops, _, out := newTestCommands(formatTable)
+	ops.status = &opsusecase.QueueStatus{Depth: 2, Oldest: []queue.QueuedJob{{PostID: "post-1", QueuedAt: testNow.Add(-90 * time.Second)}}}
+
+	if err := c.run(context.Background(), []string{"queue", "-limit", "3"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	if ops.limit != 3 {
+		t.Fatalf("expected limit 3, got %d", ops.limit)
+	}
+	for _, want := range []string{"depth: 2", "POST ID", "post-1", "1m30s"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestQueueJSON(t *testing.T) {
+	c, ops, _, out := newTestCommands(formatJSON)
+	ops.status = &opsusecase.QueueStatus{Depth: 1, Oldest: []queue.QueuedJob{{PostID: "post-1", QueuedAt: testNow}}}
+
+	if err := c.run(context.Background(), []string{"queue"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	var decoded queueView
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if decoded.Depth != 1 || len(decoded.Oldest) != 1 || decoded.Oldest[0].PostID != "post-1" {
+		t.Fatalf("unexpected decoded view: %+v", decoded)
+	}
+	if ops.limit != defaultQueueLimit {
+		t.Fatalf("expected default limit, got %d", ops.limit)
+	}
+}
+
+func TestRequeue(t *testing.T) {
+	c, _, admin, out := newTestCommands(formatJSON)
+	admin.scheduled["post-2"] = true
+
+	if err := c.run(context.Background(), []string{"requeue", "post-1", "post-2"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	var decoded requeueView
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(decoded.Enqueued) != 1 || decoded.Enqueued[0] != "post-1" || len(decoded.AlreadyScheduled) != 1 {
+		t.Fatalf("unexpected result: %+v", decoded)
+	}
+	if admin.actor != "tester" {
+		t.Fatalf("expected actor to be passed, got %q", admin.actor)
+	}
+
+	c, _, admin, _ = newTestCommands(formatTable)
+	if err := c.run(context.Background(), []string{"requeue", "-all"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	if !admin.requeuedAll {
+		t.Fatalf("expected all pending posts to be requeued")
+	}
+}
+
+func TestRequeueRequiresTarget(t *testing.T) {
+	c, _, _, _ := newTestCommands(formatTable)
+	for _, args := range [][]string{{"requeue"}, {"requeue", "-all", "post-1"}} {
+		if err := c.run(context.Background(), args); !errors.Is(err, errUsage) {
+			t.Fatalf("expected usage error for %v, got %v", args, err)
+		}
+	}
+}
+
+func TestReformat(t *testing.T) {
+	c, _, _, out := newTestCommands(formatTable)
+
+	if err := c.run(context.Background(), []string{"reformat", "post-1"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	for _, want := range []string{"fortune-v1", "整形した 本文", "rejected", "禁止語を含む", llm.ErrContentRejected.Error()} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestMarkDraw(t *testing.T) {
+	c, _, admin, out := newTestCommands(formatJSON)
+
+	if err := c.run(context.Background(), []string{"hide", "post-1"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	var decoded drawView
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if decoded.Status != string(drawdomain.StatusHidden) || admin.marked != "post-1" {
+		t.Fatalf("unexpected draw: %+v", decoded)
+	}
+
+	out.Reset()
+	if err := c.run(context.Background(), []string{"verify", "post-1"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), string(drawdomain.StatusVerified)) {
+		t.Fatalf("expected verified draw in output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := c.run(context.Background(), []string{"expire", "post-1"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), string(drawdomain.StatusExpired)) {
+		t.Fatalf("expected expired draw in output:\n%s", out.String())
+	}
+}
+
+func TestExport(t *testing.T) {
+	c, _, _, out := newTestCommands(formatTable)
+
+	if err := c.run(context.Background(), []string{"export", "posts"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), out.String())
+	}
+	var p postView
+	if err := json.Unmarshal([]byte(lines[0]), &p); err != nil || p.ID != "post-1" || p.Content != "<本文>" {
+		t.Fatalf("unexpected post line %q: %v", lines[0], err)
+	}
+
+	path := filepath.Join(t.TempDir(), "draws.jsonl")
+	out.Reset()
+	if err := c.run(context.Background(), []string{"export", "draws", "-out", path}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	var d drawView
+	if err := json.Unmarshal(bytes.TrimSpace(data), &d); err != nil || d.PostID != "post-1" {
+		t.Fatalf("unexpected draw line %q: %v", data, err)
+	}
+	if !strings.Contains(out.String(), "exported 1 draws") {
+		t.Fatalf("expected summary in output:\n%s", out.String())
+	}
+
+	if err := c.run(context.Background(), []string{"export", "reports"}); !errors.Is(err, errUsage) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestPromptStatsTable(t *testing.T) {
+	c, _, _, out := newTestCommands(formatTable)
+
+	if err := c.run(context.Background(), []string{"prompt-stats"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	for _, want := range []string{"VERSION", "fortune-v1", "75.0%", "92.5", "1.67", "accurate(4), scary(1)", "禁止語を含む(1)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestPromptStatsJSON(t *testing.T) {
+	c, _, _, out := newTestCommands(formatJSON)
+
+	if err := c.run(context.Background(), []string{"prompt-stats"}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	var decoded []experimentusecase.VariantSummary
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0].PassRate != 0.75 {
+		t.Fatalf("unexpected decoded summaries: %+v", decoded)
+	}
+
+	if err := c.run(context.Background(), []string{"prompt-stats", "extra"}); !errors.Is(err, errUsage) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestFormatReasonsOrder(t *testing.T) {
+	got := formatReasons(map[string]int{"b": 1, "a": 3, "": 1})
+	if got != "a(3), (理由なし)(1), b(1)" {
+		t.Fatalf("unexpected order: %s", got)
+	}
+	if formatReasons(nil) != "-" {
+		t.Fatalf("expected dash for empty reasons")
+	}
+}
+
+func TestFormatReactionsWithoutReactions(t *testing.T) {
+	if formatPerDraw(experimentusecase.VariantSummary{}) != "-" || formatReactions(nil) != "-" {
+		t.Fatalf("expected dash when reactions are not summarized")
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	c, _, _, _ := newTestCommands(formatTable)
+	if err := c.run(context.Background(), []string{"purge"}); !errors.Is(err, errUsage) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+type stubOps struct {
+	status *opsusecase.QueueStatus
+	limit  int
+}
+
+func (s *stubOps) QueueStatus(ctx context.Context, limit int) (*opsusecase.QueueStatus, error) {
+	s.limit = limit
+	return s.status, nil
+}
+
+func (s *stubOps) ExportPosts(ctx context.Context, fn func(*post.Post) error) error {
+	for _, id := range []post.DarkPostID{"post-1", "post-2"} {
+		p, err := post.New(id, "<本文>")
+		if err != nil {
+			return err
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stubOps) ExportDraws(ctx context.Context, fn func(*drawdomain.Draw) error) error {
+	d, err := drawdomain.New("post-1", "fortune")
+	if err != nil {
+		return err
+	}
+	return fn(d)
+}
+
+type stubAdmin struct {
+	scheduled   map[post.DarkPostID]bool
+	requeuedAll bool
+	actor       string
+	marked      post.DarkPostID
+}
+
+func (s *stubAdmin) RequeuePost(ctx context.Context, actor string, id post.DarkPostID) error {
+	s.actor = actor
+	if s.scheduled[id] {
+		return adminusecase.ErrJobAlreadyScheduled
+	}
+	s.scheduled[id] = true
+	return nil
+}
+
+func (s *stubAdmin) RequeuePending(ctx context.Context, actor string) (*adminusecase.RequeueResult, error) {
+	s.requeuedAll = true
+	return &adminusecase.RequeueResult{Enqueued: []post.DarkPostID{"post-1"}}, nil
+}
+
+func (s *stubAdmin) HideDraw(ctx context.Context, actor string, id post.DarkPostID) (*drawdomain.Draw, error) {
+	s.marked = id
+	return drawdomain.Restore(id, "fortune", drawdomain.StatusHidden)
+}
+
+func (s *stubAdmin) ExpireDraw(ctx context.Context, actor string, id post.DarkPostID) (*drawdomain.Draw, error) {
+	s.marked = id
+	return drawdomain.Restore(id, "fortune", drawdomain.StatusExpired)
+}
+
+func (s *stubAdmin) ApproveDraw(ctx context.Context, actor string, id post.DarkPostID) (*drawdomain.Draw, error) {
+	s.marked = id
+	return drawdomain.Restore(id, "fortune", drawdomain.StatusVerified)
+}
+
+type stubPreviewer struct{}
+
+func (stubPreviewer) Preview(ctx context.Context, postID string) (*worker.Preview, error) {
+	return &worker.Preview{
+		PostID:     post.DarkPostID(postID),
+		PostStatus: post.StatusPending,
+		Formatted:  &llm.FormatResult{FormattedContent: "整形した\n本文", PromptVersion: "fortune-v1"},
+		Validated: &llm.FormatResult{
+			FormattedContent: "整形した\n本文",
+			Status:           drawdomain.StatusRejected,
+			ValidationReason: "禁止語を含む",
+			PromptVersion:    "fortune-v1",
+		},
+		ValidationErr: llm.ErrContentRejected,
+	}, nil
+}
+
+type stubPromptStats struct{}
+
+func (stubPromptStats) Execute(ctx context.Context) ([]experimentusecase.VariantSummary, error) {
+	return []experimentusecase.VariantSummary{{
+		PromptVersion:    "fortune-v1",
+		Total:            4,
+		Passed:           3,
+		Rejected:         1,
+		PassRate:         0.75,
+		AverageLength:    92.5,
+		RejectionReasons: map[string]int{"禁止語を含む": 1},
+		Reactions:        reaction.Counts{reaction.KindAccurate: 4, reaction.KindScary: 1},
+		ReactionsPerDraw: 5.0 / 3,
+	}}, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"backend/internal/domain/reaction"
+	experimentusecase "backend/internal/usecase/experiment"
+)
+
+// promptStatsService はプロンプトのバリアントごとの集計ユースケース。
+type promptStatsService interface {
+	Execute(ctx context.Context) ([]experimentusecase.VariantSummary, error)
+}
+
+/**
+ * プロンプトのバリアントごとに通過率・平均文字数・反応数・却下理由を集計して表示する。
+ */
+func (c *commands) promptStats(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: prompt-stats は引数を取りません", errUsage)
+	}
+	usecase, err := c.newPromptStats(ctx)
+	if err != nil {
+		return err
+	}
+	summaries, err := usecase.Execute(ctx)
+	if err != nil {
+		return fmt.Errorf("summarize outcomes: %w", err)
+	}
+	if c.format == formatJSON {
+		return writeJSON(c.out, summaries)
+	}
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		rows = append(rows, []string{
+			s.PromptVersion,
+			fmt.Sprint(s.Total),
+			fmt.Sprint(s.Passed),
+			fmt.Sprintf("%.1f%%", s.PassRate*100),
+			fmt.Sprintf("%.1f", s.AverageLength),
+			formatPerDraw(s),
+			formatReactions(s.Reactions),
+			formatReasons(s.RejectionReasons),
+		})
+	}
+	return writeTable(c.out, []string{"VERSION", "TOTAL", "PASSED", "PASS RATE", "AVG LENGTH", "REACTIONS/DRAW", "REACTIONS", "REJECTION REASONS"}, rows)
+}
+
+/**
+ * 通過したおみくじ 1 件あたりの反応数。反応を集計していなければ "-"。
+ */
+func formatPerDraw(s experimentusecase.VariantSummary) string {
+	if s.Reactions == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", s.ReactionsPerDraw)
+}
+
+/**
+ * 反応数を種類の表示順に "種類(件数)" 形式で並べる。
+ */
+func formatReactions(counts reaction.Counts) string {
+	if counts.Total() == 0 {
+		return "-"
+	}
+	parts := make([]string, 0, len(counts))
+	for _, kind := range reaction.Kinds() {
+		if n := counts[kind]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s(%d)", kind, n))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+/**
+ * 却下理由を件数の多い順に "理由(件数)" 形式で並べる。
+ */
+func formatReasons(reasons map[string]int) string {
+	if len(reasons) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(reasons))
+	for k := range reasons {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if reasons[keys[i]] != reasons[keys[j]] {
+			return reasons[keys[i]] > reasons[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		label := k
+		if label == "" {
+			label = "(理由なし)"
+		}
+		parts = append(parts, fmt.Sprintf("%s(%d)", label, reasons[k]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"backend/internal/adapter/tracing"
 	"backend/internal/app"
 	"backend/internal/config"
+	"backend/internal/health"
+	"backend/internal/logging"
 	"backend/internal/port/queue"
 	usecaseworker "backend/internal/usecase/worker"
 )
@@ -22,44 +25,75 @@ import (
  */
 func main() {
 	config.LoadDotEnv()
+	if err := app.SetupLogger(os.Stdout); err != nil {
+		fatal("failed to configure logger", err)
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	startHealthServer(ctx)
+	// 終了時に未送信のスパンを送り切る
+	shutdownTracing, err := app.SetupTracing(ctx, app.WorkerServiceName)
+	if err != nil {
+		fatal("failed to configure tracing", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("failed to flush traces", slog.Any("error", err))
+		}
+	}()
+
+	// 依存の初期化より先に待ち受けを始め、Cloud Run の起動確認に間に合わせる
+	server := startHealthServer(ctx)
 
 	container, err := app.NewWorkerContainer(ctx)
 	if err != nil {
-		log.Fatalf("failed to initialize worker: %v", err)
+		fatal("failed to initialize worker", err)
 	}
+	server.mux.Handle("/metrics", container.MetricsHandler)
+	server.live.Set(container.LivenessChecks()...)
+	server.ready.Set(container.ReadinessChecks()...)
 	defer func() {
 		if cerr := container.Close(); cerr != nil {
-			log.Printf("worker shutdown error: %v", cerr)
+			slog.Error("worker shutdown error", slog.Any("error", cerr))
 		}
 	}()
 
-	log.Println("worker started (pending format)")
+	slog.Info("worker started", slog.String("mode", "pending format"))
 	runLoop(ctx, container)
 }
 
+// ヘルスチェック用サーバーのルーティングと、後から判定項目を差し込む死活判定
+type healthServer struct {
+	mux   *http.ServeMux
+	live  *health.Probe
+	ready *health.Probe
+}
+
 /**
  * Cloud Run のヘルスチェックに応答するHTTPサーバーを起動する。
+ * /livez はループの死活、/readyz はジョブを処理できる状態かを JSON で返す（/healthz は /livez と同じ）。
+ * 依存の初期化が終わるまでは /readyz を失敗にし、初期化後に判定項目と /metrics を追加できるよう返す。
  */
-func startHealthServer(ctx context.Context) {
+func startHealthServer(ctx context.Context) *healthServer {
 	port := os.Getenv("PORT")
 	if port == "" {
 		// API の既定ポートと衝突しないように別ポートを採用する
 		port = "8081"
 	}
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
+	server := &healthServer{
+		mux:   http.NewServeMux(),
+		live:  health.NewProbe(health.DefaultTimeout),
+		ready: health.NewProbe(health.DefaultTimeout, health.Initializing("worker")),
+	}
+	server.mux.Handle("/livez", server.live)
+	server.mux.Handle("/healthz", server.live)
+	server.mux.Handle("/readyz", server.ready)
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", port),
-		Handler: mux,
+		Handler: server.mux,
 	}
 
 	go func() {
@@ -71,24 +105,28 @@ func startHealthServer(ctx context.Context) {
 
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Fatalf("health server error: %v", err)
+			fatal("health server error", err)
 		}
 	}()
+	return server
 }
 
 /**
  * 取り出した投稿を順に整形し、終了指示や取り出し失敗を監視しながら回し続ける。
  */
 func runLoop(ctx context.Context, container *app.WorkerContainer) {
+	// ジョブを待っている間も、取り出しのポーリングごとに鼓動を残す
+	pollCtx := queue.WithPollObserver(ctx, container.Heartbeat.Beat)
 	for {
 		select {
 		case <-ctx.Done():
-			log.Printf("worker shutting down: %v", ctx.Err())
+			slog.Info("worker shutting down", slog.Any("reason", ctx.Err()))
 			return
 		default:
 		}
 
-		postID, err := container.JobQueue.DequeueFormat(ctx)
+		job, err := container.JobQueue.DequeueFormat(pollCtx)
+		container.Heartbeat.Beat()
 		if err != nil {
 			// 中断やキュー停止はそのまま終了する
 			if errors.Is(err, context.Canceled) ||
@@ -98,27 +136,51 @@ func runLoop(ctx context.Context, container *app.WorkerContainer) {
 				return
 			}
 			// それ以外は短い待機後に再試行
-			log.Printf("dequeue error: %v", err)
+			slog.ErrorContext(ctx, "dequeue error", slog.Any("error", err))
 			time.Sleep(500 * time.Millisecond)
 			continue
 		}
+		if job == nil {
+			continue
+		}
+
+		// 登録元の API リクエストと突き合わせられるよう、ジョブに残ったリクエスト ID を引き継ぐ
+		jobCtx := logging.WithRequestID(ctx, job.RequestID)
+		// 登録元の POST /posts のスパンとリンクしたスパンでジョブを処理する
+		jobCtx, span := tracing.StartJob(jobCtx, job)
+		// 投稿 ID はハッシュ化してログに残す
+		postAttr := logging.PostAttr(string(job.PostID))
 
 		// ジョブを処理し、失敗内容ごとにログの粒度を変える
-		if err := container.FormatPendingUsecase.Execute(ctx, string(postID)); err != nil {
+		err = container.FormatPendingUsecase.Execute(jobCtx, string(job.PostID))
+		tracing.EndJob(span, err)
+		if err != nil {
 			switch {
 			// draw 保存に失敗したが再キュー済みのケース
 			case errors.Is(err, usecaseworker.ErrDrawCreationFailed):
-				log.Printf("draw creation failed (post=%s): %v (requeued)", postID, err)
+				slog.WarnContext(jobCtx, "draw creation failed; requeued", postAttr, slog.Any("error", err))
 				// 再キューやロールバック自体が失敗した致命的ケース
 			case errors.Is(err, usecaseworker.ErrRequeueFailed):
-				log.Printf("draw creation rollback failed (post=%s): %v", postID, err)
+				slog.ErrorContext(jobCtx, "draw creation rollback failed", postAttr, slog.Any("error", err))
+				// 整形自体は終わったが実験集計用の記録だけ失敗したケース
+			case errors.Is(err, usecaseworker.ErrOutcomeRecordFailed):
+				slog.WarnContext(jobCtx, "outcome record failed", postAttr, slog.Any("error", err))
 			default:
 				// LLM や投稿の整形問題はログに残して次のジョブへ
-				log.Printf("format error (post=%s): %v", postID, err)
+				slog.ErrorContext(jobCtx, "format error", postAttr, slog.Any("error", err))
 			}
 			continue
 		}
 
-		log.Printf("formatted post: %s", postID)
+		container.Heartbeat.RecordSuccess()
+		slog.InfoContext(jobCtx, "formatted post", postAttr)
 	}
 }
+
+/**
+ * 起動を続けられないエラーを記録して終了する。
+ */
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}
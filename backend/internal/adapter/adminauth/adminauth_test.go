@@ -0,0 +1,71 @@
+package adminauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/api/idtoken"
+)
+
+func TestTokenVerifier(t *testing.T) {
+	if _, err := NewTokenVerifier(""); !errors.Is(err, ErrEmptyToken) {
+		t.Fatalf("expected ErrEmptyToken, got %v", err)
+	}
+	v, err := NewTokenVerifier("secret")
+	if err != nil {
+		t.Fatalf("NewTokenVerifier() error = %v", err)
+	}
+	if actor, err := v.Verify(context.Background(), "secret"); err != nil || actor != TokenActor {
+		t.Fatalf("Verify() = %q, %v", actor, err)
+	}
+	for _, token := range []string{"", "secret2", "Secret"} {
+		if _, err := v.Verify(context.Background(), token); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("%q: expected ErrInvalidToken, got %v", token, err)
+		}
+	}
+}
+
+func TestNewIDTokenVerifier_Invalid(t *testing.T) {
+	if _, err := NewIDTokenVerifier("", []string{"a@example.com"}); !errors.Is(err, ErrEmptyAudience) {
+		t.Fatalf("expected ErrEmptyAudience, got %v", err)
+	}
+	if _, err := NewIDTokenVerifier("aud", []string{" ", ""}); !errors.Is(err, ErrNoAllowedEmails) {
+		t.Fatalf("expected ErrNoAllowedEmails, got %v", err)
+	}
+}
+
+func TestIDTokenVerifier_Verify(t *testing.T) {
+	v, err := NewIDTokenVerifier("https://api.example.com", []string{" Ops@Example.com "})
+	if err != nil {
+		t.Fatalf("NewIDTokenVerifier() error = %v", err)
+	}
+	claims := map[string]map[string]any{
+		"ok":         {"email": "OPS@example.com", "email_verified": true},
+		"unverified": {"email": "ops@example.com", "email_verified": false},
+		"other":      {"email": "someone@example.com", "email_verified": true},
+	}
+	v.validate = func(ctx context.Context, token, audience string) (*idtoken.Payload, error) {
+		if audience != "https://api.example.com" {
+			t.Fatalf("unexpected audience: %q", audience)
+		}
+		c, ok := claims[token]
+		if !ok {
+			return nil, errors.New("bad signature")
+		}
+		return &idtoken.Payload{Audience: audience, Claims: c}, nil
+	}
+
+	if actor, err := v.Verify(context.Background(), "ok"); err != nil || actor != "ops@example.com" {
+		t.Fatalf("Verify() = %q, %v", actor, err)
+	}
+	cases := map[string]error{"unverified": ErrEmailNotVerified, "other": ErrEmailNotPermitted}
+	for token, want := range cases {
+		if _, err := v.Verify(context.Background(), token); !errors.Is(err, want) {
+			t.Fatalf("%s: expected %v, got %v", token, want, err)
+		}
+	}
+	if _, err := v.Verify(context.Background(), "forged"); err == nil {
+		t.Fatalf("expected error for invalid token")
+	}
+}
@@ -0,0 +1,63 @@
+package adminauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/idtoken"
+)
+
+var (
+	ErrEmptyAudience     = errors.New("adminauth: ID トークンの audience が設定されていません")
+	ErrNoAllowedEmails   = errors.New("adminauth: 管理者のメールアドレスが設定されていません")
+	ErrEmailNotVerified  = errors.New("adminauth: メールアドレスが確認されていません")
+	ErrEmailNotPermitted = errors.New("adminauth: 管理者として許可されていないメールアドレスです")
+)
+
+// IDTokenVerifier は Google が発行した ID トークン（OIDC の JWT）を確かめ、許可したメールアドレスだけを通す。
+type IDTokenVerifier struct {
+	audience string
+	allowed  map[string]struct{}
+	validate func(ctx context.Context, token, audience string) (*idtoken.Payload, error)
+}
+
+/**
+ * ID トークンの検証器を返す。audience には IAP や gcloud で発行したトークンの宛先を、
+ * emails には管理者として通すメールアドレス（大文字・小文字は区別しない）を渡す。
+ */
+func NewIDTokenVerifier(audience string, emails []string) (*IDTokenVerifier, error) {
+	if audience == "" {
+		return nil, ErrEmptyAudience
+	}
+	allowed := make(map[string]struct{}, len(emails))
+	for _, email := range emails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			allowed[email] = struct{}{}
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, ErrNoAllowedEmails
+	}
+	return &IDTokenVerifier{audience: audience, allowed: allowed, validate: idtoken.Validate}, nil
+}
+
+/**
+ * 署名・有効期限・audience を確かめたうえで、確認済みかつ許可されたメールアドレスならそれを操作者として返す。
+ */
+func (v *IDTokenVerifier) Verify(ctx context.Context, token string) (string, error) {
+	payload, err := v.validate(ctx, token, v.audience)
+	if err != nil {
+		return "", fmt.Errorf("validate id token: %w", err)
+	}
+	email, _ := payload.Claims["email"].(string)
+	if verified, _ := payload.Claims["email_verified"].(bool); !verified || email == "" {
+		return "", ErrEmailNotVerified
+	}
+	email = strings.ToLower(email)
+	if _, ok := v.allowed[email]; !ok {
+		return "", ErrEmailNotPermitted
+	}
+	return email, nil
+}
@@ -0,0 +1,92 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"backend/internal/port/blob"
+)
+
+var errEmptyRoot = errors.New("filesystemblob: 保存先ディレクトリが指定されていません")
+
+/**
+ * ローカルのディレクトリにバイト列をファイルとして保存する保存先。
+ * キーの / はサブディレクトリになる。書き込みは一時ファイルからの rename で行い、途中の状態を読ませない。
+ */
+type Store struct {
+	root string
+}
+
+var _ blob.Store = (*Store)(nil)
+
+// NewStore は root 配下に保存する保存先を作る。ディレクトリが無ければ作成する。
+func NewStore(root string) (*Store, error) {
+	if root == "" {
+		return nil, errEmptyRoot
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create blob root: %w", err)
+	}
+	return &Store{root: root}, nil
+}
+
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	if !blob.ValidKey(key) {
+		return nil, blob.ErrInvalidKey
+	}
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, blob.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read blob: %w", err)
+	}
+	return data, nil
+}
+
+func (s *Store) Put(ctx context.Context, key string, data []byte) error {
+	if !blob.ValidKey(key) {
+		return blob.ErrInvalidKey
+	}
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create blob dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp blob: %w", err)
+	}
+	// rename まで進めなかった一時ファイルは残さない
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("commit blob: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	if !blob.ValidKey(key) {
+		return blob.ErrInvalidKey
+	}
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("delete blob: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
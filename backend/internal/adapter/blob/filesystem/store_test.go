@@ -0,0 +1,73 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"backend/internal/port/blob"
+)
+
+func TestStore_PutAndGet(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewStore(filepath.Join(root, "blobs"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := s.Get(ctx, "cards/v1/a.png"); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := s.Put(ctx, "cards/v1/a.png", []byte("first")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if err := s.Put(ctx, "cards/v1/a.png", []byte("second")); err != nil {
+		t.Fatalf("overwrite: %v", err)
+	}
+
+	got, err := s.Get(ctx, "cards/v1/a.png")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if string(got) != "second" {
+		t.Fatalf("unexpected data: %q", got)
+	}
+
+	// 一時ファイルは残らない
+	entries, err := os.ReadDir(filepath.Join(root, "blobs", "cards", "v1"))
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the blob file, got %d entries", len(entries))
+	}
+
+	if err := s.Delete(ctx, "cards/v1/a.png"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := s.Get(ctx, "cards/v1/a.png"); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+	// 無いキーの削除は成功とする
+	if err := s.Delete(ctx, "cards/v1/a.png"); err != nil {
+		t.Fatalf("delete missing: %v", err)
+	}
+}
+
+func TestStore_RejectsKeysOutsideRoot(t *testing.T) {
+	s, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	for _, key := range []string{"../escape.png", "/etc/passwd", "cards/../../x"} {
+		if err := s.Put(context.Background(), key, []byte("x")); !errors.Is(err, blob.ErrInvalidKey) {
+			t.Fatalf("Put(%q): expected ErrInvalidKey, got %v", key, err)
+		}
+	}
+	if _, err := NewStore(""); err == nil {
+		t.Fatalf("expected error for empty root")
+	}
+}
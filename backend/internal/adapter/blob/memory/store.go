@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"backend/internal/port/blob"
+)
+
+/**
+ * プロセス内にバイト列を保持する保存先。インスタンスが 1 つの場合やローカル開発向け。
+ * 再起動で消えるため、作り直せるデータのキャッシュにだけ使う。
+ * 合計サイズが上限を超えたら、最も長く使われていないものから捨てる。
+ */
+type Store struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	// 先頭ほど最近使ったもの
+	order *list.List
+	blobs map[string]*list.Element
+}
+
+// entry は order に並べる保存済みの 1 件。
+type entry struct {
+	key  string
+	data []byte
+}
+
+var _ blob.Store = (*Store)(nil)
+
+// NewStore は合計 maxBytes バイトまで保持する空の保存先を作る。
+func NewStore(maxBytes int64) *Store {
+	return &Store{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		blobs:    make(map[string]*list.Element),
+	}
+}
+
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	if !blob.ValidKey(key) {
+		return nil, blob.ErrInvalidKey
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.blobs[key]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	s.order.MoveToFront(elem)
+	return clone(elem.Value.(*entry).data), nil
+}
+
+/**
+ * key に data を保存する。上限を超える分は古いものから捨てる。
+ * data 1 件だけで上限を超える場合は保存しない（キャッシュなので呼び出し元は作り直せる）。
+ */
+func (s *Store) Put(ctx context.Context, key string, data []byte) error {
+	if !blob.ValidKey(key) {
+		return blob.ErrInvalidKey
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.blobs[key]; ok {
+		s.remove(elem)
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil
+	}
+	s.blobs[key] = s.order.PushFront(&entry{key: key, data: clone(data)})
+	s.size += int64(len(data))
+	for s.size > s.maxBytes {
+		s.remove(s.order.Back())
+	}
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	if !blob.ValidKey(key) {
+		return blob.ErrInvalidKey
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.blobs[key]; ok {
+		s.remove(elem)
+	}
+	return nil
+}
+
+func (s *Store) remove(elem *list.Element) {
+	e := s.order.Remove(elem).(*entry)
+	delete(s.blobs, e.key)
+	s.size -= int64(len(e.data))
+}
+
+func clone(data []byte) []byte {
+	out := make([]byte, len(data))
+	copy(out, data)
+	return out
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/port/blob"
+)
+
+func TestStore_PutAndGet(t *testing.T) {
+	s := NewStore(1024)
+	ctx := context.Background()
+
+	if _, err := s.Get(ctx, "cards/a.png"); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	data := []byte("png")
+	if err := s.Put(ctx, "cards/a.png", data); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	// 呼び出し元が書き換えても保存済みの内容は変わらない
+	data[0] = 'x'
+
+	got, err := s.Get(ctx, "cards/a.png")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if string(got) != "png" {
+		t.Fatalf("unexpected data: %q", got)
+	}
+
+	if err := s.Delete(ctx, "cards/a.png"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := s.Get(ctx, "cards/a.png"); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+	// 無いキーの削除は成功とする
+	if err := s.Delete(ctx, "cards/a.png"); err != nil {
+		t.Fatalf("delete missing: %v", err)
+	}
+
+	for _, key := range []string{"", "/abs", "a/../b", "a//b", "a b"} {
+		if err := s.Put(ctx, key, data); !errors.Is(err, blob.ErrInvalidKey) {
+			t.Fatalf("Put(%q): expected ErrInvalidKey, got %v", key, err)
+		}
+	}
+}
+
+func TestStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	s := NewStore(6)
+	ctx := context.Background()
+
+	for _, key := range []string{"a", "b", "c"} {
+		if err := s.Put(ctx, key, []byte("xx")); err != nil {
+			t.Fatalf("put %s: %v", key, err)
+		}
+	}
+	// a を使ってから d を足すと、最も使われていない b が捨てられる
+	if _, err := s.Get(ctx, "a"); err != nil {
+		t.Fatalf("get a: %v", err)
+	}
+	if err := s.Put(ctx, "d", []byte("xx")); err != nil {
+		t.Fatalf("put d: %v", err)
+	}
+	for key, want := range map[string]error{"a": nil, "b": blob.ErrNotFound, "c": nil, "d": nil} {
+		if _, err := s.Get(ctx, key); !errors.Is(err, want) {
+			t.Fatalf("Get(%q): expected %v, got %v", key, want, err)
+		}
+	}
+
+	// 上限を超える 1 件は保存しない
+	if err := s.Put(ctx, "big", []byte("1234567")); err != nil {
+		t.Fatalf("put big: %v", err)
+	}
+	if _, err := s.Get(ctx, "big"); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("expected oversized blob to be skipped, got %v", err)
+	}
+}
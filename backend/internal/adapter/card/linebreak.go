@@ -0,0 +1,104 @@
+package card
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// 行頭に置かない文字（句読点・閉じ括弧・小書きの仮名・長音など）
+const noLineStart = "、。，．,.)）]］}｝〕〉》」』】〙〗〟’”｠»ゝゞーァィゥェォッャュョヮヵヶぁぃぅぇぉっゃゅょゎゕゖㇰㇱㇲㇳㇴㇵㇶㇷㇸㇹㇺㇻㇼㇽㇾㇿ々〻‐゠–〜～?!？！‼⁇⁈⁉・:;：；/…‥"
+
+// 行末に置かない文字（開き括弧など）
+const noLineEnd = "(（[［{｛〔〈《「『【〘〖〝‘“｟«"
+
+/**
+ * 日本語の禁則に沿って text を maxWidth 以内の行へ折り返す。
+ * 英数字の連なりは途中で切らない。行頭禁則の文字は前の行へぶら下げ、行末禁則の文字は次の行へ送る。
+ * 改行文字では必ず改行する。measure は文字列の描画幅を返す。
+ */
+func wrapText(text string, maxWidth fixed.Int26_6, measure func(string) fixed.Int26_6) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		lines = append(lines, wrapParagraph(paragraph, maxWidth, measure)...)
+	}
+	return lines
+}
+
+func wrapParagraph(paragraph string, maxWidth fixed.Int26_6, measure func(string) fixed.Int26_6) []string {
+	units := splitUnits(paragraph)
+	if len(units) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var current []string
+	for _, unit := range units {
+		candidate := strings.Join(append(current, unit), "")
+		if len(current) == 0 || measure(candidate) <= maxWidth {
+			current = append(current, unit)
+			continue
+		}
+		// 句読点などは行頭に来ないよう、はみ出しても前の行へぶら下げる
+		if strings.ContainsRune(noLineStart, firstRune(unit)) {
+			current = append(current, unit)
+			continue
+		}
+		// 開き括弧で終わる行は、その括弧ごと次の行へ送る
+		var carry []string
+		for len(current) > 1 && strings.ContainsRune(noLineEnd, lastRune(current[len(current)-1])) {
+			carry = append([]string{current[len(current)-1]}, carry...)
+			current = current[:len(current)-1]
+		}
+		lines = append(lines, strings.TrimRightFunc(strings.Join(current, ""), unicode.IsSpace))
+		current = append(carry, unit)
+		// 行頭の空白は詰める
+		if len(current) == 1 && strings.TrimSpace(unit) == "" {
+			current = current[:0]
+		}
+	}
+	if len(current) > 0 {
+		lines = append(lines, strings.TrimRightFunc(strings.Join(current, ""), unicode.IsSpace))
+	}
+	return lines
+}
+
+/**
+ * 折り返しの単位へ分ける。英数字の連なりは 1 単位、それ以外は 1 文字ずつ。
+ */
+func splitUnits(text string) []string {
+	var units []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			units = append(units, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range text {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			word.WriteRune(r)
+			continue
+		}
+		flush()
+		units = append(units, string(r))
+	}
+	flush()
+	return units
+}
+
+func firstRune(s string) rune {
+	for _, r := range s {
+		return r
+	}
+	return 0
+}
+
+func lastRune(s string) rune {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return 0
+	}
+	return runes[len(runes)-1]
+}
@@ -0,0 +1,80 @@
+package card
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// 1 文字を幅 1 として数える。
+func runeWidth(s string) fixed.Int26_6 {
+	return fixed.I(utf8.RuneCountInString(s))
+}
+
+func TestWrapText(t *testing.T) {
+	cases := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "fits",
+			text:  "今日は晴れ",
+			width: 10,
+			want:  []string{"今日は晴れ"},
+		},
+		{
+			name:  "breaks by width",
+			text:  "あいうえおかきくけこ",
+			width: 4,
+			want:  []string{"あいうえ", "おかきく", "けこ"},
+		},
+		{
+			name:  "punctuation hangs instead of starting a line",
+			text:  "あいうえ。かき",
+			width: 4,
+			want:  []string{"あいうえ。", "かき"},
+		},
+		{
+			name:  "small kana and long vowel hang",
+			text:  "ちょっとコーヒー",
+			width: 2,
+			want:  []string{"ちょっ", "とコー", "ヒー"},
+		},
+		{
+			name:  "opening bracket moves to the next line",
+			text:  "あいう「えお」",
+			width: 4,
+			want:  []string{"あいう", "「えお」"},
+		},
+		{
+			name:  "ascii words stay together",
+			text:  "今日はLuckyな日",
+			width: 6,
+			want:  []string{"今日は", "Luckyな", "日"},
+		},
+		{
+			name:  "newline forces a break",
+			text:  "あい\nう",
+			width: 10,
+			want:  []string{"あい", "う"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := wrapText(tc.text, fixed.I(tc.width), runeWidth)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("wrapText(%q, %d) = %q, want %q", tc.text, tc.width, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("あいうえお。", fixed.I(4), runeWidth); got != "あいう…" {
+		t.Fatalf("truncate = %q", got)
+	}
+}
@@ -0,0 +1,209 @@
+package card
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	portcard "backend/internal/port/card"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// OGP 画像の推奨サイズ（X・LINE などのリンクプレビューで切れない大きさ）
+const (
+	Width  = 1200
+	Height = 630
+)
+
+// 配置（ピクセル）
+const (
+	marginX        = 80
+	frameInset     = 32
+	frameThickness = 4
+	headingBaseY   = 150
+	headingSize    = 60
+	bodyTopY       = 250
+	bodyBottomY    = 530
+	brandBaseY     = 582
+	brandSize      = 32
+	lineSpacing    = 1.55
+)
+
+// 本文が収まらないときに順に試す文字サイズ
+var bodySizes = []float64{44, 38, 32}
+
+// 配色
+var (
+	backgroundTop    = color.RGBA{R: 24, G: 16, B: 40, A: 255}
+	backgroundBottom = color.RGBA{R: 62, G: 28, B: 74, A: 255}
+	accentColor      = color.RGBA{R: 222, G: 184, B: 92, A: 255}
+	bodyColor        = color.RGBA{R: 246, G: 240, B: 252, A: 255}
+	brandColor       = color.RGBA{R: 200, G: 184, B: 220, A: 255}
+)
+
+// ellipsis は収まりきらない本文の末尾に付ける記号。
+const ellipsis = "…"
+
+//go:embed fonts/ArmedLemon.ttf
+var fontData []byte
+
+var errEmptyCard = errors.New("card: 描く本文がありません")
+
+/**
+ * 共有カードを埋め込みフォントで 1200x630 の PNG に描く。外部コマンドや CGO には頼らない。
+ */
+type Renderer struct {
+	font *opentype.Font
+}
+
+var _ portcard.Renderer = (*Renderer)(nil)
+
+// NewRenderer は埋め込みフォントを読み込んだ Renderer を返す。
+func NewRenderer() (*Renderer, error) {
+	f, err := opentype.Parse(fontData)
+	if err != nil {
+		return nil, fmt.Errorf("parse card font: %w", err)
+	}
+	return &Renderer{font: f}, nil
+}
+
+/**
+ * カードを描いて PNG のバイト列を返す。本文は禁則に沿って折り返し、収まらなければ文字を小さくし、
+ * それでも収まらなければ末尾を … で切る。
+ * フォントの描画状態は共有できないため、呼び出しごとに書体を作る。
+ */
+func (r *Renderer) Render(c portcard.Card) ([]byte, error) {
+	if strings.TrimSpace(c.Body) == "" {
+		return nil, errEmptyCard
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	fillGradient(img)
+	drawFrame(img)
+
+	if c.Heading != "" {
+		face, err := r.face(headingSize)
+		if err != nil {
+			return nil, err
+		}
+		drawText(img, face, accentColor, marginX, headingBaseY, c.Heading)
+		_ = face.Close()
+	}
+
+	if err := r.drawBody(img, c.Body); err != nil {
+		return nil, err
+	}
+
+	if c.Brand != "" {
+		face, err := r.face(brandSize)
+		if err != nil {
+			return nil, err
+		}
+		width := font.MeasureString(face, c.Brand).Ceil()
+		drawText(img, face, brandColor, Width-marginX-width, brandBaseY, c.Brand)
+		_ = face.Close()
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode card png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+/**
+ * 本文を収まる大きさで描く。
+ */
+func (r *Renderer) drawBody(img *image.RGBA, body string) error {
+	maxWidth := fixed.I(Width - marginX*2)
+	for i, size := range bodySizes {
+		face, err := r.face(size)
+		if err != nil {
+			return err
+		}
+		lineHeight := int(size * lineSpacing)
+		maxLines := (bodyBottomY-bodyTopY)/lineHeight + 1
+		measure := func(s string) fixed.Int26_6 { return font.MeasureString(face, s) }
+
+		lines := wrapText(body, maxWidth, measure)
+		last := i == len(bodySizes)-1
+		if len(lines) > maxLines && !last {
+			_ = face.Close()
+			continue
+		}
+		if len(lines) > maxLines {
+			lines = lines[:maxLines]
+			lines[maxLines-1] = truncate(lines[maxLines-1], maxWidth, measure)
+		}
+		for j, line := range lines {
+			drawText(img, face, bodyColor, marginX, bodyTopY+j*lineHeight, line)
+		}
+		_ = face.Close()
+		return nil
+	}
+	return nil
+}
+
+func (r *Renderer) face(size float64) (font.Face, error) {
+	face, err := opentype.NewFace(r.font, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("create card font face: %w", err)
+	}
+	return face, nil
+}
+
+/**
+ * 行末に … を付けて maxWidth に収める。
+ */
+func truncate(line string, maxWidth fixed.Int26_6, measure func(string) fixed.Int26_6) string {
+	runes := []rune(strings.TrimRight(line, "。、"))
+	for len(runes) > 0 && measure(string(runes)+ellipsis) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + ellipsis
+}
+
+func fillGradient(img *image.RGBA) {
+	for y := 0; y < Height; y++ {
+		t := float64(y) / float64(Height-1)
+		c := color.RGBA{
+			R: lerp(backgroundTop.R, backgroundBottom.R, t),
+			G: lerp(backgroundTop.G, backgroundBottom.G, t),
+			B: lerp(backgroundTop.B, backgroundBottom.B, t),
+			A: 255,
+		}
+		draw.Draw(img, image.Rect(0, y, Width, y+1), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+}
+
+func drawFrame(img *image.RGBA) {
+	src := image.NewUniform(accentColor)
+	outer := image.Rect(frameInset, frameInset, Width-frameInset, Height-frameInset)
+	edges := []image.Rectangle{
+		image.Rect(outer.Min.X, outer.Min.Y, outer.Max.X, outer.Min.Y+frameThickness),
+		image.Rect(outer.Min.X, outer.Max.Y-frameThickness, outer.Max.X, outer.Max.Y),
+		image.Rect(outer.Min.X, outer.Min.Y, outer.Min.X+frameThickness, outer.Max.Y),
+		image.Rect(outer.Max.X-frameThickness, outer.Min.Y, outer.Max.X, outer.Max.Y),
+	}
+	for _, edge := range edges {
+		draw.Draw(img, edge, src, image.Point{}, draw.Src)
+	}
+}
+
+func drawText(img *image.RGBA, face font.Face, c color.Color, x, baseline int, text string) {
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, baseline)}
+	d.DrawString(text)
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
@@ -0,0 +1,58 @@
+package card
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	portcard "backend/internal/port/card"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	c := portcard.Card{
+		Heading: "今日のきらくじ",
+		Body:    "心の奥がじっと湿って、気になる言葉が何度も頭に残っています。ひとつずつ事実を確認し、記録を残して淡々と片付けます。最後には小さな勝ちを拾えたと笑え、ふっと癒されます。",
+		Brand:   "きらくじ",
+	}
+	data, err := r.Render(c)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != Width || b.Dy() != Height {
+		t.Fatalf("unexpected size: %v", b)
+	}
+
+	// 同じ内容からは同じ画像になる（キャッシュのキーを内容から作れる）
+	again, err := r.Render(c)
+	if err != nil {
+		t.Fatalf("Render again: %v", err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Fatalf("expected deterministic output")
+	}
+}
+
+func TestRenderer_RenderLongAndEmptyBody(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	// 収まらない本文も切り詰めて描ける
+	if _, err := r.Render(portcard.Card{Body: strings.Repeat("長い本文が続きます。", 60)}); err != nil {
+		t.Fatalf("Render long body: %v", err)
+	}
+	if _, err := r.Render(portcard.Card{Heading: "今日のきらくじ", Body: "  "}); err == nil {
+		t.Fatalf("expected error for empty body")
+	}
+}
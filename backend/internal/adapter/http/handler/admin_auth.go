@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	messageUnauthorized = "admin credentials are required"
+	// adminActorKey は認証した管理者を gin のコンテキストへ載せるキー。
+	adminActorKey = "admin_actor"
+)
+
+var errUnauthorized = apiError{status: http.StatusUnauthorized, code: CodeUnauthorized, message: messageUnauthorized}
+
+/**
+ * 管理 API の Bearer トークンを確かめる契約。
+ * 正しければ監査ログに残す操作者（固定トークンなら設定名、OIDC ならメールアドレス）を返す。
+ */
+type AdminVerifier interface {
+	Verify(ctx context.Context, token string) (actor string, err error)
+}
+
+/**
+ * Authorization: Bearer のトークンを verifier で確かめるミドルウェア。
+ * 通らなければ理由を明かさずに 401 を返し、通れば操作者を後続のハンドラーへ渡す。
+ */
+func AdminAuth(verifier AdminVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer realm="admin"`)
+			writeError(c, errUnauthorized)
+			return
+		}
+		actor, err := verifier.Verify(c.Request.Context(), token)
+		if err != nil || actor == "" {
+			slog.WarnContext(c.Request.Context(), "admin authentication failed", slog.Any("error", err))
+			c.Header("WWW-Authenticate", `Bearer realm="admin", error="invalid_token"`)
+			writeError(c, errUnauthorized)
+			return
+		}
+		c.Set(adminActorKey, actor)
+		c.Next()
+	}
+}
+
+// bearerToken は Authorization ヘッダーから Bearer トークンを取り出す。
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// adminActor は AdminAuth が認証した操作者を返す。
+func adminActor(c *gin.Context) string {
+	return c.GetString(adminActorKey)
+}
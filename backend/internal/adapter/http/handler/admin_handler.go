@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	drawdomain "backend/internal/domain/draw"
+	postdomain "backend/internal/domain/post"
+	"backend/internal/domain/report"
+	adminusecase "backend/internal/usecase/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	messageInvalidCursor     = "invalid cursor"
+	messageInvalidPostStatus = "invalid post status"
+	messagePostNotPending    = "post is not pending"
+	messageJobAlreadyQueued  = "format job is already scheduled"
+	messageInvalidTransition = "draw cannot move to the requested status"
+	messageInvalidLimit      = "limit must be a positive integer"
+)
+
+// AdminOperator は管理者による投稿とおみくじの確認・操作の契約。actor は監査ログに残す操作者。
+type AdminOperator interface {
+	ListPosts(ctx context.Context, actor string, status postdomain.Status, cursor string, limit int) (*adminusecase.PostPage, error)
+	GetPost(ctx context.Context, actor string, id postdomain.DarkPostID) (*adminusecase.PostDetail, error)
+	ApproveDraw(ctx context.Context, actor string, id postdomain.DarkPostID) (*drawdomain.Draw, error)
+	RejectDraw(ctx context.Context, actor string, id postdomain.DarkPostID, reason string) (*drawdomain.Draw, error)
+	HideDraw(ctx context.Context, actor string, id postdomain.DarkPostID) (*drawdomain.Draw, error)
+	ExpireDraw(ctx context.Context, actor string, id postdomain.DarkPostID) (*drawdomain.Draw, error)
+	RequeuePost(ctx context.Context, actor string, id postdomain.DarkPostID) error
+	DeletePost(ctx context.Context, actor string, id postdomain.DarkPostID) error
+	ListReports(ctx context.Context, actor string, limit int) ([]*report.Review, error)
+	ResolveReports(ctx context.Context, actor string, id postdomain.DarkPostID) error
+}
+
+// AdminHandler は /admin 以下の管理 API を扱う。認証は WithAdmin に渡すミドルウェアで行う。
+type AdminHandler struct {
+	usecase AdminOperator
+}
+
+// NewAdminHandler は AdminHandler を生成する。
+func NewAdminHandler(usecase AdminOperator) *AdminHandler {
+	return &AdminHandler{usecase: usecase}
+}
+
+// Register は管理 API のルートを登録する。公開 API と違いバージョンを付けず、別名も作らない。
+func (h *AdminHandler) Register(r gin.IRoutes) {
+	r.GET("/posts", h.ListPosts)
+	r.GET("/posts/:id", h.GetPost)
+	r.POST("/posts/:id/requeue", h.RequeuePost)
+	r.DELETE("/posts/:id", h.DeletePost)
+	r.POST("/draws/:id/approve", h.ApproveDraw)
+	r.POST("/draws/:id/reject", h.RejectDraw)
+	r.POST("/draws/:id/hide", h.HideDraw)
+	r.POST("/draws/:id/expire", h.ExpireDraw)
+	r.GET("/reports", h.ListReports)
+	r.POST("/reports/:id/resolve", h.ResolveReports)
+}
+
+// AdminPostResponse は管理 API で返す投稿。公開 API と違い本文をそのまま含める。
+type AdminPostResponse struct {
+	PostID  string `json:"post_id"`
+	Content string `json:"content"`
+	Status  string `json:"status"`
+}
+
+// AdminPostListResponse は GET /admin/posts のレスポンス。NextCursor は最後のページでは省略する。
+type AdminPostListResponse struct {
+	Posts      []AdminPostResponse `json:"posts"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// AdminDrawResponse は管理 API で返すおみくじ。ID は投稿 ID で、/admin/draws/:id にもこの値を使う。
+type AdminDrawResponse struct {
+	PostID        string `json:"post_id"`
+	ShareID       string `json:"share_id,omitempty"`
+	Result        string `json:"result"`
+	Status        string `json:"status"`
+	PromptVersion string `json:"prompt_version,omitempty"`
+}
+
+// AdminOutcomeResponse は整形・検証 1 回分の結果。却下なら Reason に理由が入る。
+type AdminOutcomeResponse struct {
+	PromptVersion string    `json:"prompt_version"`
+	Status        string    `json:"status"`
+	Reason        string    `json:"reason,omitempty"`
+	Length        int       `json:"length"`
+	RecordedAt    time.Time `json:"recorded_at"`
+}
+
+// AdminPostDetailResponse は GET /admin/posts/:id のレスポンス。おみくじがまだ無ければ Draw を省略する。
+type AdminPostDetailResponse struct {
+	Post     AdminPostResponse      `json:"post"`
+	Draw     *AdminDrawResponse     `json:"draw,omitempty"`
+	Outcomes []AdminOutcomeResponse `json:"outcomes"`
+}
+
+// AdminReportResponse は通報の審査待ちの項目。ID は投稿 ID で、/admin/draws/:id にもこの値を使う。
+type AdminReportResponse struct {
+	PostID         string           `json:"post_id"`
+	ReportCount    int64            `json:"report_count"`
+	Reasons        map[string]int64 `json:"reasons"`
+	LastReportedAt time.Time        `json:"last_reported_at"`
+}
+
+// AdminReportListResponse は GET /admin/reports のレスポンス。
+type AdminReportListResponse struct {
+	Reports []AdminReportResponse `json:"reports"`
+}
+
+// POST /admin/draws/:id/reject の入力。理由は監査ログに残す（省略可）。
+type RejectDrawRequest struct {
+	Reason string `json:"reason"`
+}
+
+/**
+ * 指定状態の投稿を投稿 ID 順に返す。status の既定は pending、limit の既定は 20（最大 100）。
+ */
+func (h *AdminHandler) ListPosts(c *gin.Context) {
+	limit, ok := queryLimit(c)
+	if !ok {
+		return
+	}
+	status := postdomain.Status(c.DefaultQuery("status", string(postdomain.StatusPending)))
+
+	page, err := h.usecase.ListPosts(c.Request.Context(), adminActor(c), status, c.Query("cursor"), limit)
+	if err != nil {
+		respondError(c, "admin list posts failed", err)
+		return
+	}
+	resp := AdminPostListResponse{Posts: make([]AdminPostResponse, 0, len(page.Posts)), NextCursor: page.NextCursor}
+	for _, p := range page.Posts {
+		resp.Posts = append(resp.Posts, newAdminPostResponse(p))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetPost は投稿と、そのおみくじ・整形結果を返す。
+func (h *AdminHandler) GetPost(c *gin.Context) {
+	detail, err := h.usecase.GetPost(c.Request.Context(), adminActor(c), postdomain.DarkPostID(c.Param("id")))
+	if err != nil {
+		respondError(c, "admin get post failed", err)
+		return
+	}
+	resp := AdminPostDetailResponse{
+		Post:     newAdminPostResponse(detail.Post),
+		Outcomes: make([]AdminOutcomeResponse, 0, len(detail.Outcomes)),
+	}
+	if detail.Draw != nil {
+		draw := newAdminDrawResponse(detail.Draw)
+		resp.Draw = &draw
+	}
+	for _, o := range detail.Outcomes {
+		resp.Outcomes = append(resp.Outcomes, AdminOutcomeResponse{
+			PromptVersion: o.PromptVersion(),
+			Status:        string(o.Status()),
+			Reason:        o.Reason(),
+			Length:        o.Length(),
+			RecordedAt:    o.RecordedAt(),
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RequeuePost は整形待ちの投稿を整形ジョブへ積み直し、202 を返す。
+func (h *AdminHandler) RequeuePost(c *gin.Context) {
+	if err := h.usecase.RequeuePost(c.Request.Context(), adminActor(c), postdomain.DarkPostID(c.Param("id"))); err != nil {
+		respondError(c, "admin requeue post failed", err)
+		return
+	}
+	c.Status(http.StatusAccepted)
+}
+
+/**
+ * 通報の審査待ちの項目を通報の多い順に返す。limit の既定は 20（最大 100）。
+ */
+func (h *AdminHandler) ListReports(c *gin.Context) {
+	limit, ok := queryLimit(c)
+	if !ok {
+		return
+	}
+	reviews, err := h.usecase.ListReports(c.Request.Context(), adminActor(c), limit)
+	if err != nil {
+		respondError(c, "admin list reports failed", err)
+		return
+	}
+	resp := AdminReportListResponse{Reports: make([]AdminReportResponse, 0, len(reviews))}
+	for _, rv := range reviews {
+		reasons := make(map[string]int64, len(rv.Reasons))
+		for reason, n := range rv.Reasons {
+			reasons[string(reason)] = n
+		}
+		resp.Reports = append(resp.Reports, AdminReportResponse{
+			PostID:         string(rv.PostID),
+			ReportCount:    rv.Count,
+			Reasons:        reasons,
+			LastReportedAt: rv.LastReportedAt,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ResolveReports はおみくじの状態を変えずに通報の審査待ちの項目を閉じ、204 を返す。
+func (h *AdminHandler) ResolveReports(c *gin.Context) {
+	if err := h.usecase.ResolveReports(c.Request.Context(), adminActor(c), postdomain.DarkPostID(c.Param("id"))); err != nil {
+		respondError(c, "admin resolve reports failed", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DeletePost は投稿を、それに付くおみくじ・反応・通報・整形結果・カード画像ごと削除し、204 を返す。
+func (h *AdminHandler) DeletePost(c *gin.Context) {
+	if err := h.usecase.DeletePost(c.Request.Context(), adminActor(c), postdomain.DarkPostID(c.Param("id"))); err != nil {
+		respondError(c, "admin delete post failed", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ApproveDraw は審査待ち・非公開のおみくじを公開し、遷移後のおみくじを返す。
+func (h *AdminHandler) ApproveDraw(c *gin.Context) {
+	draw, err := h.usecase.ApproveDraw(c.Request.Context(), adminActor(c), postdomain.DarkPostID(c.Param("id")))
+	h.respondDraw(c, "admin approve draw failed", draw, err)
+}
+
+// RejectDraw は審査待ちのおみくじを却下し、遷移後のおみくじを返す。本文は省略できる。
+func (h *AdminHandler) RejectDraw(c *gin.Context) {
+	var req RejectDrawRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeError(c, errInvalidRequest)
+			return
+		}
+	}
+	draw, err := h.usecase.RejectDraw(c.Request.Context(), adminActor(c), postdomain.DarkPostID(c.Param("id")), req.Reason)
+	h.respondDraw(c, "admin reject draw failed", draw, err)
+}
+
+// HideDraw は公開中のおみくじを非公開にし、遷移後のおみくじを返す。
+func (h *AdminHandler) HideDraw(c *gin.Context) {
+	draw, err := h.usecase.HideDraw(c.Request.Context(), adminActor(c), postdomain.DarkPostID(c.Param("id")))
+	h.respondDraw(c, "admin hide draw failed", draw, err)
+}
+
+// ExpireDraw は公開中のおみくじの公開期間を終え、遷移後のおみくじを返す。
+func (h *AdminHandler) ExpireDraw(c *gin.Context) {
+	draw, err := h.usecase.ExpireDraw(c.Request.Context(), adminActor(c), postdomain.DarkPostID(c.Param("id")))
+	h.respondDraw(c, "admin expire draw failed", draw, err)
+}
+
+func (h *AdminHandler) respondDraw(c *gin.Context, logMessage string, draw *drawdomain.Draw, err error) {
+	if err != nil {
+		respondError(c, logMessage, err)
+		return
+	}
+	c.JSON(http.StatusOK, newAdminDrawResponse(draw))
+}
+
+// queryLimit は limit クエリを読む。省略時は 0（ユースケースの既定）、不正なら 400 を返して false。
+func queryLimit(c *gin.Context) (int, bool) {
+	raw := c.Query("limit")
+	if raw == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		writeError(c, apiError{status: http.StatusBadRequest, code: CodeInvalidRequest, message: messageInvalidLimit})
+		return 0, false
+	}
+	return n, true
+}
+
+func newAdminPostResponse(p *postdomain.Post) AdminPostResponse {
+	return AdminPostResponse{PostID: string(p.ID()), Content: string(p.Content()), Status: string(p.Status())}
+}
+
+func newAdminDrawResponse(d *drawdomain.Draw) AdminDrawResponse {
+	return AdminDrawResponse{
+		PostID:        string(d.PostID()),
+		ShareID:       string(d.ShareID()),
+		Result:        string(d.Result()),
+		Status:        string(d.Status()),
+		PromptVersion: d.PromptVersion(),
+	}
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
+	postdomain "backend/internal/domain/post"
+	"backend/internal/domain/report"
+	"backend/internal/port/repository"
+	adminusecase "backend/internal/usecase/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testAdminToken = "admin-secret"
+
+func TestAdminAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{name: "missing header", status: http.StatusUnauthorized},
+		{name: "wrong scheme", header: "Basic " + testAdminToken, status: http.StatusUnauthorized},
+		{name: "wrong token", header: "Bearer nope", status: http.StatusUnauthorized},
+		{name: "valid token", header: "bearer " + testAdminToken, status: http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			operator := newStubAdminOperator(t)
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/admin/posts", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			newAdminRouter(operator).ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d but got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+			if tc.status == http.StatusUnauthorized {
+				var got errorResponse
+				decodeBody(t, rec.Body, &got)
+				if got.Code != CodeUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+					t.Fatalf("unexpected unauthorized response: %+v %v", got, rec.Header())
+				}
+				if operator.actor != "" {
+					t.Fatalf("usecase should not be called without credentials")
+				}
+				return
+			}
+			// 認証した操作者がユースケースへ渡る
+			if operator.actor != "ops" {
+				t.Fatalf("unexpected actor: %q", operator.actor)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_ListPosts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	operator := newStubAdminOperator(t)
+	rec := adminRequest(newAdminRouter(operator), http.MethodGet, "/admin/posts?status=ready&limit=1&cursor=abc", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 but got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got AdminPostListResponse
+	decodeBody(t, rec.Body, &got)
+	if len(got.Posts) != 1 || got.Posts[0].Content != "content" || got.NextCursor != "next" {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+	if operator.status != postdomain.StatusReady || operator.cursor != "abc" || operator.limit != 1 {
+		t.Fatalf("query not passed through: %+v", operator)
+	}
+
+	for _, query := range []string{"?limit=0", "?limit=x"} {
+		rec := adminRequest(newAdminRouter(operator), http.MethodGet, "/admin/posts"+query, "")
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400 but got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestAdminHandler_GetPost(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rec := adminRequest(newAdminRouter(newStubAdminOperator(t)), http.MethodGet, "/admin/posts/post-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 but got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got AdminPostDetailResponse
+	decodeBody(t, rec.Body, &got)
+	if got.Post.PostID != "post-1" || got.Draw == nil || got.Draw.Status != "verified" || len(got.Outcomes) != 1 || got.Outcomes[0].Reason != "禁止語を含む" {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+}
+
+func TestAdminHandler_ListReports(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	operator := newStubAdminOperator(t)
+
+	rec := adminRequest(newAdminRouter(operator), http.MethodGet, "/admin/reports?limit=5", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 but got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got AdminReportListResponse
+	decodeBody(t, rec.Body, &got)
+	if len(got.Reports) != 1 || got.Reports[0].PostID != "post-1" || got.Reports[0].ReportCount != 2 || got.Reports[0].Reasons["spam"] != 2 {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+	if operator.actor != "ops" || operator.limit != 5 {
+		t.Fatalf("unexpected operator input: %+v", operator)
+	}
+}
+
+func TestAdminHandler_Actions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   ErrorCode
+	}{
+		{name: "hide", method: http.MethodPost, path: "/admin/draws/post-1/hide", status: http.StatusOK},
+		{name: "approve verified", method: http.MethodPost, path: "/admin/draws/post-1/approve", status: http.StatusConflict, code: CodeInvalidTransition},
+		{name: "reject with reason", method: http.MethodPost, path: "/admin/draws/post-1/reject", body: `{"reason":"spam"}`, status: http.StatusConflict, code: CodeInvalidTransition},
+		{name: "reject invalid json", method: http.MethodPost, path: "/admin/draws/post-1/reject", body: `{"reason":`, status: http.StatusBadRequest, code: CodeInvalidRequest},
+		{name: "hide missing draw", method: http.MethodPost, path: "/admin/draws/missing/hide", status: http.StatusNotFound, code: CodeDrawNotFound},
+		{name: "expire", method: http.MethodPost, path: "/admin/draws/post-1/expire", status: http.StatusOK},
+		{name: "expire missing draw", method: http.MethodPost, path: "/admin/draws/missing/expire", status: http.StatusNotFound, code: CodeDrawNotFound},
+		{name: "requeue", method: http.MethodPost, path: "/admin/posts/post-1/requeue", status: http.StatusAccepted},
+		{name: "requeue scheduled", method: http.MethodPost, path: "/admin/posts/post-queued/requeue", status: http.StatusConflict, code: CodeJobConflict},
+		{name: "delete", method: http.MethodDelete, path: "/admin/posts/post-1", status: http.StatusNoContent},
+		{name: "delete missing", method: http.MethodDelete, path: "/admin/posts/missing", status: http.StatusNotFound, code: CodePostNotFound},
+		{name: "resolve reports", method: http.MethodPost, path: "/admin/reports/post-1/resolve", status: http.StatusNoContent},
+		{name: "resolve reports missing draw", method: http.MethodPost, path: "/admin/reports/missing/resolve", status: http.StatusNotFound, code: CodeDrawNotFound},
+		{name: "list reports invalid limit", method: http.MethodGet, path: "/admin/reports?limit=0", status: http.StatusBadRequest, code: CodeInvalidRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := adminRequest(newAdminRouter(newStubAdminOperator(t)), tc.method, tc.path, tc.body)
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d but got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+			if tc.code != "" {
+				var got errorResponse
+				decodeBody(t, rec.Body, &got)
+				if got.Code != tc.code {
+					t.Fatalf("unexpected code: %q", got.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestRouter_AdminRequiresAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// 認証が無ければ管理 API は登録しない
+	router := NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(&stubPostUsecaseForRouter{}), WithAdmin(NewAdminHandler(newStubAdminOperator(t)), nil))
+	rec := adminRequest(router, http.MethodGet, "/admin/posts", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 but got %d", rec.Code)
+	}
+}
+
+func newAdminRouter(operator AdminOperator) *gin.Engine {
+	return NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(&stubPostUsecaseForRouter{}),
+		WithAdmin(NewAdminHandler(operator), AdminAuth(stubAdminVerifier{})))
+}
+
+// adminRequest は管理トークン付きでリクエストを送る。
+func adminRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// stubAdminVerifier は testAdminToken だけを ops として通す。
+type stubAdminVerifier struct{}
+
+func (stubAdminVerifier) Verify(ctx context.Context, token string) (string, error) {
+	if token != testAdminToken {
+		return "", errors.New("invalid token")
+	}
+	return "ops", nil
+}
+
+// stubAdminOperator は post-1 の投稿と公開中のおみくじだけを持つ管理ユースケース。
+type stubAdminOperator struct {
+	post     *postdomain.Post
+	draw     *drawdomain.Draw
+	outcomes []*outcome.Outcome
+	actor    string
+	status   postdomain.Status
+	cursor   string
+	limit    int
+}
+
+func newStubAdminOperator(t *testing.T) *stubAdminOperator {
+	t.Helper()
+	p, err := postdomain.New("post-1", "content")
+	if err != nil {
+		t.Fatalf("post.New() error = %v", err)
+	}
+	rejected, err := outcome.New("post-1", "fortune-v1", drawdomain.StatusRejected, "禁止語を含む", "x")
+	if err != nil {
+		t.Fatalf("outcome.New() error = %v", err)
+	}
+	return &stubAdminOperator{post: p, draw: newVerifiedDraw(t, "post-1", "大吉"), outcomes: []*outcome.Outcome{rejected}}
+}
+
+func (s *stubAdminOperator) ListPosts(ctx context.Context, actor string, status postdomain.Status, cursor string, limit int) (*adminusecase.PostPage, error) {
+	s.actor, s.status, s.cursor, s.limit = actor, status, cursor, limit
+	return &adminusecase.PostPage{Posts: []*postdomain.Post{s.post}, NextCursor: "next"}, nil
+}
+
+func (s *stubAdminOperator) GetPost(ctx context.Context, actor string, id postdomain.DarkPostID) (*adminusecase.PostDetail, error) {
+	if id != s.post.ID() {
+		return nil, repository.ErrPostNotFound
+	}
+	return &adminusecase.PostDetail{Post: s.post, Draw: s.draw, Outcomes: s.outcomes}, nil
+}
+
+func (s *stubAdminOperator) ApproveDraw(ctx context.Context, actor string, id postdomain.DarkPostID) (*drawdomain.Draw, error) {
+	return s.transition(id, (*drawdomain.Draw).MarkVerified)
+}
+
+func (s *stubAdminOperator) RejectDraw(ctx context.Context, actor string, id postdomain.DarkPostID, reason string) (*drawdomain.Draw, error) {
+	return s.transition(id, (*drawdomain.Draw).MarkRejected)
+}
+
+func (s *stubAdminOperator) HideDraw(ctx context.Context, actor string, id postdomain.DarkPostID) (*drawdomain.Draw, error) {
+	return s.transition(id, (*drawdomain.Draw).MarkHidden)
+}
+
+func (s *stubAdminOperator) ExpireDraw(ctx context.Context, actor string, id postdomain.DarkPostID) (*drawdomain.Draw, error) {
+	return s.transition(id, (*drawdomain.Draw).MarkExpired)
+}
+
+func (s *stubAdminOperator) RequeuePost(ctx context.Context, actor string, id postdomain.DarkPostID) error {
+	if id == "post-queued" {
+		return adminusecase.ErrJobAlreadyScheduled
+	}
+	if id != s.post.ID() {
+		return repository.ErrPostNotFound
+	}
+	return nil
+}
+
+func (s *stubAdminOperator) DeletePost(ctx context.Context, actor string, id postdomain.DarkPostID) error {
+	if id != s.post.ID() {
+		return repository.ErrPostNotFound
+	}
+	return nil
+}
+
+func (s *stubAdminOperator) ListReports(ctx context.Context, actor string, limit int) ([]*report.Review, error) {
+	s.actor, s.limit = actor, limit
+	return []*report.Review{{PostID: "post-1", Count: 2, Reasons: map[report.Reason]int64{report.ReasonSpam: 2}, LastReportedAt: time.Now()}}, nil
+}
+
+func (s *stubAdminOperator) ResolveReports(ctx context.Context, actor string, id postdomain.DarkPostID) error {
+	if id != s.draw.PostID() {
+		return repository.ErrDrawNotFound
+	}
+	return nil
+}
+
+func (s *stubAdminOperator) transition(id postdomain.DarkPostID, change func(*drawdomain.Draw) error) (*drawdomain.Draw, error) {
+	if id != s.draw.PostID() {
+		return nil, repository.ErrDrawNotFound
+	}
+	if err := change(s.draw); err != nil {
+		return nil, err
+	}
+	return s.draw, nil
+}
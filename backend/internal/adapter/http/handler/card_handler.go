@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	drawdomain "backend/internal/domain/draw"
+	cardusecase "backend/internal/usecase/card"
+
+	"github.com/gin-gonic/gin"
+)
+
+// shareCardCacheControl は共有カード画像のキャッシュ方針。SNS のクローラーが取り直す頻度に合わせ、本文より長めにする。
+const shareCardCacheControl = "public, max-age=3600"
+
+// ShareCardProvider は共有カード画像を返すユースケースの契約。
+type ShareCardProvider interface {
+	Card(ctx context.Context, id drawdomain.ShareID) (*cardusecase.Image, error)
+}
+
+// CardHandler はおみくじの共有カード画像（OGP 画像）を返す。
+type CardHandler struct {
+	usecase ShareCardProvider
+}
+
+// NewCardHandler は CardHandler を生成する。
+func NewCardHandler(usecase ShareCardProvider) *CardHandler {
+	return &CardHandler{usecase: usecase}
+}
+
+// RegisterV1 は v1 の共有カードのルートを登録する。rateLimit があればハンドラーの前に挟む。
+func (h *CardHandler) RegisterV1(r gin.IRoutes, rateLimit gin.HandlerFunc) {
+	r.GET("/draws/:id/card.png", withOptional(rateLimit, h.GetCard)...)
+}
+
+/**
+ * 共有 ID に対応するおみくじのカード画像を PNG で返す。検証済みでなければ 404。
+ * ETag を付け、If-None-Match が一致すれば 304 を返す。
+ */
+func (h *CardHandler) GetCard(c *gin.Context) {
+	img, err := h.usecase.Card(c.Request.Context(), drawdomain.ShareID(c.Param("id")))
+	if err != nil {
+		respondError(c, "share card failed", err)
+		return
+	}
+
+	c.Header("ETag", img.ETag)
+	c.Header("Cache-Control", shareCardCacheControl)
+	if etagMatches(c.GetHeader("If-None-Match"), img.ETag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "image/png", img.PNG)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/port/repository"
+	cardusecase "backend/internal/usecase/card"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCardHandler_GetCard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	id := drawdomain.NewShareID()
+	cards := &stubShareCards{images: map[drawdomain.ShareID]*cardusecase.Image{
+		id: {PNG: []byte("\x89PNG"), ETag: `"card-1"`},
+	}}
+	router := NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(&stubCreatePostUsecase{}), WithShareCards(NewCardHandler(cards)))
+	get := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/v1/draws/"+string(id)+"/card.png", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/png" || rec.Body.String() != "\x89PNG" {
+		t.Fatalf("unexpected response: %d %v", rec.Code, rec.Header())
+	}
+	if rec.Header().Get("ETag") != `"card-1"` || rec.Header().Get("Cache-Control") != shareCardCacheControl {
+		t.Fatalf("expected caching headers, got %v", rec.Header())
+	}
+
+	if rec := get("/v1/draws/"+string(id)+"/card.png", `"card-1"`); rec.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", rec.Code)
+	}
+
+	rec = get("/v1/draws/"+string(drawdomain.NewShareID())+"/card.png", "")
+	if rec.Code != http.StatusNotFound || decodeErrorResponse(t, rec).Code != CodeDrawNotFound {
+		t.Fatalf("expected draw_not_found, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	cards.err = errors.New("font broken")
+	if rec := get("/v1/draws/"+string(id)+"/card.png", ""); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+}
+
+type stubShareCards struct {
+	images map[drawdomain.ShareID]*cardusecase.Image
+	err    error
+}
+
+func (s *stubShareCards) Card(ctx context.Context, id drawdomain.ShareID) (*cardusecase.Image, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	img, ok := s.images[id]
+	if !ok {
+		return nil, repository.ErrDrawNotFound
+	}
+	return img, nil
+}
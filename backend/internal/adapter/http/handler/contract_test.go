@@ -0,0 +1,380 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/adapter/http/openapi/openapitest"
+	drawdomain "backend/internal/domain/draw"
+	postdomain "backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/port/notifier"
+	"backend/internal/port/ratelimit"
+	cardusecase "backend/internal/usecase/card"
+	postusecase "backend/internal/usecase/post"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ginParamPattern は gin のパス引数（:id）を OpenAPI の表記（{id}）へ直すための正規表現。
+var ginParamPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// 契約テスト用に、任意設定をすべて有効にしたルーターを組み立てる。
+// 共有カードは draws が返すおみくじの共有 ID で画像を返す。
+func newContractRouter(draws *stubFortuneUsecase, posts CreatePostExecutor, opts ...RouterOption) *gin.Engine {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok","checks":{"firestore":{"status":"ok"}}}`))
+	})
+	fail := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":"fail","checks":{"firestore":{"status":"fail","error":"unreachable"}}}`))
+	})
+	cards := &stubShareCards{images: map[drawdomain.ShareID]*cardusecase.Image{}}
+	if draws.draw != nil {
+		cards.images[draws.draw.ShareID()] = &cardusecase.Image{PNG: []byte("\x89PNG"), ETag: `"card"`}
+	}
+	base := []RouterOption{
+		WithMetrics(func(c *gin.Context) { c.Next() }),
+		WithHealth(ok, fail),
+		WithShareCards(NewCardHandler(cards)),
+		WithPostEvents(NewPostEventsHandler(&stubPostWatcher{statuses: []notifier.Status{notifier.StatusQueued, notifier.StatusReady}})),
+		WithReactions(NewReactionHandler(&stubReactor{draw: draws.draw})),
+		WithReports(NewReportHandler(&stubReporter{draw: draws.draw})),
+	}
+	counter := &stubReactionCounter{counts: reaction.Counts{reaction.KindSaved: 1}}
+	return NewRouter(NewDrawHandler(draws).WithReactions(counter), NewPostHandler(posts), append(base, opts...)...)
+}
+
+func TestContract_RoutesMatchSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validator := openapitest.New(t)
+
+	limit := func(c *gin.Context) { c.Next() }
+	admin := WithAdmin(NewAdminHandler(newStubAdminOperator(t)), AdminAuth(stubAdminVerifier{}))
+	router := newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}, WithRateLimits(RateLimits{Posts: limit, Draws: limit, Cards: limit, PostEvents: limit, Reactions: limit, Reports: limit}), admin)
+
+	var registered []string
+	for _, route := range router.Routes() {
+		registered = append(registered, route.Method+" "+ginParamPattern.ReplaceAllString(route.Path, "{$1}"))
+	}
+	var specified []string
+	for path, item := range validator.Doc().Paths.Map() {
+		for method := range item.Operations() {
+			specified = append(specified, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(registered)
+	sort.Strings(specified)
+
+	// ハンドラーを足したら定義にも足す。どちらか一方だけの変更はここで落ちる
+	if strings.Join(registered, "\n") != strings.Join(specified, "\n") {
+		t.Fatalf("routes and spec diverge\nrouter:\n  %s\nspec:\n  %s", strings.Join(registered, "\n  "), strings.Join(specified, "\n  "))
+	}
+}
+
+func TestContract_ResponsesMatchSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validator := openapitest.New(t)
+
+	limited := &fixedCounter{windows: map[time.Duration]ratelimit.Window{
+		time.Minute: {Count: 2, ResetAt: time.Now().Add(30 * time.Second)},
+	}}
+	shared := newVerifiedDraw(t, "post-shared", "大吉")
+	cases := []struct {
+		name   string
+		router *gin.Engine
+		req    func() *http.Request
+		status int
+	}{
+		{
+			name:   "random draw",
+			router: newContractRouter(&stubFortuneUsecase{draw: newVerifiedDraw(t, "post-1", "大吉")}, &stubCreatePostUsecase{}),
+			req:    getRequest("/v1/draws/random"),
+			status: http.StatusOK,
+		},
+		{
+			name:   "legacy random draw",
+			router: newContractRouter(&stubFortuneUsecase{draw: newVerifiedDraw(t, "post-1", "大吉")}, &stubCreatePostUsecase{}),
+			req:    getRequest("/draws/random"),
+			status: http.StatusOK,
+		},
+		{
+			name:   "shared draw",
+			router: newContractRouter(&stubFortuneUsecase{draw: shared}, &stubCreatePostUsecase{}),
+			req:    getRequest("/v1/draws/" + string(shared.ShareID())),
+			status: http.StatusOK,
+		},
+		{
+			name:   "shared draw not found",
+			router: newContractRouter(&stubFortuneUsecase{draw: shared}, &stubCreatePostUsecase{}),
+			req:    getRequest("/v1/draws/" + string(drawdomain.NewShareID())),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "share card",
+			router: newContractRouter(&stubFortuneUsecase{draw: shared}, &stubCreatePostUsecase{}),
+			req:    getRequest("/v1/draws/" + string(shared.ShareID()) + "/card.png"),
+			status: http.StatusOK,
+		},
+		{
+			name:   "share card not found",
+			router: newContractRouter(&stubFortuneUsecase{draw: shared}, &stubCreatePostUsecase{}),
+			req:    getRequest("/v1/draws/" + string(drawdomain.NewShareID()) + "/card.png"),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "reaction created",
+			router: newContractRouter(&stubFortuneUsecase{draw: shared}, &stubCreatePostUsecase{}),
+			req:    reactionJSON(shared.ShareID(), "visitor-1", `{"kind":"accurate"}`),
+			status: http.StatusCreated,
+		},
+		{
+			name:   "reaction without visitor token",
+			router: newContractRouter(&stubFortuneUsecase{draw: shared}, &stubCreatePostUsecase{}),
+			req:    reactionJSON(shared.ShareID(), "", `{"kind":"accurate"}`),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "reaction to unknown draw",
+			router: newContractRouter(&stubFortuneUsecase{draw: shared}, &stubCreatePostUsecase{}),
+			req:    reactionJSON(drawdomain.NewShareID(), "visitor-1", `{"kind":"accurate"}`),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "report accepted",
+			router: newContractRouter(&stubFortuneUsecase{draw: shared}, &stubCreatePostUsecase{}),
+			req:    reportJSON(shared.ShareID(), "visitor-1", `{"reason":"spam"}`),
+			status: http.StatusAccepted,
+		},
+		{
+			name:   "report with unknown reason",
+			router: newContractRouter(&stubFortuneUsecase{draw: shared}, &stubCreatePostUsecase{}),
+			req:    reportJSON(shared.ShareID(), "visitor-1", `{"reason":"boring"}`),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "no draws",
+			router: newContractRouter(&stubFortuneUsecase{err: drawdomain.ErrEmptyResult}, &stubCreatePostUsecase{}),
+			req:    getRequest("/v1/draws/random"),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "draw rate limited",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}, WithRateLimits(RateLimits{Draws: RateLimit(limited, RateLimitPolicy{Name: "draws", PerMinute: 1})})),
+			req:    getRequest("/v1/draws/random"),
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "post created",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}),
+			req:    postJSON(`{"post_id":"dark-1","content":"hello"}`),
+			status: http.StatusCreated,
+		},
+		{
+			name: "post in crisis",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{
+				output: &postusecase.CreatePostOutput{DarkPostID: "dark-1", Flagged: true, Crisis: true},
+			}),
+			req:    postJSON(`{"post_id":"dark-1","content":"hello"}`),
+			status: http.StatusCreated,
+		},
+		{
+			name: "post flagged",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{
+				err: postusecase.ErrPostFlagged,
+			}),
+			req:    postJSON(`{"post_id":"dark-1","content":"hello"}`),
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "post events",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}),
+			req:    getRequest("/v1/posts/dark-1/events"),
+			status: http.StatusOK,
+		},
+		{
+			name:   "invalid json",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}),
+			req:    postJSON(`{"post_id":`),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "content too long",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{err: postdomain.ErrContentTooLong}),
+			req:    postJSON(`{"post_id":"dark-1","content":"hello"}`),
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "post conflict",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{err: postusecase.ErrPostAlreadyExists}),
+			req:    postJSON(`{"post_id":"dark-1","content":"hello"}`),
+			status: http.StatusConflict,
+		},
+		{
+			name:   "body too large",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}),
+			req:    postJSON(`{"post_id":"dark-1","content":"` + strings.Repeat("a", maxPostBodyBytes) + `"}`),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "liveness",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}),
+			req:    getRequest("/healthz"),
+			status: http.StatusOK,
+		},
+		{
+			name:   "readiness failing",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}),
+			req:    getRequest("/readyz"),
+			status: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "admin list posts",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodGet, "/admin/posts?status=pending&limit=20", ""),
+			status: http.StatusOK,
+		},
+		{
+			name:   "admin without token",
+			router: newAdminContractRouter(t),
+			req:    getRequest("/admin/posts"),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "admin get post",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodGet, "/admin/posts/post-1", ""),
+			status: http.StatusOK,
+		},
+		{
+			name:   "admin get missing post",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodGet, "/admin/posts/missing", ""),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "admin requeue post",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodPost, "/admin/posts/post-1/requeue", ""),
+			status: http.StatusAccepted,
+		},
+		{
+			name:   "admin delete post",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodDelete, "/admin/posts/post-1", ""),
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "admin hide draw",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodPost, "/admin/draws/post-1/hide", ""),
+			status: http.StatusOK,
+		},
+		{
+			name:   "admin expire draw",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodPost, "/admin/draws/post-1/expire", ""),
+			status: http.StatusOK,
+		},
+		{
+			name:   "admin reject verified draw",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodPost, "/admin/draws/post-1/reject", `{"reason":"spam"}`),
+			status: http.StatusConflict,
+		},
+		{
+			name:   "admin approve verified draw",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodPost, "/admin/draws/post-1/approve", ""),
+			status: http.StatusConflict,
+		},
+		{
+			name:   "admin list reports",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodGet, "/admin/reports?limit=20", ""),
+			status: http.StatusOK,
+		},
+		{
+			name:   "admin resolve reports",
+			router: newAdminContractRouter(t),
+			req:    adminContractRequest(http.MethodPost, "/admin/reports/post-1/resolve", ""),
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "spec",
+			router: newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}),
+			req:    getRequest("/openapi.json"),
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			validator.Wrap(t, tc.router).ServeHTTP(rec, tc.req())
+			if rec.Code != tc.status {
+				t.Fatalf("expected %d, got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+// newAdminContractRouter は管理 API を有効にした契約テスト用のルーターを組み立てる。
+func newAdminContractRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	return newContractRouter(&stubFortuneUsecase{}, &stubCreatePostUsecase{}, WithAdmin(NewAdminHandler(newStubAdminOperator(t)), AdminAuth(stubAdminVerifier{})))
+}
+
+// adminContractRequest は管理トークン付きのリクエストを作る。
+func adminContractRequest(method, path, body string) func() *http.Request {
+	return func() *http.Request {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		return req
+	}
+}
+
+func getRequest(path string) func() *http.Request {
+	return func() *http.Request {
+		return httptest.NewRequest(http.MethodGet, path, nil)
+	}
+}
+
+func postJSON(body string) func() *http.Request {
+	return func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v1/posts", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+}
+
+func reactionJSON(id drawdomain.ShareID, visitorToken, body string) func() *http.Request {
+	return drawActionJSON(id, "reactions", visitorToken, body)
+}
+
+func reportJSON(id drawdomain.ShareID, visitorToken, body string) func() *http.Request {
+	return drawActionJSON(id, "reports", visitorToken, body)
+}
+
+// drawActionJSON は訪問者トークン付きで /v1/draws/{id}/{action} へ JSON を送るリクエストを作る。
+func drawActionJSON(id drawdomain.ShareID, action, visitorToken, body string) func() *http.Request {
+	return func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v1/draws/"+string(id)+"/"+action, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if visitorToken != "" {
+			req.Header.Set(VisitorTokenHeader, visitorToken)
+		}
+		return req
+	}
+}
@@ -2,8 +2,12 @@ package handler
 
 import (
 	"context"
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	drawdomain "backend/internal/domain/draw"
 
@@ -12,17 +16,23 @@ import (
 
 const (
 	messageDrawsEmpty    = "no verified draws available"
+	messageDrawNotFound  = "draw not found"
 	messageInternalError = "internal server error"
 )
 
-// FortuneUsecase は検証済みのおみくじを 1 件返すユースケースの契約。
+// sharedDrawCacheControl は共有リンクのキャッシュ方針。非公開になった結果が長く残らないよう短めにする。
+const sharedDrawCacheControl = "public, max-age=300"
+
+// FortuneUsecase は検証済みのおみくじを返すユースケースの契約。
 type FortuneUsecase interface {
 	DrawFortune(ctx context.Context) (*drawdomain.Draw, error)
+	SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error)
 }
 
 // DrawHandler はおみくじ関連の HTTP ハンドラをまとめる。
 type DrawHandler struct {
-	usecase FortuneUsecase
+	usecase   FortuneUsecase
+	reactions ReactionCounter
 }
 
 // NewDrawHandler は DrawHandler を生成する。
@@ -30,36 +40,143 @@ func NewDrawHandler(usecase FortuneUsecase) *DrawHandler {
 	return &DrawHandler{usecase: usecase}
 }
 
-// DrawResponse は GET /draws/random のレスポンス。
+// WithReactions はレスポンスに添える反応数の取得先を設定する。nil なら反応数を載せない。
+func (h *DrawHandler) WithReactions(counter ReactionCounter) *DrawHandler {
+	h.reactions = counter
+	return h
+}
+
+// RegisterV1 は v1 のおみくじ関連ルートを登録する。rateLimit があればハンドラーの前に挟む。
+func (h *DrawHandler) RegisterV1(r gin.IRoutes, rateLimit gin.HandlerFunc) {
+	r.GET("/draws/random", withOptional(rateLimit, h.GetRandomDraw)...)
+	r.GET("/draws/:id", withOptional(rateLimit, h.GetSharedDraw)...)
+}
+
+// RegisterLegacy はバージョン無しの GET /draws/random を、配布済みのフロントエンド向けに元のレスポンスの形で登録する。
+func (h *DrawHandler) RegisterLegacy(r gin.IRoutes, rateLimit gin.HandlerFunc) {
+	r.GET("/draws/random", withOptional(rateLimit, h.GetRandomDrawLegacy)...)
+}
+
+// DrawResponse は GET /v1/draws/random のレスポンス。ShareID は共有リンク（GET /v1/draws/:id）に使う。
+// 闇投稿の ID は進み具合の購読（GET /v1/posts/:id/events）に使えてしまうため含めない。
 type DrawResponse struct {
+	ShareID   string                  `json:"share_id,omitempty"`
+	Result    string                  `json:"result"`
+	Status    string                  `json:"status"`
+	Reactions *ReactionCountsResponse `json:"reactions,omitempty"`
+}
+
+// LegacyDrawResponse は非推奨の GET /draws/random のレスポンス。v1 以前の形のまま変えない。
+type LegacyDrawResponse struct {
 	PostID string `json:"post_id"`
 	Result string `json:"result"`
 	Status string `json:"status"`
 }
 
-type errorResponse struct {
-	Message string `json:"message"`
+// SharedDrawResponse は GET /draws/:id のレスポンス。闇投稿の ID は含めない。
+type SharedDrawResponse struct {
+	ShareID   string                  `json:"share_id"`
+	Result    string                  `json:"result"`
+	Status    string                  `json:"status"`
+	Reactions *ReactionCountsResponse `json:"reactions,omitempty"`
 }
 
 // GetRandomDraw は Verified な結果を 1 件ランダムに返す。
 func (h *DrawHandler) GetRandomDraw(c *gin.Context) {
 	draw, err := h.usecase.DrawFortune(c.Request.Context())
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, "draw fortune failed", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, DrawResponse{
+		ShareID:   string(draw.ShareID()),
+		Result:    string(draw.Result()),
+		Status:    string(draw.Status()),
+		Reactions: h.reactionCounts(c, draw),
+	})
+}
+
+// GetRandomDrawLegacy は Verified な結果を 1 件ランダムに、v1 以前のレスポンスの形で返す。
+func (h *DrawHandler) GetRandomDrawLegacy(c *gin.Context) {
+	draw, err := h.usecase.DrawFortune(c.Request.Context())
+	if err != nil {
+		respondError(c, "draw fortune failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, LegacyDrawResponse{
 		PostID: string(draw.PostID()),
 		Result: string(draw.Result()),
 		Status: string(draw.Status()),
 	})
 }
 
-func (h *DrawHandler) handleError(c *gin.Context, err error) {
-	if errors.Is(err, drawdomain.ErrEmptyResult) {
-		c.JSON(http.StatusNotFound, errorResponse{Message: messageDrawsEmpty})
+/**
+ * 共有 ID に対応する検証済みのおみくじを返す。それ以外は 404。
+ * 内容から作った ETag を付け、If-None-Match が一致すれば 304 を返す。
+ */
+func (h *DrawHandler) GetSharedDraw(c *gin.Context) {
+	draw, err := h.usecase.SharedDraw(c.Request.Context(), drawdomain.ShareID(c.Param("id")))
+	if err != nil {
+		respondError(c, "shared draw failed", err)
+		return
+	}
+
+	resp := SharedDrawResponse{
+		ShareID:   string(draw.ShareID()),
+		Result:    string(draw.Result()),
+		Status:    string(draw.Status()),
+		Reactions: h.reactionCounts(c, draw),
+	}
+	etag := sharedDrawETag(resp)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", sharedDrawCacheControl)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
 		return
 	}
-	c.JSON(http.StatusInternalServerError, errorResponse{Message: messageInternalError})
+	c.JSON(http.StatusOK, resp)
+}
+
+/**
+ * おみくじの反応数を返す。取得先が未設定、または取得に失敗した場合は nil（レスポンスから省く）。
+ * 反応数は添え物なので、取れなくてもおみくじ自体は返す。
+ */
+func (h *DrawHandler) reactionCounts(c *gin.Context, draw *drawdomain.Draw) *ReactionCountsResponse {
+	if h.reactions == nil {
+		return nil
+	}
+	counts, err := h.reactions.Counts(c.Request.Context(), draw.PostID())
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "reaction counts unavailable", slog.Any("error", err))
+		return nil
+	}
+	resp := newReactionCountsResponse(counts)
+	return &resp
+}
+
+/**
+ * レスポンスの中身から強い ETag を作る。反応数が変われば別の ETag になる。
+ */
+func sharedDrawETag(resp SharedDrawResponse) string {
+	key := resp.ShareID + "\x00" + resp.Result + "\x00" + resp.Status
+	if r := resp.Reactions; r != nil {
+		key += "\x00" + strconv.FormatInt(r.Accurate, 10) + "," + strconv.FormatInt(r.Saved, 10) + "," + strconv.FormatInt(r.Scary, 10)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+/**
+ * If-None-Match のいずれかが etag と一致するかを返す（弱い比較。* はすべてに一致）。
+ */
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
 }
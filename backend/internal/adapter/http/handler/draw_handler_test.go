@@ -12,6 +12,7 @@ import (
 
 	drawdomain "backend/internal/domain/draw"
 	"backend/internal/domain/post"
+	"backend/internal/port/repository"
 	postusecase "backend/internal/usecase/post"
 
 	"github.com/gin-gonic/gin"
@@ -31,13 +32,17 @@ func TestDrawHandler_GetRandomDraw(t *testing.T) {
 			t.Fatalf("expected status %d but got %d", http.StatusOK, rec.Code)
 		}
 
+		// 闇投稿の ID は進み具合の購読に使えるため、v1 のレスポンスには含めない
+		if bytes.Contains(body.Bytes(), []byte("post_id")) {
+			t.Fatalf("v1 response should not expose post_id: %s", body.String())
+		}
 		var got DrawResponse
 		decodeBody(t, body, &got)
 
 		want := DrawResponse{
-			PostID: "post-success",
-			Result: "fortunes await",
-			Status: string(d.Status()),
+			ShareID: string(d.ShareID()),
+			Result:  "fortunes await",
+			Status:  string(d.Status()),
 		}
 
 		if got != want {
@@ -45,6 +50,23 @@ func TestDrawHandler_GetRandomDraw(t *testing.T) {
 		}
 	})
 
+	t.Run("legacy alias keeps the original shape", func(t *testing.T) {
+		d := newVerifiedDraw(t, "post-success", "fortunes await")
+		router := NewRouter(NewDrawHandler(&stubFortuneUsecase{draw: d}), NewPostHandler(&stubPostUsecaseForRouter{}))
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/draws/random", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d but got %d", http.StatusOK, rec.Code)
+		}
+
+		var got LegacyDrawResponse
+		decodeBody(t, rec.Body, &got)
+		if got != (LegacyDrawResponse{PostID: "post-success", Result: "fortunes await", Status: string(d.Status())}) {
+			t.Fatalf("unexpected response: %+v", got)
+		}
+	})
+
 	t.Run("draws depleted", func(t *testing.T) {
 		handler := NewDrawHandler(&stubFortuneUsecase{err: drawdomain.ErrEmptyResult})
 		router := NewRouter(handler, NewPostHandler(&stubPostUsecaseForRouter{}))
@@ -91,19 +113,93 @@ func (s *stubFortuneUsecase) DrawFortune(ctx context.Context) (*drawdomain.Draw,
 	return s.draw, s.err
 }
 
+func TestDrawHandler_GetSharedDraw(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	d := newVerifiedDraw(t, "post-shared", "fortunes await")
+	router := NewRouter(NewDrawHandler(&stubFortuneUsecase{draw: d}), NewPostHandler(&stubPostUsecaseForRouter{}))
+	get := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/v1/draws/"+string(d.ShareID()), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d but got %d", http.StatusOK, rec.Code)
+	}
+	var got SharedDrawResponse
+	decodeBody(t, rec.Body, &got)
+	if got != (SharedDrawResponse{ShareID: string(d.ShareID()), Result: "fortunes await", Status: string(drawdomain.StatusVerified)}) {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+	// 闇投稿の ID は共有リンクから辿れない
+	if bytes.Contains(rec.Body.Bytes(), []byte("post-shared")) {
+		t.Fatalf("post id leaked: %s", rec.Body.String())
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" || rec.Header().Get("Cache-Control") != sharedDrawCacheControl {
+		t.Fatalf("expected caching headers, got %v", rec.Header())
+	}
+
+	t.Run("not modified", func(t *testing.T) {
+		rec := get("/v1/draws/"+string(d.ShareID()), `"other", W/`+etag)
+		if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+			t.Fatalf("expected 304 without body, got %d %q", rec.Code, rec.Body.String())
+		}
+		if rec.Header().Get("ETag") != etag {
+			t.Fatalf("expected ETag on 304, got %q", rec.Header().Get("ETag"))
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rec := get("/v1/draws/"+string(drawdomain.NewShareID()), "")
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected status %d but got %d", http.StatusNotFound, rec.Code)
+		}
+		var got errorResponse
+		decodeBody(t, rec.Body, &got)
+		if got.Code != CodeDrawNotFound {
+			t.Fatalf("unexpected code: %q", got.Code)
+		}
+	})
+
+	t.Run("random route is not shadowed", func(t *testing.T) {
+		if rec := get("/v1/draws/random", ""); rec.Code != http.StatusOK || rec.Header().Get("ETag") != "" {
+			t.Fatalf("expected random draw, got %d %v", rec.Code, rec.Header())
+		}
+	})
+}
+
+func (s *stubFortuneUsecase) SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.draw == nil || s.draw.ShareID() != id {
+		return nil, repository.ErrDrawNotFound
+	}
+	return s.draw, nil
+}
+
 func newVerifiedDraw(t *testing.T, postID, result string) *drawdomain.Draw {
 	t.Helper()
 	d, err := drawdomain.New(post.DarkPostID(postID), drawdomain.FormattedContent(result))
 	if err != nil {
 		t.Fatalf("failed to create draw: %v", err)
 	}
-	d.MarkVerified()
+	if err := d.MarkVerified(); err != nil {
+		t.Fatalf("failed to mark draw verified: %v", err)
+	}
 	return d
 }
 
 func performRequest(router *gin.Engine) (*httptest.ResponseRecorder, *bytes.Buffer) {
 	rec := httptest.NewRecorder()
-	req := httptest.NewRequest(http.MethodGet, "/draws/random", nil)
+	req := httptest.NewRequest(http.MethodGet, "/v1/draws/random", nil)
 	router.ServeHTTP(rec, req)
 	return rec, rec.Body
 }
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	drawdomain "backend/internal/domain/draw"
+	postdomain "backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/domain/report"
+	"backend/internal/logging"
+	"backend/internal/port/repository"
+	adminusecase "backend/internal/usecase/admin"
+	postusecase "backend/internal/usecase/post"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorCode はエラーレスポンスに載せる機械可読なコード。フロントエンドはこの値で文言や挙動を切り替える。
+type ErrorCode string
+
+const (
+	CodeInvalidRequest    ErrorCode = "invalid_request"
+	CodeRequestTooLarge   ErrorCode = "request_too_large"
+	CodeContentEmpty      ErrorCode = "content_empty"
+	CodeContentTooLong    ErrorCode = "content_too_long"
+	CodeInvalidCharacters ErrorCode = "content_invalid_characters"
+	CodeRepetitiveContent ErrorCode = "content_repetitive"
+	CodePostConflict      ErrorCode = "post_already_exists"
+	CodePostFlagged       ErrorCode = "post_flagged"
+	CodePostNotFound      ErrorCode = "post_not_found"
+	CodeDrawsEmpty        ErrorCode = "draws_empty"
+	CodeDrawNotFound      ErrorCode = "draw_not_found"
+	CodeReactionInvalid   ErrorCode = "reaction_invalid"
+	CodeVisitorRequired   ErrorCode = "visitor_token_required"
+	CodeReactionConflict  ErrorCode = "reaction_already_exists"
+	CodeReportInvalid     ErrorCode = "report_invalid"
+	CodeUnauthorized      ErrorCode = "unauthorized"
+	CodeInvalidCursor     ErrorCode = "invalid_cursor"
+	CodeInvalidStatus     ErrorCode = "invalid_status"
+	CodePostNotPending    ErrorCode = "post_not_pending"
+	CodeJobConflict       ErrorCode = "job_already_scheduled"
+	CodeInvalidTransition ErrorCode = "invalid_status_transition"
+	CodeTooManyRequests   ErrorCode = "too_many_requests"
+	CodeInternal          ErrorCode = "internal_error"
+)
+
+/**
+ * すべてのエンドポイントで共通のエラーレスポンス。
+ * @param Code 機械可読なエラーコード
+ * @param Message 開発者向けの英語メッセージ（利用者への表示は Code から組み立てる）
+ * @param Details コードごとの補足情報（上限値など。無ければ省略）
+ * @param RequestID ログと突き合わせるためのリクエスト ID（X-Request-ID と同じ値。無ければ省略）
+ */
+type errorResponse struct {
+	Code      ErrorCode      `json:"code"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+}
+
+/**
+ * HTTP ステータスとコード、メッセージの組。
+ */
+type apiError struct {
+	status  int
+	code    ErrorCode
+	message string
+	details map[string]any
+}
+
+var (
+	errInvalidRequest       = apiError{status: http.StatusBadRequest, code: CodeInvalidRequest, message: messagePostInvalidRequest}
+	errRequestTooLarge      = apiError{status: http.StatusRequestEntityTooLarge, code: CodeRequestTooLarge, message: messagePostTooLarge, details: map[string]any{"max_bytes": maxPostBodyBytes}}
+	errPostFlagged          = apiError{status: http.StatusUnprocessableEntity, code: CodePostFlagged, message: messagePostFlagged}
+	errVisitorTokenRequired = apiError{status: http.StatusBadRequest, code: CodeVisitorRequired, message: messageVisitorTokenRequired}
+	errTooManyRequests      = apiError{status: http.StatusTooManyRequests, code: CodeTooManyRequests, message: messageTooManyRequests}
+	errInternal             = apiError{status: http.StatusInternalServerError, code: CodeInternal, message: messageInternalError}
+)
+
+/**
+ * 補足情報を差し替えた複製を返す。
+ */
+func (e apiError) withDetails(details map[string]any) apiError {
+	e.details = details
+	return e
+}
+
+/**
+ * ドメイン・ユースケースの番兵エラーと API エラーの対応表。上から順に errors.Is で照合する。
+ * ハンドラはここに載っていないエラーを 500 として扱う。
+ */
+var errorMappings = []struct {
+	target error
+	apiErr apiError
+}{
+	{postusecase.ErrNilInput, errInvalidRequest},
+	{postdomain.ErrEmptyContent, apiError{status: http.StatusBadRequest, code: CodeContentEmpty, message: messagePostInvalidRequest}},
+	{postdomain.ErrContentTooLong, apiError{status: http.StatusUnprocessableEntity, code: CodeContentTooLong, message: messagePostContentTooLong, details: map[string]any{"max_length": postdomain.MaxContentLength}}},
+	{postdomain.ErrInvalidCharacters, apiError{status: http.StatusUnprocessableEntity, code: CodeInvalidCharacters, message: messagePostInvalidCharacters}},
+	{postdomain.ErrRepetitiveContent, apiError{status: http.StatusUnprocessableEntity, code: CodeRepetitiveContent, message: messagePostRepetitiveContent}},
+	{postusecase.ErrPostAlreadyExists, apiError{status: http.StatusConflict, code: CodePostConflict, message: messagePostConflict}},
+	{postusecase.ErrJobAlreadyScheduled, apiError{status: http.StatusConflict, code: CodePostConflict, message: messagePostConflict}},
+	{postusecase.ErrPostFlagged, errPostFlagged},
+	{repository.ErrPostNotFound, apiError{status: http.StatusNotFound, code: CodePostNotFound, message: messagePostNotFound}},
+	{drawdomain.ErrEmptyResult, apiError{status: http.StatusNotFound, code: CodeDrawsEmpty, message: messageDrawsEmpty}},
+	{repository.ErrDrawNotFound, apiError{status: http.StatusNotFound, code: CodeDrawNotFound, message: messageDrawNotFound}},
+	{reaction.ErrInvalidKind, apiError{status: http.StatusBadRequest, code: CodeReactionInvalid, message: messageReactionInvalid}},
+	{reaction.ErrEmptyVisitor, errVisitorTokenRequired},
+	{repository.ErrReactionAlreadyExists, apiError{status: http.StatusConflict, code: CodeReactionConflict, message: messageReactionAlreadyExists}},
+	{report.ErrInvalidReason, apiError{status: http.StatusBadRequest, code: CodeReportInvalid, message: messageReportInvalid}},
+	{report.ErrEmptyVisitor, errVisitorTokenRequired},
+	{report.ErrEmptySource, apiError{status: http.StatusBadRequest, code: CodeInvalidRequest, message: messageClientIPRequired}},
+	{adminusecase.ErrInvalidCursor, apiError{status: http.StatusBadRequest, code: CodeInvalidCursor, message: messageInvalidCursor}},
+	{adminusecase.ErrInvalidStatus, apiError{status: http.StatusBadRequest, code: CodeInvalidStatus, message: messageInvalidPostStatus}},
+	{adminusecase.ErrPostNotPending, apiError{status: http.StatusConflict, code: CodePostNotPending, message: messagePostNotPending}},
+	{adminusecase.ErrJobAlreadyScheduled, apiError{status: http.StatusConflict, code: CodeJobConflict, message: messageJobAlreadyQueued}},
+	{drawdomain.ErrInvalidStatusTransition, apiError{status: http.StatusConflict, code: CodeInvalidTransition, message: messageInvalidTransition}},
+}
+
+/**
+ * エラーに対応する API エラーを返す。対応表に無いものは false。
+ */
+func lookupAPIError(err error) (apiError, bool) {
+	for _, m := range errorMappings {
+		if errors.Is(err, m.target) {
+			return m.apiErr, true
+		}
+	}
+	return apiError{}, false
+}
+
+/**
+ * ユースケースからのエラーを対応表に従ってレスポンスへ写し替える。
+ * 想定外のエラーは中身を返さず、ログにだけ残して 500 にする。
+ */
+func respondError(c *gin.Context, logMessage string, err error) {
+	apiErr, ok := lookupAPIError(err)
+	if !ok {
+		slog.ErrorContext(c.Request.Context(), logMessage, slog.Any("error", err))
+		apiErr = errInternal
+	}
+	writeError(c, apiErr)
+}
+
+/**
+ * エラーレスポンスを書き込み、以降のハンドラを止める。リクエスト ID があれば載せる。
+ */
+func writeError(c *gin.Context, apiErr apiError) {
+	c.AbortWithStatusJSON(apiErr.status, errorResponse{
+		Code:      apiErr.code,
+		Message:   apiErr.message,
+		Details:   apiErr.details,
+		RequestID: logging.RequestIDFromContext(c.Request.Context()),
+	})
+}
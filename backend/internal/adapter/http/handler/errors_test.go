@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	postdomain "backend/internal/domain/post"
+	"backend/internal/domain/report"
+	postusecase "backend/internal/usecase/post"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLookupAPIError(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   ErrorCode
+	}{
+		{postusecase.ErrNilInput, http.StatusBadRequest, CodeInvalidRequest},
+		{postdomain.ErrEmptyContent, http.StatusBadRequest, CodeContentEmpty},
+		{postdomain.ErrContentTooLong, http.StatusUnprocessableEntity, CodeContentTooLong},
+		{postdomain.ErrInvalidCharacters, http.StatusUnprocessableEntity, CodeInvalidCharacters},
+		{postdomain.ErrRepetitiveContent, http.StatusUnprocessableEntity, CodeRepetitiveContent},
+		{postusecase.ErrPostAlreadyExists, http.StatusConflict, CodePostConflict},
+		{postusecase.ErrJobAlreadyScheduled, http.StatusConflict, CodePostConflict},
+		{drawdomain.ErrEmptyResult, http.StatusNotFound, CodeDrawsEmpty},
+		{report.ErrEmptySource, http.StatusBadRequest, CodeInvalidRequest},
+		// ラップされていても照合できる
+		{fmt.Errorf("usecase: %w", postdomain.ErrContentTooLong), http.StatusUnprocessableEntity, CodeContentTooLong},
+	}
+	for _, tc := range cases {
+		got, ok := lookupAPIError(tc.err)
+		if !ok || got.status != tc.status || got.code != tc.code {
+			t.Fatalf("%v: expected %d %s, got %+v (ok=%v)", tc.err, tc.status, tc.code, got, ok)
+		}
+	}
+
+	if _, ok := lookupAPIError(errors.New("boom")); ok {
+		t.Fatalf("unknown errors should not be mapped")
+	}
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("post error carries code, details and request id", func(t *testing.T) {
+		router := NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(&stubCreatePostUsecase{err: postdomain.ErrContentTooLong}))
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBufferString(`{"post_id":"dark","content":"hello"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(RequestIDHeader, "req-err-1")
+		router.ServeHTTP(rec, req)
+
+		resp := decodeErrorResponse(t, rec)
+		if rec.Code != http.StatusUnprocessableEntity || resp.Code != CodeContentTooLong || resp.Message != messagePostContentTooLong {
+			t.Fatalf("unexpected response: %d %+v", rec.Code, resp)
+		}
+		if resp.RequestID != "req-err-1" {
+			t.Fatalf("expected request id in body, got %q", resp.RequestID)
+		}
+		if resp.Details["max_length"] != float64(postdomain.MaxContentLength) {
+			t.Fatalf("unexpected details: %+v", resp.Details)
+		}
+	})
+
+	t.Run("draw error shares the envelope", func(t *testing.T) {
+		router := NewRouter(NewDrawHandler(&stubFortuneUsecase{err: drawdomain.ErrEmptyResult}), NewPostHandler(&stubCreatePostUsecase{}))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/draws/random", nil))
+
+		resp := decodeErrorResponse(t, rec)
+		if rec.Code != http.StatusNotFound || resp.Code != CodeDrawsEmpty || resp.RequestID == "" {
+			t.Fatalf("unexpected response: %d %+v", rec.Code, resp)
+		}
+	})
+
+	t.Run("unknown error hides the cause", func(t *testing.T) {
+		router := NewRouter(NewDrawHandler(&stubFortuneUsecase{err: errors.New("firestore exploded")}), NewPostHandler(&stubCreatePostUsecase{}))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/draws/random", nil))
+
+		resp := decodeErrorResponse(t, rec)
+		if rec.Code != http.StatusInternalServerError || resp.Code != CodeInternal || resp.Message != messageInternalError {
+			t.Fatalf("unexpected response: %d %+v", rec.Code, resp)
+		}
+		if bytes.Contains(rec.Body.Bytes(), []byte("exploded")) {
+			t.Fatalf("internal error detail leaked: %s", rec.Body.String())
+		}
+	})
+}
+
+func decodeErrorResponse(t *testing.T, rec *httptest.ResponseRecorder) errorResponse {
+	t.Helper()
+	var resp errorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	return resp
+}
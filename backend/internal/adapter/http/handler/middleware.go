@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"backend/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader はリクエスト ID を受け渡すヘッダー名。
+const RequestIDHeader = "X-Request-ID"
+
+/**
+ * リクエスト ID を決めてコンテキストとレスポンスヘッダーへ載せるミドルウェア。
+ * 呼び出し元が妥当な X-Request-ID を付けていればそれを引き継ぎ、無ければ新しく発行する。
+ */
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !logging.ValidRequestID(id) {
+			id = logging.NewRequestID()
+		}
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+/**
+ * 処理結果を Cloud Logging の httpRequest 形式で 1 行残すミドルウェア。
+ * パスは ID などを含まないよう、ルート定義（例: /posts）で出す。クエリや本文は出さない。
+ */
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		slog.Log(c.Request.Context(), level, "http request",
+			slog.Group("httpRequest",
+				slog.String("requestMethod", c.Request.Method),
+				slog.String("requestUrl", route),
+				slog.Int("status", status),
+				slog.String("latency", fmt.Sprintf("%.3fs", time.Since(start).Seconds())),
+			),
+		)
+	}
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/internal/logging"
+	"backend/internal/logging/logtest"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(seen *string) *gin.Engine {
+		router := gin.New()
+		router.Use(RequestID())
+		router.GET("/ping", func(c *gin.Context) {
+			*seen = logging.RequestIDFromContext(c.Request.Context())
+			c.Status(http.StatusNoContent)
+		})
+		return router
+	}
+
+	t.Run("generates id", func(t *testing.T) {
+		var seen string
+		rec := httptest.NewRecorder()
+		newRouter(&seen).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+		got := rec.Header().Get(RequestIDHeader)
+		if got == "" || got != seen {
+			t.Fatalf("expected generated id to be propagated, header=%q context=%q", got, seen)
+		}
+	})
+
+	t.Run("reuses valid incoming id", func(t *testing.T) {
+		var seen string
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set(RequestIDHeader, "client-req-1")
+		newRouter(&seen).ServeHTTP(rec, req)
+
+		if seen != "client-req-1" || rec.Header().Get(RequestIDHeader) != "client-req-1" {
+			t.Fatalf("expected incoming id to be reused, got %q", seen)
+		}
+	})
+
+	t.Run("replaces invalid incoming id", func(t *testing.T) {
+		var seen string
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set(RequestIDHeader, "bad id with spaces")
+		newRouter(&seen).ServeHTTP(rec, req)
+
+		if seen == "" || seen == "bad id with spaces" {
+			t.Fatalf("expected invalid id to be replaced, got %q", seen)
+		}
+	})
+}
+
+func TestNewRouter_PropagatesRequestIDToUsecase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	buf := logtest.Capture(t)
+
+	stub := &stubCreatePostUsecase{}
+	router := NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(stub))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBufferString(`{"post_id":"dark-1","content":"秘密の闇"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIDHeader, "req-123")
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, rec.Code)
+	}
+	if stub.ctx == nil || logging.RequestIDFromContext(stub.ctx) != "req-123" {
+		t.Fatalf("expected request id to reach usecase")
+	}
+
+	// アクセスログはリクエスト ID 付きの JSON で、本文は含めない
+	var accessLog map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("expected JSON log line, got %q", line)
+		}
+		if entry["message"] == "http request" {
+			accessLog = entry
+		}
+	}
+	if accessLog == nil || accessLog["request_id"] != "req-123" || accessLog["severity"] != "INFO" {
+		t.Fatalf("unexpected access log: %+v", accessLog)
+	}
+	httpRequest, _ := accessLog["httpRequest"].(map[string]any)
+	if httpRequest["requestUrl"] != "/posts" || httpRequest["status"] != float64(http.StatusCreated) {
+		t.Fatalf("unexpected httpRequest: %+v", httpRequest)
+	}
+	logtest.AssertNotContains(t, buf, "秘密の闇", "dark-1")
+}
+
+func TestNewRouter_WithMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var measured string
+	middleware := func(c *gin.Context) {
+		c.Next()
+		measured = c.FullPath()
+	}
+	router := NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(&stubCreatePostUsecase{}), WithMetrics(middleware))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if measured != "/openapi.json" {
+		t.Fatalf("expected middleware to see route template, got %q", measured)
+	}
+
+	// /metrics は内部ポートで配信し、公開ルーターには載せない
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected /metrics to be absent from the public router, got %d", rec.Code)
+	}
+}
+
+func TestNewRouter_WithHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	measured := false
+	middleware := func(c *gin.Context) {
+		measured = true
+		c.Next()
+	}
+	liveness := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	readiness := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	router := NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(&stubCreatePostUsecase{}),
+		WithMetrics(middleware), WithHealth(liveness, readiness))
+
+	for path, want := range map[string]int{"/healthz": http.StatusOK, "/readyz": http.StatusServiceUnavailable} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Fatalf("%s: expected %d, got %d", path, want, rec.Code)
+		}
+	}
+	// 監視からの呼び出しは計測の対象にしない
+	if measured {
+		t.Fatalf("health endpoints should not pass through the metrics middleware")
+	}
+}
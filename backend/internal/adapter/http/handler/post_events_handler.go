@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	postdomain "backend/internal/domain/post"
+	"backend/internal/port/notifier"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// postEventsTimeout は 1 本のストリームを開いておく上限。終端の状態が届かなくてもここで閉じる。
+	postEventsTimeout = 2 * time.Minute
+	// postEventsKeepAlive はプロキシに切られないよう、通知が無い間に送るコメント行の間隔。
+	postEventsKeepAlive = 15 * time.Second
+)
+
+// PostWatcher は投稿の進み具合を見守るユースケースの契約。
+type PostWatcher interface {
+	Watch(ctx context.Context, id postdomain.DarkPostID) (<-chan notifier.Event, error)
+}
+
+// postEventResponse は SSE の data に載せる進み具合。
+type postEventResponse struct {
+	PostID string `json:"post_id"`
+	Status string `json:"status"`
+	At     string `json:"at"`
+}
+
+// PostEventsHandler は投稿の進み具合を Server-Sent Events で届ける。
+type PostEventsHandler struct {
+	usecase   PostWatcher
+	timeout   time.Duration
+	keepAlive time.Duration
+}
+
+// NewPostEventsHandler は PostEventsHandler を生成する。
+func NewPostEventsHandler(usecase PostWatcher) *PostEventsHandler {
+	return &PostEventsHandler{usecase: usecase, timeout: postEventsTimeout, keepAlive: postEventsKeepAlive}
+}
+
+// RegisterV1 は v1 の投稿の進み具合のルートを登録する。rateLimit があればハンドラーの前に挟む。
+func (h *PostEventsHandler) RegisterV1(r gin.IRoutes, rateLimit gin.HandlerFunc) {
+	r.GET("/posts/:id/events", withOptional(rateLimit, h.StreamEvents)...)
+}
+
+/**
+ * 投稿の進み具合（queued / formatting / ready / rejected）を text/event-stream で順に送る。
+ * ready か rejected を送ったら閉じる。上限時間を過ぎたら timeout を送って閉じ、クライアントは状態を取り直す。
+ * 投稿が無ければストリームを開かずに 404 を返す。
+ */
+func (h *PostEventsHandler) StreamEvents(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	events, err := h.usecase.Watch(ctx, postdomain.DarkPostID(c.Param("id")))
+	if err != nil {
+		respondError(c, "watch post failed", err)
+		return
+	}
+
+	// サーバー全体の書き込みタイムアウトより長く開いておくため、このレスポンスだけ期限を延ばす
+	rc := http.NewResponseController(c.Writer)
+	_ = rc.SetWriteDeadline(time.Now().Add(h.timeout + h.keepAlive))
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(h.keepAlive)
+	defer keepAlive.Stop()
+
+	for seq := 1; ; {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// 上限時間を過ぎた場合だけ知らせる。クライアントが切断した場合は書き込み先が無い
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) && c.Request.Context().Err() == nil {
+					writeSSE(c, 0, "timeout", map[string]any{})
+				}
+				return
+			}
+			writeSSE(c, seq, "status", postEventResponse{
+				PostID: string(event.PostID),
+				Status: string(event.Status),
+				At:     event.At.UTC().Format(time.RFC3339),
+			})
+			seq++
+		case <-keepAlive.C:
+			_, _ = fmt.Fprint(c.Writer, ": keep-alive\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeSSE は 1 件のイベントを書き込んですぐに送り出す。id が 0 なら id 行を省く。
+func writeSSE(c *gin.Context, id int, event string, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	if id > 0 {
+		_, _ = fmt.Fprintf(c.Writer, "id: %d\n", id)
+	}
+	_, _ = fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, payload)
+	c.Writer.Flush()
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	postdomain "backend/internal/domain/post"
+	"backend/internal/port/notifier"
+	"backend/internal/port/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPostEventsHandler_StreamsUntilTerminal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	at := time.Date(2026, 1, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	watcher := &stubPostWatcher{statuses: []notifier.Status{notifier.StatusQueued, notifier.StatusFormatting, notifier.StatusReady}, at: at}
+	rec := servePostEvents(NewPostEventsHandler(watcher), "/v1/posts/dark-1/events")
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected response: %d %v", rec.Code, rec.Header())
+	}
+	want := "id: 1\nevent: status\ndata: {\"post_id\":\"dark-1\",\"status\":\"queued\",\"at\":\"2026-01-01T00:00:00Z\"}\n\n" +
+		"id: 2\nevent: status\ndata: {\"post_id\":\"dark-1\",\"status\":\"formatting\",\"at\":\"2026-01-01T00:00:00Z\"}\n\n" +
+		"id: 3\nevent: status\ndata: {\"post_id\":\"dark-1\",\"status\":\"ready\",\"at\":\"2026-01-01T00:00:00Z\"}\n\n"
+	if rec.Body.String() != want {
+		t.Fatalf("unexpected stream:\n%s", rec.Body.String())
+	}
+}
+
+func TestPostEventsHandler_TimesOut(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewPostEventsHandler(&stubPostWatcher{statuses: []notifier.Status{notifier.StatusQueued}, hold: true})
+	h.timeout = 30 * time.Millisecond
+	h.keepAlive = 10 * time.Millisecond
+	rec := servePostEvents(h, "/v1/posts/dark-1/events")
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `"status":"queued"`) || !strings.Contains(body, ": keep-alive\n\n") {
+		t.Fatalf("expected queued event and keep-alive, got:\n%s", body)
+	}
+	if !strings.HasSuffix(body, "event: timeout\ndata: {}\n\n") {
+		t.Fatalf("expected stream to end with timeout, got:\n%s", body)
+	}
+}
+
+func TestPostEventsHandler_PostNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rec := servePostEvents(NewPostEventsHandler(&stubPostWatcher{err: repository.ErrPostNotFound}), "/v1/posts/missing/events")
+	if rec.Code != http.StatusNotFound || decodeErrorResponse(t, rec).Code != CodePostNotFound {
+		t.Fatalf("expected post_not_found, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func servePostEvents(h *PostEventsHandler, path string) *httptest.ResponseRecorder {
+	router := NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(&stubCreatePostUsecase{}), WithPostEvents(h))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+// stubPostWatcher は用意した状態を順に流す。hold なら流した後も ctx が終わるまで閉じない。
+type stubPostWatcher struct {
+	statuses []notifier.Status
+	at       time.Time
+	hold     bool
+	err      error
+}
+
+func (s *stubPostWatcher) Watch(ctx context.Context, id postdomain.DarkPostID) (<-chan notifier.Event, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	ch := make(chan notifier.Event, len(s.statuses))
+	for _, status := range s.statuses {
+		ch <- notifier.Event{PostID: id, Status: status, At: s.at}
+	}
+	if !s.hold {
+		close(ch)
+		return ch, nil
+	}
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch, nil
+}
@@ -3,7 +3,6 @@ package handler
 import (
 	"context"
 	"errors"
-	"log"
 	"net/http"
 	"strings"
 
@@ -14,10 +13,20 @@ import (
 )
 
 const (
-	messagePostInvalidRequest = "invalid post request"
-	messagePostConflict       = "post already exists"
+	messagePostInvalidRequest    = "invalid post request"
+	messagePostConflict          = "post already exists"
+	messagePostFlagged           = "post was flagged by moderation"
+	messagePostTooLarge          = "request body is too large"
+	messagePostContentTooLong    = "post content is too long"
+	messagePostInvalidCharacters = "post content contains invalid characters"
+	messagePostRepetitiveContent = "post content is repetitive"
+	messagePostNotFound          = "post not found"
 )
 
+// maxPostBodyBytes は POST /posts で読み込む本文の上限。
+// 本文の最大文字数をすべてエスケープ表記（\uXXXX）で送っても収まる大きさにする。
+const maxPostBodyBytes = 16 << 10
+
 // 投稿作成ユースケースの契約。
 type CreatePostExecutor interface {
 	Execute(ctx context.Context, in *postusecase.CreatePostInput) (*postusecase.CreatePostOutput, error)
@@ -32,15 +41,27 @@ func NewPostHandler(usecase CreatePostExecutor) *PostHandler {
 	return &PostHandler{createUsecase: usecase}
 }
 
+// RegisterV1 は v1 の投稿関連ルートを登録する。rateLimit があればハンドラーの前に挟む。
+func (h *PostHandler) RegisterV1(r gin.IRoutes, rateLimit gin.HandlerFunc) {
+	r.POST("/posts", withOptional(rateLimit, h.CreatePost)...)
+}
+
+// RegisterLegacy はバージョン無しの POST /posts を、配布済みのフロントエンド向けに登録する。
+func (h *PostHandler) RegisterLegacy(r gin.IRoutes, rateLimit gin.HandlerFunc) {
+	r.POST("/posts", withOptional(rateLimit, h.CreatePost)...)
+}
+
 // POST /posts の入力。
 type CreatePostRequest struct {
 	PostID  string `json:"post_id"`
 	Content string `json:"content"`
 }
 
-// 作成結果を表す。
+// 作成結果を表す。危機判定時のみ Status と Support を含める。
 type CreatePostResponse struct {
-	PostID string `json:"post_id"`
+	PostID  string           `json:"post_id"`
+	Status  string           `json:"status,omitempty"`
+	Support *SupportResponse `json:"support,omitempty"`
 }
 
 /**
@@ -48,14 +69,21 @@ type CreatePostResponse struct {
  */
 func (h *PostHandler) CreatePost(c *gin.Context) {
 	var req CreatePostRequest
+	// 巨大な本文を読み込む前に打ち切る
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxPostBodyBytes)
 	// JSON パースに失敗したら入力不備
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, errorResponse{Message: messagePostInvalidRequest})
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(c, errRequestTooLarge)
+			return
+		}
+		writeError(c, errInvalidRequest)
 		return
 	}
 	// ID も本文も空は受け付けない
 	if strings.TrimSpace(req.PostID) == "" || strings.TrimSpace(req.Content) == "" {
-		c.JSON(http.StatusBadRequest, errorResponse{Message: messagePostInvalidRequest})
+		writeError(c, errInvalidRequest)
 		return
 	}
 
@@ -64,30 +92,18 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 		Content:    req.Content,
 	})
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, "create post failed", err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, CreatePostResponse{PostID: out.DarkPostID})
-}
-
-/**
- * ユースケースからのエラーを HTTP ステータスとメッセージへ写し替える。
- */
-func (h *PostHandler) handleError(c *gin.Context, err error) {
-	switch {
-	// ユースケースの入力不足
-	case errors.Is(err, postusecase.ErrNilInput):
-		c.JSON(http.StatusBadRequest, errorResponse{Message: messagePostInvalidRequest})
-	// ドメインの空本文エラー
-	case errors.Is(err, postdomain.ErrEmptyContent):
-		c.JSON(http.StatusBadRequest, errorResponse{Message: messagePostInvalidRequest})
-	// 投稿もしくは整形ジョブの重複
-	case errors.Is(err, postusecase.ErrPostAlreadyExists),
-		errors.Is(err, postusecase.ErrJobAlreadyScheduled):
-		c.JSON(http.StatusConflict, errorResponse{Message: messagePostConflict})
-	default:
-		log.Printf("POST /posts 失敗: %v", err)
-		c.JSON(http.StatusInternalServerError, errorResponse{Message: messageInternalError})
+	// 危機的な兆候がある投稿は受け付けたうえで、おみくじの代わりに相談窓口を案内する
+	if out.Crisis {
+		c.JSON(http.StatusCreated, CreatePostResponse{
+			PostID:  out.DarkPostID,
+			Status:  string(postdomain.StatusFlagged),
+			Support: newSupportResponse(),
+		})
+		return
 	}
+	c.JSON(http.StatusCreated, CreatePostResponse{PostID: out.DarkPostID})
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	postdomain "backend/internal/domain/post"
@@ -45,11 +46,36 @@ func TestPostHandler_CreatePost(t *testing.T) {
 		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
 			t.Fatalf("failed to decode response: %v", err)
 		}
-		if resp.PostID != "dark-1" {
+		if resp.PostID != "dark-1" || resp.Support != nil {
 			t.Fatalf("unexpected response: %+v", resp)
 		}
 	})
 
+	t.Run("crisis returns support resources", func(t *testing.T) {
+		handler := NewPostHandler(&stubCreatePostUsecase{
+			output: &postusecase.CreatePostOutput{DarkPostID: "dark-1", Flagged: true, Crisis: true},
+		})
+		rec, body := performPostRequest(handler, `{"post_id":"dark-1","content":"消えたい"}`)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d", http.StatusCreated, rec.Code)
+		}
+		var resp CreatePostResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Status != string(postdomain.StatusFlagged) || resp.Support == nil || len(resp.Support.Resources) == 0 {
+			t.Fatalf("expected support resources, got %+v", resp)
+		}
+	})
+
+	t.Run("flagged without crisis", func(t *testing.T) {
+		handler := NewPostHandler(&stubCreatePostUsecase{
+			err: postusecase.ErrPostFlagged,
+		})
+		rec, resp := performPostRequest(handler, `{"post_id":"dark-1","content":"hello"}`)
+		expectStatusAndMessage(t, rec, resp, http.StatusUnprocessableEntity, messagePostFlagged)
+	})
+
 	t.Run("invalid json", func(t *testing.T) {
 		handler := NewPostHandler(&stubCreatePostUsecase{})
 		rec, resp := performPostRequest(handler, `{"post_id":`)
@@ -78,6 +104,33 @@ func TestPostHandler_CreatePost(t *testing.T) {
 		expectStatusAndMessage(t, rec, resp, http.StatusBadRequest, messagePostInvalidRequest)
 	})
 
+	t.Run("content validation errors", func(t *testing.T) {
+		cases := []struct {
+			err     error
+			message string
+		}{
+			{postdomain.ErrContentTooLong, messagePostContentTooLong},
+			{postdomain.ErrInvalidCharacters, messagePostInvalidCharacters},
+			{postdomain.ErrRepetitiveContent, messagePostRepetitiveContent},
+		}
+		for _, tc := range cases {
+			handler := NewPostHandler(&stubCreatePostUsecase{err: tc.err})
+			rec, resp := performPostRequest(handler, `{"post_id":"dark","content":"hello"}`)
+			expectStatusAndMessage(t, rec, resp, http.StatusUnprocessableEntity, tc.message)
+		}
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		stub := &stubCreatePostUsecase{}
+		handler := NewPostHandler(stub)
+		body := `{"post_id":"dark","content":"` + strings.Repeat("a", maxPostBodyBytes) + `"}`
+		rec, resp := performPostRequest(handler, body)
+		expectStatusAndMessage(t, rec, resp, http.StatusRequestEntityTooLarge, messagePostTooLarge)
+		if stub.ctx != nil {
+			t.Fatalf("usecase should not be called for oversized body")
+		}
+	})
+
 	t.Run("nil input error", func(t *testing.T) {
 		handler := NewPostHandler(&stubCreatePostUsecase{
 			err: postusecase.ErrNilInput,
@@ -131,10 +184,12 @@ type stubCreatePostUsecase struct {
 	output   *postusecase.CreatePostOutput
 	err      error
 	received *postusecase.CreatePostInput
+	ctx      context.Context
 }
 
 func (s *stubCreatePostUsecase) Execute(ctx context.Context, in *postusecase.CreatePostInput) (*postusecase.CreatePostOutput, error) {
 	s.received = in
+	s.ctx = ctx
 	if s.err != nil {
 		return nil, s.err
 	}
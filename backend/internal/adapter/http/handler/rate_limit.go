@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"backend/internal/port/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// VisitorTokenHeader はブラウザごとに発行した訪問者トークンを受け渡すヘッダー名。
+	VisitorTokenHeader = "X-Visitor-Token"
+
+	messageTooManyRequests = "too many requests"
+
+	// 受け取る訪問者トークンの最大長
+	maxVisitorTokenLength = 128
+)
+
+/**
+ * エンドポイントごとの呼び出し上限。0 の項目は制限しない。
+ * @param Name 上限を共有する単位（キーに含め、エンドポイント間で予算を分ける）
+ * @param PerMinute 1 分あたりの上限
+ * @param PerDay 1 日あたりの上限
+ */
+type RateLimitPolicy struct {
+	Name      string
+	PerMinute int
+	PerDay    int
+}
+
+type quota struct {
+	limit  int
+	window time.Duration
+}
+
+/**
+ * 接続元 IP と訪問者トークンのそれぞれについて呼び出し回数を数え、上限を超えたら 429 を返すミドルウェア。
+ * Retry-After には枠が切り替わるまでの秒数を入れる。
+ * 保存先に届かない場合は投稿を止めないよう通し、警告ログだけ残す。
+ */
+func RateLimit(counter ratelimit.Counter, policy RateLimitPolicy) gin.HandlerFunc {
+	var quotas []quota
+	if policy.PerMinute > 0 {
+		quotas = append(quotas, quota{limit: policy.PerMinute, window: time.Minute})
+	}
+	if policy.PerDay > 0 {
+		quotas = append(quotas, quota{limit: policy.PerDay, window: 24 * time.Hour})
+	}
+
+	return func(c *gin.Context) {
+		if counter == nil || len(quotas) == 0 {
+			c.Next()
+			return
+		}
+		ctx := c.Request.Context()
+		for _, key := range rateLimitKeys(c, policy.Name) {
+			// 短い枠から数え、超えた時点で長い枠の予算は消費しない
+			for _, q := range quotas {
+				window, err := counter.Increment(ctx, key, q.window)
+				if err != nil {
+					slog.WarnContext(ctx, "rate limit unavailable", slog.String("policy", policy.Name), slog.Any("error", err))
+					c.Next()
+					return
+				}
+				if window.Count > int64(q.limit) {
+					retryAfter := int(math.Ceil(time.Until(window.ResetAt).Seconds()))
+					if retryAfter < 1 {
+						retryAfter = 1
+					}
+					c.Header("Retry-After", strconv.Itoa(retryAfter))
+					writeError(c, errTooManyRequests.withDetails(map[string]any{"retry_after_seconds": retryAfter}))
+					return
+				}
+			}
+		}
+		c.Next()
+	}
+}
+
+/**
+ * 数える単位のキーを返す。訪問者トークンが無い、または不正な場合は IP だけで数える。
+ */
+func rateLimitKeys(c *gin.Context, name string) []string {
+	keys := []string{name + ":ip:" + c.ClientIP()}
+	if token := c.GetHeader(VisitorTokenHeader); validVisitorToken(token) {
+		keys = append(keys, name+":visitor:"+token)
+	}
+	return keys
+}
+
+/**
+ * 訪問者トークンをキーに使ってよいかを判定する。英数字と - _ だけで構成された短い値に限る。
+ */
+func validVisitorToken(token string) bool {
+	if token == "" || len(token) > maxVisitorTokenLength {
+		return false
+	}
+	for _, r := range token {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
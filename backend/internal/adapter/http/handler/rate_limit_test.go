@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	memoryratelimit "backend/internal/adapter/ratelimit/memory"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/port/notifier"
+	"backend/internal/port/ratelimit"
+	cardusecase "backend/internal/usecase/card"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitedRouter(t *testing.T, posts, draws RateLimitPolicy, opts ...RouterOption) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	counter := memoryratelimit.NewCounter()
+	d, _ := drawdomain.New("post-1", "fortune")
+	opts = append(opts, WithRateLimits(RateLimits{Posts: RateLimit(counter, posts), Draws: RateLimit(counter, draws)}))
+	return NewRouter(NewDrawHandler(&stubFortuneUsecase{draw: d}), NewPostHandler(&stubCreatePostUsecase{}), opts...)
+}
+
+func postRequest(visitor string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBufferString(`{"post_id":"p1","content":"闇"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if visitor != "" {
+		req.Header.Set(VisitorTokenHeader, visitor)
+	}
+	return req
+}
+
+func TestRateLimit_RejectsOverMinuteQuota(t *testing.T) {
+	router := newRateLimitedRouter(t, RateLimitPolicy{Name: "posts", PerMinute: 2}, RateLimitPolicy{Name: "draws", PerMinute: 10})
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, postRequest(""))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("request %d: expected %d, got %d", i, http.StatusCreated, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, postRequest(""))
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected %d, got %d", http.StatusTooManyRequests, rec.Code)
+	}
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > 60 {
+		t.Fatalf("unexpected Retry-After: %q", rec.Header().Get("Retry-After"))
+	}
+	if resp := decodeErrorResponse(t, rec); resp.Code != CodeTooManyRequests || resp.Details["retry_after_seconds"] != float64(retryAfter) {
+		t.Fatalf("unexpected error body: %+v", resp)
+	}
+
+	// 閲覧は投稿とは別の予算で数える
+	drawRec := httptest.NewRecorder()
+	router.ServeHTTP(drawRec, httptest.NewRequest(http.MethodGet, "/draws/random", nil))
+	if drawRec.Code != http.StatusOK {
+		t.Fatalf("draws should have a separate budget, got %d", drawRec.Code)
+	}
+}
+
+func TestRateLimit_CountsVisitorTokenAcrossAddresses(t *testing.T) {
+	router := newRateLimitedRouter(t, RateLimitPolicy{Name: "posts", PerMinute: 1}, RateLimitPolicy{Name: "draws"})
+
+	first := postRequest("visitor-1")
+	first.RemoteAddr = "198.51.100.1:1234"
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, first)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected %d, got %d", http.StatusCreated, rec.Code)
+	}
+
+	// IP を変えても同じ訪問者トークンなら上限に数える
+	second := postRequest("visitor-1")
+	second.RemoteAddr = "198.51.100.2:1234"
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, second)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected %d, got %d", http.StatusTooManyRequests, rec.Code)
+	}
+
+	// 別の IP と別の訪問者トークンは影響を受けない
+	third := postRequest("visitor-2")
+	third.RemoteAddr = "198.51.100.3:1234"
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, third)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected %d, got %d", http.StatusCreated, rec.Code)
+	}
+}
+
+func TestRateLimit_IgnoresForgedForwardedFor(t *testing.T) {
+	router := newRateLimitedRouter(t, RateLimitPolicy{Name: "posts", PerMinute: 1}, RateLimitPolicy{Name: "draws"})
+
+	for i, forged := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := postRequest("")
+		req.RemoteAddr = "198.51.100.1:1234"
+		req.Header.Set("X-Forwarded-For", forged)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		// 中継元を信頼していないので、X-Forwarded-For を変えても同じ接続元として数える
+		want := http.StatusCreated
+		if i > 0 {
+			want = http.StatusTooManyRequests
+		}
+		if rec.Code != want {
+			t.Fatalf("request %d: expected %d, got %d", i, want, rec.Code)
+		}
+	}
+}
+
+func TestRateLimit_UsesAddressAppendedByTrustedProxy(t *testing.T) {
+	router := newRateLimitedRouter(t, RateLimitPolicy{Name: "posts", PerMinute: 1}, RateLimitPolicy{Name: "draws"},
+		WithTrustedProxies([]string{"10.0.0.0/8"}, ""))
+
+	send := func(forwardedFor string) int {
+		req := postRequest("")
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := send("203.0.113.1, 198.51.100.7"); code != http.StatusCreated {
+		t.Fatalf("expected %d, got %d", http.StatusCreated, code)
+	}
+	// 信頼する中継元が付け足した接続元で数えるため、クライアントが先頭に書いた値を変えても予算は戻らない
+	if code := send("203.0.113.2, 198.51.100.7"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected %d, got %d", http.StatusTooManyRequests, code)
+	}
+	if code := send("198.51.100.8"); code != http.StatusCreated {
+		t.Fatalf("another client should have its own budget, got %d", code)
+	}
+}
+
+func TestRateLimit_RoutesHaveTheirOwnBudget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	d := newVerifiedDraw(t, "post-1", "fortune")
+	cards := &stubShareCards{images: map[drawdomain.ShareID]*cardusecase.Image{d.ShareID(): {PNG: []byte("\x89PNG"), ETag: `"card-1"`}}}
+	watcher := &stubPostWatcher{statuses: []notifier.Status{notifier.StatusReady}}
+
+	cases := []struct {
+		name   string
+		limits func(limit gin.HandlerFunc) RateLimits
+		req    func() *http.Request
+		status int
+	}{
+		{
+			name:   "cards",
+			limits: func(limit gin.HandlerFunc) RateLimits { return RateLimits{Cards: limit} },
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/v1/draws/"+string(d.ShareID())+"/card.png", nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "post events",
+			limits: func(limit gin.HandlerFunc) RateLimits { return RateLimits{PostEvents: limit} },
+			req:    func() *http.Request { return httptest.NewRequest(http.MethodGet, "/v1/posts/dark-1/events", nil) },
+			status: http.StatusOK,
+		},
+		{
+			name:   "reactions",
+			limits: func(limit gin.HandlerFunc) RateLimits { return RateLimits{Reactions: limit} },
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/v1/draws/"+string(d.ShareID())+"/reactions", bytes.NewBufferString(`{"kind":"saved"}`))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set(VisitorTokenHeader, "visitor-1")
+				return req
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "reports",
+			limits: func(limit gin.HandlerFunc) RateLimits { return RateLimits{Reports: limit} },
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/v1/draws/"+string(d.ShareID())+"/reports", bytes.NewBufferString(`{"reason":"spam"}`))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set(VisitorTokenHeader, "visitor-1")
+				return req
+			},
+			status: http.StatusAccepted,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			counter := memoryratelimit.NewCounter()
+			limits := tc.limits(RateLimit(counter, RateLimitPolicy{Name: tc.name, PerMinute: 1}))
+			limits.Draws = RateLimit(counter, RateLimitPolicy{Name: "draws", PerMinute: 10})
+			router := NewRouter(NewDrawHandler(&stubFortuneUsecase{draw: d}), NewPostHandler(&stubCreatePostUsecase{}),
+				WithShareCards(NewCardHandler(cards)),
+				WithPostEvents(NewPostEventsHandler(watcher)),
+				WithReactions(NewReactionHandler(&stubReactor{draw: d})),
+				WithReports(NewReportHandler(&stubReporter{draw: d})),
+				WithRateLimits(limits))
+			serve := func(req *http.Request) int {
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+				return rec.Code
+			}
+
+			if code := serve(tc.req()); code != tc.status {
+				t.Fatalf("expected %d, got %d", tc.status, code)
+			}
+			if code := serve(tc.req()); code != http.StatusTooManyRequests {
+				t.Fatalf("expected %d, got %d", http.StatusTooManyRequests, code)
+			}
+			// 使い切っても閲覧の予算は減らない
+			if code := serve(httptest.NewRequest(http.MethodGet, "/v1/draws/random", nil)); code != http.StatusOK {
+				t.Fatalf("draws should have a separate budget, got %d", code)
+			}
+		})
+	}
+}
+
+func TestRateLimit_RejectsOverDayQuota(t *testing.T) {
+	resetAt := time.Now().Add(3 * time.Hour)
+	counter := &fixedCounter{windows: map[time.Duration]ratelimit.Window{
+		time.Minute:    {Count: 1, ResetAt: time.Now().Add(time.Minute)},
+		24 * time.Hour: {Count: 51, ResetAt: resetAt},
+	}}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/posts", RateLimit(counter, RateLimitPolicy{Name: "posts", PerMinute: 5, PerDay: 50}), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, postRequest(""))
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected %d, got %d", http.StatusTooManyRequests, rec.Code)
+	}
+	if got, _ := strconv.Atoi(rec.Header().Get("Retry-After")); got < 3*3600-5 || got > 3*3600 {
+		t.Fatalf("Retry-After should point to the end of the day window, got %q", rec.Header().Get("Retry-After"))
+	}
+}
+
+func TestRateLimit_AllowsWhenCounterUnavailable(t *testing.T) {
+	counter := &fixedCounter{err: ratelimit.ErrCounterUnavailable}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/posts", RateLimit(counter, RateLimitPolicy{Name: "posts", PerMinute: 1}), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, postRequest(""))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected request to pass when the counter is unavailable, got %d", rec.Code)
+	}
+}
+
+func TestValidVisitorToken(t *testing.T) {
+	cases := map[string]bool{
+		"":                false,
+		"visitor_1-abc":   true,
+		"has space":       false,
+		"ip:198.51.100.1": false,
+		string(make([]byte, maxVisitorTokenLength+1)): false,
+	}
+	for token, want := range cases {
+		if got := validVisitorToken(token); got != want {
+			t.Fatalf("validVisitorToken(%q) = %v, want %v", token, got, want)
+		}
+	}
+}
+
+// 枠幅ごとに決まった回数を返す保存先のスタブ。
+type fixedCounter struct {
+	windows map[time.Duration]ratelimit.Window
+	err     error
+}
+
+func (f *fixedCounter) Increment(ctx context.Context, key string, window time.Duration) (ratelimit.Window, error) {
+	if f.err != nil {
+		return ratelimit.Window{}, f.err
+	}
+	return f.windows[window], nil
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	messageReactionInvalid       = "invalid reaction kind"
+	messageVisitorTokenRequired  = "visitor token is required"
+	messageReactionAlreadyExists = "already reacted to this draw"
+)
+
+// Reactor はおみくじへの反応を受け付けるユースケースの契約。
+type Reactor interface {
+	React(ctx context.Context, id drawdomain.ShareID, visitorToken string, kind reaction.Kind) (reaction.Counts, error)
+}
+
+// ReactionCounter はおみくじの反応数を返す処理の契約。
+type ReactionCounter interface {
+	Counts(ctx context.Context, postID post.DarkPostID) (reaction.Counts, error)
+}
+
+// ReactionHandler はおみくじへの反応（当たってる・救われた・こわい）を受け付ける。
+type ReactionHandler struct {
+	usecase Reactor
+}
+
+// NewReactionHandler は ReactionHandler を生成する。
+func NewReactionHandler(usecase Reactor) *ReactionHandler {
+	return &ReactionHandler{usecase: usecase}
+}
+
+// RegisterV1 は v1 の反応のルートを登録する。rateLimit があればハンドラーの前に挟む。
+func (h *ReactionHandler) RegisterV1(r gin.IRoutes, rateLimit gin.HandlerFunc) {
+	r.POST("/draws/:id/reactions", withOptional(rateLimit, h.CreateReaction)...)
+}
+
+// POST /draws/:id/reactions の入力。
+type CreateReactionRequest struct {
+	Kind string `json:"kind"`
+}
+
+// ReactionCountsResponse は種類ごとの反応数。
+type ReactionCountsResponse struct {
+	Accurate int64 `json:"accurate"`
+	Saved    int64 `json:"saved"`
+	Scary    int64 `json:"scary"`
+}
+
+// CreateReactionResponse は反応を付けた後の反応数。
+type CreateReactionResponse struct {
+	Reactions ReactionCountsResponse `json:"reactions"`
+}
+
+func newReactionCountsResponse(counts reaction.Counts) ReactionCountsResponse {
+	return ReactionCountsResponse{
+		Accurate: counts[reaction.KindAccurate],
+		Saved:    counts[reaction.KindSaved],
+		Scary:    counts[reaction.KindScary],
+	}
+}
+
+/**
+ * 共有 ID のおみくじへ反応を 1 つ付け、付けた後の反応数を返す。
+ * 訪問者ごとに 1 回までとするため X-Visitor-Token を必須にする。
+ */
+func (h *ReactionHandler) CreateReaction(c *gin.Context) {
+	token := c.GetHeader(VisitorTokenHeader)
+	if !validVisitorToken(token) {
+		writeError(c, errVisitorTokenRequired)
+		return
+	}
+	var req CreateReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errInvalidRequest)
+		return
+	}
+
+	counts, err := h.usecase.React(c.Request.Context(), drawdomain.ShareID(c.Param("id")), token, reaction.Kind(req.Kind))
+	if err != nil {
+		respondError(c, "create reaction failed", err)
+		return
+	}
+	c.JSON(http.StatusCreated, CreateReactionResponse{Reactions: newReactionCountsResponse(counts)})
+}
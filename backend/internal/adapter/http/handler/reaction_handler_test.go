@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/port/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReactionHandler_CreateReaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	d := newVerifiedDraw(t, "post-reacted", "fortunes await")
+	cases := []struct {
+		name   string
+		stub   *stubReactor
+		token  string
+		body   string
+		status int
+		code   ErrorCode
+	}{
+		{name: "created", stub: &stubReactor{draw: d}, token: "visitor-1", body: `{"kind":"saved"}`, status: http.StatusCreated},
+		{name: "missing token", stub: &stubReactor{draw: d}, body: `{"kind":"saved"}`, status: http.StatusBadRequest, code: CodeVisitorRequired},
+		{name: "invalid token", stub: &stubReactor{draw: d}, token: "not a token", body: `{"kind":"saved"}`, status: http.StatusBadRequest, code: CodeVisitorRequired},
+		{name: "invalid json", stub: &stubReactor{draw: d}, token: "visitor-1", body: `{"kind":`, status: http.StatusBadRequest, code: CodeInvalidRequest},
+		{name: "unknown kind", stub: &stubReactor{draw: d}, token: "visitor-1", body: `{"kind":"like"}`, status: http.StatusBadRequest, code: CodeReactionInvalid},
+		{name: "already reacted", stub: &stubReactor{draw: d, err: repository.ErrReactionAlreadyExists}, token: "visitor-1", body: `{"kind":"saved"}`, status: http.StatusConflict, code: CodeReactionConflict},
+		{name: "draw not found", stub: &stubReactor{}, token: "visitor-1", body: `{"kind":"saved"}`, status: http.StatusNotFound, code: CodeDrawNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(&stubPostUsecaseForRouter{}), WithReactions(NewReactionHandler(tc.stub)))
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/draws/"+string(d.ShareID())+"/reactions", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.token != "" {
+				req.Header.Set(VisitorTokenHeader, tc.token)
+			}
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d but got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+			if tc.code != "" {
+				var got errorResponse
+				decodeBody(t, rec.Body, &got)
+				if got.Code != tc.code {
+					t.Fatalf("unexpected code: %q", got.Code)
+				}
+				return
+			}
+			var got CreateReactionResponse
+			decodeBody(t, rec.Body, &got)
+			if got.Reactions != (ReactionCountsResponse{Saved: 1}) {
+				t.Fatalf("unexpected counts: %+v", got.Reactions)
+			}
+			if tc.stub.token != tc.token {
+				t.Fatalf("visitor token not passed through: %q", tc.stub.token)
+			}
+		})
+	}
+}
+
+func TestDrawHandler_Reactions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	d := newVerifiedDraw(t, "post-reacted", "fortunes await")
+	counter := &stubReactionCounter{counts: reaction.Counts{reaction.KindAccurate: 2, reaction.KindScary: 1}}
+	router := NewRouter(NewDrawHandler(&stubFortuneUsecase{draw: d}).WithReactions(counter), NewPostHandler(&stubPostUsecaseForRouter{}))
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	var random DrawResponse
+	decodeBody(t, get("/v1/draws/random").Body, &random)
+	if random.Reactions == nil || *random.Reactions != (ReactionCountsResponse{Accurate: 2, Scary: 1}) {
+		t.Fatalf("unexpected reactions: %+v", random.Reactions)
+	}
+
+	// 反応数が変われば共有リンクの ETag も変わる
+	before := get("/v1/draws/" + string(d.ShareID())).Header().Get("ETag")
+	counter.counts = reaction.Counts{reaction.KindAccurate: 3, reaction.KindScary: 1}
+	if after := get("/v1/draws/" + string(d.ShareID())).Header().Get("ETag"); after == before {
+		t.Fatalf("expected ETag to change with counts, got %q", after)
+	}
+
+	// 数えられなくてもおみくじは返し、反応数だけ省く
+	counter.err = errors.New("unavailable")
+	rec := get("/v1/draws/random")
+	if rec.Code != http.StatusOK || bytes.Contains(rec.Body.Bytes(), []byte("reactions")) {
+		t.Fatalf("expected draw without reactions, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+type stubReactor struct {
+	draw  *drawdomain.Draw
+	err   error
+	token string
+}
+
+func (s *stubReactor) React(ctx context.Context, id drawdomain.ShareID, visitorToken string, kind reaction.Kind) (reaction.Counts, error) {
+	if !kind.Valid() {
+		return nil, reaction.ErrInvalidKind
+	}
+	if s.draw == nil || s.draw.ShareID() != id {
+		return nil, repository.ErrDrawNotFound
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.token = visitorToken
+	return reaction.Counts{kind: 1}, nil
+}
+
+type stubReactionCounter struct {
+	counts reaction.Counts
+	err    error
+}
+
+func (s *stubReactionCounter) Counts(ctx context.Context, postID post.DarkPostID) (reaction.Counts, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.counts, nil
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	messageReportInvalid    = "invalid report reason"
+	messageClientIPRequired = "client ip could not be determined"
+)
+
+// Reporter はおみくじへの通報を受け付けるユースケースの契約。
+type Reporter interface {
+	Report(ctx context.Context, id drawdomain.ShareID, visitorToken, clientIP string, reason report.Reason) error
+}
+
+// ReportHandler はおみくじへの通報を受け付ける。
+type ReportHandler struct {
+	usecase Reporter
+}
+
+// NewReportHandler は ReportHandler を生成する。
+func NewReportHandler(usecase Reporter) *ReportHandler {
+	return &ReportHandler{usecase: usecase}
+}
+
+// RegisterV1 は v1 の通報のルートを登録する。rateLimit があればハンドラーの前に挟む。
+func (h *ReportHandler) RegisterV1(r gin.IRoutes, rateLimit gin.HandlerFunc) {
+	r.POST("/draws/:id/reports", withOptional(rateLimit, h.CreateReport)...)
+}
+
+// POST /draws/:id/reports の入力。
+type CreateReportRequest struct {
+	Reason string `json:"reason"`
+}
+
+/**
+ * 共有 ID のおみくじへの通報を受け付け、202 を返す。
+ * 訪問者ごとに 1 回だけ数えるため X-Visitor-Token を必須にする。2 回目も同じく 202 を返す。
+ * トークンは作り直せるため、信頼する中継元を考慮した接続元 IP も渡し、接続元ごとにも 1 回だけ数える。
+ */
+func (h *ReportHandler) CreateReport(c *gin.Context) {
+	token := c.GetHeader(VisitorTokenHeader)
+	if !validVisitorToken(token) {
+		writeError(c, errVisitorTokenRequired)
+		return
+	}
+	var req CreateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errInvalidRequest)
+		return
+	}
+
+	if err := h.usecase.Report(c.Request.Context(), drawdomain.ShareID(c.Param("id")), token, c.ClientIP(), report.Reason(req.Reason)); err != nil {
+		respondError(c, "create report failed", err)
+		return
+	}
+	c.Status(http.StatusAccepted)
+}
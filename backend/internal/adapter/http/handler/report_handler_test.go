@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/report"
+	"backend/internal/port/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReportHandler_CreateReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	d := newVerifiedDraw(t, "post-reported", "fortunes await")
+	cases := []struct {
+		name   string
+		stub   *stubReporter
+		token  string
+		body   string
+		status int
+		code   ErrorCode
+	}{
+		{name: "accepted", stub: &stubReporter{draw: d}, token: "visitor-1", body: `{"reason":"spam"}`, status: http.StatusAccepted},
+		{name: "missing token", stub: &stubReporter{draw: d}, body: `{"reason":"spam"}`, status: http.StatusBadRequest, code: CodeVisitorRequired},
+		{name: "invalid json", stub: &stubReporter{draw: d}, token: "visitor-1", body: `{"reason":`, status: http.StatusBadRequest, code: CodeInvalidRequest},
+		{name: "unknown reason", stub: &stubReporter{draw: d}, token: "visitor-1", body: `{"reason":"boring"}`, status: http.StatusBadRequest, code: CodeReportInvalid},
+		{name: "draw not found", stub: &stubReporter{}, token: "visitor-1", body: `{"reason":"spam"}`, status: http.StatusNotFound, code: CodeDrawNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := NewRouter(NewDrawHandler(&stubFortuneUsecase{}), NewPostHandler(&stubPostUsecaseForRouter{}), WithReports(NewReportHandler(tc.stub)))
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/draws/"+string(d.ShareID())+"/reports", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.token != "" {
+				req.Header.Set(VisitorTokenHeader, tc.token)
+			}
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d but got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+			if tc.code != "" {
+				var got errorResponse
+				decodeBody(t, rec.Body, &got)
+				if got.Code != tc.code {
+					t.Fatalf("unexpected code: %q", got.Code)
+				}
+				return
+			}
+			// 接続元 IP も渡す（httptest の RemoteAddr は 192.0.2.1）
+			if tc.stub.reason != report.ReasonSpam || tc.stub.token != tc.token || tc.stub.ip != "192.0.2.1" {
+				t.Fatalf("report not passed through: %+v", tc.stub)
+			}
+		})
+	}
+}
+
+type stubReporter struct {
+	draw   *drawdomain.Draw
+	token  string
+	ip     string
+	reason report.Reason
+}
+
+func (s *stubReporter) Report(ctx context.Context, id drawdomain.ShareID, visitorToken, clientIP string, reason report.Reason) error {
+	if !reason.Valid() {
+		return report.ErrInvalidReason
+	}
+	if s.draw == nil || s.draw.ShareID() != id {
+		return repository.ErrDrawNotFound
+	}
+	s.token = visitorToken
+	s.ip = clientIP
+	s.reason = reason
+	return nil
+}
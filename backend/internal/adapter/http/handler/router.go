@@ -1,39 +1,178 @@
 package handler
 
 import (
-	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"strings"
 	"time"
 
+	"backend/internal/adapter/http/openapi"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
+// routerOptions は NewRouter の任意設定。
+type routerOptions struct {
+	tracingMiddleware gin.HandlerFunc
+	metricsMiddleware gin.HandlerFunc
+	livenessHandler   http.Handler
+	readinessHandler  http.Handler
+	rateLimits        RateLimits
+	cardHandler       *CardHandler
+	postEventsHandler *PostEventsHandler
+	reactionHandler   *ReactionHandler
+	reportHandler     *ReportHandler
+	adminHandler      *AdminHandler
+	adminAuth         gin.HandlerFunc
+	trustedProxies    []string
+	clientIPHeader    string
+}
+
+// RouterOption は NewRouter に任意の設定を渡す。
+type RouterOption func(*routerOptions)
+
+// WithMetrics はリクエストを計測するミドルウェアを設定する。/metrics 自体は公開ルーターに載せず、内部ポートで配信する。
+func WithMetrics(middleware gin.HandlerFunc) RouterOption {
+	return func(o *routerOptions) {
+		o.metricsMiddleware = middleware
+	}
+}
+
+// WithTracing はリクエストごとにスパンを作るミドルウェアを設定する。ログにトレース ID を添えられるよう最初に通す。
+func WithTracing(middleware gin.HandlerFunc) RouterOption {
+	return func(o *routerOptions) {
+		o.tracingMiddleware = middleware
+	}
+}
+
+// WithHealth は /healthz（プロセスの死活）と /readyz（リクエストを受けられるか）で応答するハンドラーを設定する。
+func WithHealth(liveness, readiness http.Handler) RouterOption {
+	return func(o *routerOptions) {
+		o.livenessHandler = liveness
+		o.readinessHandler = readiness
+	}
+}
+
+// WithTrustedProxies は接続元 IP を決めるときに信頼する中継元と、前段が必ず上書きする接続元 IP のヘッダーを設定する。
+// 設定しない場合は転送ヘッダーを信頼せず、接続してきた相手の IP を使う。
+func WithTrustedProxies(proxies []string, clientIPHeader string) RouterOption {
+	return func(o *routerOptions) {
+		o.trustedProxies = proxies
+		o.clientIPHeader = clientIPHeader
+	}
+}
+
+// RateLimits はルートごとの呼び出し上限。それぞれ別の予算で数え、nil の項目は制限しない。
+type RateLimits struct {
+	// POST /posts
+	Posts gin.HandlerFunc
+	// GET /draws/random と GET /draws/:id
+	Draws gin.HandlerFunc
+	// GET /draws/:id/card.png（画像を描くため閲覧より重い）
+	Cards gin.HandlerFunc
+	// GET /posts/:id/events（接続を開いたままにするため、開く回数を絞る）
+	PostEvents gin.HandlerFunc
+	// POST /draws/:id/reactions（書き込みのため閲覧より厳しくする）
+	Reactions gin.HandlerFunc
+	// POST /draws/:id/reports（おみくじを非公開にしうる書き込みのため、反応よりさらに厳しくする）
+	Reports gin.HandlerFunc
+}
+
+// WithRateLimits はルートごとに別の予算で呼び出し上限を設定する。
+func WithRateLimits(limits RateLimits) RouterOption {
+	return func(o *routerOptions) {
+		o.rateLimits = limits
+	}
+}
+
+// WithShareCards はおみくじの共有カード画像（GET /draws/:id/card.png）を返すハンドラーを設定する。
+func WithShareCards(h *CardHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.cardHandler = h
+	}
+}
+
+// WithPostEvents は投稿の進み具合（GET /posts/:id/events）を届けるハンドラーを設定する。
+func WithPostEvents(h *PostEventsHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.postEventsHandler = h
+	}
+}
+
+// WithReactions はおみくじへの反応（POST /draws/:id/reactions）を受け付けるハンドラーを設定する。
+func WithReactions(h *ReactionHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.reactionHandler = h
+	}
+}
+
+// WithReports はおみくじへの通報（POST /draws/:id/reports）を受け付けるハンドラーを設定する。
+func WithReports(h *ReportHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.reportHandler = h
+	}
+}
+
+// WithAdmin は /admin 以下の管理 API を設定する。auth を通ったリクエストだけをハンドラーへ渡す。
+func WithAdmin(h *AdminHandler, auth gin.HandlerFunc) RouterOption {
+	return func(o *routerOptions) {
+		o.adminHandler = h
+		o.adminAuth = auth
+	}
+}
+
 // NewRouter は HTTP ハンドラーを紐づけた gin.Engine を返す。
-func NewRouter(drawHandler *DrawHandler, postHandler *PostHandler) *gin.Engine {
+func NewRouter(drawHandler *DrawHandler, postHandler *PostHandler, opts ...RouterOption) *gin.Engine {
+	var options routerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	router := gin.New()
-	router.Use(gin.Recovery())
+	// gin は既定ですべての中継元を信頼するため、偽の X-Forwarded-For で呼び出し上限の予算を取り直せてしまう
+	if err := router.SetTrustedProxies(options.trustedProxies); err != nil {
+		slog.Error("invalid trusted proxies; ignoring forwarded headers", slog.Any("error", err))
+		_ = router.SetTrustedProxies(nil)
+	}
+	router.TrustedPlatform = options.clientIPHeader
+	// 監視から頻繁に呼ばれるため、アクセスログ・計測・トレースの対象より先に登録する
+	if options.livenessHandler != nil {
+		router.GET("/healthz", gin.WrapH(options.livenessHandler))
+	}
+	if options.readinessHandler != nil {
+		router.GET("/readyz", gin.WrapH(options.readinessHandler))
+	}
+	if options.tracingMiddleware != nil {
+		router.Use(options.tracingMiddleware)
+	}
+	// リクエスト ID を最初に決め、以降のログ（パニック復旧を含む）へ添える
+	router.Use(RequestID(), AccessLog(), gin.Recovery())
+	if options.metricsMiddleware != nil {
+		router.Use(options.metricsMiddleware)
+	}
 
 	// CORS設定
 	config := cors.Config{
-		AllowMethods:     []string{"GET", "POST", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", RequestIDHeader, VisitorTokenHeader, "traceparent", "tracestate"},
+		ExposeHeaders:    []string{"Content-Length", RequestIDHeader, "Retry-After", "Deprecation", "Sunset", "Link"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
 
 	corsOrigins := os.Getenv("CORS_ALLOW_ORIGINS")
 	if corsOrigins == "" {
-		log.Println("警告: CORS_ALLOW_ORIGINS が設定されていません。開発用のデフォルト http://localhost:3000 を使用します")
+		slog.Warn("CORS_ALLOW_ORIGINS is not set; falling back to http://localhost:3000 for development")
 		config.AllowOrigins = []string{"http://localhost:3000"}
 	} else {
 		origins := strings.Split(corsOrigins, ",")
 		for i := range origins {
 			origins[i] = strings.TrimSpace(origins[i])
 			if !strings.HasPrefix(origins[i], "http://") && !strings.HasPrefix(origins[i], "https://") {
-				log.Fatalf("CORS_ALLOW_ORIGINS の値が不正です: %s", origins[i])
+				slog.Error("invalid CORS_ALLOW_ORIGINS entry", slog.String("origin", origins[i]))
+				os.Exit(1)
 			}
 		}
 		config.AllowOrigins = origins
@@ -41,8 +180,39 @@ func NewRouter(drawHandler *DrawHandler, postHandler *PostHandler) *gin.Engine {
 
 	router.Use(cors.New(config))
 
-	router.GET("/draws/random", drawHandler.GetRandomDraw)
-	router.POST("/posts", postHandler.CreatePost)
+	// 各ハンドラーがバージョンごとに自分のルートを登録する。レスポンスの形を変えるときは新しいバージョンを足す
+	v1 := router.Group("/" + APIVersionV1)
+	drawHandler.RegisterV1(v1, options.rateLimits.Draws)
+	postHandler.RegisterV1(v1, options.rateLimits.Posts)
+	if options.cardHandler != nil {
+		options.cardHandler.RegisterV1(v1, options.rateLimits.Cards)
+	}
+	if options.postEventsHandler != nil {
+		options.postEventsHandler.RegisterV1(v1, options.rateLimits.PostEvents)
+	}
+	if options.reactionHandler != nil {
+		options.reactionHandler.RegisterV1(v1, options.rateLimits.Reactions)
+	}
+	if options.reportHandler != nil {
+		options.reportHandler.RegisterV1(v1, options.rateLimits.Reports)
+	}
+	// 配布済みのフロントエンドが呼ぶ 2 本だけ、バージョン無しのパスを非推奨の別名として残す。新しいルートは /v1 にだけ置く
+	legacy := router.Group("", DeprecatedAlias(APIVersionV1, legacyDeprecatedAt, legacySunset))
+	drawHandler.RegisterLegacy(legacy, options.rateLimits.Draws)
+	postHandler.RegisterLegacy(legacy, options.rateLimits.Posts)
+	// 認証を設定しないまま管理 API を公開しないよう、両方そろったときだけ登録する
+	if options.adminHandler != nil && options.adminAuth != nil {
+		options.adminHandler.Register(router.Group("/admin", options.adminAuth))
+	}
+	router.GET("/openapi.json", gin.WrapH(openapi.Handler()))
 
 	return router
 }
+
+// withOptional は設定されたミドルウェアがあればハンドラーの前に挟む。
+func withOptional(middleware gin.HandlerFunc, handler gin.HandlerFunc) []gin.HandlerFunc {
+	if middleware == nil {
+		return []gin.HandlerFunc{handler}
+	}
+	return []gin.HandlerFunc{middleware, handler}
+}
@@ -0,0 +1,34 @@
+package handler
+
+// 危機的な兆候がある投稿に返す案内文。
+const messageSupport = "ひとりで抱え込まずに、話を聞いてくれる窓口を頼ってください。この投稿はおみくじにはしません。"
+
+// 相談窓口 1 件分。
+type SupportResource struct {
+	Name    string `json:"name"`
+	Contact string `json:"contact,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// 危機判定時のレスポンスに含める案内。
+type SupportResponse struct {
+	Message   string            `json:"message"`
+	Resources []SupportResource `json:"resources"`
+}
+
+// DefaultSupportResources は国内の公的・民間の相談窓口。
+var DefaultSupportResources = []SupportResource{
+	{Name: "よりそいホットライン", Contact: "0120-279-338"},
+	{Name: "いのちの電話", Contact: "0570-783-556"},
+	{Name: "こころの健康相談統一ダイヤル", Contact: "0570-064-556"},
+	{Name: "まもろうよ こころ（厚生労働省）", URL: "https://www.mhlw.go.jp/mamorouyokokoro/"},
+}
+
+/**
+ * 既定の相談窓口で案内レスポンスを組み立てる。
+ */
+func newSupportResponse() *SupportResponse {
+	resources := make([]SupportResource, len(DefaultSupportResources))
+	copy(resources, DefaultSupportResources)
+	return &SupportResponse{Message: messageSupport, Resources: resources}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersionV1 は現行の API バージョン。ルートは /v1 配下に置く。
+const APIVersionV1 = "v1"
+
+// バージョン無しのパス（/draws/random, /posts）を非推奨にした日時と、提供を終える予定日時。
+var (
+	legacyDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	legacySunset       = time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+)
+
+/**
+ * 非推奨の別名ルートに Deprecation（RFC 9745）と Sunset（RFC 8594）ヘッダーを付けるミドルウェア。
+ * 移行先は Link ヘッダー（rel="successor-version"）で /<version> 配下のパスを示す。
+ */
+func DeprecatedAlias(version string, deprecatedAt, sunset time.Time) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	sunsetValue := sunset.UTC().Format(http.TimeFormat)
+	return func(c *gin.Context) {
+		// ルートのパターン（/draws/:id）ではなく、実際に呼ばれたパスとクエリで移行先を示す
+		successor := "/" + version + c.Request.URL.EscapedPath()
+		if c.Request.URL.RawQuery != "" {
+			successor += "?" + c.Request.URL.RawQuery
+		}
+		c.Header("Deprecation", deprecation)
+		c.Header("Sunset", sunsetValue)
+		c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
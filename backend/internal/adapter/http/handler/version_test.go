@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewRouter_Versioning(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stub := &stubCreatePostUsecase{}
+	router := NewRouter(NewDrawHandler(&stubFortuneUsecase{draw: newVerifiedDraw(t, "post-v1", "大吉")}), NewPostHandler(stub))
+
+	post := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"post_id":"dark-1","content":"hello"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("v1 routes are not deprecated", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/draws/random", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d", http.StatusOK, rec.Code)
+		}
+		if rec.Header().Get("Deprecation") != "" || rec.Header().Get("Sunset") != "" {
+			t.Fatalf("v1 route should not carry deprecation headers: %v", rec.Header())
+		}
+		if rec := post("/v1/posts"); rec.Code != http.StatusCreated {
+			t.Fatalf("expected %d, got %d", http.StatusCreated, rec.Code)
+		}
+	})
+
+	t.Run("bare paths are deprecated aliases", func(t *testing.T) {
+		rec := post("/posts")
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected %d, got %d", http.StatusCreated, rec.Code)
+		}
+		if got := rec.Header().Get("Deprecation"); got != "@1792281600" {
+			t.Fatalf("unexpected Deprecation: %q", got)
+		}
+		if got := rec.Header().Get("Sunset"); got != "Thu, 01 Apr 2027 00:00:00 GMT" {
+			t.Fatalf("unexpected Sunset: %q", got)
+		}
+		if got := rec.Header().Get("Link"); got != `</v1/posts>; rel="successor-version"` {
+			t.Fatalf("unexpected Link: %q", got)
+		}
+	})
+}
+
+func TestDeprecatedAlias_LinksConcretePath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/draws/:id", DeprecatedAlias(APIVersionV1, legacyDeprecatedAt, legacySunset), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/draws/share-123?ref=x", nil))
+	if got := rec.Header().Get("Link"); got != `</v1/draws/share-123?ref=x>; rel="successor-version"` {
+		t.Fatalf("unexpected Link: %q", got)
+	}
+}
@@ -0,0 +1,31 @@
+// Package openapi は API の OpenAPI 3 定義を持つ。
+// 定義は openapi.json を正とし、ハンドラーとの食い違いは handler パッケージの契約テストで検出する。
+// フロントエンドの型はこの定義から生成する。
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+/**
+ * OpenAPI 定義（JSON）を返す。呼び出し元が書き換えても影響しないよう複製を渡す。
+ */
+func JSON() []byte {
+	out := make([]byte, len(spec))
+	copy(out, spec)
+	return out
+}
+
+/**
+ * OpenAPI 定義をそのまま返す HTTP ハンドラー。
+ */
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(spec)
+	})
+}
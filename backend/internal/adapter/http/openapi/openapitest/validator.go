@@ -0,0 +1,106 @@
+// Package openapitest はテストで HTTP のやり取りを OpenAPI 定義と突き合わせる。
+package openapitest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/adapter/http/openapi"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// Server-Sent Events のストリームは本文を文字列として扱い、形は検証しない。
+func init() {
+	openapi3filter.RegisterBodyDecoder("text/event-stream", openapi3filter.PlainBodyDecoder)
+}
+
+// Validator は OpenAPI 定義を読み込んだ検証器。
+type Validator struct {
+	doc    *openapi3.T
+	router routers.Router
+}
+
+/**
+ * 埋め込みの OpenAPI 定義を読み込み、定義自体の妥当性も確かめたうえで検証器を返す。
+ */
+func New(t testing.TB) *Validator {
+	t.Helper()
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(openapi.JSON())
+	if err != nil {
+		t.Fatalf("openapitest: load spec: %v", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		t.Fatalf("openapitest: invalid spec: %v", err)
+	}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("openapitest: build router: %v", err)
+	}
+	return &Validator{doc: doc, router: router}
+}
+
+// Doc は読み込んだ OpenAPI 定義を返す。
+func (v *Validator) Doc() *openapi3.T {
+	return v.doc
+}
+
+/**
+ * next を通したやり取りを検証する HTTP ミドルウェア。食い違いは t.Errorf で報告する。
+ * 定義に無いルートや、定義に無いステータス・形のレスポンスは常に報告する。
+ * リクエストの食い違いは、ハンドラーが受け付けた（2xx を返した）ときだけ報告する。
+ * 不正な入力を 4xx で弾くこと自体はハンドラーの正しい振る舞いのため。
+ */
+func (v *Validator) Wrap(t testing.TB, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		t.Helper()
+		route, pathParams, err := v.router.FindRoute(req)
+		if err != nil {
+			t.Errorf("openapitest: %s %s is not in the spec: %v", req.Method, req.URL.Path, err)
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		options := &openapi3filter.Options{
+			AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+			IncludeResponseStatus: true,
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		// 本文は検証で読み切られるため、検証後に読み直せるよう差し替えられる
+		requestErr := openapi3filter.ValidateRequest(req.Context(), input)
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, req)
+
+		if requestErr != nil && rec.Code >= 200 && rec.Code < 300 {
+			t.Errorf("openapitest: %s %s accepted a request the spec rejects: %v", req.Method, req.URL.Path, requestErr)
+		}
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 rec.Code,
+			Header:                 rec.Header(),
+			Options:                options,
+		}
+		responseErr := openapi3filter.ValidateResponse(req.Context(), responseInput.SetBodyBytes(rec.Body.Bytes()))
+		if responseErr != nil {
+			t.Errorf("openapitest: %s %s returned %d that does not match the spec: %v", req.Method, req.URL.Path, rec.Code, responseErr)
+		}
+
+		for key, values := range rec.Header() {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(rec.Body.Bytes())
+	})
+}
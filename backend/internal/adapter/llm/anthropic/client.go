@@ -0,0 +1,127 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBaseURL  = "https://api.anthropic.com"
+	messagesPath    = "/v1/messages"
+	apiVersion      = "2023-06-01"
+	requestTimeout  = 60 * time.Second
+	roleUser        = "user"
+	contentTypeText = "text"
+)
+
+// Messages API に送るメッセージ 1 件分。
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Messages API に送るリクエスト本文。
+type MessageRequest struct {
+	Model       string    `json:"model"`
+	MaxTokens   int       `json:"max_tokens"`
+	Temperature float32   `json:"temperature"`
+	Messages    []Message `json:"messages"`
+}
+
+// 応答に含まれるコンテンツブロック。
+type ContentBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Messages API の応答本文。
+type MessageResponse struct {
+	ID         string         `json:"id"`
+	Content    []ContentBlock `json:"content"`
+	StopReason string         `json:"stop_reason"`
+}
+
+// Messages API が失敗時に返すエラー本文。
+type errorResponse struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// net/http で Messages API を直接呼び出す既定のクライアント。
+type httpMessagesClient struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+/**
+ * API キーと接続先から Messages API 用のクライアントを組み立てる。
+ * HTTP クライアント未指定時はタイムアウト付きの既定値を使う。
+ */
+func newHTTPMessagesClient(apiKey, baseURL string, httpClient *http.Client) *httpMessagesClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: requestTimeout}
+	}
+	return &httpMessagesClient{
+		apiKey:     apiKey,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+/**
+ * Messages API へリクエストを送り、2xx 以外はエラー本文を添えて返す。
+ */
+func (c *httpMessagesClient) CreateMessage(ctx context.Context, req MessageRequest) (MessageResponse, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return MessageResponse{}, fmt.Errorf("encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+messagesPath, bytes.NewReader(payload))
+	if err != nil {
+		return MessageResponse{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", c.apiKey)
+	httpReq.Header.Set("anthropic-version", apiVersion)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return MessageResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return MessageResponse{}, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return MessageResponse{}, decodeAPIError(resp.StatusCode, body)
+	}
+
+	var decoded MessageResponse
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return MessageResponse{}, fmt.Errorf("decode response: %w", err)
+	}
+	return decoded, nil
+}
+
+/**
+ * エラー応答から種別とメッセージを取り出し、読めない場合はステータスだけ返す。
+ */
+func decodeAPIError(statusCode int, body []byte) error {
+	var apiErr errorResponse
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Error.Message == "" {
+		return fmt.Errorf("anthropic status=%d", statusCode)
+	}
+	return fmt.Errorf("anthropic status=%d type=%s: %s", statusCode, apiErr.Error.Type, apiErr.Error.Message)
+}
@@ -0,0 +1,70 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPMessagesClient_CreateMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != messagesPath {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("x-api-key") != "secret" || r.Header.Get("anthropic-version") != apiVersion {
+			t.Fatalf("missing auth headers: %v", r.Header)
+		}
+		var req MessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Model != "claude-test" || len(req.Messages) != 1 {
+			t.Fatalf("unexpected request: %+v", req)
+		}
+		_, _ = w.Write([]byte(`{"id":"msg_1","content":[{"type":"text","text":"ok"}],"stop_reason":"end_turn"}`))
+	}))
+	defer server.Close()
+
+	client := newHTTPMessagesClient("secret", server.URL, server.Client())
+	resp, err := client.CreateMessage(context.Background(), MessageRequest{
+		Model:     "claude-test",
+		MaxTokens: 16,
+		Messages:  []Message{{Role: roleUser, Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Content) != 1 || resp.Content[0].Text != "ok" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestHTTPMessagesClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"type":"error","error":{"type":"rate_limit_error","message":"slow down"}}`))
+	}))
+	defer server.Close()
+
+	client := newHTTPMessagesClient("secret", server.URL, server.Client())
+	_, err := client.CreateMessage(context.Background(), MessageRequest{Model: "m"})
+	if err == nil || !strings.Contains(err.Error(), "rate_limit_error") || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("expected api error with type and status, got %v", err)
+	}
+}
+
+func TestHTTPMessagesClient_UnreadableError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`<html>bad gateway</html>`))
+	}))
+	defer server.Close()
+
+	client := newHTTPMessagesClient("secret", server.URL, server.Client())
+	if _, err := client.CreateMessage(context.Background(), MessageRequest{Model: "m"}); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("expected status error, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package anthropic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend/internal/adapter/llm/fortune"
+	"backend/internal/adapter/llm/prompt"
+	"backend/internal/config"
+	"backend/internal/port/llm"
+)
+
+const (
+	maxOutputTokens = 1024
+	temperature     = 0.4
+)
+
+/**
+ * Anthropic の Messages API へリクエストを送るのに必要な最小限の操作をまとめた窓口。
+ */
+type MessagesClient interface {
+	CreateMessage(ctx context.Context, req MessageRequest) (MessageResponse, error)
+}
+
+/**
+ * Claude と会話して闇投稿を整形し、検証処理も担う本体。
+ */
+type Formatter struct {
+	client MessagesClient
+	model  string
+	prompt *prompt.Template
+}
+
+/**
+ * API キーやモデル名を点検してから Claude との橋渡し役を組み立てる。
+ */
+func NewFormatter(apiKey, model string) (*Formatter, error) {
+	if strings.TrimSpace(apiKey) == "" {
+		return nil, fmt.Errorf("anthropic formatter: API キーが設定されていません")
+	}
+	if model == "" {
+		model = config.DefaultAnthropicModel
+	}
+	return &Formatter{
+		client: newHTTPMessagesClient(apiKey, defaultBaseURL, nil),
+		model:  model,
+	}, nil
+}
+
+/**
+ * 整形に使うプロンプトテンプレートを差し替える。nil の場合は既定のままにする。
+ */
+func (f *Formatter) WithPrompt(tmpl *prompt.Template) *Formatter {
+	if tmpl != nil {
+		f.prompt = tmpl
+	}
+	return f
+}
+
+/**
+ * HTTP クライアントは後片付け不要なので互換性のためだけに戻り値を返す。
+ */
+func (f *Formatter) Close() error {
+	return nil
+}
+
+/**
+ * 闇投稿本文を Claude に渡し、整形した文章を検証待ちの状態で受け取る。
+ */
+func (f *Formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
+	if err := fortune.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tmpl := prompt.OrDefault(f.prompt)
+	promptText, err := tmpl.Build(string(req.DarkContent))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.CreateMessage(ctx, MessageRequest{
+		Model:       f.model,
+		MaxTokens:   maxOutputTokens,
+		Temperature: temperature,
+		Messages: []Message{
+			{Role: roleUser, Content: promptText},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", llm.ErrFormatterUnavailable, err)
+	}
+
+	text, err := extractFirstText(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return fortune.PendingResult(ctx, "anthropic", req, text, tmpl.Version()), nil
+}
+
+/**
+ * 整形済みの文章を共通の規約で検証し、公開可否を決める。
+ */
+func (f *Formatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	return fortune.Validate(ctx, result)
+}
+
+/**
+ * Claude の応答ブロックから text 型の本文を連結し、余白を取り除いて返す。
+ */
+func extractFirstText(resp MessageResponse) (string, error) {
+	var builder strings.Builder
+	for _, block := range resp.Content {
+		if block.Type != contentTypeText {
+			continue
+		}
+		builder.WriteString(block.Text)
+	}
+	trimmed := strings.TrimSpace(builder.String())
+	if trimmed == "" {
+		return "", llm.ErrInvalidFormat
+	}
+	return trimmed, nil
+}
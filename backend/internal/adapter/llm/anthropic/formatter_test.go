@@ -0,0 +1,140 @@
+package anthropic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"backend/internal/config"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/logging/logtest"
+	"backend/internal/port/llm"
+)
+
+type stubMessagesClient struct {
+	resp        MessageResponse
+	err         error
+	capturedCtx context.Context
+	capturedReq MessageRequest
+}
+
+var fortuneValid = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続いています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進めます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
+
+func (s *stubMessagesClient) CreateMessage(ctx context.Context, req MessageRequest) (MessageResponse, error) {
+	s.capturedCtx = ctx
+	s.capturedReq = req
+	return s.resp, s.err
+}
+
+func TestFormatterFormatSuccess(t *testing.T) {
+	client := &stubMessagesClient{
+		resp: MessageResponse{Content: []ContentBlock{{Type: "text", Text: " 整形済み "}}},
+	}
+	f := &Formatter{client: client, model: "test-model"}
+
+	res, err := f.Format(context.Background(), &llm.FormatRequest{
+		DarkPostID:  "post-1",
+		DarkContent: "闇",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(res.FormattedContent) != "整形済み" {
+		t.Fatalf("unexpected content: %q", res.FormattedContent)
+	}
+	if res.Status != drawdomain.StatusPending {
+		t.Fatalf("expected pending status, got %s", res.Status)
+	}
+	if client.capturedReq.Model != "test-model" || client.capturedReq.MaxTokens != maxOutputTokens {
+		t.Fatalf("unexpected request: %+v", client.capturedReq)
+	}
+	if len(client.capturedReq.Messages) != 1 || !strings.Contains(client.capturedReq.Messages[0].Content, "闇") {
+		t.Fatalf("prompt should contain original content: %+v", client.capturedReq.Messages)
+	}
+}
+
+func TestFormatterFormatClientError(t *testing.T) {
+	f := &Formatter{client: &stubMessagesClient{err: errors.New("boom")}, model: "test"}
+
+	_, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p", DarkContent: "x"})
+	if !errors.Is(err, llm.ErrFormatterUnavailable) {
+		t.Fatalf("expected formatter unavailable, got %v", err)
+	}
+}
+
+func TestFormatterFormatInvalidResponse(t *testing.T) {
+	f := &Formatter{client: &stubMessagesClient{}, model: "test"}
+
+	_, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p", DarkContent: "x"})
+	if !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format, got %v", err)
+	}
+}
+
+func TestFormatterFormatInvalidRequest(t *testing.T) {
+	f := &Formatter{client: &stubMessagesClient{}, model: "test"}
+	if _, err := f.Format(context.Background(), nil); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format for nil request, got %v", err)
+	}
+	if _, err := f.Format(context.Background(), &llm.FormatRequest{}); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format, got %v", err)
+	}
+}
+
+func TestFormatterValidate(t *testing.T) {
+	f := &Formatter{}
+	result, err := f.Validate(context.Background(), &llm.FormatResult{
+		DarkPostID:       "post",
+		FormattedContent: drawdomain.FormattedContent(fortuneValid),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != drawdomain.StatusVerified {
+		t.Fatalf("expected verified, got %s", result.Status)
+	}
+}
+
+func TestExtractFirstTextSkipsNonText(t *testing.T) {
+	text, err := extractFirstText(MessageResponse{Content: []ContentBlock{
+		{Type: "thinking", Text: "ignored"},
+		{Type: "text", Text: "今日の"},
+		{Type: "text", Text: "きらくじ"},
+	}})
+	if err != nil || text != "今日のきらくじ" {
+		t.Fatalf("unexpected result: %q %v", text, err)
+	}
+}
+
+func TestNewFormatterRequiresKey(t *testing.T) {
+	if _, err := NewFormatter(" ", "model"); err == nil {
+		t.Fatalf("expected error when key is missing")
+	}
+}
+
+func TestNewFormatterDefaults(t *testing.T) {
+	f, err := NewFormatter("dummy", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.model != config.DefaultAnthropicModel {
+		t.Fatalf("expected default model, got %s", f.model)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("close should succeed")
+	}
+}
+
+func TestFormatterFormatDoesNotLogContent(t *testing.T) {
+	buf := logtest.Capture(t)
+	client := &stubMessagesClient{
+		resp: MessageResponse{Content: []ContentBlock{{Type: "text", Text: "整形された秘密の文章"}}},
+	}
+	f := &Formatter{client: client, model: "test-model"}
+
+	if _, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "post-secret", DarkContent: "元の闇"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logtest.AssertNotContains(t, buf, "post-secret", "元の闇", "整形された秘密の文章")
+}
@@ -0,0 +1,121 @@
+package circuit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"backend/internal/port/llm"
+)
+
+// ErrCircuitOpen は接続失敗が続いたため LLM 呼び出しを止めている状態を表す。
+// llm.ErrFormatterUnavailable としても判定できるよう包んで返す。
+var ErrCircuitOpen = errors.New("circuit: LLM への接続失敗が続いたため呼び出しを止めています")
+
+// 回路の状態
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half_open"
+)
+
+/**
+ * 接続失敗が続いたら一定時間 LLM を呼ばずに失敗させる整形器。
+ * 止めている間も待機時間が過ぎたら 1 件だけ試し、成功すれば元に戻す。
+ * 接続失敗（llm.ErrFormatterUnavailable）以外のエラーは LLM が応答できている証拠として扱う。
+ */
+type Formatter struct {
+	next      llm.Formatter
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+var _ llm.Formatter = (*Formatter)(nil)
+
+/**
+ * 連続失敗の上限と、止めてから再度試すまでの待機時間を指定して整形器を包む。
+ */
+func NewFormatter(next llm.Formatter, threshold int, cooldown time.Duration) *Formatter {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &Formatter{next: next, threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+func (f *Formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
+	if !f.allow() {
+		return nil, fmt.Errorf("%w: %w", llm.ErrFormatterUnavailable, ErrCircuitOpen)
+	}
+	result, err := f.next.Format(ctx, req)
+	f.record(err)
+	return result, err
+}
+
+// 検証は手元で完結するため回路の対象にしない
+func (f *Formatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	return f.next.Validate(ctx, result)
+}
+
+/**
+ * 現在の回路の状態（closed / open / half_open）を返す。
+ */
+func (f *Formatter) State() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.stateLocked()
+}
+
+func (f *Formatter) stateLocked() string {
+	if f.failures < f.threshold {
+		return StateClosed
+	}
+	if f.probing || f.now().Sub(f.openedAt) >= f.cooldown {
+		return StateHalfOpen
+	}
+	return StateOpen
+}
+
+/**
+ * 呼び出してよいかを判定する。待機明けは試しの 1 件だけを通す。
+ */
+func (f *Formatter) allow() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch f.stateLocked() {
+	case StateClosed:
+		return true
+	case StateHalfOpen:
+		if f.probing {
+			return false
+		}
+		f.probing = true
+		return true
+	default:
+		return false
+	}
+}
+
+/**
+ * 呼び出し結果から連続失敗数を更新する。
+ */
+func (f *Formatter) record(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.probing = false
+	if !errors.Is(err, llm.ErrFormatterUnavailable) {
+		f.failures = 0
+		return
+	}
+	f.failures++
+	if f.failures >= f.threshold {
+		f.openedAt = f.now()
+	}
+}
@@ -0,0 +1,94 @@
+package circuit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/internal/port/llm"
+	workertestutil "backend/internal/usecase/worker/testutil"
+)
+
+func newTestFormatter(stub *workertestutil.StubFormatter, now *time.Time) *Formatter {
+	f := NewFormatter(stub, 2, time.Minute)
+	f.now = func() time.Time { return *now }
+	return f
+}
+
+/**
+ * 接続失敗が続いたら LLM を呼ばずに失敗させ、待機明けの試行が成功すれば戻ることを確認する。
+ */
+func TestFormatter_OpensAfterConsecutiveFailures(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stub := &workertestutil.StubFormatter{FormatErr: llm.ErrFormatterUnavailable}
+	f := newTestFormatter(stub, &now)
+
+	for range 2 {
+		_, _ = f.Format(context.Background(), &llm.FormatRequest{})
+	}
+	if f.State() != StateOpen {
+		t.Fatalf("state = %s, want open", f.State())
+	}
+
+	_, err := f.Format(context.Background(), &llm.FormatRequest{})
+	if !errors.Is(err, ErrCircuitOpen) || !errors.Is(err, llm.ErrFormatterUnavailable) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+	if stub.FormatCalls != 2 {
+		t.Fatalf("open circuit should not call the LLM: calls = %d", stub.FormatCalls)
+	}
+
+	now = now.Add(time.Minute)
+	if f.State() != StateHalfOpen {
+		t.Fatalf("state = %s, want half_open", f.State())
+	}
+	stub.FormatErr = nil
+	stub.FormatResult = &llm.FormatResult{}
+	if _, err := f.Format(context.Background(), &llm.FormatRequest{}); err != nil {
+		t.Fatalf("probe should reach the LLM: %v", err)
+	}
+	if f.State() != StateClosed {
+		t.Fatalf("state = %s, want closed", f.State())
+	}
+}
+
+/**
+ * 待機明けの試行が失敗すればまた止めることを確認する。
+ */
+func TestFormatter_ReopensWhenProbeFails(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stub := &workertestutil.StubFormatter{FormatErr: llm.ErrFormatterUnavailable}
+	f := newTestFormatter(stub, &now)
+	for range 2 {
+		_, _ = f.Format(context.Background(), &llm.FormatRequest{})
+	}
+
+	now = now.Add(time.Minute)
+	_, _ = f.Format(context.Background(), &llm.FormatRequest{})
+	if f.State() != StateOpen {
+		t.Fatalf("state = %s, want open", f.State())
+	}
+	if stub.FormatCalls != 3 {
+		t.Fatalf("half open should let one probe through: calls = %d", stub.FormatCalls)
+	}
+}
+
+/**
+ * 接続失敗以外のエラーは LLM が応答している証拠として数え直すことを確認する。
+ */
+func TestFormatter_OtherErrorsResetFailures(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stub := &workertestutil.StubFormatter{FormatErr: llm.ErrFormatterUnavailable}
+	f := newTestFormatter(stub, &now)
+
+	_, _ = f.Format(context.Background(), &llm.FormatRequest{})
+	stub.FormatErr = llm.ErrInvalidFormat
+	_, _ = f.Format(context.Background(), &llm.FormatRequest{})
+	stub.FormatErr = llm.ErrFormatterUnavailable
+	_, _ = f.Format(context.Background(), &llm.FormatRequest{})
+
+	if f.State() != StateClosed {
+		t.Fatalf("state = %s, want closed", f.State())
+	}
+}
@@ -0,0 +1,121 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"backend/internal/port/llm"
+)
+
+var (
+	ErrNoVariants    = errors.New("experiment: バリアントが指定されていません")
+	ErrInvalidWeight = errors.New("experiment: バリアントの重みが不正です")
+)
+
+/**
+ * A/B 実験の 1 バリアント。
+ * @param Version プロンプトのバージョン（結果の PromptVersion と一致させる）
+ * @param Weight 配分の重み（0 なら新規割り当てを止める）
+ * @param Formatter そのプロンプトを設定済みの整形器
+ */
+type Variant struct {
+	Version   string
+	Weight    int
+	Formatter llm.Formatter
+}
+
+/**
+ * 投稿 ID のハッシュでバリアントを決め、該当する整形器へ処理を委ねる整形器。
+ * 同じ投稿は再整形されても常に同じバリアントに割り当てられる。
+ */
+type Formatter struct {
+	variants    []Variant
+	totalWeight uint32
+}
+
+var _ llm.Formatter = (*Formatter)(nil)
+
+/**
+ * バリアント一覧から実験用の整形器を組み立てる。
+ * 重みの合計が 0 の場合や整形器が欠けている場合はエラーにする。
+ */
+func NewFormatter(variants []Variant) (*Formatter, error) {
+	if len(variants) == 0 {
+		return nil, ErrNoVariants
+	}
+	var total uint32
+	for _, v := range variants {
+		if strings.TrimSpace(v.Version) == "" || v.Formatter == nil {
+			return nil, fmt.Errorf("%w: バージョンと整形器が必要です", ErrNoVariants)
+		}
+		if v.Weight < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWeight, v.Version)
+		}
+		total += uint32(v.Weight)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: 重みの合計が 0 です", ErrInvalidWeight)
+	}
+	copied := make([]Variant, len(variants))
+	copy(copied, variants)
+	return &Formatter{variants: copied, totalWeight: total}, nil
+}
+
+/**
+ * 投稿 ID から割り当てるバリアントのバージョンを返す。
+ */
+func (f *Formatter) Assign(postID string) string {
+	return f.assign(postID).Version
+}
+
+/**
+ * 割り当てたバリアントで整形し、結果にはそのプロンプトのバージョンを残す。
+ */
+func (f *Formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: 整形リクエストが空です", llm.ErrInvalidFormat)
+	}
+	variant := f.assign(string(req.DarkPostID))
+	result, err := variant.Formatter.Format(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil && result.PromptVersion == "" {
+		result.PromptVersion = variant.Version
+	}
+	return result, nil
+}
+
+/**
+ * 整形に使ったバリアントの検証へ委ねる。該当が無ければ先頭のバリアントで検証する。
+ */
+func (f *Formatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	if result == nil {
+		return nil, fmt.Errorf("%w: 検証対象が空です", llm.ErrInvalidFormat)
+	}
+	for _, v := range f.variants {
+		if v.Version == result.PromptVersion {
+			return v.Formatter.Validate(ctx, result)
+		}
+	}
+	return f.variants[0].Formatter.Validate(ctx, result)
+}
+
+/**
+ * FNV-1a ハッシュを重みの合計で割った余りから、累積重みでバリアントを選ぶ。
+ */
+func (f *Formatter) assign(key string) Variant {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	point := h.Sum32() % f.totalWeight
+	for _, v := range f.variants {
+		if point < uint32(v.Weight) {
+			return v
+		}
+		point -= uint32(v.Weight)
+	}
+	return f.variants[len(f.variants)-1]
+}
@@ -0,0 +1,113 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/port/llm"
+)
+
+type stubFormatter struct {
+	version   string
+	formatted int
+	validated int
+}
+
+func (s *stubFormatter) Format(_ context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
+	s.formatted++
+	return &llm.FormatResult{DarkPostID: req.DarkPostID, FormattedContent: "ok", Status: drawdomain.StatusPending, PromptVersion: s.version}, nil
+}
+
+func (s *stubFormatter) Validate(_ context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	s.validated++
+	result.Status = drawdomain.StatusVerified
+	return result, nil
+}
+
+func TestNewFormatterValidation(t *testing.T) {
+	if _, err := NewFormatter(nil); !errors.Is(err, ErrNoVariants) {
+		t.Fatalf("expected ErrNoVariants, got %v", err)
+	}
+	if _, err := NewFormatter([]Variant{{Version: "a", Weight: 1}}); !errors.Is(err, ErrNoVariants) {
+		t.Fatalf("expected ErrNoVariants when formatter is missing, got %v", err)
+	}
+	if _, err := NewFormatter([]Variant{{Version: "a", Weight: -1, Formatter: &stubFormatter{}}}); !errors.Is(err, ErrInvalidWeight) {
+		t.Fatalf("expected ErrInvalidWeight, got %v", err)
+	}
+	if _, err := NewFormatter([]Variant{{Version: "a", Weight: 0, Formatter: &stubFormatter{}}}); !errors.Is(err, ErrInvalidWeight) {
+		t.Fatalf("expected ErrInvalidWeight for zero total, got %v", err)
+	}
+}
+
+func TestAssignIsDeterministicAndWeighted(t *testing.T) {
+	f, err := NewFormatter([]Variant{
+		{Version: "a", Weight: 80, Formatter: &stubFormatter{version: "a"}},
+		{Version: "b", Weight: 20, Formatter: &stubFormatter{version: "b"}},
+		{Version: "c", Weight: 0, Formatter: &stubFormatter{version: "c"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		id := fmt.Sprintf("post-%d", i)
+		got := f.Assign(id)
+		if again := f.Assign(id); again != got {
+			t.Fatalf("assignment not deterministic for %s: %s vs %s", id, got, again)
+		}
+		counts[got]++
+	}
+	if counts["c"] != 0 {
+		t.Fatalf("zero-weight variant should not be assigned, got %d", counts["c"])
+	}
+	// 80:20 の配分から大きく外れていないこと
+	if counts["a"] < 1400 || counts["a"] > 1800 {
+		t.Fatalf("unexpected distribution: %v", counts)
+	}
+}
+
+func TestFormatAndValidateDelegateToAssignedVariant(t *testing.T) {
+	a := &stubFormatter{version: "a"}
+	b := &stubFormatter{version: "b"}
+	f, err := NewFormatter([]Variant{{Version: "a", Weight: 1, Formatter: a}, {Version: "b", Weight: 1, Formatter: b}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	postID := "post-1"
+	want := f.Assign(postID)
+	res, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "post-1", DarkContent: "闇"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.PromptVersion != want {
+		t.Fatalf("expected prompt version %s, got %s", want, res.PromptVersion)
+	}
+	if _, err := f.Validate(context.Background(), res); err != nil {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+	assigned, other := a, b
+	if want == "b" {
+		assigned, other = b, a
+	}
+	if assigned.formatted != 1 || assigned.validated != 1 || other.formatted != 0 || other.validated != 0 {
+		t.Fatalf("expected only the assigned variant to be used: a=%+v b=%+v", a, b)
+	}
+}
+
+func TestNilInputs(t *testing.T) {
+	f, err := NewFormatter([]Variant{{Version: "a", Weight: 1, Formatter: &stubFormatter{}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.Format(context.Background(), nil); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format, got %v", err)
+	}
+	if _, err := f.Validate(context.Background(), nil); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package fortune
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/logging"
+	"backend/internal/port/llm"
+	"backend/internal/port/moderation"
+)
+
+// 各 LLM 整形器で共有するきらくじの規約。
+const (
+	MaxFormattedLength    = 150
+	MinFormattedLength    = 30
+	Prefix                = "今日のきらくじ:"
+	ExpectedSentenceCount = 3
+)
+
+var rejectionKeywords = []string{"kill", "suicide", "die"}
+
+/**
+ * LLM の出力から検証待ちの整形結果を作る。
+ * 整形結果は本文を出さず、ハッシュ ID と文字数だけをログに残す（デバッグ用サンプルが有効なら期限付きの書き込み先へ送る）。
+ */
+func PendingResult(ctx context.Context, provider string, req *llm.FormatRequest, text, promptVersion string) *llm.FormatResult {
+	logging.Formatted(ctx, provider, string(req.DarkPostID), text)
+	return &llm.FormatResult{
+		DarkPostID:       req.DarkPostID,
+		FormattedContent: drawdomain.FormattedContent(text),
+		Status:           drawdomain.StatusPending,
+		PromptVersion:    promptVersion,
+	}
+}
+
+/**
+ * 整形依頼に ID と本文が入っているかを確かめる。
+ */
+func ValidateRequest(req *llm.FormatRequest) error {
+	if req == nil || req.DarkPostID == "" {
+		return llm.ErrInvalidFormat
+	}
+	if strings.TrimSpace(string(req.DarkContent)) == "" {
+		return llm.ErrInvalidFormat
+	}
+	return nil
+}
+
+/**
+ * 整形結果が投稿規約に沿っているかを再確認し、公開可否を決める。
+ * 禁止語や文字数違反などが見つかったら拒否理由を付けて返す。
+ */
+func Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	if result == nil || result.DarkPostID == "" {
+		return nil, llm.ErrInvalidFormat
+	}
+
+	trimmed := strings.TrimSpace(string(result.FormattedContent))
+	if trimmed == "" {
+		result.Status = drawdomain.StatusRejected
+		result.ValidationReason = "整形結果が空です"
+		return result, llm.ErrInvalidFormat
+	}
+
+	normalized := NormalizeText(trimmed)
+	if reason, rejected := ShouldReject(normalized); rejected {
+		result.Status = drawdomain.StatusRejected
+		result.ValidationReason = reason
+		return result, llm.ErrContentRejected
+	}
+
+	result.Status = drawdomain.StatusVerified
+	result.FormattedContent = drawdomain.FormattedContent(normalized)
+	result.ValidationReason = ""
+	return result, nil
+}
+
+/**
+ * 各プロバイダの検証の後に、出力側のモデレーションを加える整形器。
+ * 英語の禁止語だけでは拾えない日本語の自傷・加害表現を、規約を満たした整形結果に対して再確認する。
+ */
+type ModeratedFormatter struct {
+	next      llm.Formatter
+	moderator moderation.Moderator
+}
+
+var _ llm.Formatter = (*ModeratedFormatter)(nil)
+
+/**
+ * 整形器を包み、検証を通った結果を moderator で再確認させる。moderator が nil なら包まずに返す。
+ */
+func WithModeration(next llm.Formatter, moderator moderation.Moderator) llm.Formatter {
+	if moderator == nil {
+		return next
+	}
+	return &ModeratedFormatter{next: next, moderator: moderator}
+}
+
+func (f *ModeratedFormatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
+	return f.next.Format(ctx, req)
+}
+
+/**
+ * 包んだ整形器で検証し、公開できる結果だけを moderator に掛ける。
+ * 判定器が失敗した場合は、規約に沿った整形結果をそのまま公開できるものとして扱う。
+ */
+func (f *ModeratedFormatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	validated, err := f.next.Validate(ctx, result)
+	if err != nil || validated == nil || validated.Status != drawdomain.StatusVerified {
+		return validated, err
+	}
+	if verdict, err := f.moderator.Moderate(ctx, string(validated.FormattedContent)); err == nil && verdict.Flagged {
+		validated.Status = drawdomain.StatusRejected
+		validated.ValidationReason = "自傷や加害を連想させる表現が含まれています"
+		return validated, llm.ErrContentRejected
+	}
+	return validated, nil
+}
+
+/**
+ * 文字数・禁止語・URL などの検査を行い、違反が見つかったら拒否理由を返す。
+ */
+func ShouldReject(text string) (string, bool) {
+	length := utf8.RuneCountInString(text)
+	if length < MinFormattedLength {
+		return "整形結果が短すぎます", true
+	}
+	if length > MaxFormattedLength {
+		return "整形結果が長すぎます", true
+	}
+
+	lower := strings.ToLower(text)
+	for _, keyword := range rejectionKeywords {
+		if strings.Contains(lower, keyword) {
+			return fmt.Sprintf("不適切な語句(%s)が含まれています", keyword), true
+		}
+	}
+	if strings.Contains(lower, "http://") || strings.Contains(lower, "https://") {
+		return "URL は含めないでください", true
+	}
+	if !strings.HasPrefix(text, Prefix) {
+		return fmt.Sprintf("冒頭は「%s」で始めてください", Prefix), true
+	}
+	if reason, rejected := violatesStructure(text); rejected {
+		return reason, true
+	}
+	return "", false
+}
+
+/**
+ * 改行や余白を整え、検証しやすい形へ揃える。
+ */
+func NormalizeText(text string) string {
+	noCR := strings.ReplaceAll(text, "\r", "")
+	noLF := strings.ReplaceAll(noCR, "\n", "")
+	return strings.TrimSpace(noLF)
+}
+
+/**
+ * お告げ文の構成や語尾が条件を満たしているかを調べる。
+ */
+func violatesStructure(text string) (string, bool) {
+	body := strings.TrimSpace(strings.TrimPrefix(text, Prefix))
+	sentences := splitSentences(body)
+	if len(sentences) != ExpectedSentenceCount {
+		return "お告げは3文構成で書いてください", true
+	}
+	for idx, sentence := range sentences {
+		if !strings.HasSuffix(sentence, "ます") {
+			return fmt.Sprintf("%d文目は「〜ます」で終えてください", idx+1), true
+		}
+	}
+	return "", false
+}
+
+/**
+ * 句点で区切った文を抽出し、空の要素を除いて返す。
+ */
+func splitSentences(body string) []string {
+	raw := strings.Split(body, "。")
+	sentences := make([]string, 0, len(raw))
+	for _, part := range raw {
+		trimmed := strings.TrimSpace(part)
+		if trimmed == "" {
+			continue
+		}
+		sentences = append(sentences, trimmed)
+	}
+	return sentences
+}
@@ -0,0 +1,191 @@
+package fortune
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/logging/logtest"
+	"backend/internal/port/llm"
+	"backend/internal/port/moderation"
+)
+
+var (
+	fortuneShort         = "今日のきらくじ: つらいです。待ちます。笑えます。"
+	fortuneValid         = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続いています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進めます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
+	fortuneLong          = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続き、ため息が増えています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進め、証拠の順序も丁寧に整えます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒される余韻がしばらく長く残ります。"
+	fortuneKeyword       = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、killという語がちらついています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付けます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
+	fortuneURL           = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、https://example.comの通知が気になります。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付けます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
+	fortuneMissingPrefix = "心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も少し続いて眠りも浅くなっています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進めます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
+	fortuneTwoSentences  = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続いています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず黙々と進め、返信の時間も決め、痕跡を整え、最後は少し笑えて癒されます。"
+)
+
+func TestValidateRequest(t *testing.T) {
+	if err := ValidateRequest(&llm.FormatRequest{DarkPostID: "id", DarkContent: "body"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateRequest(&llm.FormatRequest{}); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format, got %v", err)
+	}
+	if err := ValidateRequest(nil); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format for nil request")
+	}
+	if err := ValidateRequest(&llm.FormatRequest{DarkPostID: "id", DarkContent: "   "}); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format for empty content")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	result, err := Validate(context.Background(), &llm.FormatResult{
+		DarkPostID:       "post",
+		FormattedContent: drawdomain.FormattedContent(fortuneValid + "\n"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != drawdomain.StatusVerified {
+		t.Fatalf("expected verified, got %s", result.Status)
+	}
+	if string(result.FormattedContent) != fortuneValid {
+		t.Fatalf("expected normalized content, got %q", result.FormattedContent)
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	result, err := Validate(context.Background(), &llm.FormatResult{
+		DarkPostID:       "post",
+		FormattedContent: drawdomain.FormattedContent(fortuneKeyword),
+	})
+	if !errors.Is(err, llm.ErrContentRejected) {
+		t.Fatalf("expected rejected, got %v", err)
+	}
+	if result.Status != drawdomain.StatusRejected || result.ValidationReason == "" {
+		t.Fatalf("expected rejected status with reason, got %+v", result)
+	}
+}
+
+func TestValidateEmpty(t *testing.T) {
+	if _, err := Validate(context.Background(), nil); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format for nil result")
+	}
+	result, err := Validate(context.Background(), &llm.FormatResult{DarkPostID: "post", FormattedContent: "  "})
+	if !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format for empty content, got %v", err)
+	}
+	if result.Status != drawdomain.StatusRejected {
+		t.Fatalf("expected rejected status")
+	}
+}
+
+func TestShouldReject(t *testing.T) {
+	if reason, ok := ShouldReject(fortuneShort); !ok || !strings.Contains(reason, "短すぎます") {
+		t.Fatalf("expected rejection for short text")
+	}
+	if reason, ok := ShouldReject(fortuneLong); !ok || !strings.Contains(reason, "長すぎます") {
+		t.Fatalf("expected rejection for long text")
+	}
+	if reason, ok := ShouldReject(fortuneKeyword); !ok || !strings.Contains(reason, "kill") {
+		t.Fatalf("expected keyword rejection, reason=%v", reason)
+	}
+	if reason, ok := ShouldReject(fortuneURL); !ok || !strings.Contains(reason, "URL") {
+		t.Fatalf("expected rejection for URL")
+	}
+	if reason, ok := ShouldReject(fortuneMissingPrefix); !ok || !strings.Contains(reason, "冒頭") {
+		t.Fatalf("expected rejection for prefix")
+	}
+	if reason, ok := ShouldReject(fortuneTwoSentences); !ok || !strings.Contains(reason, "3文") {
+		t.Fatalf("expected rejection for sentence count")
+	}
+	if reason, ok := ShouldReject(fortuneValid); ok || reason != "" {
+		t.Fatalf("expected acceptance, got %v %v", ok, reason)
+	}
+}
+
+func TestWithModerationRejectsModeratedContent(t *testing.T) {
+	moderator := &stubModerator{result: &moderation.Result{Flagged: true, Crisis: true}}
+	formatter := WithModeration(validatingFormatter{}, moderator)
+	result, err := formatter.Validate(context.Background(), &llm.FormatResult{
+		DarkPostID:       "post",
+		FormattedContent: drawdomain.FormattedContent("  " + fortuneValid + "\n"),
+	})
+	if !errors.Is(err, llm.ErrContentRejected) {
+		t.Fatalf("expected content rejected, got %v", err)
+	}
+	if !strings.Contains(result.ValidationReason, "自傷") {
+		t.Fatalf("unexpected reason: %q", result.ValidationReason)
+	}
+	if moderator.received != fortuneValid {
+		t.Fatalf("expected normalized text to be moderated, got %q", moderator.received)
+	}
+
+	// 判定器が失敗しても、規約に沿った整形結果は公開できる
+	failing := &stubModerator{err: errors.New("boom")}
+	if _, err := WithModeration(validatingFormatter{}, failing).Validate(context.Background(), &llm.FormatResult{
+		DarkPostID:       "post",
+		FormattedContent: drawdomain.FormattedContent(fortuneValid),
+	}); err != nil {
+		t.Fatalf("expected moderator error to be ignored, got %v", err)
+	}
+
+	// 規約違反で却下した結果は判定器に渡さない
+	unused := &stubModerator{result: &moderation.Result{Flagged: true}}
+	if _, err := WithModeration(validatingFormatter{}, unused).Validate(context.Background(), &llm.FormatResult{
+		DarkPostID:       "post",
+		FormattedContent: drawdomain.FormattedContent(fortuneShort),
+	}); !errors.Is(err, llm.ErrContentRejected) || unused.received != "" {
+		t.Fatalf("expected rejection without moderation, got %v (received %q)", err, unused.received)
+	}
+}
+
+func TestWithModerationWithoutModerator(t *testing.T) {
+	next := validatingFormatter{}
+	if got := WithModeration(next, nil); got != llm.Formatter(next) {
+		t.Fatalf("expected the formatter to be returned as is, got %T", got)
+	}
+}
+
+// validatingFormatter は共通の Validate だけを行う整形器（各プロバイダの代わり）。
+type validatingFormatter struct{}
+
+func (validatingFormatter) Format(context.Context, *llm.FormatRequest) (*llm.FormatResult, error) {
+	return nil, llm.ErrInvalidFormat
+}
+
+func (validatingFormatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	return Validate(ctx, result)
+}
+
+func TestNormalizeText(t *testing.T) {
+	raw := "今日の闇みくじ:\r\n 一文目です。\n 二文目です。\n 三文目です。"
+	got := NormalizeText(raw)
+	want := "今日の闇みくじ: 一文目です。 二文目です。 三文目です。"
+	if got != want {
+		t.Fatalf("NormalizeText mismatch\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+type stubModerator struct {
+	result   *moderation.Result
+	err      error
+	received string
+}
+
+func (s *stubModerator) Moderate(_ context.Context, content string) (*moderation.Result, error) {
+	s.received = content
+	return s.result, s.err
+}
+
+func TestPendingResult(t *testing.T) {
+	buf := logtest.Capture(t)
+	got := PendingResult(context.Background(), "test", &llm.FormatRequest{DarkPostID: "post-secret", DarkContent: "元の闇"}, "整形された秘密の文章", "fortune-v1")
+	if got.DarkPostID != "post-secret" || got.FormattedContent != "整形された秘密の文章" || got.Status != drawdomain.StatusPending || got.PromptVersion != "fortune-v1" {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+	// 整形結果は本文を出さず、ハッシュ ID と文字数だけを残す
+	logtest.AssertNotContains(t, buf, "post-secret", "元の闇", "整形された秘密の文章")
+	if !strings.Contains(buf.String(), `"length":10`) {
+		t.Fatalf("expected content length to be logged, got:\n%s", buf.String())
+	}
+}
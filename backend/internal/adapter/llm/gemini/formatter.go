@@ -3,26 +3,17 @@ package gemini
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
-	"unicode/utf8"
 
-	drawdomain "backend/internal/domain/draw"
+	"backend/internal/adapter/llm/fortune"
+	"backend/internal/adapter/llm/prompt"
 	"backend/internal/port/llm"
 
 	"github.com/google/generative-ai-go/genai"
 	"google.golang.org/api/option"
 )
 
-const (
-	defaultModelName      = "gemini-2.5-flash"
-	maxFormattedLength    = 150
-	minFormattedLength    = 30
-	fortunePrefix         = "今日のきらくじ:"
-	expectedSentenceCount = 3
-)
-
-var rejectionKeywords = []string{"kill", "suicide", "die"}
+const defaultModelName = "gemini-2.5-flash"
 
 var newGeminiClient = genai.NewClient
 
@@ -36,6 +27,7 @@ type Formatter struct {
 	generator contentGenerator
 	closeFn   func() error
 	modelName string
+	prompt    *prompt.Template
 }
 
 /**
@@ -68,6 +60,16 @@ func NewFormatter(ctx context.Context, apiKey, modelName string, extraOpts ...op
 	}, nil
 }
 
+/**
+ * 整形に使うプロンプトテンプレートを差し替える。nil の場合は既定のままにする。
+ */
+func (f *Formatter) WithPrompt(tmpl *prompt.Template) *Formatter {
+	if tmpl != nil {
+		f.prompt = tmpl
+	}
+	return f
+}
+
 /**
  * 内部で保持している接続を後片付けする。
  * そもそも接続していない場合は何もせずに戻る。
@@ -84,7 +86,7 @@ func (f *Formatter) Close() error {
  * 依頼が空だったり応答が壊れている場合は、理由を添えて失敗を知らせる。
  */
 func (f *Formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
-	if err := validateFormatRequest(req); err != nil {
+	if err := fortune.ValidateRequest(req); err != nil {
 		return nil, err
 	}
 	if ctx == nil {
@@ -94,8 +96,12 @@ func (f *Formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.Fo
 		return nil, fmt.Errorf("%w: gemini formatter: 生成器が初期化されていません", llm.ErrFormatterUnavailable)
 	}
 
-	prompt := buildPrompt(string(req.DarkContent))
-	resp, err := f.generator.GenerateContent(ctx, genai.Text(prompt))
+	tmpl := prompt.OrDefault(f.prompt)
+	promptText, err := tmpl.Build(string(req.DarkContent))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.generator.GenerateContent(ctx, genai.Text(promptText))
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", llm.ErrFormatterUnavailable, err)
 	}
@@ -105,13 +111,7 @@ func (f *Formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.Fo
 		return nil, err
 	}
 
-	log.Printf("[gemini] formatted dark_post_id=%s text=%q", req.DarkPostID, text)
-
-	return &llm.FormatResult{
-		DarkPostID:       req.DarkPostID,
-		FormattedContent: drawdomain.FormattedContent(text),
-		Status:           drawdomain.StatusPending,
-	}, nil
+	return fortune.PendingResult(ctx, "gemini", req, text, tmpl.Version()), nil
 }
 
 /**
@@ -119,42 +119,7 @@ func (f *Formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.Fo
  * 禁止語や文字数違反などが見つかったら拒否理由を付けて返す。
  */
 func (f *Formatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
-	if result == nil || result.DarkPostID == "" {
-		return nil, llm.ErrInvalidFormat
-	}
-
-	trimmed := strings.TrimSpace(string(result.FormattedContent))
-	if trimmed == "" {
-		result.Status = drawdomain.StatusRejected
-		result.ValidationReason = "整形結果が空です"
-		return result, llm.ErrInvalidFormat
-	}
-
-	normalized := normalizeFortuneText(trimmed)
-	if reason, rejected := shouldReject(normalized); rejected {
-		result.Status = drawdomain.StatusRejected
-		result.ValidationReason = reason
-		return result, llm.ErrContentRejected
-	}
-
-	result.Status = drawdomain.StatusVerified
-	result.FormattedContent = drawdomain.FormattedContent(normalized)
-	result.ValidationReason = ""
-
-	return result, nil
-}
-
-/**
- * 整形依頼に ID と本文が入っているかを確かめる。
- */
-func validateFormatRequest(req *llm.FormatRequest) error {
-	if req == nil || req.DarkPostID == "" {
-		return llm.ErrInvalidFormat
-	}
-	if strings.TrimSpace(string(req.DarkContent)) == "" {
-		return llm.ErrInvalidFormat
-	}
-	return nil
+	return fortune.Validate(ctx, result)
 }
 
 /**
@@ -167,32 +132,6 @@ func resolveModelName(name string) string {
 	return name
 }
 
-/**
- * 整形時の言い回しや禁止事項を明記したガイド文を作り、投稿本文を差し込む。
- */
-func buildPrompt(content string) string {
-	template := `
-あなたは他人の闇投稿をもとに、別の人が引く「きらくじ」を作る占い師です。出力は日本語のみで行い、次の指示を厳守してください。
-
-【文章ルール】
-1. 合計 30〜150 文字の 3 文構成で書く。
-2. 各文の内容: (1) 今の状況は少し重めに捉える (2) 賢明な行動は具体的で粘り強く、ねちねちした現実的な対処 (3) 結末は少しユーモアを含めつつ、癒しになるような余韻を残す。
-3. 3 文すべて「〜ます。」で終え、句点（。）で区切る。
-4. 固有名詞・URL・箇条書き・顔文字は禁止
-5. A さんへの直接メッセージにはせず、B さんが引くきらくじとして書く。
-
-【出力フォーマット】
-今日のきらくじ: 一文目。二文目。三文目。
-- 冒頭は必ず「今日のきらくじ:」ではじめ、余計な前置きや後書きは不要
-- 改行せず 1 行で書ききる
-
-上記ルールを完全に満たす文章だけを 1 行で返してください。
-
-元になった闇投稿:
-%s`
-	return fmt.Sprintf(strings.TrimSpace(template), strings.TrimSpace(content))
-}
-
 /**
  * Gemini の応答候補から先頭の文章を取り出す。
  * 何も得られない場合は整形不備として扱う。
@@ -201,18 +140,17 @@ func extractFirstText(resp *genai.GenerateContentResponse) (string, error) {
 	if resp == nil {
 		return "", llm.ErrInvalidFormat
 	}
-	for idx, candidate := range resp.Candidates {
+	for _, candidate := range resp.Candidates {
 		if candidate == nil || candidate.Content == nil {
 			continue
 		}
 		var builder strings.Builder
-		for pIdx, part := range candidate.Content.Parts {
+		for _, part := range candidate.Content.Parts {
 			if part == nil {
 				continue
 			}
 			if text, ok := part.(genai.Text); ok {
 				builder.WriteString(string(text))
-				log.Printf("[gemini debug] candidate=%d part=%d text=%q", idx, pIdx, string(text))
 			}
 		}
 		trimmed := strings.TrimSpace(builder.String())
@@ -234,72 +172,6 @@ func extractFirstText(resp *genai.GenerateContentResponse) (string, error) {
 	return "", llm.ErrInvalidFormat
 }
 
-/**
- * 文字数・禁止語・URL などの検査を行い、違反が見つかったら拒否理由を返す。
- */
-func shouldReject(text string) (string, bool) {
-	length := utf8.RuneCountInString(text)
-	if length < minFormattedLength {
-		return "整形結果が短すぎます", true
-	}
-	if length > maxFormattedLength {
-		return "整形結果が長すぎます", true
-	}
-
-	lower := strings.ToLower(text)
-	for _, keyword := range rejectionKeywords {
-		if strings.Contains(lower, keyword) {
-			return fmt.Sprintf("不適切な語句(%s)が含まれています", keyword), true
-		}
-	}
-	if strings.Contains(lower, "http://") || strings.Contains(lower, "https://") {
-		return "URL は含めないでください", true
-	}
-	if !strings.HasPrefix(text, fortunePrefix) {
-		return fmt.Sprintf("冒頭は「%s」で始めてください", fortunePrefix), true
-	}
-	if reason, rejected := violatesFortuneStructure(text); rejected {
-		return reason, true
-	}
-	return "", false
-}
-
-func normalizeFortuneText(text string) string {
-	noCR := strings.ReplaceAll(text, "\r", "")
-	noLF := strings.ReplaceAll(noCR, "\n", "")
-	return strings.TrimSpace(noLF)
-}
-
-func violatesFortuneStructure(text string) (string, bool) {
-	body := strings.TrimSpace(strings.TrimPrefix(text, fortunePrefix))
-	sentences := splitSentences(body)
-	if len(sentences) != expectedSentenceCount {
-		return "お告げは3文構成で書いてください", true
-	}
-	for idx, sentence := range sentences {
-		if !strings.HasSuffix(sentence, "ます") {
-			return fmt.Sprintf("%d文目は「〜ます」で終えてください", idx+1), true
-		}
-	}
-	return "", false
-}
-
-/**
- * 句点で区切った文を抽出し、空の要素を除いて返す。
- */
-func splitSentences(body string) []string {
-	raw := strings.Split(body, "。")
-	sentences := make([]string, 0, len(raw))
-	for _, part := range raw {
-		trimmed := strings.TrimSpace(part)
-		if trimmed == "" {
-			continue
-		}
-		sentences = append(sentences, trimmed)
-	}
-	return sentences
-}
-
 /**
  * 候補数・文字数上限・温度などの設定を行い、生成器として扱えるようにする。
  */
@@ -6,8 +6,10 @@ import (
 	"strings"
 	"testing"
 
+	"backend/internal/adapter/llm/prompt"
 	drawdomain "backend/internal/domain/draw"
 	"backend/internal/domain/post"
+	"backend/internal/logging/logtest"
 	"backend/internal/port/llm"
 
 	"github.com/google/generative-ai-go/genai"
@@ -21,11 +23,8 @@ type fakeGenerator struct {
 }
 
 var (
-	fortuneShort         = "今日のきらくじ: つらいです。待ちます。笑えます。"
 	fortuneValid         = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続いています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進めます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
-	fortuneLong          = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続き、ため息が増えています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進め、証拠の順序も丁寧に整えます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒される余韻がしばらく長く残ります。"
 	fortuneKeyword       = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、killという語がちらついています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付けます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
-	fortuneURL           = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、https://example.comの通知が気になります。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付けます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
 	fortuneMissingPrefix = "心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も少し続いて眠りも浅くなっています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進めます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
 	fortuneTwoSentences  = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続いています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず黙々と進め、返信の時間も決め、痕跡を整え、最後は少し笑えて癒されます。"
 )
@@ -71,6 +70,9 @@ func TestFormatter_FormatSuccess(t *testing.T) {
 	if got := string(result.FormattedContent); got != "やさしいメッセージです" {
 		t.Fatalf("unexpected formatted content: %s", got)
 	}
+	if result.PromptVersion != prompt.DefaultVersion {
+		t.Fatalf("expected default prompt version, got %q", result.PromptVersion)
+	}
 	if len(gen.parts) != 1 {
 		t.Fatalf("expected prompt to be sent once, got %d times", len(gen.parts))
 	}
@@ -181,15 +183,6 @@ func TestFormatter_ValidateRejectsSentenceCount(t *testing.T) {
 	}
 }
 
-func TestFormatter_NormalizeFortuneText(t *testing.T) {
-	raw := "今日の闇みくじ:\r\n 一文目です。\n 二文目です。\n 三文目です。"
-	got := normalizeFortuneText(raw)
-	want := "今日の闇みくじ: 一文目です。 二文目です。 三文目です。"
-	if got != want {
-		t.Fatalf("normalizeFortuneText mismatch\ngot:  %q\nwant: %q", got, want)
-	}
-}
-
 func TestFormatter_FormatRequestValidation(t *testing.T) {
 	gen := &fakeGenerator{}
 	f := &Formatter{generator: gen}
@@ -419,24 +412,6 @@ func TestExtractFirstTextErrors(t *testing.T) {
 	}
 }
 
-func TestShouldReject(t *testing.T) {
-	if reason, rejected := shouldReject(fortuneShort); !rejected || !strings.Contains(reason, "短すぎます") {
-		t.Fatalf("expected rejection for short text")
-	}
-	if reason, rejected := shouldReject(fortuneLong); !rejected || !strings.Contains(reason, "長すぎます") {
-		t.Fatalf("expected rejection for long text")
-	}
-	if reason, rejected := shouldReject(fortuneKeyword); !rejected || !strings.Contains(reason, "不適切") {
-		t.Fatalf("expected rejection for keyword, got %v", reason)
-	}
-	if reason, rejected := shouldReject(fortuneURL); !rejected || !strings.Contains(reason, "URL") {
-		t.Fatalf("expected rejection for url, got %v", reason)
-	}
-	if reason, rejected := shouldReject(fortuneValid); rejected {
-		t.Fatalf("unexpected rejection: %v", reason)
-	}
-}
-
 func TestResolveModelName(t *testing.T) {
 	if got := resolveModelName(""); got != defaultModelName {
 		t.Fatalf("expected default model, got %s", got)
@@ -445,3 +420,20 @@ func TestResolveModelName(t *testing.T) {
 		t.Fatalf("expected custom value untouched")
 	}
 }
+
+func TestFormatter_FormatDoesNotLogContent(t *testing.T) {
+	buf := logtest.Capture(t)
+	gen := &fakeGenerator{
+		response: &genai.GenerateContentResponse{
+			Candidates: []*genai.Candidate{{
+				Content: &genai.Content{Parts: []genai.Part{genai.Text("整形された"), genai.Text("秘密の文章")}},
+			}},
+		},
+	}
+	f := &Formatter{generator: gen}
+
+	if _, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "post-secret", DarkContent: "元の闇"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logtest.AssertNotContains(t, buf, "post-secret", "元の闇", "整形された", "秘密の文章")
+}
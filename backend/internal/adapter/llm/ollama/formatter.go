@@ -0,0 +1,185 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"backend/internal/adapter/llm/fortune"
+	"backend/internal/adapter/llm/prompt"
+	"backend/internal/config"
+	"backend/internal/port/llm"
+)
+
+const (
+	chatPath = "/api/chat"
+	// エラー応答の本文はログ用に先頭だけ残す
+	maxErrorBodyBytes = 512
+)
+
+/**
+ * Ollama 互換サーバーへ HTTP リクエストを送る最小限の窓口。
+ */
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+/**
+ * ローカルの Ollama 互換サーバーと会話して闇投稿を整形し、検証処理も担う本体。
+ */
+type Formatter struct {
+	client  HTTPClient
+	host    string
+	model   string
+	options map[string]any
+	prompt  *prompt.Template
+}
+
+// Ollama の /api/chat に送るメッセージ 1 件分。
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Ollama の /api/chat に送るリクエスト本文。
+type chatRequest struct {
+	Model    string         `json:"model"`
+	Messages []chatMessage  `json:"messages"`
+	Stream   bool           `json:"stream"`
+	Options  map[string]any `json:"options,omitempty"`
+}
+
+// Ollama の /api/chat から返る応答本文（非ストリーミング）。
+type chatResponse struct {
+	Message chatMessage `json:"message"`
+	Done    bool        `json:"done"`
+	Error   string      `json:"error"`
+}
+
+/**
+ * 接続先やモデル名を点検してから Ollama 互換サーバーとの橋渡し役を組み立てる。
+ */
+func NewFormatter(host, model string, options map[string]any, timeout time.Duration) (*Formatter, error) {
+	host = strings.TrimRight(strings.TrimSpace(host), "/")
+	if host == "" {
+		host = config.DefaultOllamaHost
+	}
+	if model == "" {
+		model = config.DefaultOllamaModel
+	}
+	if timeout <= 0 {
+		timeout = config.DefaultOllamaTimeout
+	}
+	return &Formatter{
+		client:  &http.Client{Timeout: timeout},
+		host:    host,
+		model:   model,
+		options: options,
+	}, nil
+}
+
+/**
+ * 整形に使うプロンプトテンプレートを差し替える。nil の場合は既定のままにする。
+ */
+func (f *Formatter) WithPrompt(tmpl *prompt.Template) *Formatter {
+	if tmpl != nil {
+		f.prompt = tmpl
+	}
+	return f
+}
+
+/**
+ * HTTP クライアントは後片付け不要なので互換性のためだけに戻り値を返す。
+ */
+func (f *Formatter) Close() error {
+	return nil
+}
+
+/**
+ * 闇投稿本文をローカル LLM に渡し、整形した文章を検証待ちの状態で受け取る。
+ */
+func (f *Formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
+	if err := fortune.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tmpl := prompt.OrDefault(f.prompt)
+	promptText, err := tmpl.Build(string(req.DarkContent))
+	if err != nil {
+		return nil, err
+	}
+	text, err := f.chat(ctx, promptText)
+	if err != nil {
+		return nil, err
+	}
+
+	return fortune.PendingResult(ctx, "ollama", req, text, tmpl.Version()), nil
+}
+
+/**
+ * 整形済みの文章を共通の規約で検証し、公開可否を決める。
+ */
+func (f *Formatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	return fortune.Validate(ctx, result)
+}
+
+/**
+ * /api/chat へ非ストリーミングで問い合わせ、応答本文を取り出す。
+ * 接続失敗や 2xx 以外の応答は整形サービス停止として扱う。
+ */
+func (f *Formatter) chat(ctx context.Context, prompt string) (string, error) {
+	payload, err := json.Marshal(chatRequest{
+		Model:    f.model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+		Stream:   false,
+		Options:  f.options,
+	})
+	if err != nil {
+		return "", fmt.Errorf("ollama formatter: リクエストを組み立てられません: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, f.host+chatPath, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", llm.ErrFormatterUnavailable, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", llm.ErrFormatterUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return "", fmt.Errorf("%w: ollama status=%d body=%s", llm.ErrFormatterUnavailable, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var decoded chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return "", fmt.Errorf("%w: %v", llm.ErrInvalidFormat, err)
+	}
+	if decoded.Error != "" {
+		return "", fmt.Errorf("%w: %s", llm.ErrFormatterUnavailable, decoded.Error)
+	}
+	return extractText(decoded)
+}
+
+/**
+ * 応答のメッセージ本文から余白を取り除き、空なら整形不備として扱う。
+ */
+func extractText(resp chatResponse) (string, error) {
+	trimmed := strings.TrimSpace(resp.Message.Content)
+	if trimmed == "" {
+		return "", llm.ErrInvalidFormat
+	}
+	return trimmed, nil
+}
@@ -0,0 +1,179 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/config"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/logging/logtest"
+	"backend/internal/port/llm"
+)
+
+var fortuneValid = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続いています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進めます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
+
+type stubHTTPClient struct {
+	resp     *http.Response
+	err      error
+	captured *http.Request
+}
+
+func (s *stubHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	s.captured = req
+	return s.resp, s.err
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}
+}
+
+func TestFormatterFormatSuccess(t *testing.T) {
+	var received chatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != chatPath {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":" 整形済み "},"done":true}`))
+	}))
+	defer server.Close()
+
+	f, err := NewFormatter(server.URL+"/", "local-model", map[string]any{"temperature": 0.3}, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	res, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "post-1", DarkContent: "とてもつらかった"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(res.FormattedContent) != "整形済み" {
+		t.Fatalf("unexpected content: %q", res.FormattedContent)
+	}
+	if res.Status != drawdomain.StatusPending {
+		t.Fatalf("expected pending status, got %s", res.Status)
+	}
+	if received.Model != "local-model" || received.Stream {
+		t.Fatalf("unexpected request: %+v", received)
+	}
+	if received.Options["temperature"] != 0.3 {
+		t.Fatalf("options not forwarded: %v", received.Options)
+	}
+	if len(received.Messages) != 1 || !strings.Contains(received.Messages[0].Content, "とてもつらかった") {
+		t.Fatalf("prompt should contain original content: %+v", received.Messages)
+	}
+}
+
+func TestFormatterFormatClientError(t *testing.T) {
+	f := &Formatter{client: &stubHTTPClient{err: errors.New("connection refused")}, host: "http://localhost", model: "m"}
+
+	_, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p", DarkContent: "x"})
+	if !errors.Is(err, llm.ErrFormatterUnavailable) {
+		t.Fatalf("expected formatter unavailable, got %v", err)
+	}
+}
+
+func TestFormatterFormatNon2xx(t *testing.T) {
+	client := &stubHTTPClient{resp: jsonResponse(http.StatusNotFound, `{"error":"model not found"}`)}
+	f := &Formatter{client: client, host: "http://localhost", model: "m"}
+
+	_, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p", DarkContent: "x"})
+	if !errors.Is(err, llm.ErrFormatterUnavailable) {
+		t.Fatalf("expected formatter unavailable, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "model not found") {
+		t.Fatalf("expected error body in message, got %v", err)
+	}
+}
+
+func TestFormatterFormatErrorField(t *testing.T) {
+	client := &stubHTTPClient{resp: jsonResponse(http.StatusOK, `{"error":"out of memory"}`)}
+	f := &Formatter{client: client, host: "http://localhost", model: "m"}
+
+	_, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p", DarkContent: "x"})
+	if !errors.Is(err, llm.ErrFormatterUnavailable) {
+		t.Fatalf("expected formatter unavailable, got %v", err)
+	}
+}
+
+func TestFormatterFormatInvalidResponse(t *testing.T) {
+	for _, body := range []string{`not-json`, `{"message":{"content":"   "}}`} {
+		client := &stubHTTPClient{resp: jsonResponse(http.StatusOK, body)}
+		f := &Formatter{client: client, host: "http://localhost", model: "m"}
+
+		_, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p", DarkContent: "x"})
+		if !errors.Is(err, llm.ErrInvalidFormat) {
+			t.Fatalf("expected invalid format for %q, got %v", body, err)
+		}
+	}
+}
+
+func TestFormatterFormatInvalidRequest(t *testing.T) {
+	client := &stubHTTPClient{}
+	f := &Formatter{client: client, host: "http://localhost", model: "m"}
+
+	if _, err := f.Format(context.Background(), nil); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format for nil request, got %v", err)
+	}
+	if _, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p"}); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("expected invalid format for empty content, got %v", err)
+	}
+	if client.captured != nil {
+		t.Fatalf("request should not be sent for invalid input")
+	}
+}
+
+func TestFormatterValidate(t *testing.T) {
+	f := &Formatter{}
+	result, err := f.Validate(context.Background(), &llm.FormatResult{
+		DarkPostID:       "post",
+		FormattedContent: drawdomain.FormattedContent(fortuneValid),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != drawdomain.StatusVerified {
+		t.Fatalf("expected verified, got %s", result.Status)
+	}
+}
+
+func TestNewFormatterDefaults(t *testing.T) {
+	f, err := NewFormatter(" ", "", nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.host != config.DefaultOllamaHost || f.model != config.DefaultOllamaModel {
+		t.Fatalf("unexpected defaults: host=%s model=%s", f.host, f.model)
+	}
+	client, ok := f.client.(*http.Client)
+	if !ok || client.Timeout != config.DefaultOllamaTimeout {
+		t.Fatalf("expected default timeout client, got %#v", f.client)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("close should succeed")
+	}
+}
+
+func TestFormatterFormatDoesNotLogContent(t *testing.T) {
+	buf := logtest.Capture(t)
+	client := &stubHTTPClient{resp: jsonResponse(http.StatusOK, `{"message":{"role":"assistant","content":"整形された秘密の文章"},"done":true}`)}
+	f := &Formatter{client: client, host: "http://localhost", model: "m"}
+
+	if _, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "post-secret", DarkContent: "元の闇"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logtest.AssertNotContains(t, buf, "post-secret", "元の闇", "整形された秘密の文章")
+}
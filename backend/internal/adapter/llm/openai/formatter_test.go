@@ -6,8 +6,10 @@ import (
 	"strings"
 	"testing"
 
+	"backend/internal/adapter/llm/prompt"
 	"backend/internal/config"
 	drawdomain "backend/internal/domain/draw"
+	"backend/internal/logging/logtest"
 	"backend/internal/port/llm"
 
 	githubOpenAI "github.com/sashabaranov/go-openai"
@@ -21,13 +23,8 @@ type stubChatClient struct {
 }
 
 var (
-	fortuneShort         = "今日のきらくじ: つらいです。待ちます。笑えます。"
-	fortuneValid         = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続いています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進めます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
-	fortuneLong          = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続き、ため息が増えています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進め、証拠の順序も丁寧に整えます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒される余韻がしばらく長く残ります。"
-	fortuneKeyword       = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、killという語がちらついています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付けます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
-	fortuneURL           = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、https://example.comの通知が気になります。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付けます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
-	fortuneMissingPrefix = "心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も少し続いて眠りも浅くなっています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進めます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
-	fortuneTwoSentences  = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続いています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず黙々と進め、返信の時間も決め、痕跡を整え、最後は少し笑えて癒されます。"
+	fortuneValid   = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、寝不足も続いています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付け、手順を崩さず進めます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
+	fortuneKeyword = "今日のきらくじ: 心の奥がじっと湿って、気になる言葉が何度も頭に残り、killという語がちらついています。ひとつずつ事実を確認し、記録を残して、反応を待ちながら淡々と片付けます。最後には執念が効いて小さな勝ちを拾えたと笑え、ふっと癒されます。"
 )
 
 func (s *stubChatClient) CreateChatCompletion(ctx context.Context, req githubOpenAI.ChatCompletionRequest) (githubOpenAI.ChatCompletionResponse, error) {
@@ -61,6 +58,54 @@ func TestFormatterFormatSuccess(t *testing.T) {
 	}
 }
 
+func TestFormatterFormatKeepsMenheraPromptByDefault(t *testing.T) {
+	client := &stubChatClient{
+		resp: githubOpenAI.ChatCompletionResponse{
+			Choices: []githubOpenAI.ChatCompletionChoice{{
+				Message: githubOpenAI.ChatCompletionMessage{Content: "整形済み"},
+			}},
+		},
+	}
+	f := &Formatter{client: client, model: "test"}
+
+	res, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p", DarkContent: "闇"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.PromptVersion != prompt.MenheraVersion {
+		t.Fatalf("expected %s by default, got %q", prompt.MenheraVersion, res.PromptVersion)
+	}
+	if got := client.capturedReq.Messages[0].Content; !strings.Contains(got, "30〜100 文字") {
+		t.Fatalf("expected the original length rule, got %s", got)
+	}
+}
+
+func TestFormatterFormatUsesPromptTemplate(t *testing.T) {
+	client := &stubChatClient{
+		resp: githubOpenAI.ChatCompletionResponse{
+			Choices: []githubOpenAI.ChatCompletionChoice{{
+				Message: githubOpenAI.ChatCompletionMessage{Content: "整形済み"},
+			}},
+		},
+	}
+	tmpl, err := prompt.Load("fortune-v1", "")
+	if err != nil {
+		t.Fatalf("load prompt: %v", err)
+	}
+	f := (&Formatter{client: client, model: "test"}).WithPrompt(tmpl)
+
+	res, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p", DarkContent: "闇"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.PromptVersion != "fortune-v1" {
+		t.Fatalf("expected prompt version to be recorded, got %q", res.PromptVersion)
+	}
+	if got := client.capturedReq.Messages[0].Content; strings.Contains(got, "メンヘラ占い師") {
+		t.Fatalf("expected the configured prompt to replace the default, got %s", got)
+	}
+}
+
 func TestFormatterFormatClientError(t *testing.T) {
 	client := &stubChatClient{err: errors.New("boom")}
 	f := &Formatter{client: client, model: "test"}
@@ -207,54 +252,6 @@ func TestExtractFirstTextError(t *testing.T) {
 	}
 }
 
-func TestValidateFormatRequest(t *testing.T) {
-	if err := validateFormatRequest(&llm.FormatRequest{DarkPostID: "id", DarkContent: "body"}); err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-	if err := validateFormatRequest(&llm.FormatRequest{}); !errors.Is(err, llm.ErrInvalidFormat) {
-		t.Fatalf("expected invalid format, got %v", err)
-	}
-}
-
-func TestValidateFormatRequestNil(t *testing.T) {
-	if err := validateFormatRequest(nil); !errors.Is(err, llm.ErrInvalidFormat) {
-		t.Fatalf("expected invalid format for nil request")
-	}
-}
-
-func TestValidateFormatRequestEmptyContent(t *testing.T) {
-	if err := validateFormatRequest(&llm.FormatRequest{DarkPostID: "id", DarkContent: "   "}); !errors.Is(err, llm.ErrInvalidFormat) {
-		t.Fatalf("expected invalid format for empty content")
-	}
-}
-
-func TestShouldReject(t *testing.T) {
-	if reason, ok := shouldReject(fortuneShort); !ok || !strings.Contains(reason, "短すぎます") {
-		t.Fatalf("expected rejection for short text")
-	}
-	if reason, ok := shouldReject(fortuneLong); !ok || !strings.Contains(reason, "長すぎます") {
-		t.Fatalf("expected rejection for long text")
-	}
-	if reason, ok := shouldReject(fortuneURL); !ok || !strings.Contains(reason, "URL") {
-		t.Fatalf("expected rejection for URL")
-	}
-	if reason, ok := shouldReject(fortuneMissingPrefix); !ok || !strings.Contains(reason, "冒頭") {
-		t.Fatalf("expected rejection for prefix")
-	}
-	if reason, ok := shouldReject(fortuneTwoSentences); !ok || !strings.Contains(reason, "3文") {
-		t.Fatalf("expected rejection for sentence count")
-	}
-	if reason, ok := shouldReject(fortuneValid); ok || reason != "" {
-		t.Fatalf("expected acceptance, got %v %v", ok, reason)
-	}
-}
-
-func TestShouldRejectKeywords(t *testing.T) {
-	if reason, ok := shouldReject(fortuneKeyword); !ok || !strings.Contains(reason, "kill") {
-		t.Fatalf("expected keyword rejection, reason=%v", reason)
-	}
-}
-
 func TestNewFormatterRequiresKey(t *testing.T) {
 	if _, err := NewFormatter(" ", "model", ""); err == nil {
 		t.Fatalf("expected error when key is missing")
@@ -280,9 +277,19 @@ func TestNewFormatterWithBaseURL(t *testing.T) {
 	}
 }
 
-func TestBuildPromptTrims(t *testing.T) {
-	got := buildPrompt(" こんにちは ")
-	if !strings.Contains(got, "こんにちは") {
-		t.Fatalf("prompt does not contain content: %s", got)
+func TestFormatterFormatDoesNotLogContent(t *testing.T) {
+	buf := logtest.Capture(t)
+	client := &stubChatClient{
+		resp: githubOpenAI.ChatCompletionResponse{
+			Choices: []githubOpenAI.ChatCompletionChoice{{
+				Message: githubOpenAI.ChatCompletionMessage{Content: "整形された秘密の文章"},
+			}},
+		},
+	}
+	f := &Formatter{client: client, model: "test-model"}
+
+	if _, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "post-secret", DarkContent: "元の闇"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
+	logtest.AssertNotContains(t, buf, "post-secret", "元の闇", "整形された秘密の文章")
 }
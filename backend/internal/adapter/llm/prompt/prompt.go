@@ -0,0 +1,205 @@
+package prompt
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"backend/internal/adapter/llm/fortune"
+)
+
+// 埋め込みテンプレートを置くディレクトリと拡張子。
+const (
+	templateDir = "templates"
+	templateExt = ".tmpl"
+)
+
+const (
+	// DefaultVersion は設定が無い場合に使うプロンプトのバージョン。
+	DefaultVersion = "fortune-v1"
+	// MenheraVersion はテンプレート化する前から OpenAI が使っていたプロンプトのバージョン。
+	MenheraVersion = "menhera-v1"
+)
+
+var (
+	ErrUnknownVersion  = errors.New("prompt: 指定されたバージョンのテンプレートが存在しません")
+	ErrInvalidTemplate = errors.New("prompt: テンプレートを解釈できません")
+)
+
+//go:embed templates/*.tmpl
+var embedded embed.FS
+
+var defaultTemplate = mustLoadEmbedded(DefaultVersion)
+
+/**
+ * テンプレートへ差し込む名前付き変数。
+ * 文字数や冒頭句は検証ルールと同じ値を使い、プロンプトと検証のずれを防ぐ。
+ */
+type Variables struct {
+	Content       string
+	MinLength     int
+	MaxLength     int
+	SentenceCount int
+	Prefix        string
+}
+
+/**
+ * バージョン付きのプロンプトテンプレート。
+ */
+type Template struct {
+	version string
+	tmpl    *template.Template
+}
+
+/**
+ * 既定バージョンの埋め込みテンプレートを返す。
+ */
+func Default() *Template {
+	return defaultTemplate
+}
+
+/**
+ * 埋め込みテンプレートを読み込む。存在しないバージョンは組み込みの誤りなので panic する。
+ */
+func MustLoad(version string) *Template {
+	return mustLoadEmbedded(version)
+}
+
+/**
+ * 未設定（nil）なら既定テンプレートに置き換えて返す。
+ */
+func OrDefault(t *Template) *Template {
+	if t == nil {
+		return defaultTemplate
+	}
+	return t
+}
+
+/**
+ * テンプレートを読み込む。path が空なら埋め込みテンプレートから version を探し、
+ * path が指定されていればそのファイルを使う（version が空ならファイル名をバージョンにする）。
+ */
+func Load(version, path string) (*Template, error) {
+	version = strings.TrimSpace(version)
+	path = strings.TrimSpace(path)
+
+	if path == "" {
+		if version == "" {
+			version = DefaultVersion
+		}
+		return loadEmbedded(version)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("prompt: テンプレートファイルを読み込めません: %w", err)
+	}
+	if version == "" {
+		version = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return parse(version, string(raw))
+}
+
+/**
+ * 埋め込み済みテンプレートのバージョン一覧を昇順で返す。
+ */
+func Versions() []string {
+	entries, err := fs.ReadDir(embedded, templateDir)
+	if err != nil {
+		return nil
+	}
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), templateExt) {
+			continue
+		}
+		versions = append(versions, strings.TrimSuffix(entry.Name(), templateExt))
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+/**
+ * テンプレートのバージョンを返す。
+ */
+func (t *Template) Version() string {
+	if t == nil {
+		return ""
+	}
+	return t.version
+}
+
+/**
+ * 投稿本文と検証ルールの値を差し込み、LLM へ渡すプロンプトを組み立てる。
+ */
+func (t *Template) Build(content string) (string, error) {
+	if t == nil || t.tmpl == nil {
+		return "", fmt.Errorf("%w: テンプレートが初期化されていません", ErrInvalidTemplate)
+	}
+	return t.render(newVariables(content))
+}
+
+/**
+ * 検証ルールの値と本文から差し込み用の変数を作る。
+ */
+func newVariables(content string) Variables {
+	return Variables{
+		Content:       strings.TrimSpace(content),
+		MinLength:     fortune.MinFormattedLength,
+		MaxLength:     fortune.MaxFormattedLength,
+		SentenceCount: fortune.ExpectedSentenceCount,
+		Prefix:        fortune.Prefix,
+	}
+}
+
+func (t *Template) render(vars Variables) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+/**
+ * 埋め込みテンプレートから指定バージョンを読み込む。
+ */
+func loadEmbedded(version string) (*Template, error) {
+	raw, err := embedded.ReadFile(templateDir + "/" + version + templateExt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownVersion, version)
+	}
+	return parse(version, string(raw))
+}
+
+/**
+ * テンプレートを解釈し、試しに描画して未定義の変数が無いかを起動時に確かめる。
+ */
+func parse(version, text string) (*Template, error) {
+	if strings.TrimSpace(version) == "" {
+		return nil, fmt.Errorf("%w: バージョンが空です", ErrInvalidTemplate)
+	}
+	tmpl, err := template.New(version).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	t := &Template{version: version, tmpl: tmpl}
+	if _, err := t.render(newVariables("")); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func mustLoadEmbedded(version string) *Template {
+	t, err := loadEmbedded(version)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
@@ -0,0 +1,151 @@
+package prompt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"backend/internal/adapter/llm/fortune"
+)
+
+func TestDefault(t *testing.T) {
+	tmpl := Default()
+	if tmpl.Version() != DefaultVersion {
+		t.Fatalf("unexpected default version: %s", tmpl.Version())
+	}
+
+	got, err := tmpl.Build(" こんにちは ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(got, "元になった闇投稿:\nこんにちは") {
+		t.Fatalf("prompt does not contain trimmed content: %s", got)
+	}
+	if !strings.Contains(got, "30〜150 文字の 3 文構成") {
+		t.Fatalf("prompt should use validation limits: %s", got)
+	}
+	if !strings.Contains(got, fortune.Prefix) {
+		t.Fatalf("prompt should contain prefix: %s", got)
+	}
+}
+
+func TestEmbeddedTemplatesBuild(t *testing.T) {
+	versions := Versions()
+	if len(versions) < 2 {
+		t.Fatalf("expected embedded versions, got %v", versions)
+	}
+	for _, version := range versions {
+		tmpl, err := Load(version, "")
+		if err != nil {
+			t.Fatalf("load %s: %v", version, err)
+		}
+		got, err := tmpl.Build("闇")
+		if err != nil {
+			t.Fatalf("build %s: %v", version, err)
+		}
+		if !strings.HasSuffix(got, "元になった闇投稿:\n闇") {
+			t.Fatalf("%s should end with the content: %s", version, got)
+		}
+	}
+}
+
+func TestMenheraKeepsOriginalOpenAIPrompt(t *testing.T) {
+	got, err := MustLoad(MenheraVersion).Build(" 闇 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// テンプレート化する前の OpenAI の文面と一致させる（文字数の指示も 30〜100 のまま）
+	want := `あなたは他人の闇投稿をもとに、別の人が引く「きらくじ」を作るメンヘラ占い師です。出力は日本語のみで行い、次の指示を厳守してください。
+
+【文章ルール】
+1. 合計 30〜100 文字の 3 文構成で書く。
+2. 各文の内容: (1) 今の状況は少し重めに捉える (2) ねちねちした現実的なメンヘラ占い師の思想 (3) メンヘラの毒を出す。
+3. 3 文すべて「〜ます。」で終え、句点（。）で区切る。
+4. 固有名詞・URL・箇条書き・顔文字は禁止
+5. A さんへの直接メッセージにはせず、B さんが引くきらくじとして書く
+
+【出力フォーマット】
+今日のきらくじ: 一文目。二文目。三文目。
+- 余計な前置きや後書きは不要
+- 改行せず 1 行で書ききる
+
+上記ルールを完全に満たす文章だけを 1 行で返してください。
+
+元になった闇投稿:
+闇`
+	if got != want {
+		t.Fatalf("menhera prompt changed:\n%s", got)
+	}
+}
+
+func TestLoadUnknownVersion(t *testing.T) {
+	if _, err := Load("missing-v9", ""); !errors.Is(err, ErrUnknownVersion) {
+		t.Fatalf("expected ErrUnknownVersion, got %v", err)
+	}
+}
+
+func TestLoadFromPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "custom-v2.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Prefix}} {{.MaxLength}} {{.Content}}"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	tmpl, err := Load("", path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.Version() != "custom-v2" {
+		t.Fatalf("expected version from file name, got %s", tmpl.Version())
+	}
+	got, err := tmpl.Build("本文")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != fortune.Prefix+" 150 本文" {
+		t.Fatalf("unexpected prompt: %q", got)
+	}
+
+	named, err := Load("experiment-a", path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if named.Version() != "experiment-a" {
+		t.Fatalf("expected explicit version, got %s", named.Version())
+	}
+}
+
+func TestLoadFromPathErrors(t *testing.T) {
+	if _, err := Load("", filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.tmpl")
+	if err := os.WriteFile(broken, []byte("{{.Content"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	if _, err := Load("", broken); !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("expected ErrInvalidTemplate for parse error, got %v", err)
+	}
+
+	unknownVar := filepath.Join(dir, "unknown.tmpl")
+	if err := os.WriteFile(unknownVar, []byte("{{.Mood}}"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	if _, err := Load("", unknownVar); !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("expected ErrInvalidTemplate for unknown variable, got %v", err)
+	}
+}
+
+func TestBuildNilTemplate(t *testing.T) {
+	var tmpl *Template
+	if _, err := tmpl.Build("x"); !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("expected ErrInvalidTemplate, got %v", err)
+	}
+	if tmpl.Version() != "" {
+		t.Fatalf("expected empty version for nil template")
+	}
+}
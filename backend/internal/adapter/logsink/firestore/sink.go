@@ -0,0 +1,48 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"backend/internal/port/logsink"
+
+	"cloud.google.com/go/firestore"
+)
+
+// SamplesCollection はデバッグ用サンプルを置くコレクション名。
+// expire_at に Firestore の TTL ポリシーを設定して期限切れのドキュメントを自動削除させる。
+const SamplesCollection = "debug_samples"
+
+var errNilClient = errors.New("firestorelogsink: Firestore クライアントが指定されていません")
+
+// Sink はデバッグ用サンプルを Firestore の専用コレクションへ書き込む。
+type Sink struct {
+	client *firestore.Client
+}
+
+var _ logsink.Sink = (*Sink)(nil)
+
+// NewSink は Firestore を書き込み先にする。
+func NewSink(client *firestore.Client) (*Sink, error) {
+	if client == nil {
+		return nil, errNilClient
+	}
+	return &Sink{client: client}, nil
+}
+
+// Write はサンプルを自動採番のドキュメントとして追記する。
+func (s *Sink) Write(ctx context.Context, sample logsink.Sample) error {
+	data := map[string]interface{}{
+		"stage":        sample.Stage,
+		"provider":     sample.Provider,
+		"post_id_hash": sample.PostIDHash,
+		"content":      sample.Content,
+		"created_at":   sample.CreatedAt,
+		"expire_at":    sample.ExpiresAt,
+	}
+	if _, _, err := s.client.Collection(SamplesCollection).Add(ctx, data); err != nil {
+		return fmt.Errorf("%w: %v", logsink.ErrSinkUnavailable, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"backend/internal/port/logsink"
+
+	"cloud.google.com/go/firestore"
+)
+
+func TestNewSink_RequiresClient(t *testing.T) {
+	if _, err := NewSink(nil); !errors.Is(err, errNilClient) {
+		t.Fatalf("expected errNilClient, got %v", err)
+	}
+}
+
+func TestSink_Integration(t *testing.T) {
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST is not set; skipping Firestore integration tests")
+	}
+	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	if projectID == "" {
+		projectID = "firestore-integration-test"
+	}
+	ctx := context.Background()
+	client, err := firestore.NewClient(ctx, projectID)
+	if err != nil {
+		t.Fatalf("failed to create firestore client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	sink, err := NewSink(client)
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+	now := time.Now()
+	if err := sink.Write(ctx, logsink.Sample{
+		Stage:      "formatted",
+		Provider:   "openai",
+		PostIDHash: "abc",
+		Content:    "sample",
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("write sample: %v", err)
+	}
+
+	docs, err := client.Collection(SamplesCollection).Where("post_id_hash", "==", "abc").Documents(ctx).GetAll()
+	if err != nil {
+		t.Fatalf("get samples: %v", err)
+	}
+	if len(docs) == 0 {
+		t.Fatalf("expected sample to be written")
+	}
+	if _, err := docs[0].DataAt("expire_at"); err != nil {
+		t.Fatalf("expected expire_at to be set: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/port/logsink"
+)
+
+/**
+ * プロセス内にデバッグ用サンプルを保持する書き込み先。ローカル開発での確認用。
+ * 期限切れのサンプルは書き込み・取得のたびに捨てる。保持件数が上限を超えたら古いものから捨てる。
+ */
+type Sink struct {
+	mu       sync.Mutex
+	samples  []logsink.Sample
+	capacity int
+	now      func() time.Time
+}
+
+// DefaultCapacity は保持するサンプル数の既定の上限。
+const DefaultCapacity = 100
+
+var _ logsink.Sink = (*Sink)(nil)
+
+/**
+ * 保持件数の上限を指定して書き込み先を作る。0 以下なら DefaultCapacity を使う。
+ */
+func NewSink(capacity int) *Sink {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Sink{capacity: capacity, now: time.Now}
+}
+
+func (s *Sink) Write(ctx context.Context, sample logsink.Sample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+	s.samples = append(s.samples, sample)
+	if over := len(s.samples) - s.capacity; over > 0 {
+		s.samples = append([]logsink.Sample(nil), s.samples[over:]...)
+	}
+	return nil
+}
+
+/**
+ * 期限内のサンプルを古い順に返す。
+ */
+func (s *Sink) List() []logsink.Sample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+	return append([]logsink.Sample(nil), s.samples...)
+}
+
+func (s *Sink) pruneLocked() {
+	now := s.now()
+	kept := s.samples[:0]
+	for _, sample := range s.samples {
+		if sample.ExpiresAt.IsZero() || now.Before(sample.ExpiresAt) {
+			kept = append(kept, sample)
+		}
+	}
+	s.samples = kept
+}
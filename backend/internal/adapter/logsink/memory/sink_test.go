@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/port/logsink"
+)
+
+func TestSink_DropsExpiredSamples(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSink(0)
+	s.now = func() time.Time { return now }
+
+	_ = s.Write(context.Background(), logsink.Sample{Content: "old", ExpiresAt: now.Add(time.Minute)})
+	_ = s.Write(context.Background(), logsink.Sample{Content: "new", ExpiresAt: now.Add(time.Hour)})
+	if got := s.List(); len(got) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(got))
+	}
+
+	now = now.Add(10 * time.Minute)
+	got := s.List()
+	if len(got) != 1 || got[0].Content != "new" {
+		t.Fatalf("expected only unexpired sample, got %+v", got)
+	}
+}
+
+func TestSink_KeepsLatestWithinCapacity(t *testing.T) {
+	s := NewSink(2)
+	expires := time.Now().Add(time.Hour)
+	for _, c := range []string{"a", "b", "c"} {
+		_ = s.Write(context.Background(), logsink.Sample{Content: c, ExpiresAt: expires})
+	}
+	got := s.List()
+	if len(got) != 2 || got[0].Content != "b" || got[1].Content != "c" {
+		t.Fatalf("unexpected samples: %+v", got)
+	}
+}
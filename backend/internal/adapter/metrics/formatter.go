@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/internal/port/llm"
+)
+
+// LLM エラーの種類ラベル
+const (
+	errorKindUnavailable   = "unavailable"
+	errorKindInvalidFormat = "invalid_format"
+	errorKindOther         = "other"
+)
+
+// formatter は LLM 呼び出しの所要時間・失敗と検証結果を記録する整形器のデコレーター。
+type formatter struct {
+	next     llm.Formatter
+	provider string
+	metrics  *Metrics
+}
+
+/**
+ * 整形器を包み、プロバイダごとの所要時間・失敗と、検証の通過・却下理由を記録する。
+ */
+func (m *Metrics) InstrumentFormatter(next llm.Formatter, provider string) llm.Formatter {
+	return &formatter{next: next, provider: provider, metrics: m}
+}
+
+func (f *formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
+	start := time.Now()
+	result, err := f.next.Format(ctx, req)
+	f.metrics.llmDuration.WithLabelValues(f.provider).Observe(time.Since(start).Seconds())
+	if err != nil {
+		f.metrics.llmErrors.WithLabelValues(f.provider, errorKind(err)).Inc()
+	}
+	return result, err
+}
+
+func (f *formatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	validated, err := f.next.Validate(ctx, result)
+	if validated != nil && validated.Status != "" {
+		f.metrics.formatResults.WithLabelValues(string(validated.Status), validated.ValidationReason, validated.PromptVersion).Inc()
+	}
+	return validated, err
+}
+
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, llm.ErrFormatterUnavailable):
+		return errorKindUnavailable
+	case errors.Is(err, llm.ErrInvalidFormat):
+		return errorKindInvalidFormat
+	default:
+		return errorKindOther
+	}
+}
+
+var _ llm.Formatter = (*formatter)(nil)
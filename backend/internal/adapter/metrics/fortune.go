@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	drawdomain "backend/internal/domain/draw"
+	drawusecase "backend/internal/usecase/draw"
+)
+
+// おみくじ提供結果のラベル
+const (
+	drawResultServed = "served"
+	drawResultEmpty  = "empty"
+)
+
+type fortuneDrawer struct {
+	next    drawusecase.FortuneDrawer
+	metrics *Metrics
+}
+
+/**
+ * おみくじを返す処理を包み、提供できた件数・空振り・失敗を数える。
+ */
+func (m *Metrics) InstrumentFortune(next drawusecase.FortuneDrawer) drawusecase.FortuneDrawer {
+	return &fortuneDrawer{next: next, metrics: m}
+}
+
+func (f *fortuneDrawer) DrawFortune(ctx context.Context) (*drawdomain.Draw, error) {
+	d, err := f.next.DrawFortune(ctx)
+	result := drawResultServed
+	switch {
+	case errors.Is(err, drawdomain.ErrEmptyResult):
+		result = drawResultEmpty
+	case err != nil:
+		result = resultError
+	}
+	f.metrics.drawsServed.WithLabelValues(result).Inc()
+	return d, err
+}
+
+// SharedDraw は共有リンクからの閲覧。抽選ではないため提供件数には数えない。
+func (f *fortuneDrawer) SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	return f.next.SharedDraw(ctx, id)
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace はメトリクス名の接頭辞。
+const namespace = "kirakuji"
+
+/**
+ * API とワーカーで使う Prometheus メトリクス一式。
+ * 業務コードには持ち込まず、ポートを包むデコレーターと HTTP ミドルウェアからだけ記録する。
+ */
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	postsCreated *prometheus.CounterVec
+	drawsServed  *prometheus.CounterVec
+
+	jobsEnqueued *prometheus.CounterVec
+	jobsDequeued *prometheus.CounterVec
+
+	formatResults *prometheus.CounterVec
+	llmDuration   *prometheus.HistogramVec
+	llmErrors     *prometheus.CounterVec
+
+	repoDuration *prometheus.HistogramVec
+	repoErrors   *prometheus.CounterVec
+}
+
+/**
+ * 専用のレジストリにメトリクスを登録して返す。Go ランタイムとプロセスの標準メトリクスも含める。
+ */
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+	m := &Metrics{
+		registry: registry,
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		postsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "posts_created_total",
+			Help:      "Posts saved by initial status (pending or flagged).",
+		}, []string{"status"}),
+		drawsServed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "draws_served_total",
+			Help:      "Random draw requests by result (served, empty or error).",
+		}, []string{"result"}),
+		jobsEnqueued: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "format_jobs_enqueued_total",
+			Help:      "Format jobs enqueued by result.",
+		}, []string{"result"}),
+		jobsDequeued: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "format_jobs_dequeued_total",
+			Help:      "Format jobs dequeued by result.",
+		}, []string{"result"}),
+		formatResults: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "format_results_total",
+			Help:      "Validated format results by status, rejection reason and prompt version.",
+		}, []string{"status", "reason", "prompt_version"}),
+		llmDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "llm_request_duration_seconds",
+			Help:      "LLM format call latency by provider.",
+			Buckets:   []float64{0.25, 0.5, 1, 2, 5, 10, 20, 30, 60, 120},
+		}, []string{"provider"}),
+		llmErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "llm_errors_total",
+			Help:      "LLM format call errors by provider and kind.",
+		}, []string{"provider", "kind"}),
+		repoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_operation_duration_seconds",
+			Help:      "Repository call latency by repository and operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"repository", "operation"}),
+		repoErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "repository_errors_total",
+			Help:      "Repository call errors by repository and operation.",
+		}, []string{"repository", "operation"}),
+	}
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.postsCreated, m.drawsServed,
+		m.jobsEnqueued, m.jobsDequeued,
+		m.formatResults, m.llmDuration, m.llmErrors,
+		m.repoDuration, m.repoErrors,
+	)
+	return m
+}
+
+/**
+ * /metrics に応答する HTTP ハンドラーを返す。
+ */
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+/**
+ * 追加のコレクター（キュー滞留数など）を登録する。
+ */
+func (m *Metrics) Register(c prometheus.Collector) error {
+	return m.registry.Register(c)
+}
+
+// 成否ラベルの値
+const (
+	resultOK    = "ok"
+	resultError = "error"
+)
+
+func resultLabel(err error) string {
+	if err != nil {
+		return resultError
+	}
+	return resultOK
+}
@@ -0,0 +1,197 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/port/llm"
+	"backend/internal/port/queue"
+	workertestutil "backend/internal/usecase/worker/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+/**
+ * ルート定義ごとにリクエスト数が数えられ、/metrics で公開されることを確認する。
+ */
+func TestGinMiddleware_RecordsRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := New()
+	router := gin.New()
+	router.Use(m.GinMiddleware())
+	router.GET("/draws/:id", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+
+	for _, id := range []string{"a", "b"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/draws/"+id, nil))
+	}
+
+	if got := testutil.ToFloat64(m.httpRequests.WithLabelValues(http.MethodGet, "/draws/:id", "404")); got != 2 {
+		t.Fatalf("http_requests_total = %v, want 2", got)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{`kirakuji_http_requests_total{method="GET",route="/draws/:id",status="404"} 2`, "go_goroutines"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("metrics output missing %q", want)
+		}
+	}
+}
+
+/**
+ * 失敗を種類ごとに数え、検証結果を状態・理由・プロンプト版ごとに数えることを確認する。
+ */
+func TestInstrumentFormatter(t *testing.T) {
+	m := New()
+	stub := &workertestutil.StubFormatter{FormatErr: fmt.Errorf("wrap: %w", llm.ErrFormatterUnavailable)}
+	f := m.InstrumentFormatter(stub, "openai")
+
+	if _, err := f.Format(context.Background(), &llm.FormatRequest{}); !errors.Is(err, llm.ErrFormatterUnavailable) {
+		t.Fatalf("error should pass through: %v", err)
+	}
+	if got := testutil.ToFloat64(m.llmErrors.WithLabelValues("openai", errorKindUnavailable)); got != 1 {
+		t.Fatalf("llm_errors_total = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(m.llmDuration); got != 1 {
+		t.Fatalf("llm duration series = %d, want 1", got)
+	}
+
+	stub.ValidateResult = &llm.FormatResult{Status: drawdomain.StatusRejected, ValidationReason: "too_long", PromptVersion: "v2"}
+	stub.ValidateErr = llm.ErrInvalidFormat
+	if _, err := f.Validate(context.Background(), &llm.FormatResult{}); !errors.Is(err, llm.ErrInvalidFormat) {
+		t.Fatalf("validate error should pass through: %v", err)
+	}
+	if got := testutil.ToFloat64(m.formatResults.WithLabelValues(string(drawdomain.StatusRejected), "too_long", "v2")); got != 1 {
+		t.Fatalf("format_results_total = %v, want 1", got)
+	}
+}
+
+/**
+ * 保存できた投稿だけを初期状態ごとに数えることを確認する。
+ */
+func TestInstrumentPostRepository_CountsCreatedByStatus(t *testing.T) {
+	m := New()
+	stub := workertestutil.NewStubPostRepository(nil)
+	repo := m.InstrumentPostRepository(stub)
+
+	pending, _ := post.New("p1", "content")
+	flagged, _ := post.New("p2", "content")
+	if err := flagged.MarkFlagged(); err != nil {
+		t.Fatalf("flag: %v", err)
+	}
+	for _, p := range []*post.Post{pending, flagged} {
+		if err := repo.Create(context.Background(), p); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	failing := m.InstrumentPostRepository(&failingPostRepository{StubPostRepository: stub})
+	_ = failing.Create(context.Background(), pending)
+
+	if got := testutil.ToFloat64(m.postsCreated.WithLabelValues(string(post.StatusPending))); got != 1 {
+		t.Fatalf("pending posts = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.postsCreated.WithLabelValues(string(post.StatusFlagged))); got != 1 {
+		t.Fatalf("flagged posts = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.repoErrors.WithLabelValues("posts", "create")); got != 1 {
+		t.Fatalf("repository errors = %v, want 1", got)
+	}
+}
+
+/**
+ * 提供・空振り・失敗を区別して数えることを確認する。
+ */
+func TestInstrumentFortune(t *testing.T) {
+	m := New()
+	for _, err := range []error{nil, drawdomain.ErrEmptyResult, errors.New("boom")} {
+		_, _ = m.InstrumentFortune(stubFortune{err: err}).DrawFortune(context.Background())
+	}
+	for _, result := range []string{drawResultServed, drawResultEmpty, resultError} {
+		if got := testutil.ToFloat64(m.drawsServed.WithLabelValues(result)); got != 1 {
+			t.Fatalf("draws_served_total{result=%q} = %v, want 1", result, got)
+		}
+	}
+}
+
+/**
+ * 取り出しの停止はエラーと区別し、滞留数は元のキューに委ねることを確認する。
+ */
+func TestInstrumentJobQueue(t *testing.T) {
+	m := New()
+	q := m.InstrumentJobQueue(&depthQueue{depth: 7})
+
+	if err := q.EnqueueFormat(context.Background(), "p1"); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if _, err := q.DequeueFormat(context.Background()); !errors.Is(err, queue.ErrQueueClosed) {
+		t.Fatalf("dequeue error should pass through: %v", err)
+	}
+	if got := testutil.ToFloat64(m.jobsEnqueued.WithLabelValues(resultOK)); got != 1 {
+		t.Fatalf("enqueued = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.jobsDequeued.WithLabelValues(resultClosed)); got != 1 {
+		t.Fatalf("dequeued closed = %v, want 1", got)
+	}
+
+	reporter, ok := q.(queue.DepthReporter)
+	if !ok {
+		t.Fatalf("instrumented queue should report depth")
+	}
+	if err := m.RegisterQueueDepth(reporter); err != nil {
+		t.Fatalf("register depth: %v", err)
+	}
+	expected := strings.NewReader(`
+# HELP kirakuji_format_queue_depth Format jobs waiting in the queue. -1 when the depth could not be read.
+# TYPE kirakuji_format_queue_depth gauge
+kirakuji_format_queue_depth 7
+`)
+	if err := testutil.GatherAndCompare(m.registry, expected, "kirakuji_format_queue_depth"); err != nil {
+		t.Fatalf("queue depth gauge: %v", err)
+	}
+
+	if _, err := m.InstrumentJobQueue(workertestutil.StubJobQueue{}).(queue.DepthReporter).Depth(context.Background()); !errors.Is(err, queue.ErrDepthUnsupported) {
+		t.Fatalf("expected ErrDepthUnsupported, got %v", err)
+	}
+}
+
+// 保存に失敗する投稿リポジトリのスタブ。
+type failingPostRepository struct {
+	*workertestutil.StubPostRepository
+}
+
+func (r *failingPostRepository) Create(ctx context.Context, p *post.Post) error {
+	return errors.New("boom")
+}
+
+type stubFortune struct {
+	err error
+}
+
+func (s stubFortune) DrawFortune(ctx context.Context) (*drawdomain.Draw, error) {
+	return nil, s.err
+}
+
+func (s stubFortune) SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	return nil, s.err
+}
+
+// 滞留数を返せるキューのスタブ。
+type depthQueue struct {
+	workertestutil.StubJobQueue
+	depth int64
+}
+
+func (q *depthQueue) Depth(ctx context.Context) (int64, error) {
+	return q.depth, nil
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/internal/domain/post"
+	"backend/internal/port/queue"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 滞留数を数える際の上限時間。スクレイプを長く止めないようにする。
+const depthTimeout = 3 * time.Second
+
+// 取り出しの結果ラベル。停止・中断はエラーと区別する。
+const resultClosed = "closed"
+
+// jobQueue は登録・取り出しの件数を記録するキューのデコレーター。
+type jobQueue struct {
+	next    queue.JobQueue
+	metrics *Metrics
+}
+
+/**
+ * キューを包み、登録・取り出しの件数を記録する。
+ */
+func (m *Metrics) InstrumentJobQueue(next queue.JobQueue) queue.JobQueue {
+	return &jobQueue{next: next, metrics: m}
+}
+
+func (q *jobQueue) EnqueueFormat(ctx context.Context, postID post.DarkPostID) error {
+	err := q.next.EnqueueFormat(ctx, postID)
+	q.metrics.jobsEnqueued.WithLabelValues(resultLabel(err)).Inc()
+	return err
+}
+
+func (q *jobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	job, err := q.next.DequeueFormat(ctx)
+	result := resultLabel(err)
+	if errors.Is(err, queue.ErrQueueClosed) || errors.Is(err, queue.ErrContextClosed) {
+		result = resultClosed
+	}
+	q.metrics.jobsDequeued.WithLabelValues(result).Inc()
+	return job, err
+}
+
+func (q *jobQueue) Close() error {
+	return q.next.Close()
+}
+
+/**
+ * 元のキューが滞留数を返せる場合はそのまま委ねる。
+ */
+func (q *jobQueue) Depth(ctx context.Context) (int64, error) {
+	reporter, ok := q.next.(queue.DepthReporter)
+	if !ok {
+		return 0, queue.ErrDepthUnsupported
+	}
+	return reporter.Depth(ctx)
+}
+
+/**
+ * 元のキューがジョブの取り消しに対応している場合はそのまま委ねる。
+ */
+func (q *jobQueue) CancelFormat(ctx context.Context, postID post.DarkPostID) error {
+	canceller, ok := q.next.(queue.Canceller)
+	if !ok {
+		return queue.ErrCancelUnsupported
+	}
+	return canceller.CancelFormat(ctx, postID)
+}
+
+/**
+ * スクレイプのたびにキューの滞留数を数えるゲージを登録する。
+ */
+func (m *Metrics) RegisterQueueDepth(reporter queue.DepthReporter) error {
+	return m.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "format_queue_depth",
+		Help:      "Format jobs waiting in the queue. -1 when the depth could not be read.",
+	}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), depthTimeout)
+		defer cancel()
+		depth, err := reporter.Depth(ctx)
+		if err != nil {
+			return -1
+		}
+		return float64(depth)
+	}))
+}
+
+var (
+	_ queue.JobQueue      = (*jobQueue)(nil)
+	_ queue.DepthReporter = (*jobQueue)(nil)
+	_ queue.Canceller     = (*jobQueue)(nil)
+)
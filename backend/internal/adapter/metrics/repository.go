@@ -0,0 +1,246 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain/audit"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/domain/report"
+	"backend/internal/port/repository"
+)
+
+/**
+ * リポジトリ呼び出しの所要時間と失敗を記録する。
+ */
+func (m *Metrics) observeRepo(name, operation string, start time.Time, err error) {
+	m.repoDuration.WithLabelValues(name, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.repoErrors.WithLabelValues(name, operation).Inc()
+	}
+}
+
+// postRepository は投稿の保存件数と呼び出し時間を記録するデコレーター。
+type postRepository struct {
+	next    repository.PostRepository
+	metrics *Metrics
+}
+
+/**
+ * 投稿リポジトリを包み、保存できた投稿を初期状態（pending / flagged）ごとに数える。
+ */
+func (m *Metrics) InstrumentPostRepository(next repository.PostRepository) repository.PostRepository {
+	return &postRepository{next: next, metrics: m}
+}
+
+func (r *postRepository) Create(ctx context.Context, p *post.Post) error {
+	start := time.Now()
+	err := r.next.Create(ctx, p)
+	r.metrics.observeRepo("posts", "create", start, err)
+	if err == nil && p != nil {
+		r.metrics.postsCreated.WithLabelValues(string(p.Status())).Inc()
+	}
+	return err
+}
+
+func (r *postRepository) Get(ctx context.Context, id post.DarkPostID) (*post.Post, error) {
+	start := time.Now()
+	p, err := r.next.Get(ctx, id)
+	r.metrics.observeRepo("posts", "get", start, err)
+	return p, err
+}
+
+func (r *postRepository) ListReady(ctx context.Context, limit int) ([]*post.Post, error) {
+	start := time.Now()
+	posts, err := r.next.ListReady(ctx, limit)
+	r.metrics.observeRepo("posts", "list_ready", start, err)
+	return posts, err
+}
+
+func (r *postRepository) Update(ctx context.Context, p *post.Post) error {
+	start := time.Now()
+	err := r.next.Update(ctx, p)
+	r.metrics.observeRepo("posts", "update", start, err)
+	return err
+}
+
+func (r *postRepository) ListByStatus(ctx context.Context, status post.Status, after post.DarkPostID, limit int) ([]*post.Post, error) {
+	start := time.Now()
+	posts, err := r.next.ListByStatus(ctx, status, after, limit)
+	r.metrics.observeRepo("posts", "list_by_status", start, err)
+	return posts, err
+}
+
+func (r *postRepository) Delete(ctx context.Context, id post.DarkPostID) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, id)
+	r.metrics.observeRepo("posts", "delete", start, err)
+	return err
+}
+
+// drawRepository はおみくじ結果の呼び出し時間を記録するデコレーター。
+type drawRepository struct {
+	next    repository.DrawRepository
+	metrics *Metrics
+}
+
+/**
+ * おみくじ結果リポジトリを包み、呼び出し時間と失敗を記録する。
+ */
+func (m *Metrics) InstrumentDrawRepository(next repository.DrawRepository) repository.DrawRepository {
+	return &drawRepository{next: next, metrics: m}
+}
+
+func (r *drawRepository) Create(ctx context.Context, d *drawdomain.Draw) error {
+	start := time.Now()
+	err := r.next.Create(ctx, d)
+	r.metrics.observeRepo("draws", "create", start, err)
+	return err
+}
+
+func (r *drawRepository) GetByPostID(ctx context.Context, postID post.DarkPostID) (*drawdomain.Draw, error) {
+	start := time.Now()
+	d, err := r.next.GetByPostID(ctx, postID)
+	r.metrics.observeRepo("draws", "get_by_post_id", start, err)
+	return d, err
+}
+
+func (r *drawRepository) GetByShareID(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	start := time.Now()
+	d, err := r.next.GetByShareID(ctx, id)
+	r.metrics.observeRepo("draws", "get_by_share_id", start, err)
+	return d, err
+}
+
+func (r *drawRepository) ListReady(ctx context.Context) ([]*drawdomain.Draw, error) {
+	start := time.Now()
+	draws, err := r.next.ListReady(ctx)
+	r.metrics.observeRepo("draws", "list_ready", start, err)
+	return draws, err
+}
+
+func (r *drawRepository) Update(ctx context.Context, d *drawdomain.Draw) error {
+	start := time.Now()
+	err := r.next.Update(ctx, d)
+	r.metrics.observeRepo("draws", "update", start, err)
+	return err
+}
+
+func (r *drawRepository) Delete(ctx context.Context, postID post.DarkPostID) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, postID)
+	r.metrics.observeRepo("draws", "delete", start, err)
+	return err
+}
+
+// reactionRepository はおみくじへの反応の呼び出し時間を記録するデコレーター。
+type reactionRepository struct {
+	next    repository.ReactionRepository
+	metrics *Metrics
+}
+
+/**
+ * 反応リポジトリを包み、呼び出し時間と失敗を記録する。
+ */
+func (m *Metrics) InstrumentReactionRepository(next repository.ReactionRepository) repository.ReactionRepository {
+	return &reactionRepository{next: next, metrics: m}
+}
+
+func (r *reactionRepository) Add(ctx context.Context, rc *reaction.Reaction) error {
+	start := time.Now()
+	err := r.next.Add(ctx, rc)
+	r.metrics.observeRepo("reactions", "add", start, err)
+	return err
+}
+
+func (r *reactionRepository) CountByPostID(ctx context.Context, postID post.DarkPostID) (reaction.Counts, error) {
+	start := time.Now()
+	counts, err := r.next.CountByPostID(ctx, postID)
+	r.metrics.observeRepo("reactions", "count_by_post_id", start, err)
+	return counts, err
+}
+
+func (r *reactionRepository) CountByPostIDs(ctx context.Context, postIDs []post.DarkPostID) (map[post.DarkPostID]reaction.Counts, error) {
+	start := time.Now()
+	counts, err := r.next.CountByPostIDs(ctx, postIDs)
+	r.metrics.observeRepo("reactions", "count_by_post_ids", start, err)
+	return counts, err
+}
+
+func (r *reactionRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	start := time.Now()
+	err := r.next.DeleteByPostID(ctx, postID)
+	r.metrics.observeRepo("reactions", "delete_by_post_id", start, err)
+	return err
+}
+
+// reportRepository はおみくじへの通報の呼び出し時間を記録するデコレーター。
+type reportRepository struct {
+	next    repository.ReportRepository
+	metrics *Metrics
+}
+
+/**
+ * 通報リポジトリを包み、呼び出し時間と失敗を記録する。
+ */
+func (m *Metrics) InstrumentReportRepository(next repository.ReportRepository) repository.ReportRepository {
+	return &reportRepository{next: next, metrics: m}
+}
+
+func (r *reportRepository) Add(ctx context.Context, rp *report.Report) (*report.Review, error) {
+	start := time.Now()
+	review, err := r.next.Add(ctx, rp)
+	r.metrics.observeRepo("reports", "add", start, err)
+	return review, err
+}
+
+func (r *reportRepository) ListOpen(ctx context.Context, limit int) ([]*report.Review, error) {
+	start := time.Now()
+	reviews, err := r.next.ListOpen(ctx, limit)
+	r.metrics.observeRepo("moderation_queue", "list_open", start, err)
+	return reviews, err
+}
+
+func (r *reportRepository) Resolve(ctx context.Context, postID post.DarkPostID) error {
+	start := time.Now()
+	err := r.next.Resolve(ctx, postID)
+	r.metrics.observeRepo("moderation_queue", "resolve", start, err)
+	return err
+}
+
+func (r *reportRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	start := time.Now()
+	err := r.next.DeleteByPostID(ctx, postID)
+	r.metrics.observeRepo("reports", "delete_by_post_id", start, err)
+	return err
+}
+
+// auditLogRepository は管理操作の記録の呼び出し時間を記録するデコレーター。
+type auditLogRepository struct {
+	next    repository.AuditLogRepository
+	metrics *Metrics
+}
+
+/**
+ * 監査ログリポジトリを包み、呼び出し時間と失敗を記録する。
+ */
+func (m *Metrics) InstrumentAuditLogRepository(next repository.AuditLogRepository) repository.AuditLogRepository {
+	return &auditLogRepository{next: next, metrics: m}
+}
+
+func (r *auditLogRepository) Record(ctx context.Context, e *audit.Entry) error {
+	start := time.Now()
+	err := r.next.Record(ctx, e)
+	r.metrics.observeRepo("admin_audit_logs", "record", start, err)
+	return err
+}
+
+var (
+	_ repository.PostRepository     = (*postRepository)(nil)
+	_ repository.DrawRepository     = (*drawRepository)(nil)
+	_ repository.ReactionRepository = (*reactionRepository)(nil)
+	_ repository.ReportRepository   = (*reportRepository)(nil)
+	_ repository.AuditLogRepository = (*auditLogRepository)(nil)
+)
@@ -0,0 +1,92 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"backend/internal/port/moderation"
+)
+
+var ErrNoModerators = errors.New("moderation chain: モデレーターが指定されていません")
+
+/**
+ * 複数のモデレーターを順に呼び出して結果をまとめるモデレーター。
+ * 先頭（ローカル判定）で危機的な兆候が見つかった場合は、本文を外部へ送らずにその場で返す。
+ * 2 番目以降が判定サービス停止で失敗した場合は、ログを残して残りの結果で判定を続ける。
+ */
+type Moderator struct {
+	moderators []moderation.Moderator
+}
+
+var _ moderation.Moderator = (*Moderator)(nil)
+
+/**
+ * 呼び出し順にモデレーターを受け取り、チェーンを組み立てる。
+ */
+func New(moderators ...moderation.Moderator) (*Moderator, error) {
+	var filtered []moderation.Moderator
+	for _, m := range moderators {
+		if m != nil {
+			filtered = append(filtered, m)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, ErrNoModerators
+	}
+	return &Moderator{moderators: filtered}, nil
+}
+
+/**
+ * 各モデレーターの判定を統合して返す。
+ */
+func (m *Moderator) Moderate(ctx context.Context, content string) (*moderation.Result, error) {
+	var results []*moderation.Result
+	for i, moderator := range m.moderators {
+		result, err := moderator.Moderate(ctx, content)
+		if err != nil {
+			// 先頭の判定は必須、それ以外の外部判定は停止していても投稿を止めない
+			if i > 0 && errors.Is(err, moderation.ErrModeratorUnavailable) {
+				slog.WarnContext(ctx, "optional moderator unavailable", slog.Any("error", err))
+				continue
+			}
+			return nil, err
+		}
+		results = append(results, result)
+		if result != nil && result.Crisis {
+			break
+		}
+	}
+	return merge(results...), nil
+}
+
+/**
+ * 結果を 1 つにまとめる。いずれかが該当すれば該当扱いにし、カテゴリと根拠は重複なく連結する。
+ */
+func merge(results ...*moderation.Result) *moderation.Result {
+	merged := &moderation.Result{}
+	seenCategory := make(map[string]struct{})
+	seenMatch := make(map[string]struct{})
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		merged.Flagged = merged.Flagged || r.Flagged
+		merged.Crisis = merged.Crisis || r.Crisis
+		for _, c := range r.Categories {
+			if _, ok := seenCategory[c]; ok {
+				continue
+			}
+			seenCategory[c] = struct{}{}
+			merged.Categories = append(merged.Categories, c)
+		}
+		for _, match := range r.Matches {
+			if _, ok := seenMatch[match]; ok {
+				continue
+			}
+			seenMatch[match] = struct{}{}
+			merged.Matches = append(merged.Matches, match)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,88 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"backend/internal/port/moderation"
+)
+
+type stubModerator struct {
+	result *moderation.Result
+	err    error
+	calls  int
+}
+
+func (s *stubModerator) Moderate(context.Context, string) (*moderation.Result, error) {
+	s.calls++
+	return s.result, s.err
+}
+
+func TestNewRequiresModerator(t *testing.T) {
+	if _, err := New(nil); !errors.Is(err, ErrNoModerators) {
+		t.Fatalf("expected ErrNoModerators, got %v", err)
+	}
+}
+
+func TestModerateMergesResults(t *testing.T) {
+	local := &stubModerator{result: &moderation.Result{Flagged: true, Categories: []string{moderation.CategoryViolence}, Matches: []string{"殺す"}}}
+	remote := &stubModerator{result: &moderation.Result{Flagged: true, Categories: []string{moderation.CategoryViolence, "harassment"}, Matches: []string{"violence"}}}
+	m, err := New(local, remote)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := m.Moderate(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Flagged || got.Crisis {
+		t.Fatalf("unexpected flags: %+v", got)
+	}
+	if len(got.Categories) != 2 || len(got.Matches) != 2 {
+		t.Fatalf("expected deduplicated categories and matches, got %+v", got)
+	}
+}
+
+func TestModerateStopsOnLocalCrisis(t *testing.T) {
+	local := &stubModerator{result: &moderation.Result{Flagged: true, Crisis: true, Categories: []string{moderation.CategoryCrisis}}}
+	remote := &stubModerator{result: &moderation.Result{}}
+	m, _ := New(local, remote)
+
+	got, err := m.Moderate(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Crisis {
+		t.Fatalf("expected crisis result")
+	}
+	// 危機判定済みの本文は外部へ送らない
+	if remote.calls != 0 {
+		t.Fatalf("remote moderator should not be called, got %d calls", remote.calls)
+	}
+}
+
+func TestModerateSkipsUnavailableOptionalModerator(t *testing.T) {
+	local := &stubModerator{result: &moderation.Result{}}
+	remote := &stubModerator{err: fmt.Errorf("%w: timeout", moderation.ErrModeratorUnavailable)}
+	m, _ := New(local, remote)
+
+	got, err := m.Moderate(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Flagged {
+		t.Fatalf("expected clean result, got %+v", got)
+	}
+}
+
+func TestModerateReturnsPrimaryError(t *testing.T) {
+	primaryErr := errors.New("boom")
+	m, _ := New(&stubModerator{err: primaryErr}, &stubModerator{result: &moderation.Result{}})
+
+	if _, err := m.Moderate(context.Background(), "text"); !errors.Is(err, primaryErr) {
+		t.Fatalf("expected primary error, got %v", err)
+	}
+}
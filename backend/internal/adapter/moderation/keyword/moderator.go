@@ -0,0 +1,138 @@
+package keyword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"backend/internal/port/moderation"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrInvalidRule = errors.New("keyword moderator: 判定ルールが不正です")
+
+/**
+ * 判定ルール。Pattern は正規化後の本文（NFKC・小文字・ひらがな化・空白除去）に対して評価する。
+ * @param Category 該当時のカテゴリ（moderation.CategoryCrisis なら危機扱い）
+ * @param Label ログや集計に残す識別名（本文そのものは残さない）
+ * @param Pattern 正規表現
+ */
+type Rule struct {
+	Category string
+	Label    string
+	Pattern  string
+}
+
+// DefaultRules は日本語の自傷・自殺の兆候と加害予告を拾う既定ルール。
+// 正規化でカタカナはひらがなに揃えるため、パターンはひらがなと漢字で書く。
+var DefaultRules = []Rule{
+	{Category: moderation.CategoryCrisis, Label: "want_to_die", Pattern: `(死|し)(にたい|にたく|なせて)`},
+	{Category: moderation.CategoryCrisis, Label: "want_to_disappear", Pattern: `(消|き)え(たい|たく)`},
+	{Category: moderation.CategoryCrisis, Label: "suicide", Pattern: `自殺|じさつ|自死`},
+	{Category: moderation.CategoryCrisis, Label: "no_will_to_live", Pattern: `(生|い)きて(い)?たくない|(生|い)きる(意味|価値)が?(ない|無い)`},
+	{Category: moderation.CategoryCrisis, Label: "hanging", Pattern: `首(を)?(吊|つ)`},
+	{Category: moderation.CategoryCrisis, Label: "jumping", Pattern: `(飛|と)び(降|お)り`},
+	{Category: moderation.CategoryCrisis, Label: "self_harm", Pattern: `自傷|りすか|りすとかっと|(手首|腕)を?(切|き)`},
+	{Category: moderation.CategoryCrisis, Label: "overdose", Pattern: `おーばーどーず|(^|[^a-z])od([^a-z]|$)`},
+	{Category: moderation.CategoryCrisis, Label: "suicide_en", Pattern: `suicide|killmyself|wanttodie`},
+	// ひらがなの「ころ」は「この頃」「ところ」と続くと誤検知するため、言い切りの形だけを拾う
+	{Category: moderation.CategoryViolence, Label: "kill_threat", Pattern: `殺(す|してやる|したい)|ころしてやる|ころすぞ|ぶっ(殺|ころ)`},
+}
+
+type compiledRule struct {
+	category string
+	label    string
+	re       *regexp.Regexp
+}
+
+/**
+ * 正規表現ルールで判定するローカルのモデレーター。外部通信は行わない。
+ */
+type Moderator struct {
+	rules []compiledRule
+}
+
+var _ moderation.Moderator = (*Moderator)(nil)
+
+/**
+ * 既定ルールでモデレーターを作る。
+ */
+func New() *Moderator {
+	m, err := NewWithRules(DefaultRules)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+/**
+ * 任意のルールでモデレーターを作る。正規表現が解釈できない場合はエラーを返す。
+ */
+func NewWithRules(rules []Rule) (*Moderator, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, r := range rules {
+		if strings.TrimSpace(r.Category) == "" || strings.TrimSpace(r.Pattern) == "" {
+			return nil, fmt.Errorf("%w: カテゴリとパターンが必要です", ErrInvalidRule)
+		}
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRule, r.Label, err)
+		}
+		label := r.Label
+		if label == "" {
+			label = r.Category
+		}
+		compiled = append(compiled, compiledRule{category: r.Category, label: label, re: re})
+	}
+	return &Moderator{rules: compiled}, nil
+}
+
+/**
+ * 本文を正規化してルールに照らし、該当したカテゴリとルール名を返す。
+ */
+func (m *Moderator) Moderate(ctx context.Context, content string) (*moderation.Result, error) {
+	normalized := Normalize(content)
+	result := &moderation.Result{}
+	seen := make(map[string]struct{})
+	for _, r := range m.rules {
+		if !r.re.MatchString(normalized) {
+			continue
+		}
+		result.Flagged = true
+		if r.category == moderation.CategoryCrisis {
+			result.Crisis = true
+		}
+		if _, ok := seen[r.category]; !ok {
+			seen[r.category] = struct{}{}
+			result.Categories = append(result.Categories, r.category)
+		}
+		result.Matches = append(result.Matches, r.label)
+	}
+	return result, nil
+}
+
+/**
+ * 表記ゆれを吸収するため、NFKC 正規化・小文字化・カタカナのひらがな化・空白と記号の除去を行う。
+ * 「シ ニ タ イ」「ｼﾆﾀｲ」「死.に.た.い」なども同じ文字列になる。
+ */
+func Normalize(content string) string {
+	folded := strings.ToLower(norm.NFKC.String(content))
+	var b strings.Builder
+	b.Grow(len(folded))
+	for _, r := range folded {
+		switch {
+		case unicode.IsSpace(r), unicode.IsPunct(r) && r != 'ー', unicode.IsSymbol(r):
+			continue
+		case r >= 'ァ' && r <= 'ヶ':
+			// カタカナはひらがなへ寄せる（ヴ・ヵ・ヶも同じ並びで対応する）
+			b.WriteRune(r - 'ァ' + 'ぁ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package keyword
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/port/moderation"
+)
+
+func TestModerateDetectsCrisisVariants(t *testing.T) {
+	m := New()
+	cases := []string{
+		"もう死にたい",
+		"しにたいって毎日思う",
+		"シ ニ タ イ",
+		"ｼﾆﾀｲ",
+		"消えたいな",
+		"自殺の方法を調べた",
+		"生きていたくない",
+		"首を吊ろうかと",
+		"屋上から飛び降りたい",
+		"またリスカした",
+		"薬でODした",
+		"オーバードーズしそう",
+		"I want to die",
+	}
+	for _, content := range cases {
+		res, err := m.Moderate(context.Background(), content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !res.Flagged || !res.Crisis {
+			t.Fatalf("expected crisis for %q, got %+v", content, res)
+		}
+		if res.Categories[0] != moderation.CategoryCrisis {
+			t.Fatalf("expected crisis category for %q, got %v", content, res.Categories)
+		}
+	}
+}
+
+func TestModerateViolenceIsNotCrisis(t *testing.T) {
+	res, err := New().Moderate(context.Background(), "上司をぶっ殺してやりたい")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Flagged || res.Crisis {
+		t.Fatalf("expected violence flag without crisis, got %+v", res)
+	}
+	if len(res.Matches) != 1 || res.Matches[0] != "kill_threat" {
+		t.Fatalf("expected rule label to be reported, got %v", res.Matches)
+	}
+}
+
+func TestModerateKillThreatVariants(t *testing.T) {
+	m := New()
+	for _, content := range []string{"殺してやる", "あいつを殺したい", "コロシテヤル", "ころすぞ", "ぶっころ"} {
+		res, err := m.Moderate(context.Background(), content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !res.Flagged || res.Matches[0] != "kill_threat" {
+			t.Fatalf("expected kill_threat for %q, got %+v", content, res)
+		}
+	}
+}
+
+func TestModerateCleanContent(t *testing.T) {
+	m := New()
+	for _, content := range []string{
+		"上司の言い方がきつくて落ち込んだ",
+		"good job と言われたかった",
+		"予定を消したい",
+		"いきなり雨が降ってきた",
+		"このころすごく忙しい",
+		"あのころしたいことが多かった",
+		"ちょうどいいところすぐ見つかった",
+	} {
+		res, err := m.Moderate(context.Background(), content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.Flagged {
+			t.Fatalf("expected %q to pass, got %+v", content, res)
+		}
+	}
+}
+
+func TestNewWithRulesValidation(t *testing.T) {
+	if _, err := NewWithRules([]Rule{{Category: moderation.CategoryCrisis, Pattern: "("}}); !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("expected ErrInvalidRule for broken regexp, got %v", err)
+	}
+	if _, err := NewWithRules([]Rule{{Pattern: "x"}}); !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("expected ErrInvalidRule for missing category, got %v", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize(" ｼﾆ・タイ！ "); got != "しにたい" {
+		t.Fatalf("unexpected normalized text: %q", got)
+	}
+	if got := Normalize("オーバー"); got != "おーばー" {
+		t.Fatalf("expected long vowel mark to be kept: %q", got)
+	}
+}
@@ -0,0 +1,108 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend/internal/port/moderation"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// DefaultModel は日本語にも対応したモデレーションモデル。
+const DefaultModel = openai.ModerationOmniLatest
+
+/**
+ * OpenAI Moderation API を呼ぶのに必要な最小限の操作をまとめた窓口。
+ */
+type ModerationClient interface {
+	Moderations(ctx context.Context, req openai.ModerationRequest) (openai.ModerationResponse, error)
+}
+
+/**
+ * OpenAI Moderation API で本文を判定するモデレーター。
+ * 自傷系のカテゴリは危機、暴力・脅迫系のカテゴリは暴力として扱い、それ以外は判定に使わない。
+ */
+type Moderator struct {
+	client ModerationClient
+	model  string
+}
+
+var _ moderation.Moderator = (*Moderator)(nil)
+
+/**
+ * API キーを点検してから Moderation API のクライアントを組み立てる。
+ */
+func NewModerator(apiKey, model, baseURL string) (*Moderator, error) {
+	if strings.TrimSpace(apiKey) == "" {
+		return nil, fmt.Errorf("openai moderator: API キーが設定されていません")
+	}
+	cfg := openai.DefaultConfig(apiKey)
+	if baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+	if model == "" {
+		model = DefaultModel
+	}
+	return &Moderator{client: openai.NewClientWithConfig(cfg), model: model}, nil
+}
+
+/**
+ * 本文を Moderation API に送り、該当カテゴリを共通の判定結果へ写し替える。
+ */
+func (m *Moderator) Moderate(ctx context.Context, content string) (*moderation.Result, error) {
+	resp, err := m.client.Moderations(ctx, openai.ModerationRequest{Input: content, Model: m.model})
+	if err != nil {
+		return nil, fmt.Errorf("%w: openai moderator: %v", moderation.ErrModeratorUnavailable, err)
+	}
+
+	result := &moderation.Result{}
+	for _, r := range resp.Results {
+		c := r.Categories
+		crisis := labels(map[string]bool{
+			"self-harm":              c.SelfHarm,
+			"self-harm/intent":       c.SelfHarmIntent,
+			"self-harm/instructions": c.SelfHarmInstructions,
+		})
+		violence := labels(map[string]bool{
+			"violence":               c.Violence,
+			"harassment/threatening": c.HarassmentThreatening,
+			"hate/threatening":       c.HateThreatening,
+		})
+		if len(crisis) > 0 {
+			result.Crisis = true
+			result.Matches = append(result.Matches, crisis...)
+			result.Categories = appendUnique(result.Categories, moderation.CategoryCrisis)
+		}
+		if len(violence) > 0 {
+			result.Matches = append(result.Matches, violence...)
+			result.Categories = appendUnique(result.Categories, moderation.CategoryViolence)
+		}
+	}
+	result.Flagged = len(result.Categories) > 0
+	return result, nil
+}
+
+// 真になっているカテゴリ名を固定順で返す。
+func labels(flags map[string]bool) []string {
+	var out []string
+	for _, name := range []string{
+		"self-harm", "self-harm/intent", "self-harm/instructions",
+		"violence", "harassment/threatening", "hate/threatening",
+	} {
+		if flags[name] {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func appendUnique(values []string, v string) []string {
+	for _, existing := range values {
+		if existing == v {
+			return values
+		}
+	}
+	return append(values, v)
+}
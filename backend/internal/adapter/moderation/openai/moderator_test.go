@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/port/moderation"
+
+	githubOpenAI "github.com/sashabaranov/go-openai"
+)
+
+type stubModerationClient struct {
+	resp        githubOpenAI.ModerationResponse
+	err         error
+	capturedReq githubOpenAI.ModerationRequest
+}
+
+func (s *stubModerationClient) Moderations(_ context.Context, req githubOpenAI.ModerationRequest) (githubOpenAI.ModerationResponse, error) {
+	s.capturedReq = req
+	return s.resp, s.err
+}
+
+func TestModerateMapsSelfHarmToCrisis(t *testing.T) {
+	client := &stubModerationClient{resp: githubOpenAI.ModerationResponse{Results: []githubOpenAI.Result{{
+		Flagged:    true,
+		Categories: githubOpenAI.ResultCategories{SelfHarm: true, SelfHarmIntent: true},
+	}}}}
+	m := &Moderator{client: client, model: DefaultModel}
+
+	res, err := m.Moderate(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Flagged || !res.Crisis {
+		t.Fatalf("expected crisis result, got %+v", res)
+	}
+	if len(res.Categories) != 1 || res.Categories[0] != moderation.CategoryCrisis {
+		t.Fatalf("unexpected categories: %v", res.Categories)
+	}
+	if len(res.Matches) != 2 {
+		t.Fatalf("expected matched labels to be reported, got %v", res.Matches)
+	}
+	if client.capturedReq.Model != DefaultModel {
+		t.Fatalf("expected default model, got %s", client.capturedReq.Model)
+	}
+}
+
+func TestModerateMapsViolence(t *testing.T) {
+	client := &stubModerationClient{resp: githubOpenAI.ModerationResponse{Results: []githubOpenAI.Result{{
+		Flagged:    true,
+		Categories: githubOpenAI.ResultCategories{HarassmentThreatening: true},
+	}}}}
+	res, err := (&Moderator{client: client}).Moderate(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Flagged || res.Crisis || res.Categories[0] != moderation.CategoryViolence {
+		t.Fatalf("expected violence result, got %+v", res)
+	}
+}
+
+func TestModerateIgnoresUnmappedCategories(t *testing.T) {
+	client := &stubModerationClient{resp: githubOpenAI.ModerationResponse{Results: []githubOpenAI.Result{{
+		Flagged:    true,
+		Categories: githubOpenAI.ResultCategories{Harassment: true},
+	}}}}
+	res, err := (&Moderator{client: client}).Moderate(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 愚痴に多い強い言葉だけでは止めない
+	if res.Flagged {
+		t.Fatalf("expected unmapped categories to be ignored, got %+v", res)
+	}
+}
+
+func TestModerateClientError(t *testing.T) {
+	m := &Moderator{client: &stubModerationClient{err: errors.New("boom")}}
+	if _, err := m.Moderate(context.Background(), "text"); !errors.Is(err, moderation.ErrModeratorUnavailable) {
+		t.Fatalf("expected ErrModeratorUnavailable, got %v", err)
+	}
+}
+
+func TestNewModeratorRequiresKey(t *testing.T) {
+	if _, err := NewModerator(" ", "", ""); err == nil {
+		t.Fatalf("expected error when key is missing")
+	}
+	m, err := NewModerator("key", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.model != DefaultModel {
+		t.Fatalf("expected default model, got %s", m.model)
+	}
+}
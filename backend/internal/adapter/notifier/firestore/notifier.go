@@ -0,0 +1,143 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"backend/internal/domain/post"
+	"backend/internal/logging"
+	"backend/internal/port/notifier"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// postsCollection は状態を書き込む posts コレクション名（投稿リポジトリと同じ）。
+const postsCollection = "posts"
+
+// 投稿ドキュメントに書き足す進み具合のフィールド。投稿リポジトリの Update は触らない。
+const (
+	fieldProgressStatus = "progress_status"
+	fieldProgressAt     = "progress_at"
+)
+
+var (
+	errNilClient   = errors.New("firestorenotifier: Firestore クライアントが指定されていません")
+	errEmptyPostID = errors.New("firestorenotifier: post id is empty")
+)
+
+/**
+ * posts/{id} ドキュメントを介して通知する Notifier。API と Worker が別プロセスでも届く。
+ * 通知は進み具合をドキュメントへ書き込み、購読はドキュメントの変更を Firestore のスナップショットで受け取る。
+ */
+type Notifier struct {
+	client *firestore.Client
+}
+
+var _ notifier.Notifier = (*Notifier)(nil)
+
+// NewNotifier は Firestore を通知の受け渡し先にする。
+func NewNotifier(client *firestore.Client) (*Notifier, error) {
+	if client == nil {
+		return nil, errNilClient
+	}
+	return &Notifier{client: client}, nil
+}
+
+// Publish は投稿ドキュメントへ進み具合を書き込む。投稿が無ければ何もしない。
+func (n *Notifier) Publish(ctx context.Context, event notifier.Event) error {
+	if event.PostID == "" {
+		return errEmptyPostID
+	}
+	at := event.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	_, err := n.client.Collection(postsCollection).Doc(string(event.PostID)).Update(ctx, []firestore.Update{
+		{Path: fieldProgressStatus, Value: string(event.Status)},
+		{Path: fieldProgressAt, Value: at},
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("publish post progress: %w", err)
+	}
+	return nil
+}
+
+/**
+ * 投稿ドキュメントの変更を購読する。最初のスナップショットで現在の状態を届け、以降は状態が変わったときだけ届ける。
+ * ctx が終わるか終端の状態を届けたところでチャネルを閉じる。
+ */
+func (n *Notifier) Subscribe(ctx context.Context, postID post.DarkPostID) (<-chan notifier.Event, error) {
+	if postID == "" {
+		return nil, errEmptyPostID
+	}
+
+	out := make(chan notifier.Event)
+	iter := n.client.Collection(postsCollection).Doc(string(postID)).Snapshots(ctx)
+	go func() {
+		defer close(out)
+		defer iter.Stop()
+
+		var last notifier.Status
+		for {
+			snap, err := iter.Next()
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.WarnContext(ctx, "post progress subscription stopped", logging.PostAttr(string(postID)), slog.Any("error", err))
+				}
+				return
+			}
+			if !snap.Exists() {
+				continue
+			}
+			event := eventFromDocument(postID, snap.Data(), snap.UpdateTime)
+			if event.Status == last {
+				continue
+			}
+			last = event.Status
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+			if event.Status.Terminal() {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+/**
+ * 投稿ドキュメントから進み具合を読み取る。投稿の状態（ready / flagged）を進み具合の記録より優先する。
+ */
+func eventFromDocument(postID post.DarkPostID, data map[string]any, updatedAt time.Time) notifier.Event {
+	event := notifier.Event{PostID: postID, Status: notifier.StatusQueued, At: updatedAt}
+	if at, ok := data[fieldProgressAt].(time.Time); ok {
+		event.At = at
+	}
+
+	postStatus, _ := data["status"].(string)
+	switch post.Status(postStatus) {
+	case post.StatusReady:
+		event.Status = notifier.StatusReady
+		return event
+	case post.StatusFlagged:
+		event.Status = notifier.StatusRejected
+		return event
+	}
+
+	progress, _ := data[fieldProgressStatus].(string)
+	switch s := notifier.Status(progress); s {
+	case notifier.StatusQueued, notifier.StatusFormatting, notifier.StatusRejected:
+		event.Status = s
+	}
+	return event
+}
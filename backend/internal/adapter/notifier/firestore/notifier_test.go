@@ -0,0 +1,100 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"backend/internal/domain/post"
+	"backend/internal/port/notifier"
+
+	"cloud.google.com/go/firestore"
+)
+
+func TestNewNotifier_RequiresClient(t *testing.T) {
+	if _, err := NewNotifier(nil); !errors.Is(err, errNilClient) {
+		t.Fatalf("expected errNilClient, got %v", err)
+	}
+}
+
+func TestEventFromDocument(t *testing.T) {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		data map[string]any
+		want notifier.Status
+	}{
+		{"pending without progress", map[string]any{"status": "pending"}, notifier.StatusQueued},
+		{"formatting", map[string]any{"status": "pending", fieldProgressStatus: "formatting"}, notifier.StatusFormatting},
+		{"rejected by validation", map[string]any{"status": "pending", fieldProgressStatus: "rejected"}, notifier.StatusRejected},
+		{"ready wins over progress", map[string]any{"status": "ready", fieldProgressStatus: "formatting"}, notifier.StatusReady},
+		{"flagged", map[string]any{"status": "flagged"}, notifier.StatusRejected},
+		{"unknown progress", map[string]any{"status": "pending", fieldProgressStatus: "paused"}, notifier.StatusQueued},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := eventFromDocument("post-1", tc.data, at)
+			if event.Status != tc.want || event.PostID != "post-1" || !event.At.Equal(at) {
+				t.Fatalf("unexpected event: %+v", event)
+			}
+		})
+	}
+}
+
+func TestNotifier_Integration(t *testing.T) {
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST is not set; skipping Firestore integration tests")
+	}
+	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	if projectID == "" {
+		projectID = "firestore-integration-test"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := firestore.NewClient(ctx, projectID)
+	if err != nil {
+		t.Fatalf("failed to create firestore client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	n, err := NewNotifier(client)
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+	postID := post.DarkPostID("notifier-" + time.Now().Format("20060102150405.000000000"))
+	doc := client.Collection(postsCollection).Doc(string(postID))
+	if _, err := doc.Set(ctx, map[string]any{"post_id": string(postID), "content": "test", "status": "pending"}); err != nil {
+		t.Fatalf("seed post: %v", err)
+	}
+	t.Cleanup(func() { _, _ = doc.Delete(context.Background()) })
+
+	events, err := n.Subscribe(ctx, postID)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if event := <-events; event.Status != notifier.StatusQueued {
+		t.Fatalf("expected queued first, got %+v", event)
+	}
+	if err := n.Publish(ctx, notifier.Event{PostID: postID, Status: notifier.StatusFormatting}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if event := <-events; event.Status != notifier.StatusFormatting {
+		t.Fatalf("expected formatting, got %+v", event)
+	}
+	if _, err := doc.Update(ctx, []firestore.Update{{Path: "status", Value: "ready"}}); err != nil {
+		t.Fatalf("mark ready: %v", err)
+	}
+	if event := <-events; event.Status != notifier.StatusReady {
+		t.Fatalf("expected ready, got %+v", event)
+	}
+	if _, ok := <-events; ok {
+		t.Fatal("expected channel to close after ready")
+	}
+
+	// 投稿が無い場合の通知は失敗にしない
+	if err := n.Publish(ctx, notifier.Event{PostID: "missing-post", Status: notifier.StatusQueued}); err != nil {
+		t.Fatalf("expected no error for missing post, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"backend/internal/domain/post"
+	"backend/internal/port/notifier"
+)
+
+var errEmptyPostID = errors.New("memorynotifier: post id is empty")
+
+// retention は直近の状態を覚えておく期間。購読が通知より遅れて始まっても追いつけるようにする。
+const retention = 10 * time.Minute
+
+// subscriberBuffer は購読者ごとに溜めておける通知の数。溢れた通知は捨てる。
+const subscriberBuffer = 8
+
+/**
+ * 同じプロセス内で通知を配る Broker。API と Worker を 1 プロセスで動かすときや、テストで使う。
+ * 別プロセスの Worker からの通知は届かない。
+ */
+type Broker struct {
+	mu          sync.Mutex
+	now         func() time.Time
+	subscribers map[post.DarkPostID]map[chan notifier.Event]struct{}
+	latest      map[post.DarkPostID]notifier.Event
+}
+
+var _ notifier.Notifier = (*Broker)(nil)
+
+// NewBroker は Broker を生成する。
+func NewBroker() *Broker {
+	return &Broker{
+		now:         time.Now,
+		subscribers: make(map[post.DarkPostID]map[chan notifier.Event]struct{}),
+		latest:      make(map[post.DarkPostID]notifier.Event),
+	}
+}
+
+/**
+ * 購読中の相手へ通知を配り、直近の状態として覚える。受け取りが詰まっている購読者の分は捨てる。
+ */
+func (b *Broker) Publish(ctx context.Context, event notifier.Event) error {
+	if event.PostID == "" {
+		return errEmptyPostID
+	}
+	if event.At.IsZero() {
+		event.At = b.now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pruneLocked()
+	b.latest[event.PostID] = event
+	for ch := range b.subscribers[event.PostID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+/**
+ * 投稿の状態変化を購読する。直近の状態を覚えていれば最初に届ける。
+ * ctx が終わるか終端の状態を届けたところでチャネルを閉じる。
+ */
+func (b *Broker) Subscribe(ctx context.Context, postID post.DarkPostID) (<-chan notifier.Event, error) {
+	if postID == "" {
+		return nil, errEmptyPostID
+	}
+
+	in := make(chan notifier.Event, subscriberBuffer)
+	b.mu.Lock()
+	if latest, ok := b.latest[postID]; ok {
+		in <- latest
+	}
+	if b.subscribers[postID] == nil {
+		b.subscribers[postID] = make(map[chan notifier.Event]struct{})
+	}
+	b.subscribers[postID][in] = struct{}{}
+	b.mu.Unlock()
+
+	out := make(chan notifier.Event)
+	go func() {
+		defer close(out)
+		defer b.unsubscribe(postID, in)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-in:
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+				if event.Status.Terminal() {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (b *Broker) unsubscribe(postID post.DarkPostID, ch chan notifier.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subscribers[postID], ch)
+	if len(b.subscribers[postID]) == 0 {
+		delete(b.subscribers, postID)
+	}
+}
+
+// 覚えておく期間を過ぎた状態を捨てる。
+func (b *Broker) pruneLocked() {
+	cutoff := b.now().Add(-retention)
+	for id, event := range b.latest {
+		if event.At.Before(cutoff) {
+			delete(b.latest, id)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/port/notifier"
+)
+
+func TestBroker_DeliversUntilTerminal(t *testing.T) {
+	b := NewBroker()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events, err := b.Subscribe(ctx, "post-1")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	for _, s := range []notifier.Status{notifier.StatusFormatting, notifier.StatusReady} {
+		if err := b.Publish(ctx, notifier.Event{PostID: "post-1", Status: s}); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+	if err := b.Publish(ctx, notifier.Event{PostID: "post-2", Status: notifier.StatusRejected}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	var got []notifier.Status
+	for event := range events {
+		if event.PostID != "post-1" || event.At.IsZero() {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+		got = append(got, event.Status)
+	}
+	if len(got) != 2 || got[0] != notifier.StatusFormatting || got[1] != notifier.StatusReady {
+		t.Fatalf("unexpected events: %v", got)
+	}
+}
+
+func TestBroker_ReplaysLatestToLateSubscriber(t *testing.T) {
+	b := NewBroker()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := b.Publish(ctx, notifier.Event{PostID: "post-1", Status: notifier.StatusRejected}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	events, err := b.Subscribe(ctx, "post-1")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	event, ok := <-events
+	if !ok || event.Status != notifier.StatusRejected {
+		t.Fatalf("expected replayed rejected event, got %+v (open=%v)", event, ok)
+	}
+	if _, ok := <-events; ok {
+		t.Fatal("expected channel to close after a terminal event")
+	}
+}
+
+func TestBroker_ForgetsOldStates(t *testing.T) {
+	b := NewBroker()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+
+	_ = b.Publish(context.Background(), notifier.Event{PostID: "post-1", Status: notifier.StatusQueued})
+	now = now.Add(retention + time.Second)
+	_ = b.Publish(context.Background(), notifier.Event{PostID: "post-2", Status: notifier.StatusQueued})
+
+	if _, ok := b.latest["post-1"]; ok {
+		t.Fatal("expected old state to be pruned")
+	}
+}
+
+func TestBroker_ClosesOnCancel(t *testing.T) {
+	b := NewBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := b.Subscribe(ctx, "post-1")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	cancel()
+	if _, ok := <-events; ok {
+		t.Fatal("expected channel to close on cancel")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.subscribers) != 0 {
+		t.Fatalf("expected subscriber to be removed, got %d", len(b.subscribers))
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"backend/internal/domain/post"
+	"backend/internal/logging"
 	"backend/internal/port/queue"
 
 	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -20,6 +22,7 @@ const (
 	jobStatusPending     = "pending"
 	pollIntervalMin      = 1 * time.Minute
 	pollIntervalMax      = 1 * time.Minute
+	depthAlias           = "depth"
 )
 
 var (
@@ -31,9 +34,11 @@ var (
 
 // Firestore に記録する整形ジョブ 1 件分の姿
 type jobDocument struct {
-	PostID string    `firestore:"post_id"`
-	Status string    `firestore:"status"`
-	Queued time.Time `firestore:"created_at"`
+	PostID       string            `firestore:"post_id"`
+	Status       string            `firestore:"status"`
+	RequestID    string            `firestore:"request_id"`
+	TraceContext map[string]string `firestore:"trace_context"`
+	Queued       time.Time         `firestore:"created_at"`
 }
 
 // Firestore を永続化に使う整形待ちキュー
@@ -60,6 +65,7 @@ func NewFirestoreJobQueue(client *firestore.Client) (*FirestoreJobQueue, error)
 
 /**
  * 整形待ち投稿の ID を Firestore に書き込み、二重登録なら専用エラーを返す。
+ * ワーカーのログやトレースを登録元の API リクエストと突き合わせられるよう、リクエスト ID とトレースコンテキストも残す。
  */
 func (q *FirestoreJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostID) error {
 	if err := q.ensureReady(ctx); err != nil {
@@ -73,8 +79,12 @@ func (q *FirestoreJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostI
 	payload := map[string]any{
 		"post_id":    string(id),
 		"status":     jobStatusPending,
+		"request_id": logging.RequestIDFromContext(ctx),
 		"created_at": firestore.ServerTimestamp,
 	}
+	if carrier := queue.TraceContextFromContext(ctx); carrier != nil {
+		payload["trace_context"] = carrier
+	}
 	_, err := doc.Create(ctx, payload)
 	if status.Code(err) == codes.AlreadyExists {
 		return queue.ErrJobAlreadyScheduled
@@ -87,31 +97,96 @@ func (q *FirestoreJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostI
 
 /**
  * Firestore 上で最も古い整形待ちを 1 件だけ取得し、見つかるまで待機を繰り返す。
+ * 問い合わせのたびにコンテキストのポーリング通知先を呼ぶ。
  */
-func (q *FirestoreJobQueue) DequeueFormat(ctx context.Context) (post.DarkPostID, error) {
+func (q *FirestoreJobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
 	waitInterval := pollIntervalMin
 	for {
 		if err := q.ensureReady(ctx); err != nil {
-			return "", err
+			return nil, err
 		}
-		id, err := q.dequeueOnce(ctx)
+		queue.NotifyPoll(ctx)
+		job, err := q.dequeueOnce(ctx)
 		if err == nil {
-			return id, nil
+			return job, nil
 		}
 		// ジョブがまだ用意されていない場合は停止指示を監視しながら待機して再試行する
 		if errors.Is(err, errNoJobAvailable) {
 			select {
 			case <-ctx.Done():
-				return "", fmt.Errorf("%w: %v", queue.ErrContextClosed, ctx.Err())
+				return nil, fmt.Errorf("%w: %v", queue.ErrContextClosed, ctx.Err())
 			case <-q.closedCh:
-				return "", queue.ErrQueueClosed
+				return nil, queue.ErrQueueClosed
 			case <-time.After(waitInterval):
 				waitInterval = nextPollInterval(waitInterval)
 				continue
 			}
 		}
-		return "", err
+		return nil, err
+	}
+}
+
+/**
+ * format_jobs に残っている整形待ちジョブの件数を集計クエリで数える。
+ */
+func (q *FirestoreJobQueue) Depth(ctx context.Context) (int64, error) {
+	if err := q.ensureReady(ctx); err != nil {
+		return 0, err
+	}
+	result, err := q.client.Collection(q.collection).NewAggregationQuery().WithCount(depthAlias).Get(ctx)
+	if err != nil {
+		return 0, translateContextError(fmt.Errorf("count jobs: %w", err))
+	}
+	value, ok := result[depthAlias].(*firestorepb.Value)
+	if !ok {
+		return 0, fmt.Errorf("count jobs: 集計結果の型が想定外です: %T", result[depthAlias])
 	}
+	return value.GetIntegerValue(), nil
+}
+
+/**
+ * format_jobs に残っているジョブを登録の古い順に最大 limit 件、取り出さずに返す。
+ */
+func (q *FirestoreJobQueue) Oldest(ctx context.Context, limit int) ([]queue.QueuedJob, error) {
+	if err := q.ensureReady(ctx); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+	docs, err := q.client.Collection(q.collection).OrderBy("created_at", firestore.Asc).Limit(limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, translateContextError(fmt.Errorf("list jobs: %w", err))
+	}
+	jobs := make([]queue.QueuedJob, 0, len(docs))
+	for _, doc := range docs {
+		var job jobDocument
+		if err := doc.DataTo(&job); err != nil {
+			return nil, fmt.Errorf("%w: %v", errDecodeJobFailed, err)
+		}
+		jobs = append(jobs, queue.QueuedJob{
+			PostID:    post.DarkPostID(job.PostID),
+			RequestID: job.RequestID,
+			QueuedAt:  job.Queued,
+		})
+	}
+	return jobs, nil
+}
+
+/**
+ * 整形待ちのジョブを format_jobs から取り除く。ドキュメントが無ければ何もしない。
+ */
+func (q *FirestoreJobQueue) CancelFormat(ctx context.Context, id post.DarkPostID) error {
+	if err := q.ensureReady(ctx); err != nil {
+		return err
+	}
+	if id == "" {
+		return errEmptyPostID
+	}
+	if _, err := q.client.Collection(q.collection).Doc(string(id)).Delete(ctx); err != nil {
+		return translateContextError(fmt.Errorf("cancel job: %w", err))
+	}
+	return nil
 }
 
 /**
@@ -155,9 +230,9 @@ func (q *FirestoreJobQueue) ensureReady(ctx context.Context) error {
 /**
  * Firestore の format_jobs から一番古いジョブをトランザクションで取得し、その場で削除する。
  */
-func (q *FirestoreJobQueue) dequeueOnce(ctx context.Context) (post.DarkPostID, error) {
+func (q *FirestoreJobQueue) dequeueOnce(ctx context.Context) (*queue.FormatJob, error) {
 	query := q.client.Collection(q.collection).OrderBy("created_at", firestore.Asc).Limit(1)
-	var dequeued post.DarkPostID
+	var dequeued *queue.FormatJob
 	// トランザクションでドキュメント取得と削除をまとめ、複数ワーカーからの重複処理を避ける
 	err := q.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
 		docs, err := tx.Documents(query).GetAll()
@@ -181,15 +256,19 @@ func (q *FirestoreJobQueue) dequeueOnce(ctx context.Context) (post.DarkPostID, e
 			}
 			return err
 		}
-		dequeued = post.DarkPostID(job.PostID)
+		dequeued = &queue.FormatJob{
+			PostID:       post.DarkPostID(job.PostID),
+			RequestID:    job.RequestID,
+			TraceContext: job.TraceContext,
+		}
 		return nil
 	}, firestore.MaxAttempts(5))
 	// トランザクション結果をキュー用のエラーへ丸める
 	if err != nil {
 		if errors.Is(err, errNoJobAvailable) {
-			return "", errNoJobAvailable
+			return nil, errNoJobAvailable
 		}
-		return "", translateContextError(fmt.Errorf("dequeue tx: %w", err))
+		return nil, translateContextError(fmt.Errorf("dequeue tx: %w", err))
 	}
 	return dequeued, nil
 }
@@ -219,4 +298,9 @@ func nextPollInterval(current time.Duration) time.Duration {
 	return next
 }
 
-var _ queue.JobQueue = (*FirestoreJobQueue)(nil)
+var (
+	_ queue.JobQueue      = (*FirestoreJobQueue)(nil)
+	_ queue.DepthReporter = (*FirestoreJobQueue)(nil)
+	_ queue.Canceller     = (*FirestoreJobQueue)(nil)
+	_ queue.Inspector     = (*FirestoreJobQueue)(nil)
+)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"backend/internal/domain/post"
+	"backend/internal/logging"
 	portqueue "backend/internal/port/queue"
 
 	"cloud.google.com/go/firestore"
@@ -25,17 +26,153 @@ func TestFirestoreJobQueue_EnqueueAndDequeue(t *testing.T) {
 		t.Fatalf("NewFirestoreJobQueue: %v", err)
 	}
 
-	ctx := context.Background()
+	ctx := logging.WithRequestID(context.Background(), "req-1")
 	if err := queue.EnqueueFormat(ctx, post.DarkPostID("post-firestore-1")); err != nil {
 		t.Fatalf("enqueue: %v", err)
 	}
 
-	got, err := queue.DequeueFormat(ctx)
+	got, err := queue.DequeueFormat(context.Background())
+	if err != nil {
+		t.Fatalf("dequeue: %v", err)
+	}
+	if got.PostID != post.DarkPostID("post-firestore-1") {
+		t.Fatalf("unexpected id: %s", got.PostID)
+	}
+	// 登録元のリクエスト ID をワーカー側でも参照できる
+	if got.RequestID != "req-1" {
+		t.Fatalf("unexpected request id: %q", got.RequestID)
+	}
+}
+
+func TestFirestoreJobQueue_PropagatesTraceContext(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, formatJobsCollection)
+
+	queue, err := NewFirestoreJobQueue(client)
+	if err != nil {
+		t.Fatalf("NewFirestoreJobQueue: %v", err)
+	}
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := portqueue.WithTraceContext(context.Background(), map[string]string{"traceparent": traceparent})
+	if err := queue.EnqueueFormat(ctx, post.DarkPostID("post-trace-1")); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	got, err := queue.DequeueFormat(context.Background())
+	if err != nil {
+		t.Fatalf("dequeue: %v", err)
+	}
+	// 登録時のスパンをワーカー側でリンクできるよう traceparent が残る
+	if got.TraceContext["traceparent"] != traceparent {
+		t.Fatalf("unexpected trace context: %v", got.TraceContext)
+	}
+}
+
+func TestFirestoreJobQueue_DequeueNotifiesPoll(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, formatJobsCollection)
+
+	queue, err := NewFirestoreJobQueue(client)
 	if err != nil {
+		t.Fatalf("NewFirestoreJobQueue: %v", err)
+	}
+	if err := queue.EnqueueFormat(context.Background(), post.DarkPostID("post-poll-1")); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	polls := 0
+	ctx := portqueue.WithPollObserver(context.Background(), func() { polls++ })
+	if _, err := queue.DequeueFormat(ctx); err != nil {
 		t.Fatalf("dequeue: %v", err)
 	}
-	if got != post.DarkPostID("post-firestore-1") {
-		t.Fatalf("unexpected id: %s", got)
+	// ワーカーの死活判定に使うため、問い合わせのたびに通知される
+	if polls != 1 {
+		t.Fatalf("expected 1 poll notification, got %d", polls)
+	}
+}
+
+func TestFirestoreJobQueue_Depth(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, formatJobsCollection)
+
+	queue, err := NewFirestoreJobQueue(client)
+	if err != nil {
+		t.Fatalf("NewFirestoreJobQueue: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, id := range []post.DarkPostID{"depth-1", "depth-2"} {
+		if err := queue.EnqueueFormat(ctx, id); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+	depth, err := queue.Depth(ctx)
+	if err != nil {
+		t.Fatalf("depth: %v", err)
+	}
+	if depth != 2 {
+		t.Fatalf("expected depth 2, got %d", depth)
+	}
+}
+
+func TestFirestoreJobQueue_CancelFormat(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, formatJobsCollection)
+
+	queue, err := NewFirestoreJobQueue(client)
+	if err != nil {
+		t.Fatalf("NewFirestoreJobQueue: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := queue.EnqueueFormat(ctx, post.DarkPostID("cancel-1")); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if err := queue.CancelFormat(ctx, post.DarkPostID("cancel-1")); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	// 取り消し済み（取り出し済み）のジョブをもう一度取り消しても失敗しない
+	if err := queue.CancelFormat(ctx, post.DarkPostID("cancel-1")); err != nil {
+		t.Fatalf("cancel again: %v", err)
+	}
+	if depth, err := queue.Depth(ctx); err != nil || depth != 0 {
+		t.Fatalf("expected empty queue, got %d %v", depth, err)
+	}
+	// 取り消した投稿は積み直せる
+	if err := queue.EnqueueFormat(ctx, post.DarkPostID("cancel-1")); err != nil {
+		t.Fatalf("enqueue after cancel: %v", err)
+	}
+}
+
+func TestFirestoreJobQueue_Oldest(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, formatJobsCollection)
+
+	queue, err := NewFirestoreJobQueue(client)
+	if err != nil {
+		t.Fatalf("NewFirestoreJobQueue: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, id := range []post.DarkPostID{"oldest-1", "oldest-2", "oldest-3"} {
+		if err := queue.EnqueueFormat(ctx, id); err != nil {
+			t.Fatalf("enqueue %s: %v", id, err)
+		}
+	}
+	jobs, err := queue.Oldest(ctx, 2)
+	if err != nil {
+		t.Fatalf("oldest: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].PostID != "oldest-1" || jobs[1].PostID != "oldest-2" {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+	if jobs[0].QueuedAt.IsZero() {
+		t.Fatalf("expected queued time to be set")
+	}
+	// 覗いただけなのでジョブは残っている
+	if depth, err := queue.Depth(ctx); err != nil || depth != 3 {
+		t.Fatalf("expected depth 3, got %d %v", depth, err)
 	}
 }
 
@@ -75,8 +212,12 @@ func TestFirestoreJobQueue_DequeueWaitsForNewJob(t *testing.T) {
 	}
 	done := make(chan result)
 	go func() {
-		id, err := queue.DequeueFormat(ctx)
-		done <- result{id: id, err: err}
+		job, err := queue.DequeueFormat(ctx)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		done <- result{id: job.PostID}
 	}()
 
 	time.Sleep(200 * time.Millisecond)
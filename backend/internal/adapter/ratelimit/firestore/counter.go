@@ -0,0 +1,82 @@
+package firestore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"backend/internal/port/ratelimit"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CountersCollection は呼び出し回数を置くコレクション名。
+// expire_at に Firestore の TTL ポリシーを設定して枠の切り替わったドキュメントを自動削除させる。
+const CountersCollection = "rate_limits"
+
+var errNilClient = errors.New("firestoreratelimit: Firestore クライアントが指定されていません")
+
+type counterDocument struct {
+	Count    int64     `firestore:"count"`
+	ExpireAt time.Time `firestore:"expire_at"`
+}
+
+/**
+ * Firestore で呼び出し回数を数える保存先。複数インスタンスで上限を共有する。
+ * ドキュメント ID はキーと枠の開始時刻のハッシュにし、IP アドレスなどの生の値は保存しない。
+ */
+type Counter struct {
+	client *firestore.Client
+	now    func() time.Time
+}
+
+var _ ratelimit.Counter = (*Counter)(nil)
+
+// NewCounter は Firestore を保存先にする。
+func NewCounter(client *firestore.Client) (*Counter, error) {
+	if client == nil {
+		return nil, errNilClient
+	}
+	return &Counter{client: client, now: time.Now}, nil
+}
+
+/**
+ * 枠に対応するドキュメントの回数をトランザクションで 1 つ増やし、増やした後の回数を返す。
+ */
+func (c *Counter) Increment(ctx context.Context, key string, window time.Duration) (ratelimit.Window, error) {
+	start := c.now().Truncate(window)
+	resetAt := start.Add(window)
+	doc := c.client.Collection(CountersCollection).Doc(documentID(key, window, start))
+
+	var count int64
+	err := c.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(doc)
+		var current counterDocument
+		switch {
+		case status.Code(err) == codes.NotFound:
+		case err != nil:
+			return err
+		default:
+			if err := snap.DataTo(&current); err != nil {
+				return err
+			}
+		}
+		count = current.Count + 1
+		return tx.Set(doc, counterDocument{Count: count, ExpireAt: resetAt})
+	})
+	if err != nil {
+		return ratelimit.Window{}, fmt.Errorf("%w: %v", ratelimit.ErrCounterUnavailable, err)
+	}
+	return ratelimit.Window{Count: count, ResetAt: resetAt}, nil
+}
+
+func documentID(key string, window time.Duration, start time.Time) string {
+	sum := sha256.Sum256([]byte(key + "|" + window.String() + "|" + strconv.FormatInt(start.Unix(), 10)))
+	return hex.EncodeToString(sum[:])
+}
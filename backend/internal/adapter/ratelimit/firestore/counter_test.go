@@ -0,0 +1,60 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+func TestNewCounter_RequiresClient(t *testing.T) {
+	if _, err := NewCounter(nil); !errors.Is(err, errNilClient) {
+		t.Fatalf("expected errNilClient, got %v", err)
+	}
+}
+
+func TestDocumentID_DoesNotContainRawKey(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	id := documentID("ip:198.51.100.1", time.Minute, start)
+	if strings.Contains(id, "198.51.100.1") || strings.Contains(id, "/") {
+		t.Fatalf("document id should be an opaque hash: %s", id)
+	}
+	if id == documentID("ip:198.51.100.1", time.Minute, start.Add(time.Minute)) {
+		t.Fatalf("each window should have its own document")
+	}
+}
+
+func TestCounter_Integration(t *testing.T) {
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST is not set; skipping Firestore integration tests")
+	}
+	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	if projectID == "" {
+		projectID = "firestore-integration-test"
+	}
+	ctx := context.Background()
+	client, err := firestore.NewClient(ctx, projectID)
+	if err != nil {
+		t.Fatalf("failed to create firestore client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	counter, err := NewCounter(client)
+	if err != nil {
+		t.Fatalf("new counter: %v", err)
+	}
+	key := "visitor:integration-" + time.Now().Format(time.RFC3339Nano)
+	for want := int64(1); want <= 2; want++ {
+		got, err := counter.Increment(ctx, key, time.Hour)
+		if err != nil {
+			t.Fatalf("increment: %v", err)
+		}
+		if got.Count != want {
+			t.Fatalf("count = %d, want %d", got.Count, want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/port/ratelimit"
+)
+
+type bucket struct {
+	count   int64
+	resetAt time.Time
+}
+
+/**
+ * プロセス内で呼び出し回数を数える保存先。インスタンスが 1 つの場合やローカル開発向け。
+ * 枠が切り替わったキーは数えるたびにまとめて捨てる。
+ */
+type Counter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	lastPrune time.Time
+}
+
+var _ ratelimit.Counter = (*Counter)(nil)
+
+// NewCounter は空の保存先を作る。
+func NewCounter() *Counter {
+	return &Counter{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (c *Counter) Increment(ctx context.Context, key string, window time.Duration) (ratelimit.Window, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.pruneLocked(now)
+	start := now.Truncate(window)
+	id := key + "@" + window.String()
+	b, ok := c.buckets[id]
+	if !ok || !now.Before(b.resetAt) {
+		b = &bucket{resetAt: start.Add(window)}
+		c.buckets[id] = b
+	}
+	b.count++
+	return ratelimit.Window{Count: b.count, ResetAt: b.resetAt}, nil
+}
+
+// 全件の走査は 1 分に 1 回までにする
+func (c *Counter) pruneLocked(now time.Time) {
+	if now.Sub(c.lastPrune) < time.Minute {
+		return
+	}
+	c.lastPrune = now
+	for id, b := range c.buckets {
+		if !now.Before(b.resetAt) {
+			delete(c.buckets, id)
+		}
+	}
+}
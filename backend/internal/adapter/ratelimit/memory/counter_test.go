@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCounter_CountsWithinWindow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 10, 0, time.UTC)
+	c := NewCounter()
+	c.now = func() time.Time { return now }
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := c.Increment(context.Background(), "ip:198.51.100.1", time.Minute)
+		if err != nil {
+			t.Fatalf("increment: %v", err)
+		}
+		if got.Count != want {
+			t.Fatalf("count = %d, want %d", got.Count, want)
+		}
+		if !got.ResetAt.Equal(time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)) {
+			t.Fatalf("unexpected reset time: %s", got.ResetAt)
+		}
+	}
+
+	// 別のキーや別の枠幅は独立して数える
+	if got, _ := c.Increment(context.Background(), "ip:198.51.100.2", time.Minute); got.Count != 1 {
+		t.Fatalf("other key count = %d, want 1", got.Count)
+	}
+	if got, _ := c.Increment(context.Background(), "ip:198.51.100.1", 24*time.Hour); got.Count != 1 {
+		t.Fatalf("other window count = %d, want 1", got.Count)
+	}
+}
+
+func TestCounter_ResetsAfterWindow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 50, 0, time.UTC)
+	c := NewCounter()
+	c.now = func() time.Time { return now }
+
+	_, _ = c.Increment(context.Background(), "ip:198.51.100.1", time.Minute)
+	_, _ = c.Increment(context.Background(), "ip:198.51.100.1", time.Minute)
+
+	now = now.Add(15 * time.Second)
+	got, _ := c.Increment(context.Background(), "ip:198.51.100.1", time.Minute)
+	if got.Count != 1 {
+		t.Fatalf("count after window = %d, want 1", got.Count)
+	}
+
+	// 枠の切り替わったキーは捨てられる
+	now = now.Add(2 * time.Minute)
+	_, _ = c.Increment(context.Background(), "ip:198.51.100.2", time.Minute)
+	if len(c.buckets) != 1 {
+		t.Fatalf("expected expired buckets to be pruned, got %d", len(c.buckets))
+	}
+}
@@ -0,0 +1,117 @@
+package redaction
+
+import (
+	"regexp"
+	"strings"
+
+	"backend/internal/port/redaction"
+)
+
+// 形で判別できる個人情報の正規表現（NFKC 正規化後の本文に適用する）。
+const (
+	patternEmail   = `[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`
+	patternURL     = `(https?://|www\.)[^\s]+`
+	patternPhone   = `(\+81[-\s]?|\b0)\d{1,4}[-\s]?\d{1,4}[-\s]?\d{3,4}\b`
+	patternAddress = `〒\s?\d{3}-?\d{4}|\b\d{3}-\d{4}\b|\d+丁目(\d+番地?)?(\d+号)?|\d+番地(\d+号)?`
+	patternSchool  = `[\p{Han}\p{Katakana}ー]{2,10}(小学校|中学校|高等学校|高校|中学|大学|専門学校|学園|学院)`
+)
+
+// 電話番号とみなす国内表記の桁数（固定電話 10 桁、携帯電話 11 桁）。
+const (
+	minPhoneDigits = 10
+	maxPhoneDigits = 11
+)
+
+// DefaultSurnames は人名検出に使う代表的な姓。
+var DefaultSurnames = []string{
+	"佐藤", "鈴木", "高橋", "田中", "伊藤", "渡辺", "渡部", "山本", "中村", "小林",
+	"加藤", "吉田", "山田", "佐々木", "山口", "松本", "井上", "木村", "斎藤", "斉藤",
+	"清水", "山崎", "池田", "橋本", "阿部", "石川", "山下", "中島", "石井", "小川",
+	"前田", "岡田", "長谷川", "藤田", "後藤", "近藤", "村上", "遠藤", "青木", "坂本",
+	"福田", "太田", "西村", "藤井", "金子", "岡本", "藤原", "中野", "三浦", "原田",
+	"中川", "松田", "竹内", "小野", "田村", "中山", "和田", "石田", "森田", "上田",
+	"柴田", "酒井", "工藤", "横山", "宮崎", "宮本", "内田", "高木", "安藤", "谷口",
+	"大野", "丸山", "今井", "高田", "藤本", "武田", "村田", "上野", "杉山", "増田",
+	"小山", "大塚", "平野", "菅原", "久保", "松井", "千葉", "岩崎", "桜井", "木下",
+	"野口", "松尾", "菊地", "野村", "新井",
+	// 1 文字の姓は一般名詞と紛らわしいため敬称付きの場合のみ検出する
+	"林", "森", "原", "東", "西", "南", "北",
+}
+
+// DefaultHonorifics は人名の後ろに付く敬称・呼称。
+var DefaultHonorifics = []string{
+	"さん", "くん", "君", "ちゃん", "様", "さま", "氏", "先生", "先輩", "後輩",
+	"部長", "課長", "係長", "社長", "店長",
+}
+
+// DefaultPlaceNames は地名検出に使う都道府県名と主な都市名。
+var DefaultPlaceNames = []string{
+	"北海道", "青森", "岩手", "宮城", "秋田", "山形", "福島", "茨城", "栃木", "群馬",
+	"埼玉", "千葉", "東京", "神奈川", "新潟", "富山", "石川", "福井", "山梨", "長野",
+	"岐阜", "静岡", "愛知", "三重", "滋賀", "京都", "大阪", "兵庫", "奈良", "和歌山",
+	"鳥取", "島根", "岡山", "広島", "山口", "徳島", "香川", "愛媛", "高知", "福岡",
+	"佐賀", "長崎", "熊本", "大分", "宮崎", "鹿児島", "沖縄",
+	"札幌", "仙台", "さいたま", "横浜", "川崎", "相模原", "名古屋", "神戸", "北九州",
+	"渋谷", "新宿", "池袋", "品川", "梅田", "難波", "天神",
+}
+
+// DefaultPlaceSuffixes は地名の後ろに付く行政区分。
+var DefaultPlaceSuffixes = []string{"都", "道", "府", "県", "市", "区", "町", "村", "駅"}
+
+/**
+ * 既定の検出器一式を返す。形で判別できるものを先に、辞書によるものを後に並べる。
+ * 人名と地名の両方に当たる語（例: 千葉・宮崎）は、先に登録した人名として扱う。
+ */
+func DefaultDetectors() []Detector {
+	return []Detector{
+		mustRegex(redaction.KindEmail, patternEmail),
+		mustRegex(redaction.KindURL, patternURL),
+		&phoneDetector{RegexDetector: mustRegex(redaction.KindPhone, patternPhone)},
+		mustRegex(redaction.KindAddress, patternAddress),
+		mustRegex(redaction.KindSchool, patternSchool),
+		NewDictionaryDetector(redaction.KindName, DefaultSurnames, DefaultHonorifics, 2),
+		NewDictionaryDetector(redaction.KindPlace, DefaultPlaceNames, DefaultPlaceSuffixes, 0),
+	}
+}
+
+/**
+ * 既定の検出器で伏せ字処理を組み立てる。
+ */
+func NewDefault() *Redactor {
+	r, err := New(DefaultDetectors()...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// phoneDetector は正規表現の一致のうち、桁数が電話番号として妥当なものだけを残す。
+type phoneDetector struct {
+	*RegexDetector
+}
+
+var digitPattern = regexp.MustCompile(`\d`)
+
+func (d *phoneDetector) Find(text string) [][2]int {
+	var spans [][2]int
+	for _, loc := range d.RegexDetector.Find(text) {
+		digits := len(digitPattern.FindAllString(text[loc[0]:loc[1]], -1))
+		if strings.HasPrefix(text[loc[0]:], "+81") {
+			// 国番号 81 を除き、省略された先頭の 0 を足して国内表記の桁数に揃える
+			digits = digits - 2 + 1
+		}
+		if digits < minPhoneDigits || digits > maxPhoneDigits {
+			continue
+		}
+		spans = append(spans, loc)
+	}
+	return spans
+}
+
+func mustRegex(kind, pattern string) *RegexDetector {
+	d, err := NewRegexDetector(kind, pattern)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
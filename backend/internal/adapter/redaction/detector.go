@@ -0,0 +1,128 @@
+package redaction
+
+import (
+	"regexp"
+	"sort"
+	"unicode/utf8"
+)
+
+/**
+ * 本文中の個人情報らしき箇所を探す検出器。
+ * Kind: 検出する種類（port/redaction の Kind 定数）
+ * Find: 該当箇所のバイト範囲 [start, end) を返す
+ */
+type Detector interface {
+	Kind() string
+	Find(text string) [][2]int
+}
+
+/**
+ * 正規表現で検出する検出器。電話番号・メール・URL のように形が決まっているものに使う。
+ */
+type RegexDetector struct {
+	kind string
+	re   *regexp.Regexp
+}
+
+/**
+ * 正規表現から検出器を作る。パターンが不正な場合はエラーを返す。
+ */
+func NewRegexDetector(kind, pattern string) (*RegexDetector, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &RegexDetector{kind: kind, re: re}, nil
+}
+
+func (d *RegexDetector) Kind() string {
+	return d.kind
+}
+
+func (d *RegexDetector) Find(text string) [][2]int {
+	var spans [][2]int
+	for _, loc := range d.re.FindAllStringIndex(text, -1) {
+		spans = append(spans, [2]int{loc[0], loc[1]})
+	}
+	return spans
+}
+
+/**
+ * 辞書の語で検出する検出器。人名や地名のように形では判別できないものに使う。
+ * 語の直後に敬称などの接尾辞があればあわせて伏せる。
+ * RequireSuffixBelow より短い語（例: 1 文字の姓）は、誤検出を避けるため接尾辞がある場合のみ検出する。
+ */
+type DictionaryDetector struct {
+	kind               string
+	words              []string
+	suffixes           []string
+	requireSuffixBelow int
+}
+
+/**
+ * 辞書と接尾辞から検出器を作る。長い語を優先して照合するよう並べ替えておく。
+ */
+func NewDictionaryDetector(kind string, words, suffixes []string, requireSuffixBelow int) *DictionaryDetector {
+	sortedWords := sortByLengthDesc(words)
+	sortedSuffixes := sortByLengthDesc(suffixes)
+	return &DictionaryDetector{
+		kind:               kind,
+		words:              sortedWords,
+		suffixes:           sortedSuffixes,
+		requireSuffixBelow: requireSuffixBelow,
+	}
+}
+
+func (d *DictionaryDetector) Kind() string {
+	return d.kind
+}
+
+func (d *DictionaryDetector) Find(text string) [][2]int {
+	var spans [][2]int
+	for i := 0; i < len(text); {
+		end := d.matchAt(text, i)
+		if end > i {
+			spans = append(spans, [2]int{i, end})
+			i = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return spans
+}
+
+// i から始まる辞書語（と接尾辞）の終端を返す。該当しなければ i を返す。
+func (d *DictionaryDetector) matchAt(text string, i int) int {
+	for _, w := range d.words {
+		if len(text)-i < len(w) || text[i:i+len(w)] != w {
+			continue
+		}
+		end := i + len(w)
+		suffixEnd := end
+		for _, s := range d.suffixes {
+			if len(text)-end >= len(s) && text[end:end+len(s)] == s {
+				suffixEnd = end + len(s)
+				break
+			}
+		}
+		if suffixEnd == end && utf8.RuneCountInString(w) < d.requireSuffixBelow {
+			continue
+		}
+		return suffixEnd
+	}
+	return i
+}
+
+func sortByLengthDesc(values []string) []string {
+	sorted := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			sorted = append(sorted, v)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+	return sorted
+}
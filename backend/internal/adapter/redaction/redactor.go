@@ -0,0 +1,108 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"backend/internal/port/redaction"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrNoDetectors = errors.New("redaction: 検出器が指定されていません")
+
+// DefaultPlaceholders は種類ごとの伏せ字。
+var DefaultPlaceholders = map[string]string{
+	redaction.KindPhone:   "[電話番号]",
+	redaction.KindEmail:   "[メールアドレス]",
+	redaction.KindURL:     "[URL]",
+	redaction.KindName:    "[人名]",
+	redaction.KindPlace:   "[地名]",
+	redaction.KindSchool:  "[学校名]",
+	redaction.KindAddress: "[住所]",
+}
+
+/**
+ * 検出器を順に適用し、該当箇所を伏せ字へ置き換える。
+ * 同じ位置を複数の検出器が拾った場合は、先に始まるもの・長いもの・先に登録された検出器の順で優先する。
+ */
+type Redactor struct {
+	detectors    []Detector
+	placeholders map[string]string
+}
+
+var _ redaction.Redactor = (*Redactor)(nil)
+
+/**
+ * 検出器から伏せ字処理を組み立てる。伏せ字は DefaultPlaceholders を使い、未定義の種類は "[種類]" にする。
+ */
+func New(detectors ...Detector) (*Redactor, error) {
+	var filtered []Detector
+	for _, d := range detectors {
+		if d != nil {
+			filtered = append(filtered, d)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, ErrNoDetectors
+	}
+	return &Redactor{detectors: filtered, placeholders: DefaultPlaceholders}, nil
+}
+
+type span struct {
+	start, end int
+	kind       string
+	order      int
+}
+
+/**
+ * 全角数字などの表記ゆれを NFKC で揃えてから検出し、伏せ字に置き換えた本文と件数を返す。
+ */
+func (r *Redactor) Redact(ctx context.Context, text string) (*redaction.Result, error) {
+	normalized := norm.NFKC.String(text)
+
+	var spans []span
+	for order, d := range r.detectors {
+		for _, loc := range d.Find(normalized) {
+			if loc[0] < 0 || loc[1] > len(normalized) || loc[0] >= loc[1] {
+				continue
+			}
+			spans = append(spans, span{start: loc[0], end: loc[1], kind: d.Kind(), order: order})
+		}
+	}
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+		if spans[i].end-spans[i].start != spans[j].end-spans[j].start {
+			return spans[i].end-spans[i].start > spans[j].end-spans[j].start
+		}
+		return spans[i].order < spans[j].order
+	})
+
+	counts := make(map[string]int)
+	var b strings.Builder
+	cursor := 0
+	for _, s := range spans {
+		// 既に伏せた範囲と重なるものは捨てる
+		if s.start < cursor {
+			continue
+		}
+		b.WriteString(normalized[cursor:s.start])
+		b.WriteString(r.placeholder(s.kind))
+		counts[s.kind]++
+		cursor = s.end
+	}
+	b.WriteString(normalized[cursor:])
+
+	return &redaction.Result{Text: b.String(), Counts: counts}, nil
+}
+
+func (r *Redactor) placeholder(kind string) string {
+	if p, ok := r.placeholders[kind]; ok {
+		return p
+	}
+	return "[" + kind + "]"
+}
@@ -0,0 +1,111 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"backend/internal/port/redaction"
+)
+
+func TestRedactor_Default(t *testing.T) {
+	r := NewDefault()
+
+	cases := []struct {
+		name     string
+		input    string
+		want     string
+		wantKind string
+	}{
+		{name: "mobile phone", input: "連絡は090-1234-5678まで", want: "連絡は[電話番号]まで", wantKind: redaction.KindPhone},
+		{name: "full width phone", input: "０３１２３４５６７８に電話", want: "[電話番号]に電話", wantKind: redaction.KindPhone},
+		{name: "international phone", input: "+81 90-1234-5678", want: "[電話番号]", wantKind: redaction.KindPhone},
+		{name: "email", input: "foo.bar@example.co.jpに送った", want: "[メールアドレス]に送った", wantKind: redaction.KindEmail},
+		{name: "url", input: "ここ見て https://example.com/a?b=1 ひどい", want: "ここ見て [URL] ひどい", wantKind: redaction.KindURL},
+		{name: "postal code", input: "〒150-0001に住んでる", want: "[住所]に住んでる", wantKind: redaction.KindAddress},
+		{name: "chome", input: "3丁目2番地5号の家", want: "[住所]の家", wantKind: redaction.KindAddress},
+		{name: "school", input: "桜ヶ丘中学校の先生がひどい", want: "[学校名]の先生がひどい", wantKind: redaction.KindSchool},
+		{name: "surname with honorific", input: "田中さんに怒られた", want: "[人名]に怒られた", wantKind: redaction.KindName},
+		{name: "surname without honorific", input: "佐々木がうざい", want: "[人名]がうざい", wantKind: redaction.KindName},
+		{name: "single char surname with honorific", input: "林先輩が嫌い", want: "[人名]が嫌い", wantKind: redaction.KindName},
+		{name: "place with suffix", input: "大阪府に引っ越したい", want: "[地名]に引っ越したい", wantKind: redaction.KindPlace},
+		{name: "place containing other place", input: "東京都の満員電車", want: "[地名]の満員電車", wantKind: redaction.KindPlace},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := r.Redact(context.Background(), tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Text != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, res.Text)
+			}
+			if res.Counts[tc.wantKind] != 1 {
+				t.Fatalf("expected 1 %s, got %+v", tc.wantKind, res.Counts)
+			}
+		})
+	}
+}
+
+func TestRedactor_KeepsOrdinaryText(t *testing.T) {
+	r := NewDefault()
+	inputs := []string{
+		"林の中を散歩した",
+		"東の空が明るい",
+		"2024年は最悪だった",
+		"残業が100時間を超えた",
+	}
+	for _, input := range inputs {
+		res, err := r.Redact(context.Background(), input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.Text != input || len(res.Counts) != 0 {
+			t.Fatalf("expected %q to be kept, got %q (%+v)", input, res.Text, res.Counts)
+		}
+	}
+}
+
+func TestRedactor_CountsMultipleKinds(t *testing.T) {
+	r := NewDefault()
+	res, err := r.Redact(context.Background(), "田中さんと鈴木くんが090-1234-5678で京都駅に呼び出した")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(res.Text, "田中") || strings.Contains(res.Text, "090") || strings.Contains(res.Text, "京都") {
+		t.Fatalf("personal information leaked: %q", res.Text)
+	}
+	if res.Counts[redaction.KindName] != 2 || res.Counts[redaction.KindPhone] != 1 || res.Counts[redaction.KindPlace] != 1 {
+		t.Fatalf("unexpected counts: %+v", res.Counts)
+	}
+}
+
+func TestRedactor_OverlapPrefersEarlierAndLonger(t *testing.T) {
+	short, _ := NewRegexDetector("short", `ab`)
+	long, _ := NewRegexDetector("long", `abc`)
+	later, _ := NewRegexDetector("later", `bcd`)
+	r, err := New(short, long, later)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res, err := r.Redact(context.Background(), "xabcdx")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Text != "x[long]dx" {
+		t.Fatalf("unexpected text: %q", res.Text)
+	}
+	if res.Counts["long"] != 1 || len(res.Counts) != 1 {
+		t.Fatalf("unexpected counts: %+v", res.Counts)
+	}
+}
+
+func TestNew_RequiresDetectors(t *testing.T) {
+	if _, err := New(); !errors.Is(err, ErrNoDetectors) {
+		t.Fatalf("expected ErrNoDetectors, got %v", err)
+	}
+	if _, err := NewRegexDetector(redaction.KindPhone, "("); err == nil {
+		t.Fatalf("expected invalid pattern error")
+	}
+}
@@ -0,0 +1,52 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"backend/internal/domain/audit"
+	"backend/internal/port/repository"
+
+	"cloud.google.com/go/firestore"
+)
+
+// auditLogsCollection は管理操作の記録を追記するコレクション名。
+const auditLogsCollection = "admin_audit_logs"
+
+var errNilAuditEntry = errors.New("firestorerepository: audit entry is nil")
+
+// AuditLogRepository は管理操作の記録を Firestore に追記するリポジトリ。
+type AuditLogRepository struct {
+	client *firestore.Client
+}
+
+var _ repository.AuditLogRepository = (*AuditLogRepository)(nil)
+
+// NewAuditLogRepository は Firestore を利用するリポジトリを生成する。
+func NewAuditLogRepository(client *firestore.Client) (*AuditLogRepository, error) {
+	if client == nil {
+		return nil, errMissingRepository
+	}
+	return &AuditLogRepository{client: client}, nil
+}
+
+// Record は操作の記録を自動採番のドキュメントとして追記する。
+func (r *AuditLogRepository) Record(ctx context.Context, e *audit.Entry) error {
+	if e == nil {
+		return errNilAuditEntry
+	}
+	data := map[string]any{
+		"actor":       e.Actor(),
+		"action":      string(e.Action()),
+		"target":      e.Target(),
+		"detail":      e.Detail(),
+		"succeeded":   e.Succeeded(),
+		"error":       e.Err(),
+		"occurred_at": e.OccurredAt(),
+	}
+	if _, _, err := r.client.Collection(auditLogsCollection).Add(ctx, data); err != nil {
+		return fmt.Errorf("add audit log document: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+/**
+ * refs と、queries のいずれかに当たるドキュメントをまとめて削除する。無いドキュメントの削除は成功として扱う。
+ * 投稿の削除に合わせて付随するデータを消すためのもので、途中で失敗しても呼び直せば残りを消せる。
+ */
+func deleteDocuments(ctx context.Context, client *firestore.Client, refs []*firestore.DocumentRef, queries ...firestore.Query) error {
+	for _, query := range queries {
+		// 本文は要らないため、ドキュメント ID だけを読む
+		found, err := documentRefs(query.Select().Documents(ctx))
+		if err != nil {
+			return err
+		}
+		refs = append(refs, found...)
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	writer := client.BulkWriter(ctx)
+	jobs := make([]*firestore.BulkWriterJob, 0, len(refs))
+	for _, ref := range refs {
+		job, err := writer.Delete(ref)
+		if err != nil {
+			writer.End()
+			return fmt.Errorf("queue document delete: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	writer.End()
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return fmt.Errorf("delete document: %w", err)
+		}
+	}
+	return nil
+}
+
+func documentRefs(iter *firestore.DocumentIterator) ([]*firestore.DocumentRef, error) {
+	defer iter.Stop()
+
+	var refs []*firestore.DocumentRef
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return refs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list documents to delete: %w", err)
+		}
+		refs = append(refs, snap.Ref)
+	}
+}
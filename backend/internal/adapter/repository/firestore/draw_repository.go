@@ -53,10 +53,12 @@ func (r *DrawRepository) Create(ctx context.Context, d *drawdomain.Draw) error {
 	doc := r.client.Collection(drawsCollection).Doc(string(postID))
 	// Firestore に保存するフィールド群。
 	data := map[string]interface{}{
-		"post_id":    string(d.PostID()),
-		"result":     string(d.Result()),
-		"status":     string(d.Status()),
-		"created_at": firestore.ServerTimestamp,
+		"post_id":        string(d.PostID()),
+		"result":         string(d.Result()),
+		"status":         string(d.Status()),
+		"prompt_version": d.PromptVersion(),
+		"share_id":       string(d.ShareID()),
+		"created_at":     firestore.ServerTimestamp,
 	}
 
 	//保存するときのエラーチェック
@@ -87,6 +89,28 @@ func (r *DrawRepository) GetByPostID(ctx context.Context, postID post.DarkPostID
 	return restoreDrawFromDoc(doc)
 }
 
+// GetByShareID は共有 ID で Draw を検索する。
+func (r *DrawRepository) GetByShareID(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	if id == "" {
+		return nil, repository.ErrDrawNotFound
+	}
+
+	iter := r.client.Collection(drawsCollection).
+		Where("share_id", "==", string(id)).
+		Limit(1).
+		Documents(ctx)
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if errors.Is(err, iterator.Done) {
+		return nil, repository.ErrDrawNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query draw by share id: %w", err)
+	}
+	return restoreDrawFromDoc(doc)
+}
+
 // ListReady は Verified な Draw を Firestore から列挙する。
 func (r *DrawRepository) ListReady(ctx context.Context) ([]*drawdomain.Draw, error) {
 	// status が verified の個体のみ抽出するクエリ。
@@ -115,12 +139,53 @@ func (r *DrawRepository) ListReady(ctx context.Context) ([]*drawdomain.Draw, err
 	return draws, nil
 }
 
+// Update は Draw の状態を Firestore に保存する。ドキュメントが無ければ ErrDrawNotFound を返す。
+func (r *DrawRepository) Update(ctx context.Context, d *drawdomain.Draw) error {
+	if d == nil {
+		return errNilDraw
+	}
+	postID := d.PostID()
+	if postID == "" {
+		return errEmptyPostID
+	}
+
+	_, err := r.client.Collection(drawsCollection).Doc(string(postID)).Update(ctx, []firestore.Update{
+		{Path: "status", Value: string(d.Status())},
+		{Path: "updated_at", Value: firestore.ServerTimestamp},
+	})
+	if status.Code(err) == codes.NotFound {
+		return repository.ErrDrawNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update draw document: %w", err)
+	}
+	return nil
+}
+
+// Delete は Draw を Firestore から削除する。ドキュメントが無ければ ErrDrawNotFound を返す。
+func (r *DrawRepository) Delete(ctx context.Context, postID post.DarkPostID) error {
+	if postID == "" {
+		return repository.ErrDrawNotFound
+	}
+
+	_, err := r.client.Collection(drawsCollection).Doc(string(postID)).Delete(ctx, firestore.Exists)
+	if status.Code(err) == codes.NotFound {
+		return repository.ErrDrawNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete draw document: %w", err)
+	}
+	return nil
+}
+
 // restoreDrawFromDoc は Firestore ドキュメントをドメインオブジェクトに変換する。
 func restoreDrawFromDoc(doc *firestore.DocumentSnapshot) (*drawdomain.Draw, error) {
 	var payload struct {
-		PostID string `firestore:"post_id"`
-		Result string `firestore:"result"`
-		Status string `firestore:"status"`
+		PostID        string `firestore:"post_id"`
+		Result        string `firestore:"result"`
+		Status        string `firestore:"status"`
+		PromptVersion string `firestore:"prompt_version"`
+		ShareID       string `firestore:"share_id"`
 	}
 	if err := doc.DataTo(&payload); err != nil {
 		return nil, fmt.Errorf("decode draw document: %w", err)
@@ -130,5 +195,9 @@ func restoreDrawFromDoc(doc *firestore.DocumentSnapshot) (*drawdomain.Draw, erro
 	if err != nil {
 		return nil, fmt.Errorf("restore draw: %w", err)
 	}
+	// prompt_version 導入前のドキュメントは空のまま復元する
+	restored.SetPromptVersion(payload.PromptVersion)
+	// share_id 導入前のドキュメントは共有できないため空のまま復元する
+	restored.SetShareID(drawdomain.ShareID(payload.ShareID))
 	return restored, nil
 }
@@ -2,11 +2,19 @@ package firestore
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	"backend/internal/domain/audit"
 	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
 	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/domain/report"
+	"backend/internal/port/repository"
 
 	"cloud.google.com/go/firestore"
 	"google.golang.org/api/iterator"
@@ -71,7 +79,10 @@ func TestDrawRepository_Integration(t *testing.T) {
 	if err != nil {
 		t.Fatalf("new draw: %v", err)
 	}
-	draw.MarkVerified()
+	if err := draw.MarkVerified(); err != nil {
+		t.Fatalf("mark verified: %v", err)
+	}
+	draw.SetPromptVersion("fortune-v1")
 
 	if err := repo.Create(ctx, draw); err != nil {
 		t.Fatalf("create draw: %v", err)
@@ -81,10 +92,21 @@ func TestDrawRepository_Integration(t *testing.T) {
 	if err != nil {
 		t.Fatalf("get draw: %v", err)
 	}
-	if fetched.Result() != draw.Result() || fetched.Status() != draw.Status() {
+	if fetched.Result() != draw.Result() || fetched.Status() != draw.Status() || fetched.PromptVersion() != draw.PromptVersion() || fetched.ShareID() != draw.ShareID() {
 		t.Fatalf("fetched draw mismatch")
 	}
 
+	shared, err := repo.GetByShareID(ctx, draw.ShareID())
+	if err != nil {
+		t.Fatalf("get draw by share id: %v", err)
+	}
+	if shared.PostID() != draw.PostID() {
+		t.Fatalf("unexpected draw by share id: %s", shared.PostID())
+	}
+	if _, err := repo.GetByShareID(ctx, drawdomain.NewShareID()); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound, got %v", err)
+	}
+
 	list, err := repo.ListReady(ctx)
 	if err != nil {
 		t.Fatalf("list ready: %v", err)
@@ -92,4 +114,256 @@ func TestDrawRepository_Integration(t *testing.T) {
 	if len(list) != 1 {
 		t.Fatalf("expected 1 draw got %d", len(list))
 	}
+
+	// 非公開にしたおみくじは公開一覧から外れる
+	if err := fetched.MarkHidden(); err != nil {
+		t.Fatalf("mark hidden: %v", err)
+	}
+	if err := repo.Update(ctx, fetched); err != nil {
+		t.Fatalf("update draw: %v", err)
+	}
+	if hidden, err := repo.GetByPostID(ctx, "post-1"); err != nil || hidden.Status() != drawdomain.StatusHidden {
+		t.Fatalf("expected hidden draw, got %v %v", hidden, err)
+	}
+	if list, err := repo.ListReady(ctx); err != nil || len(list) != 0 {
+		t.Fatalf("expected no ready draws, got %d %v", len(list), err)
+	}
+	missing, _ := drawdomain.New("post-missing", "fortune")
+	if err := repo.Update(ctx, missing); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, "post-1"); err != nil {
+		t.Fatalf("delete draw: %v", err)
+	}
+	if err := repo.Delete(ctx, "post-1"); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound, got %v", err)
+	}
+}
+
+func TestOutcomeRepository_Integration(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, outcomesCollection)
+
+	repo, err := NewOutcomeRepository(client)
+	if err != nil {
+		t.Fatalf("new outcome repo: %v", err)
+	}
+
+	ctx := context.Background()
+	rejected, err := outcome.New(post.DarkPostID("post-1"), "menhera-v1", drawdomain.StatusRejected, "禁止語を含む", "x")
+	if err != nil {
+		t.Fatalf("new outcome: %v", err)
+	}
+	rejected.SetRedactions(map[string]int{"phone": 1})
+	if err := repo.Record(ctx, rejected); err != nil {
+		t.Fatalf("record outcome: %v", err)
+	}
+	passed, err := outcome.New(post.DarkPostID("post-2"), "fortune-v1", drawdomain.StatusVerified, "", "fortune smiles")
+	if err != nil {
+		t.Fatalf("new outcome: %v", err)
+	}
+	if err := repo.Record(ctx, passed); err != nil {
+		t.Fatalf("record outcome: %v", err)
+	}
+
+	all, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("list outcomes: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 outcomes got %d", len(all))
+	}
+
+	byPost, err := repo.ListByPostID(ctx, "post-1")
+	if err != nil {
+		t.Fatalf("list outcomes by post: %v", err)
+	}
+	if len(byPost) != 1 || byPost[0].Reason() != "禁止語を含む" || byPost[0].PromptVersion() != "menhera-v1" || byPost[0].Redactions()["phone"] != 1 {
+		t.Fatalf("unexpected outcomes for post-1: %+v", byPost)
+	}
+
+	if err := repo.DeleteByPostID(ctx, "post-1"); err != nil {
+		t.Fatalf("delete outcomes: %v", err)
+	}
+	if all, err := repo.List(ctx); err != nil || len(all) != 1 || all[0].PostID() != "post-2" {
+		t.Fatalf("expected only post-2 to remain, got %+v %v", all, err)
+	}
+}
+
+func TestReactionRepository_Integration(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, reactionsCollection)
+
+	repo, err := NewReactionRepository(client)
+	if err != nil {
+		t.Fatalf("new reaction repo: %v", err)
+	}
+
+	ctx := context.Background()
+	postID := post.DarkPostID("reaction-" + time.Now().Format("20060102150405.000000000"))
+	for i, kind := range []reaction.Kind{reaction.KindAccurate, reaction.KindAccurate, reaction.KindSaved, reaction.KindScary, reaction.KindAccurate} {
+		r, err := reaction.New(postID, fmt.Sprintf("visitor-%d", i), kind)
+		if err != nil {
+			t.Fatalf("new reaction: %v", err)
+		}
+		if err := repo.Add(ctx, r); err != nil {
+			t.Fatalf("add reaction: %v", err)
+		}
+	}
+	again, _ := reaction.New(postID, "visitor-0", reaction.KindScary)
+	if err := repo.Add(ctx, again); !errors.Is(err, repository.ErrReactionAlreadyExists) {
+		t.Fatalf("expected ErrReactionAlreadyExists, got %v", err)
+	}
+
+	counts, err := repo.CountByPostID(ctx, postID)
+	if err != nil {
+		t.Fatalf("count reactions: %v", err)
+	}
+	if counts[reaction.KindAccurate] != 3 || counts[reaction.KindSaved] != 1 || counts[reaction.KindScary] != 1 {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+
+	all, err := repo.CountByPostIDs(ctx, []post.DarkPostID{postID, "reaction-missing"})
+	if err != nil {
+		t.Fatalf("count reactions by posts: %v", err)
+	}
+	if len(all) != 1 || all[postID].Total() != 5 {
+		t.Fatalf("unexpected counts: %v", all)
+	}
+
+	if err := repo.DeleteByPostID(ctx, postID); err != nil {
+		t.Fatalf("delete reactions: %v", err)
+	}
+	if counts, err := repo.CountByPostID(ctx, postID); err != nil || counts.Total() != 0 {
+		t.Fatalf("expected reactions to be deleted, got %v %v", counts, err)
+	}
+	if err := repo.Add(ctx, again); err != nil {
+		t.Fatalf("expected the visitor record to be deleted, got %v", err)
+	}
+}
+
+func TestReportRepository_Integration(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, reportsCollection)
+	truncateCollection(t, client, reportSourcesCollection)
+	truncateCollection(t, client, moderationQueueCollection)
+
+	repo, err := NewReportRepository(client)
+	if err != nil {
+		t.Fatalf("new report repo: %v", err)
+	}
+
+	ctx := context.Background()
+	var review *report.Review
+	for i, reason := range []report.Reason{report.ReasonSpam, report.ReasonOffensive, report.ReasonSpam} {
+		r, err := report.New("post-reported", fmt.Sprintf("visitor-%d", i), fmt.Sprintf("198.51.100.%d", i), reason)
+		if err != nil {
+			t.Fatalf("new report: %v", err)
+		}
+		if review, err = repo.Add(ctx, r); err != nil {
+			t.Fatalf("add report: %v", err)
+		}
+	}
+	if review.PostID != "post-reported" || review.Count != 3 || review.Reasons[report.ReasonSpam] != 2 || review.Reasons[report.ReasonOffensive] != 1 {
+		t.Fatalf("unexpected review: %+v", review)
+	}
+
+	again, _ := report.New("post-reported", "visitor-0", "198.51.100.9", report.ReasonOther)
+	if _, err := repo.Add(ctx, again); !errors.Is(err, repository.ErrReportAlreadyExists) {
+		t.Fatalf("expected ErrReportAlreadyExists, got %v", err)
+	}
+	renewed, _ := report.New("post-reported", "visitor-9", "198.51.100.0", report.ReasonOther)
+	if _, err := repo.Add(ctx, renewed); !errors.Is(err, repository.ErrReportAlreadyExists) {
+		t.Fatalf("expected ErrReportAlreadyExists for the same client ip, got %v", err)
+	}
+	snap, err := client.Collection(moderationQueueCollection).Doc("post-reported").Get(ctx)
+	if err != nil {
+		t.Fatalf("get review: %v", err)
+	}
+	if snap.Data()["report_count"] != int64(3) || snap.Data()["status"] != reviewStatusOpen {
+		t.Fatalf("unexpected review document: %v", snap.Data())
+	}
+
+	if err := repo.DeleteByPostID(ctx, "post-reported"); err != nil {
+		t.Fatalf("delete reports: %v", err)
+	}
+	if open, err := repo.ListOpen(ctx, 0); err != nil || len(open) != 0 {
+		t.Fatalf("expected the review to be deleted, got %+v %v", open, err)
+	}
+	if _, err := repo.Add(ctx, renewed); err != nil {
+		t.Fatalf("expected the report records to be deleted, got %v", err)
+	}
+}
+
+func TestAuditLogRepository_Integration(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, auditLogsCollection)
+
+	repo, err := NewAuditLogRepository(client)
+	if err != nil {
+		t.Fatalf("new audit log repo: %v", err)
+	}
+
+	ctx := context.Background()
+	entry, err := audit.New("admin@example.com", audit.ActionHideDraw, "post-1", map[string]string{"from": "verified"})
+	if err != nil {
+		t.Fatalf("new entry: %v", err)
+	}
+	entry.Fail(errors.New("draw not found"))
+	if err := repo.Record(ctx, entry); err != nil {
+		t.Fatalf("record entry: %v", err)
+	}
+
+	docs, err := client.Collection(auditLogsCollection).Documents(ctx).GetAll()
+	if err != nil {
+		t.Fatalf("get audit logs: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("expected 1 audit log got %d", len(docs))
+	}
+	data := docs[0].Data()
+	if data["actor"] != "admin@example.com" || data["action"] != "hide_draw" || data["succeeded"] != false || data["error"] != "draw not found" {
+		t.Fatalf("unexpected audit log document: %v", data)
+	}
+}
+
+func TestPostRepository_ListByStatusAndDelete_Integration(t *testing.T) {
+	client := newTestFirestoreClient(t)
+	truncateCollection(t, client, postsCollection)
+
+	repo, err := NewPostRepository(client)
+	if err != nil {
+		t.Fatalf("new post repo: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, id := range []post.DarkPostID{"post-c", "post-a", "post-b"} {
+		p, _ := post.New(id, "content")
+		if err := repo.Create(ctx, p); err != nil {
+			t.Fatalf("create post: %v", err)
+		}
+	}
+
+	first, err := repo.ListByStatus(ctx, post.StatusPending, "", 2)
+	if err != nil {
+		t.Fatalf("list by status: %v", err)
+	}
+	if len(first) != 2 || first[0].ID() != "post-a" || first[1].ID() != "post-b" {
+		t.Fatalf("unexpected first page: %v", first)
+	}
+	rest, err := repo.ListByStatus(ctx, post.StatusPending, first[1].ID(), 2)
+	if err != nil {
+		t.Fatalf("list by status: %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID() != "post-c" {
+		t.Fatalf("unexpected second page: %v", rest)
+	}
+
+	if err := repo.Delete(ctx, "post-a"); err != nil {
+		t.Fatalf("delete post: %v", err)
+	}
+	if err := repo.Delete(ctx, "post-a"); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Fatalf("expected ErrPostNotFound, got %v", err)
+	}
 }
@@ -0,0 +1,144 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
+	"backend/internal/domain/post"
+	"backend/internal/port/repository"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// outcomesCollection は整形・検証結果を追記するコレクション名。
+const outcomesCollection = "format_outcomes"
+
+// errNilOutcome は nil を記録しようとした際のバリデーションエラー。
+var errNilOutcome = errors.New("firestorerepository: outcome is nil")
+
+// OutcomeRepository は整形結果を Firestore に追記するリポジトリ。
+type OutcomeRepository struct {
+	client *firestore.Client
+}
+
+var _ repository.OutcomeRepository = (*OutcomeRepository)(nil)
+
+// NewOutcomeRepository は Firestore を利用するリポジトリを生成する。
+func NewOutcomeRepository(client *firestore.Client) (*OutcomeRepository, error) {
+	if client == nil {
+		return nil, errMissingRepository
+	}
+	return &OutcomeRepository{client: client}, nil
+}
+
+// Record は整形結果を自動採番のドキュメントとして追記する。
+func (r *OutcomeRepository) Record(ctx context.Context, o *outcome.Outcome) error {
+	if o == nil {
+		return errNilOutcome
+	}
+	data := map[string]interface{}{
+		"post_id":        string(o.PostID()),
+		"prompt_version": o.PromptVersion(),
+		"status":         string(o.Status()),
+		"reason":         o.Reason(),
+		"length":         o.Length(),
+		"redactions":     o.Redactions(),
+		"recorded_at":    o.RecordedAt(),
+	}
+	if _, _, err := r.client.Collection(outcomesCollection).Add(ctx, data); err != nil {
+		return fmt.Errorf("add outcome document: %w", err)
+	}
+	return nil
+}
+
+// List は記録済みの結果をすべて返す。
+func (r *OutcomeRepository) List(ctx context.Context) ([]*outcome.Outcome, error) {
+	return r.collect(r.client.Collection(outcomesCollection).Documents(ctx))
+}
+
+// ListByPostID は指定投稿の結果を記録順に返す。
+func (r *OutcomeRepository) ListByPostID(ctx context.Context, postID post.DarkPostID) ([]*outcome.Outcome, error) {
+	if postID == "" {
+		return nil, errEmptyPostID
+	}
+	// 複合インデックスを増やさないよう、並べ替えは取得後に行う
+	outcomes, err := r.collect(r.client.Collection(outcomesCollection).
+		Where("post_id", "==", string(postID)).
+		Documents(ctx))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(outcomes, func(i, j int) bool {
+		return outcomes[i].RecordedAt().Before(outcomes[j].RecordedAt())
+	})
+	return outcomes, nil
+}
+
+// DeleteByPostID は指定投稿の結果をすべて削除する。投稿を消すときに使う。
+func (r *OutcomeRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	if postID == "" {
+		return errEmptyPostID
+	}
+	if err := deleteDocuments(ctx, r.client, nil,
+		r.client.Collection(outcomesCollection).Where("post_id", "==", string(postID)),
+	); err != nil {
+		return fmt.Errorf("delete outcomes: %w", err)
+	}
+	return nil
+}
+
+func (r *OutcomeRepository) collect(iter *firestore.DocumentIterator) ([]*outcome.Outcome, error) {
+	defer iter.Stop()
+
+	var outcomes []*outcome.Outcome
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("iterate outcomes: %w", err)
+		}
+		o, err := restoreOutcomeFromDoc(doc)
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, o)
+	}
+	return outcomes, nil
+}
+
+// restoreOutcomeFromDoc は Firestore ドキュメントをドメインオブジェクトに変換する。
+func restoreOutcomeFromDoc(doc *firestore.DocumentSnapshot) (*outcome.Outcome, error) {
+	var payload struct {
+		PostID        string         `firestore:"post_id"`
+		PromptVersion string         `firestore:"prompt_version"`
+		Status        string         `firestore:"status"`
+		Reason        string         `firestore:"reason"`
+		Length        int            `firestore:"length"`
+		Redactions    map[string]int `firestore:"redactions"`
+		RecordedAt    time.Time      `firestore:"recorded_at"`
+	}
+	if err := doc.DataTo(&payload); err != nil {
+		return nil, fmt.Errorf("decode outcome document: %w", err)
+	}
+	restored, err := outcome.Restore(
+		post.DarkPostID(payload.PostID),
+		payload.PromptVersion,
+		drawdomain.Status(payload.Status),
+		payload.Reason,
+		payload.Length,
+		payload.RecordedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("restore outcome: %w", err)
+	}
+	restored.SetRedactions(payload.Redactions)
+	return restored, nil
+}
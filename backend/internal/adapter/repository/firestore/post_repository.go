@@ -142,6 +142,60 @@ func (r *PostRepository) Update(ctx context.Context, p *postdomain.Post) error {
 	return nil
 }
 
+/**
+ * 指定状態の Post をドキュメント ID 順に、after より後ろから最大 limit 件取得する。
+ * 状態の等価条件と ID 順だけなので、複合索引は要らない。
+ */
+func (r *PostRepository) ListByStatus(ctx context.Context, st postdomain.Status, after postdomain.DarkPostID, limit int) ([]*postdomain.Post, error) {
+	query := r.client.Collection(postsCollection).
+		Where("status", "==", string(st)).
+		OrderBy(firestore.DocumentID, firestore.Asc)
+	if after != "" {
+		query = query.StartAfter(r.client.Collection(postsCollection).Doc(string(after)))
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	var posts []*postdomain.Post
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("iterate posts by status: %w", err)
+		}
+
+		p, err := restorePostFromDoc(doc)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+	return posts, nil
+}
+
+// Delete は Post を Firestore から削除する。ドキュメントが無ければ ErrPostNotFound を返す。
+func (r *PostRepository) Delete(ctx context.Context, id postdomain.DarkPostID) error {
+	if id == "" {
+		return repository.ErrPostNotFound
+	}
+
+	// 存在しないドキュメントの削除は成功扱いになるため、事前条件で未存在を検出する
+	_, err := r.client.Collection(postsCollection).Doc(string(id)).Delete(ctx, firestore.Exists)
+	if status.Code(err) == codes.NotFound {
+		return repository.ErrPostNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete post document: %w", err)
+	}
+	return nil
+}
+
 // restorePostFromDoc は Firestore ドキュメントから Post ドメインを復元する。
 func restorePostFromDoc(doc *firestore.DocumentSnapshot) (*postdomain.Post, error) {
 	var payload postDocument
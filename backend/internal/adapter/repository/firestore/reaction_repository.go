@@ -0,0 +1,179 @@
+package firestore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/port/repository"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// reactionsCollection は訪問者ごとの反応を置くコレクション名。1 人 1 回の判定に使う。
+	reactionsCollection = "reactions"
+	// reactionCountersCollection は反応数の分割カウンターを置くコレクション名。
+	reactionCountersCollection = "reaction_counters"
+	// reactionShardsCollection は reaction_counters/{post_id} の下に置く分割カウンターのサブコレクション名。
+	reactionShardsCollection = "shards"
+	// reactionShardCount は 1 件のおみくじの反応数を分けて持つ数。同じドキュメントへの書き込みが集中しないようにする。
+	reactionShardCount = 4
+	// getAllChunk は GetAll 1 回で読むドキュメント数の上限。
+	getAllChunk = 300
+)
+
+var errNilReaction = errors.New("firestorerepository: reaction is nil")
+
+/**
+ * おみくじへの反応を Firestore で数えるリポジトリ。
+ * 反応そのものは reactions/{hash} に、数は reaction_counters/{post_id}/shards/{n} に分けて持ち、
+ * 1 回のトランザクションで両方を書く。
+ */
+type ReactionRepository struct {
+	client *firestore.Client
+}
+
+var _ repository.ReactionRepository = (*ReactionRepository)(nil)
+
+// NewReactionRepository は Firestore を利用するリポジトリを生成する。
+func NewReactionRepository(client *firestore.Client) (*ReactionRepository, error) {
+	if client == nil {
+		return nil, errMissingRepository
+	}
+	return &ReactionRepository{client: client}, nil
+}
+
+/**
+ * 反応を保存し、ランダムに選んだ分割カウンターへ 1 を足す。
+ * 反応のドキュメント ID はおみくじと訪問者から決まるため、2 回目は作成に失敗して ErrReactionAlreadyExists になる。
+ */
+func (r *ReactionRepository) Add(ctx context.Context, rc *reaction.Reaction) error {
+	if rc == nil {
+		return errNilReaction
+	}
+	if rc.PostID() == "" {
+		return errEmptyPostID
+	}
+
+	reactionRef := r.client.Collection(reactionsCollection).Doc(reactionDocumentID(rc))
+	shardRef := r.shards(rc.PostID()).Doc(strconv.Itoa(rand.IntN(reactionShardCount)))
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if err := tx.Create(reactionRef, map[string]any{
+			"post_id":     string(rc.PostID()),
+			"visitor_key": rc.VisitorKey(),
+			"kind":        string(rc.Kind()),
+			"created_at":  rc.CreatedAt(),
+		}); err != nil {
+			return err
+		}
+		return tx.Set(shardRef, map[string]any{
+			"post_id":         string(rc.PostID()),
+			string(rc.Kind()): firestore.Increment(1),
+		}, firestore.MergeAll)
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		return repository.ErrReactionAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("add reaction: %w", err)
+	}
+	return nil
+}
+
+// CountByPostID は分割カウンターを合計して種類ごとの反応数を返す。
+func (r *ReactionRepository) CountByPostID(ctx context.Context, postID post.DarkPostID) (reaction.Counts, error) {
+	if postID == "" {
+		return nil, errEmptyPostID
+	}
+	counts, err := r.CountByPostIDs(ctx, []post.DarkPostID{postID})
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := counts[postID]; ok {
+		return c, nil
+	}
+	return reaction.Counts{}, nil
+}
+
+/**
+ * 複数のおみくじの分割カウンターをまとめて読み、合計する。
+ * 分割カウンターの ID は決まっているため、クエリ（と索引）を使わずに GetAll で読む。
+ */
+func (r *ReactionRepository) CountByPostIDs(ctx context.Context, postIDs []post.DarkPostID) (map[post.DarkPostID]reaction.Counts, error) {
+	refs := make([]*firestore.DocumentRef, 0, len(postIDs)*reactionShardCount)
+	for _, id := range postIDs {
+		if id == "" {
+			continue
+		}
+		for n := 0; n < reactionShardCount; n++ {
+			refs = append(refs, r.shards(id).Doc(strconv.Itoa(n)))
+		}
+	}
+
+	result := make(map[post.DarkPostID]reaction.Counts)
+	for start := 0; start < len(refs); start += getAllChunk {
+		end := min(start+getAllChunk, len(refs))
+		snaps, err := r.client.GetAll(ctx, refs[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("get reaction shards: %w", err)
+		}
+		for _, snap := range snaps {
+			if !snap.Exists() {
+				continue
+			}
+			postID := post.DarkPostID(snap.Ref.Parent.Parent.ID)
+			data := snap.Data()
+			for _, kind := range reaction.Kinds() {
+				n, ok := data[string(kind)].(int64)
+				if !ok || n == 0 {
+					continue
+				}
+				if result[postID] == nil {
+					result[postID] = make(reaction.Counts)
+				}
+				result[postID][kind] += n
+			}
+		}
+	}
+	return result, nil
+}
+
+/**
+ * 指定おみくじの反応と分割カウンターをすべて削除する。投稿を消すときに使う。
+ * 分割カウンターの ID は決まっているため、クエリで探すのは反応だけにする。
+ */
+func (r *ReactionRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	if postID == "" {
+		return errEmptyPostID
+	}
+	refs := make([]*firestore.DocumentRef, 0, reactionShardCount+1)
+	for n := 0; n < reactionShardCount; n++ {
+		refs = append(refs, r.shards(postID).Doc(strconv.Itoa(n)))
+	}
+	refs = append(refs, r.client.Collection(reactionCountersCollection).Doc(string(postID)))
+	if err := deleteDocuments(ctx, r.client, refs,
+		r.client.Collection(reactionsCollection).Where("post_id", "==", string(postID)),
+	); err != nil {
+		return fmt.Errorf("delete reactions: %w", err)
+	}
+	return nil
+}
+
+func (r *ReactionRepository) shards(postID post.DarkPostID) *firestore.CollectionRef {
+	return r.client.Collection(reactionCountersCollection).Doc(string(postID)).Collection(reactionShardsCollection)
+}
+
+// reactionDocumentID はおみくじと訪問者の組から反応のドキュメント ID を決める。
+func reactionDocumentID(rc *reaction.Reaction) string {
+	sum := sha256.Sum256([]byte(string(rc.PostID()) + "\x00" + rc.VisitorKey()))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,219 @@
+package firestore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend/internal/domain/post"
+	"backend/internal/domain/report"
+	"backend/internal/port/repository"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// reportsCollection は訪問者ごとの通報を置くコレクション名。1 人 1 回の判定に使う。
+	reportsCollection = "reports"
+	// reportSourcesCollection は接続元 IP ごとの通報の印を置くコレクション名。トークンを作り直した通報を弾く。
+	reportSourcesCollection = "report_sources"
+	// moderationQueueCollection はおみくじごとの審査待ちの項目を置くコレクション名。
+	moderationQueueCollection = "moderation_queue"
+	// reviewStatusOpen はまだ誰も審査していない項目の状態。
+	reviewStatusOpen = "open"
+	// reviewStatusResolved は管理者が審査を済ませた項目の状態。次の通報で open に戻る。
+	reviewStatusResolved = "resolved"
+)
+
+var errNilReport = errors.New("firestorerepository: report is nil")
+
+/**
+ * おみくじへの通報を Firestore で扱うリポジトリ。
+ * 通報そのものは reports/{hash} に、接続元 IP の印は report_sources/{hash} に、審査待ちの項目は moderation_queue/{post_id} に置き、
+ * 1 回のトランザクションでまとめて書く。
+ */
+type ReportRepository struct {
+	client *firestore.Client
+}
+
+var _ repository.ReportRepository = (*ReportRepository)(nil)
+
+// NewReportRepository は Firestore を利用するリポジトリを生成する。
+func NewReportRepository(client *firestore.Client) (*ReportRepository, error) {
+	if client == nil {
+		return nil, errMissingRepository
+	}
+	return &ReportRepository{client: client}, nil
+}
+
+// reviewDocument は moderation_queue のドキュメント。
+type reviewDocument struct {
+	PostID         string           `firestore:"post_id"`
+	Status         string           `firestore:"status"`
+	ReportCount    int64            `firestore:"report_count"`
+	Reasons        map[string]int64 `firestore:"reasons"`
+	LastReportedAt time.Time        `firestore:"last_reported_at"`
+}
+
+/**
+ * 通報を保存し、審査待ちの項目の数と理由を積み上げる。
+ * 通報と接続元の印のドキュメント ID はおみくじと訪問者・接続元 IP から決まるため、どちらかの 2 回目は作成に失敗して ErrReportAlreadyExists になる。
+ * 閾値の判定に正確な数が要るため、分割カウンターではなく項目を読んでから書く。
+ */
+func (r *ReportRepository) Add(ctx context.Context, rp *report.Report) (*report.Review, error) {
+	if rp == nil {
+		return nil, errNilReport
+	}
+	if rp.PostID() == "" {
+		return nil, errEmptyPostID
+	}
+
+	reportRef := r.client.Collection(reportsCollection).Doc(reportDocumentID(rp.PostID(), rp.VisitorKey()))
+	sourceRef := r.client.Collection(reportSourcesCollection).Doc(reportDocumentID(rp.PostID(), rp.SourceKey()))
+	reviewRef := r.client.Collection(moderationQueueCollection).Doc(string(rp.PostID()))
+	var review *report.Review
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc := reviewDocument{PostID: string(rp.PostID()), Reasons: map[string]int64{}}
+		snap, err := tx.Get(reviewRef)
+		switch {
+		case status.Code(err) == codes.NotFound:
+		case err != nil:
+			return err
+		default:
+			if err := snap.DataTo(&doc); err != nil {
+				return fmt.Errorf("decode review document: %w", err)
+			}
+			if doc.Reasons == nil {
+				doc.Reasons = map[string]int64{}
+			}
+		}
+		doc.Status = reviewStatusOpen
+		doc.ReportCount++
+		doc.Reasons[string(rp.Reason())]++
+		doc.LastReportedAt = rp.CreatedAt()
+
+		if err := tx.Create(reportRef, map[string]any{
+			"post_id":     string(rp.PostID()),
+			"visitor_key": rp.VisitorKey(),
+			"reason":      string(rp.Reason()),
+			"created_at":  rp.CreatedAt(),
+		}); err != nil {
+			return err
+		}
+		if err := tx.Create(sourceRef, map[string]any{
+			"post_id":    string(rp.PostID()),
+			"source_key": rp.SourceKey(),
+			"created_at": rp.CreatedAt(),
+		}); err != nil {
+			return err
+		}
+		if err := tx.Set(reviewRef, doc); err != nil {
+			return err
+		}
+		review = reviewFromDocument(doc)
+		return nil
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		return nil, repository.ErrReportAlreadyExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("add report: %w", err)
+	}
+	return review, nil
+}
+
+/**
+ * 審査待ちの項目を通報の多い順に最大 limit 件返す。
+ * 複合インデックスを増やさないよう、並べ替えは取得後に行う（審査待ちの項目は多くならない前提）。
+ */
+func (r *ReportRepository) ListOpen(ctx context.Context, limit int) ([]*report.Review, error) {
+	iter := r.client.Collection(moderationQueueCollection).
+		Where("status", "==", reviewStatusOpen).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var reviews []*report.Review
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list open reviews: %w", err)
+		}
+		var doc reviewDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return nil, fmt.Errorf("decode review document: %w", err)
+		}
+		reviews = append(reviews, reviewFromDocument(doc))
+	}
+	report.SortReviews(reviews)
+	if limit > 0 && len(reviews) > limit {
+		reviews = reviews[:limit]
+	}
+	return reviews, nil
+}
+
+/**
+ * 審査待ちの項目を resolved にし、数と理由を 0 に戻す。項目が無ければ何もしない。
+ * reports / report_sources は残すため、同じ訪問者・接続元 IP からの再通報は数えない。
+ */
+func (r *ReportRepository) Resolve(ctx context.Context, postID post.DarkPostID) error {
+	if postID == "" {
+		return errEmptyPostID
+	}
+	_, err := r.client.Collection(moderationQueueCollection).Doc(string(postID)).Update(ctx, []firestore.Update{
+		{Path: "status", Value: reviewStatusResolved},
+		{Path: "report_count", Value: 0},
+		{Path: "reasons", Value: map[string]int64{}},
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("resolve review: %w", err)
+	}
+	return nil
+}
+
+/**
+ * 指定おみくじの通報・接続元 IP の印・審査待ちの項目をすべて削除する。投稿を消すときに使う。
+ */
+func (r *ReportRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	if postID == "" {
+		return errEmptyPostID
+	}
+	if err := deleteDocuments(ctx, r.client,
+		[]*firestore.DocumentRef{r.client.Collection(moderationQueueCollection).Doc(string(postID))},
+		r.client.Collection(reportsCollection).Where("post_id", "==", string(postID)),
+		r.client.Collection(reportSourcesCollection).Where("post_id", "==", string(postID)),
+	); err != nil {
+		return fmt.Errorf("delete reports: %w", err)
+	}
+	return nil
+}
+
+func reviewFromDocument(doc reviewDocument) *report.Review {
+	reasons := make(map[report.Reason]int64, len(doc.Reasons))
+	for reason, n := range doc.Reasons {
+		reasons[report.Reason(reason)] = n
+	}
+	return &report.Review{
+		PostID:         post.DarkPostID(doc.PostID),
+		Count:          doc.ReportCount,
+		Reasons:        reasons,
+		LastReportedAt: doc.LastReportedAt,
+	}
+}
+
+// reportDocumentID はおみくじと訪問者（または接続元）のキーの組からドキュメント ID を決める。
+func reportDocumentID(postID post.DarkPostID, key string) string {
+	sum := sha256.Sum256([]byte(string(postID) + "\x00" + key))
+	return hex.EncodeToString(sum[:])
+}
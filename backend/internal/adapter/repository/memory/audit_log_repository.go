@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"backend/internal/domain/audit"
+	"backend/internal/port/repository"
+)
+
+var errNilAuditEntry = errors.New("memoryrepository: audit entry is nil")
+
+// InMemoryAuditLogRepository は管理操作の記録を記録順にメモリへ保持するリポジトリ。
+type InMemoryAuditLogRepository struct {
+	mu      sync.RWMutex
+	entries []*audit.Entry
+}
+
+var _ repository.AuditLogRepository = (*InMemoryAuditLogRepository)(nil)
+
+// NewInMemoryAuditLogRepository は InMemoryAuditLogRepository を生成する。
+func NewInMemoryAuditLogRepository() *InMemoryAuditLogRepository {
+	return &InMemoryAuditLogRepository{}
+}
+
+// Record は操作の記録を追記する。
+func (r *InMemoryAuditLogRepository) Record(ctx context.Context, e *audit.Entry) error {
+	if e == nil {
+		return errNilAuditEntry
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clone := *e
+	r.entries = append(r.entries, &clone)
+	return nil
+}
+
+// Entries は記録済みの操作を記録順に返す。
+func (r *InMemoryAuditLogRepository) Entries() []*audit.Entry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*audit.Entry, 0, len(r.entries))
+	for _, e := range r.entries {
+		clone := *e
+		result = append(result, &clone)
+	}
+	return result
+}
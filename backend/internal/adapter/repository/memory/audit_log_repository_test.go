@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"backend/internal/domain/audit"
+)
+
+func TestInMemoryAuditLogRepository_Record(t *testing.T) {
+	repo := NewInMemoryAuditLogRepository()
+	if err := repo.Record(context.Background(), nil); err == nil {
+		t.Fatalf("expected error for nil entry")
+	}
+
+	for _, action := range []audit.Action{audit.ActionHideDraw, audit.ActionDeletePost} {
+		e, err := audit.New("admin", action, "post-1", nil)
+		if err != nil {
+			t.Fatalf("new entry: %v", err)
+		}
+		if err := repo.Record(context.Background(), e); err != nil {
+			t.Fatalf("record returned error: %v", err)
+		}
+	}
+
+	entries := repo.Entries()
+	if len(entries) != 2 || entries[0].Action() != audit.ActionHideDraw || entries[1].Action() != audit.ActionDeletePost {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}
@@ -65,6 +65,23 @@ func (r *InMemoryDrawRepository) GetByPostID(ctx context.Context, postID post.Da
 	return cloneDraw(d), nil
 }
 
+// GetByShareID は指定した共有 ID の Draw を返す。
+func (r *InMemoryDrawRepository) GetByShareID(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	if id == "" {
+		return nil, repository.ErrDrawNotFound
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, d := range r.store {
+		if d != nil && d.ShareID() == id {
+			return cloneDraw(d), nil
+		}
+	}
+	return nil, repository.ErrDrawNotFound
+}
+
 // ListReady は Verified な Draw をすべて返す。
 func (r *InMemoryDrawRepository) ListReady(ctx context.Context) ([]*drawdomain.Draw, error) {
 	r.mu.RLock()
@@ -77,16 +94,44 @@ func (r *InMemoryDrawRepository) ListReady(ctx context.Context) ([]*drawdomain.D
 		}
 		if d.Status() == drawdomain.StatusVerified {
 			result = append(result, cloneDraw(d))
-			continue
-		}
-		if d.Status() == drawdomain.StatusPending || d.Status() == drawdomain.StatusRejected {
-			continue
 		}
 	}
 
 	return result, nil
 }
 
+// Update は保存済みの Draw を置き換える。未存在なら ErrDrawNotFound を返す。
+func (r *InMemoryDrawRepository) Update(ctx context.Context, d *drawdomain.Draw) error {
+	if d == nil {
+		return errNilDraw
+	}
+	postID := d.PostID()
+	if postID == "" {
+		return errEmptyPostID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.store[postID]; !exists {
+		return repository.ErrDrawNotFound
+	}
+	r.store[postID] = cloneDraw(d)
+	return nil
+}
+
+// Delete は Draw を取り除く。未存在なら ErrDrawNotFound を返す。
+func (r *InMemoryDrawRepository) Delete(ctx context.Context, postID post.DarkPostID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.store[postID]; !exists {
+		return repository.ErrDrawNotFound
+	}
+	delete(r.store, postID)
+	return nil
+}
+
 func cloneDraw(d *drawdomain.Draw) *drawdomain.Draw {
 	if d == nil {
 		return nil
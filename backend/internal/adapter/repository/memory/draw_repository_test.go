@@ -40,6 +40,32 @@ func TestInMemoryDrawRepository_CreateAndGet(t *testing.T) {
 	}
 }
 
+func TestInMemoryDrawRepository_GetByShareID(t *testing.T) {
+	t.Parallel()
+
+	repo := NewInMemoryDrawRepository()
+	ctx := context.Background()
+
+	draw := newVerifiedDraw(t, "post-1", "fortune-1")
+	if err := repo.Create(ctx, draw); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.GetByShareID(ctx, draw.ShareID())
+	if err != nil {
+		t.Fatalf("GetByShareID() error = %v", err)
+	}
+	if got.PostID() != draw.PostID() {
+		t.Fatalf("unexpected post id: want %s, got %s", draw.PostID(), got.PostID())
+	}
+
+	for _, id := range []drawdomain.ShareID{"", drawdomain.NewShareID()} {
+		if _, err := repo.GetByShareID(ctx, id); !errors.Is(err, repository.ErrDrawNotFound) {
+			t.Fatalf("GetByShareID(%q): expected ErrDrawNotFound, got %v", id, err)
+		}
+	}
+}
+
 func TestInMemoryDrawRepository_ListReady(t *testing.T) {
 	t.Parallel()
 
@@ -72,6 +98,56 @@ func TestInMemoryDrawRepository_ListReady(t *testing.T) {
 	}
 }
 
+func TestInMemoryDrawRepository_UpdateHidesFromListReady(t *testing.T) {
+	t.Parallel()
+
+	repo := NewInMemoryDrawRepository()
+	ctx := context.Background()
+
+	draw := newVerifiedDraw(t, "post-hidden", "hidden")
+	if err := repo.Create(ctx, draw); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := draw.MarkHidden(); err != nil {
+		t.Fatalf("MarkHidden() error = %v", err)
+	}
+	if err := repo.Update(ctx, draw); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := repo.GetByPostID(ctx, draw.PostID())
+	if err != nil || got.Status() != drawdomain.StatusHidden {
+		t.Fatalf("expected hidden draw, got %v %v", got, err)
+	}
+	if results, _ := repo.ListReady(ctx); len(results) != 0 {
+		t.Fatalf("hidden draw should not be listed, got %d", len(results))
+	}
+
+	missing := newVerifiedDraw(t, "post-missing", "missing")
+	if err := repo.Update(ctx, missing); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound, got %v", err)
+	}
+}
+
+func TestInMemoryDrawRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryDrawRepository()
+	draw := newVerifiedDraw(t, "post-1", "fortune")
+	if err := repo.Create(ctx, draw); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := repo.Delete(ctx, draw.PostID()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.GetByShareID(ctx, draw.ShareID()); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, draw.PostID()); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound, got %v", err)
+	}
+}
+
 func newVerifiedDraw(t *testing.T, postID, result string) *drawdomain.Draw {
 	t.Helper()
 
@@ -79,6 +155,8 @@ func newVerifiedDraw(t *testing.T, postID, result string) *drawdomain.Draw {
 	if err != nil {
 		t.Fatalf("drawdomain.New() error = %v", err)
 	}
-	d.MarkVerified()
+	if err := d.MarkVerified(); err != nil {
+		t.Fatalf("MarkVerified() error = %v", err)
+	}
 	return d
 }
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"backend/internal/domain/outcome"
+	"backend/internal/domain/post"
+	"backend/internal/port/repository"
+)
+
+var errNilOutcome = errors.New("memoryrepository: outcome is nil")
+
+// InMemoryOutcomeRepository は整形結果を記録順にメモリへ保持するリポジトリ。
+type InMemoryOutcomeRepository struct {
+	mu       sync.RWMutex
+	outcomes []*outcome.Outcome
+}
+
+var _ repository.OutcomeRepository = (*InMemoryOutcomeRepository)(nil)
+
+// NewInMemoryOutcomeRepository は InMemoryOutcomeRepository を生成する。
+func NewInMemoryOutcomeRepository() *InMemoryOutcomeRepository {
+	return &InMemoryOutcomeRepository{}
+}
+
+// Record は整形結果を追記する。
+func (r *InMemoryOutcomeRepository) Record(ctx context.Context, o *outcome.Outcome) error {
+	if o == nil {
+		return errNilOutcome
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clone := *o
+	r.outcomes = append(r.outcomes, &clone)
+	return nil
+}
+
+// List は記録済みの結果をすべて返す。
+func (r *InMemoryOutcomeRepository) List(ctx context.Context) ([]*outcome.Outcome, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*outcome.Outcome, 0, len(r.outcomes))
+	for _, o := range r.outcomes {
+		clone := *o
+		result = append(result, &clone)
+	}
+	return result, nil
+}
+
+// ListByPostID は指定投稿の結果を記録順に返す。
+func (r *InMemoryOutcomeRepository) ListByPostID(ctx context.Context, postID post.DarkPostID) ([]*outcome.Outcome, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*outcome.Outcome
+	for _, o := range r.outcomes {
+		if o.PostID() != postID {
+			continue
+		}
+		clone := *o
+		result = append(result, &clone)
+	}
+	return result, nil
+}
+
+// DeleteByPostID は指定投稿の結果をすべて消す。
+func (r *InMemoryOutcomeRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.outcomes[:0]
+	for _, o := range r.outcomes {
+		if o.PostID() != postID {
+			kept = append(kept, o)
+		}
+	}
+	clear(r.outcomes[len(kept):])
+	r.outcomes = kept
+	return nil
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
+	"backend/internal/domain/post"
+)
+
+func TestInMemoryOutcomeRepository_RecordAndList(t *testing.T) {
+	t.Parallel()
+
+	repo := NewInMemoryOutcomeRepository()
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		postID string
+		status drawdomain.Status
+	}{
+		{"post-1", drawdomain.StatusRejected},
+		{"post-2", drawdomain.StatusVerified},
+		{"post-1", drawdomain.StatusVerified},
+	} {
+		o, err := outcome.New(post.DarkPostID(tc.postID), "fortune-v1", tc.status, "", "result")
+		if err != nil {
+			t.Fatalf("outcome.New() error = %v", err)
+		}
+		if err := repo.Record(ctx, o); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	all, err := repo.List(ctx)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("List() = %d, %v", len(all), err)
+	}
+
+	// 同じ投稿の再整形は記録順に両方返る
+	byPost, err := repo.ListByPostID(ctx, "post-1")
+	if err != nil {
+		t.Fatalf("ListByPostID() error = %v", err)
+	}
+	if len(byPost) != 2 || byPost[0].Passed() || !byPost[1].Passed() {
+		t.Fatalf("unexpected outcomes for post-1: %+v", byPost)
+	}
+
+	if err := repo.DeleteByPostID(ctx, "post-1"); err != nil {
+		t.Fatalf("DeleteByPostID() error = %v", err)
+	}
+	if byPost, _ := repo.ListByPostID(ctx, "post-1"); len(byPost) != 0 {
+		t.Fatalf("outcomes for post-1 should be deleted, got %d", len(byPost))
+	}
+	if all, _ := repo.List(ctx); len(all) != 1 || all[0].PostID() != "post-2" {
+		t.Fatalf("other outcomes should be kept, got %+v", all)
+	}
+
+	if err := repo.Record(ctx, nil); !errors.Is(err, errNilOutcome) {
+		t.Fatalf("expected errNilOutcome, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"backend/internal/domain/post"
@@ -84,3 +85,37 @@ func (r *InMemoryPostRepository) Update(ctx context.Context, p *post.Post) error
 	r.store[p.ID()] = p
 	return nil
 }
+
+/**
+ * 指定状態の投稿を ID 順に並べ、after より後ろから最大 limit 件返す。
+ */
+func (r *InMemoryPostRepository) ListByStatus(ctx context.Context, status post.Status, after post.DarkPostID, limit int) ([]*post.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*post.Post, 0)
+	for id, p := range r.store {
+		if p != nil && p.Status() == status && id > after {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID() < result[j].ID() })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+/**
+ * 投稿を取り除き、未登録なら NotFound を返す。
+ */
+func (r *InMemoryPostRepository) Delete(ctx context.Context, id post.DarkPostID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[id]; !ok {
+		return repository.ErrPostNotFound
+	}
+	delete(r.store, id)
+	return nil
+}
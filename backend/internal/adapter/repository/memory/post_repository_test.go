@@ -87,3 +87,46 @@ func TestInMemoryPostRepository_Update(t *testing.T) {
 		t.Fatalf("expected ErrPostNotFound, got %v", err)
 	}
 }
+
+func TestInMemoryPostRepository_ListByStatusPaginates(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	for _, id := range []post.DarkPostID{"post-c", "post-a", "post-b"} {
+		p, _ := post.New(id, "content")
+		_ = repo.Create(context.Background(), p)
+	}
+	ready, _ := post.New("post-ready", "content")
+	_ = ready.MarkReady()
+	_ = repo.Create(context.Background(), ready)
+
+	first, err := repo.ListByStatus(context.Background(), post.StatusPending, "", 2)
+	if err != nil {
+		t.Fatalf("list by status returned error: %v", err)
+	}
+	if len(first) != 2 || first[0].ID() != "post-a" || first[1].ID() != "post-b" {
+		t.Fatalf("unexpected first page: %v", first)
+	}
+
+	rest, err := repo.ListByStatus(context.Background(), post.StatusPending, first[1].ID(), 2)
+	if err != nil {
+		t.Fatalf("list by status returned error: %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID() != "post-c" {
+		t.Fatalf("unexpected second page: %v", rest)
+	}
+}
+
+func TestInMemoryPostRepository_Delete(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	p, _ := post.New("post-1", "content")
+	_ = repo.Create(context.Background(), p)
+
+	if err := repo.Delete(context.Background(), p.ID()); err != nil {
+		t.Fatalf("delete returned error: %v", err)
+	}
+	if _, err := repo.Get(context.Background(), p.ID()); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Fatalf("expected ErrPostNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(context.Background(), p.ID()); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Fatalf("expected ErrPostNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/port/repository"
+)
+
+var errNilReaction = errors.New("memoryrepository: reaction is nil")
+
+// InMemoryReactionRepository はメモリ上でおみくじへの反応を数えるリポジトリ。
+type InMemoryReactionRepository struct {
+	mu       sync.RWMutex
+	visitors map[post.DarkPostID]map[string]struct{}
+	counts   map[post.DarkPostID]reaction.Counts
+}
+
+var _ repository.ReactionRepository = (*InMemoryReactionRepository)(nil)
+
+// NewInMemoryReactionRepository は InMemoryReactionRepository を生成する。
+func NewInMemoryReactionRepository() *InMemoryReactionRepository {
+	return &InMemoryReactionRepository{
+		visitors: make(map[post.DarkPostID]map[string]struct{}),
+		counts:   make(map[post.DarkPostID]reaction.Counts),
+	}
+}
+
+// Add は反応を数える。同じ訪問者の 2 回目は ErrReactionAlreadyExists を返す。
+func (r *InMemoryReactionRepository) Add(ctx context.Context, rc *reaction.Reaction) error {
+	if rc == nil {
+		return errNilReaction
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	postID := rc.PostID()
+	if _, exists := r.visitors[postID][rc.VisitorKey()]; exists {
+		return repository.ErrReactionAlreadyExists
+	}
+	if r.visitors[postID] == nil {
+		r.visitors[postID] = make(map[string]struct{})
+		r.counts[postID] = make(reaction.Counts)
+	}
+	r.visitors[postID][rc.VisitorKey()] = struct{}{}
+	r.counts[postID][rc.Kind()]++
+	return nil
+}
+
+// CountByPostID は指定おみくじの種類ごとの反応数を返す。
+func (r *InMemoryReactionRepository) CountByPostID(ctx context.Context, postID post.DarkPostID) (reaction.Counts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return cloneCounts(r.counts[postID]), nil
+}
+
+// CountByPostIDs は複数のおみくじの反応数を返す。反応の無いおみくじは含めない。
+func (r *InMemoryReactionRepository) CountByPostIDs(ctx context.Context, postIDs []post.DarkPostID) (map[post.DarkPostID]reaction.Counts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[post.DarkPostID]reaction.Counts, len(postIDs))
+	for _, id := range postIDs {
+		if counts, ok := r.counts[id]; ok {
+			result[id] = cloneCounts(counts)
+		}
+	}
+	return result, nil
+}
+
+// DeleteByPostID は指定おみくじの反応と数を消す。
+func (r *InMemoryReactionRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.visitors, postID)
+	delete(r.counts, postID)
+	return nil
+}
+
+func cloneCounts(c reaction.Counts) reaction.Counts {
+	clone := make(reaction.Counts, len(c))
+	for kind, n := range c {
+		clone[kind] = n
+	}
+	return clone
+}
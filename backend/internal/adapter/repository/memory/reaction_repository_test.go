@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/port/repository"
+)
+
+func TestInMemoryReactionRepository_AddAndCount(t *testing.T) {
+	t.Parallel()
+
+	repo := NewInMemoryReactionRepository()
+	ctx := context.Background()
+	add := func(postID post.DarkPostID, visitor string, kind reaction.Kind) error {
+		r, err := reaction.New(postID, visitor, kind)
+		if err != nil {
+			t.Fatalf("reaction.New() error = %v", err)
+		}
+		return repo.Add(ctx, r)
+	}
+
+	for _, tc := range []struct {
+		postID  post.DarkPostID
+		visitor string
+		kind    reaction.Kind
+	}{
+		{"post-1", "visitor-a", reaction.KindAccurate},
+		{"post-1", "visitor-b", reaction.KindAccurate},
+		{"post-1", "visitor-c", reaction.KindScary},
+		{"post-2", "visitor-a", reaction.KindSaved},
+	} {
+		if err := add(tc.postID, tc.visitor, tc.kind); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	// 同じ訪問者は種類を変えても 2 回目は数えない
+	if err := add("post-1", "visitor-a", reaction.KindSaved); !errors.Is(err, repository.ErrReactionAlreadyExists) {
+		t.Fatalf("expected ErrReactionAlreadyExists, got %v", err)
+	}
+
+	counts, err := repo.CountByPostID(ctx, "post-1")
+	if err != nil {
+		t.Fatalf("CountByPostID() error = %v", err)
+	}
+	if counts[reaction.KindAccurate] != 2 || counts[reaction.KindScary] != 1 || counts[reaction.KindSaved] != 0 {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+
+	all, err := repo.CountByPostIDs(ctx, []post.DarkPostID{"post-1", "post-2", "post-3"})
+	if err != nil {
+		t.Fatalf("CountByPostIDs() error = %v", err)
+	}
+	if len(all) != 2 || all["post-2"][reaction.KindSaved] != 1 {
+		t.Fatalf("unexpected counts: %v", all)
+	}
+
+	// 返した値を書き換えても保存内容は変わらない
+	counts[reaction.KindAccurate] = 100
+	if again, _ := repo.CountByPostID(ctx, "post-1"); again[reaction.KindAccurate] != 2 {
+		t.Fatalf("counts should be copied, got %v", again)
+	}
+
+	// 消した投稿の反応は数えず、同じ訪問者の記録も残さない
+	if err := repo.DeleteByPostID(ctx, "post-1"); err != nil {
+		t.Fatalf("DeleteByPostID() error = %v", err)
+	}
+	if counts, _ := repo.CountByPostID(ctx, "post-1"); counts.Total() != 0 {
+		t.Fatalf("reactions should be deleted, got %v", counts)
+	}
+	if err := add("post-1", "visitor-a", reaction.KindSaved); err != nil {
+		t.Fatalf("visitor record should be deleted, got %v", err)
+	}
+	if counts, _ := repo.CountByPostID(ctx, "post-2"); counts.Total() != 1 {
+		t.Fatalf("other reactions should be kept, got %v", counts)
+	}
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"backend/internal/domain/post"
+	"backend/internal/domain/report"
+	"backend/internal/port/repository"
+)
+
+var errNilReport = errors.New("memoryrepository: report is nil")
+
+// InMemoryReportRepository はメモリ上でおみくじへの通報と審査待ちの項目を管理するリポジトリ。
+type InMemoryReportRepository struct {
+	mu       sync.RWMutex
+	visitors map[post.DarkPostID]map[string]struct{}
+	sources  map[post.DarkPostID]map[string]struct{}
+	reviews  map[post.DarkPostID]*report.Review
+}
+
+var _ repository.ReportRepository = (*InMemoryReportRepository)(nil)
+
+// NewInMemoryReportRepository は InMemoryReportRepository を生成する。
+func NewInMemoryReportRepository() *InMemoryReportRepository {
+	return &InMemoryReportRepository{
+		visitors: make(map[post.DarkPostID]map[string]struct{}),
+		sources:  make(map[post.DarkPostID]map[string]struct{}),
+		reviews:  make(map[post.DarkPostID]*report.Review),
+	}
+}
+
+// Add は通報を審査待ちの項目へ積み上げ、その複製を返す。同じ訪問者・同じ接続元 IP の 2 回目は ErrReportAlreadyExists を返す。
+func (r *InMemoryReportRepository) Add(ctx context.Context, rp *report.Report) (*report.Review, error) {
+	if rp == nil {
+		return nil, errNilReport
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	postID := rp.PostID()
+	if _, exists := r.visitors[postID][rp.VisitorKey()]; exists {
+		return nil, repository.ErrReportAlreadyExists
+	}
+	if _, exists := r.sources[postID][rp.SourceKey()]; exists {
+		return nil, repository.ErrReportAlreadyExists
+	}
+	if r.visitors[postID] == nil {
+		r.visitors[postID] = make(map[string]struct{})
+		r.sources[postID] = make(map[string]struct{})
+		r.reviews[postID] = &report.Review{PostID: postID, Reasons: make(map[report.Reason]int64)}
+	}
+	r.visitors[postID][rp.VisitorKey()] = struct{}{}
+	r.sources[postID][rp.SourceKey()] = struct{}{}
+	review := r.reviews[postID]
+	review.Count++
+	review.Reasons[rp.Reason()]++
+	review.LastReportedAt = rp.CreatedAt()
+	return cloneReview(review), nil
+}
+
+// ListOpen は数が 1 以上の項目を、通報の多い順（同数なら最後の通報が新しい順）に最大 limit 件返す。
+func (r *InMemoryReportRepository) ListOpen(ctx context.Context, limit int) ([]*report.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reviews := make([]*report.Review, 0, len(r.reviews))
+	for _, review := range r.reviews {
+		if review.Count > 0 {
+			reviews = append(reviews, cloneReview(review))
+		}
+	}
+	report.SortReviews(reviews)
+	if limit > 0 && len(reviews) > limit {
+		reviews = reviews[:limit]
+	}
+	return reviews, nil
+}
+
+// Resolve は項目の数と理由を 0 に戻す。通報済みの訪問者・接続元 IP の記録は残す。
+func (r *InMemoryReportRepository) Resolve(ctx context.Context, postID post.DarkPostID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if review, ok := r.reviews[postID]; ok {
+		review.Count = 0
+		review.Reasons = make(map[report.Reason]int64)
+	}
+	return nil
+}
+
+// DeleteByPostID は指定おみくじの通報・接続元 IP の記録・審査待ちの項目を消す。
+func (r *InMemoryReportRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.visitors, postID)
+	delete(r.sources, postID)
+	delete(r.reviews, postID)
+	return nil
+}
+
+func cloneReview(rv *report.Review) *report.Review {
+	clone := *rv
+	clone.Reasons = make(map[report.Reason]int64, len(rv.Reasons))
+	for reason, n := range rv.Reasons {
+		clone.Reasons[reason] = n
+	}
+	return &clone
+}
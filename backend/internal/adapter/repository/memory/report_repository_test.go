@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/domain/post"
+	"backend/internal/domain/report"
+	"backend/internal/port/repository"
+)
+
+func TestInMemoryReportRepository_Add(t *testing.T) {
+	t.Parallel()
+
+	repo := NewInMemoryReportRepository()
+	ctx := context.Background()
+
+	first, _ := report.New("post-1", "visitor-a", "198.51.100.1", report.ReasonSpam)
+	if _, err := repo.Add(ctx, first); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	second, _ := report.New("post-1", "visitor-b", "198.51.100.2", report.ReasonOffensive)
+	review, err := repo.Add(ctx, second)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if review.PostID != "post-1" || review.Count != 2 || review.Reasons[report.ReasonSpam] != 1 || review.Reasons[report.ReasonOffensive] != 1 {
+		t.Fatalf("unexpected review: %+v", review)
+	}
+
+	// 同じ訪問者の 2 回目は数えない
+	again, _ := report.New("post-1", "visitor-a", "198.51.100.9", report.ReasonOther)
+	if _, err := repo.Add(ctx, again); !errors.Is(err, repository.ErrReportAlreadyExists) {
+		t.Fatalf("expected ErrReportAlreadyExists, got %v", err)
+	}
+	// トークンを作り直しても、同じ接続元 IP の 2 回目は数えない
+	renewed, _ := report.New("post-1", "visitor-z", "198.51.100.1", report.ReasonOther)
+	if _, err := repo.Add(ctx, renewed); !errors.Is(err, repository.ErrReportAlreadyExists) {
+		t.Fatalf("expected ErrReportAlreadyExists for the same client ip, got %v", err)
+	}
+	// 別のおみくじは別に数える
+	other, _ := report.New("post-2", "visitor-a", "198.51.100.1", report.ReasonSpam)
+	if review, err := repo.Add(ctx, other); err != nil || review.Count != 1 {
+		t.Fatalf("unexpected review for another draw: %+v %v", review, err)
+	}
+
+	// 返した項目を書き換えても保存側には影響しない
+	review.Reasons[report.ReasonSpam] = 100
+	third, _ := report.New("post-1", "visitor-c", "198.51.100.3", report.ReasonSpam)
+	if review, _ := repo.Add(ctx, third); review.Reasons[report.ReasonSpam] != 2 {
+		t.Fatalf("review should be copied, got %+v", review)
+	}
+}
+
+func TestInMemoryReportRepository_ListOpenAndResolve(t *testing.T) {
+	t.Parallel()
+
+	repo := NewInMemoryReportRepository()
+	ctx := context.Background()
+	for _, rp := range []struct {
+		postID  string
+		visitor string
+		ip      string
+	}{
+		{"post-1", "visitor-a", "198.51.100.1"},
+		{"post-2", "visitor-a", "198.51.100.1"},
+		{"post-2", "visitor-b", "198.51.100.2"},
+	} {
+		r, _ := report.New(post.DarkPostID(rp.postID), rp.visitor, rp.ip, report.ReasonSpam)
+		if _, err := repo.Add(ctx, r); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	// 通報の多い順に並ぶ
+	open, err := repo.ListOpen(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListOpen() error = %v", err)
+	}
+	if len(open) != 2 || open[0].PostID != "post-2" || open[0].Count != 2 || open[1].PostID != "post-1" {
+		t.Fatalf("unexpected open reviews: %+v", open)
+	}
+	if limited, _ := repo.ListOpen(ctx, 1); len(limited) != 1 {
+		t.Fatalf("expected limit to apply, got %d", len(limited))
+	}
+
+	// 閉じた項目は一覧から外れ、次の通報は 0 から数え直す
+	if err := repo.Resolve(ctx, "post-2"); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if open, _ := repo.ListOpen(ctx, 10); len(open) != 1 || open[0].PostID != "post-1" {
+		t.Fatalf("resolved review should leave the list: %+v", open)
+	}
+	next, _ := report.New("post-2", "visitor-c", "198.51.100.3", report.ReasonOther)
+	review, err := repo.Add(ctx, next)
+	if err != nil || review.Count != 1 || review.Reasons[report.ReasonSpam] != 0 {
+		t.Fatalf("expected a fresh count after resolve, got %+v %v", review, err)
+	}
+	// 閉じる前に通報した接続元は数えない
+	again, _ := report.New("post-2", "visitor-z", "198.51.100.1", report.ReasonOther)
+	if _, err := repo.Add(ctx, again); !errors.Is(err, repository.ErrReportAlreadyExists) {
+		t.Fatalf("expected ErrReportAlreadyExists, got %v", err)
+	}
+	if err := repo.Resolve(ctx, "missing"); err != nil {
+		t.Fatalf("Resolve() of a missing review should be a no-op, got %v", err)
+	}
+
+	// 消した投稿は項目も通報の記録も残さない
+	if err := repo.DeleteByPostID(ctx, "post-2"); err != nil {
+		t.Fatalf("DeleteByPostID() error = %v", err)
+	}
+	if open, _ := repo.ListOpen(ctx, 10); len(open) != 1 || open[0].PostID != "post-1" {
+		t.Fatalf("deleted review should leave the list: %+v", open)
+	}
+	if _, err := repo.Add(ctx, again); err != nil {
+		t.Fatalf("report records should be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+
+	"backend/internal/port/llm"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// formatter は LLM 呼び出しと検証をスパンとして記録する整形器のデコレーター。
+// 本文・整形結果はスパンに載せない。
+type formatter struct {
+	next     llm.Formatter
+	provider string
+}
+
+/**
+ * 整形器を包み、LLM 呼び出しと検証をプロバイダ名つきのスパンにする。
+ */
+func InstrumentFormatter(next llm.Formatter, provider string) llm.Formatter {
+	return &formatter{next: next, provider: provider}
+}
+
+func (f *formatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
+	ctx, span := tracer().Start(ctx, "chat "+f.provider,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.GenAIOperationNameChat,
+			semconv.GenAIProviderNameKey.String(f.provider),
+		),
+	)
+	defer span.End()
+	result, err := f.next.Format(ctx, req)
+	if result != nil && result.PromptVersion != "" {
+		span.SetAttributes(attribute.String("app.prompt_version", result.PromptVersion))
+	}
+	recordError(span, err)
+	return result, err
+}
+
+func (f *formatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
+	ctx, span := start(ctx, "validate formatted content")
+	defer span.End()
+	validated, err := f.next.Validate(ctx, result)
+	if validated != nil {
+		span.SetAttributes(
+			attribute.String("app.format.status", string(validated.Status)),
+			attribute.String("app.format.reason", validated.ValidationReason),
+		)
+	}
+	recordError(span, err)
+	return validated, err
+}
+
+var _ llm.Formatter = (*formatter)(nil)
@@ -0,0 +1,23 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// スパンを作らないパス（スクレイプで頻繁に呼ばれる監視用）
+var untracedPaths = map[string]bool{
+	"/metrics": true,
+}
+
+/**
+ * リクエストごとにサーバースパンを作る Gin ミドルウェア。
+ * 受け取った traceparent があれば、その続きとしてスパンを作る。
+ */
+func GinMiddleware(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return !untracedPaths[r.URL.Path]
+	}))
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+
+	"backend/internal/logging"
+	"backend/internal/port/queue"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+/**
+ * ワーカーが 1 件のジョブを処理するスパンを開始する。
+ * ジョブを登録した POST /posts のスパンとはリンクでつなぎ、ジョブごとに新しいトレースにする。
+ */
+func StartJob(ctx context.Context, job *queue.FormatJob) (context.Context, trace.Span) {
+	opts := []trace.SpanStartOption{
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingOperationTypeProcess,
+			semconv.MessagingDestinationName(formatJobsDestination),
+			attribute.String(postHashKey, logging.HashID(string(job.PostID))),
+		),
+	}
+	if origin := trace.SpanContextFromContext(Extract(context.Background(), job.TraceContext)); origin.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: origin}))
+	}
+	return tracer().Start(ctx, "format_jobs process", opts...)
+}
+
+/**
+ * ジョブ処理の結果をスパンに記録して閉じる。
+ */
+func EndJob(span trace.Span, err error) {
+	recordError(span, err)
+	span.End()
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"backend/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName はこのアプリが作るスパンの計装名。
+const instrumentationName = "backend"
+
+/**
+ * 送り先に応じたトレーサープロバイダーを既定にし、終了時に未送信のスパンを送り切る関数を返す。
+ * none の場合は OpenTelemetry 既定の no-op のまま、トレースコンテキストの受け渡しだけを有効にする。
+ * サービス名は OTEL_SERVICE_NAME が指定されていればそちらを優先する。
+ */
+func Setup(ctx context.Context, exporter, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if exporter == config.TraceExporterNone || exporter == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exp, err := newExporter(ctx, exporter)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: リソース情報を組み立てられません: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, exporter string) (sdktrace.SpanExporter, error) {
+	switch exporter {
+	case config.TraceExporterOTLP:
+		// 接続先は OTEL_EXPORTER_OTLP_ENDPOINT などの標準環境変数から読み込まれる
+		exp, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("tracing: OTLP エクスポーターを作れません: %w", err)
+		}
+		return exp, nil
+	case config.TraceExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("tracing: 標準出力エクスポーターを作れません: %w", err)
+		}
+		return exp, nil
+	default:
+		return nil, fmt.Errorf("tracing: 未対応のエクスポーターです: %q", exporter)
+	}
+}
+
+/**
+ * アプリのスパンを作るトレーサーを返す。呼び出し時点の既定プロバイダーを使う。
+ */
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
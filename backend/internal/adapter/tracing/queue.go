@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+
+	"backend/internal/domain/post"
+	"backend/internal/logging"
+	"backend/internal/port/queue"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// formatJobsDestination は整形キューの宛先名（Firestore のコレクション名）。
+	formatJobsDestination = "format_jobs"
+	// postHashKey は投稿 ID のハッシュを載せる属性。生の ID はスパンに残さない。
+	postHashKey = "app.post.hash"
+)
+
+// jobQueue はジョブ登録のスパンを作るキューのデコレーター。
+type jobQueue struct {
+	next queue.JobQueue
+}
+
+/**
+ * キューを包み、ジョブ登録をスパンとして記録する。
+ * 登録のスパンをトレースコンテキストとしてコンテキストに載せ、元のキューにジョブと一緒に保存させる。
+ */
+func InstrumentJobQueue(next queue.JobQueue) queue.JobQueue {
+	return &jobQueue{next: next}
+}
+
+func (q *jobQueue) EnqueueFormat(ctx context.Context, postID post.DarkPostID) error {
+	ctx, span := tracer().Start(ctx, "format_jobs send",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingDestinationName(formatJobsDestination),
+			attribute.String(postHashKey, logging.HashID(string(postID))),
+		),
+	)
+	defer span.End()
+	err := q.next.EnqueueFormat(queue.WithTraceContext(ctx, Inject(ctx)), postID)
+	recordError(span, err)
+	return err
+}
+
+// 取り出しは空きを待つ長いポーリングになるため、スパンは作らない
+func (q *jobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	return q.next.DequeueFormat(ctx)
+}
+
+func (q *jobQueue) Close() error {
+	return q.next.Close()
+}
+
+/**
+ * 元のキューが滞留数を返せる場合はそのまま委ねる。
+ */
+func (q *jobQueue) Depth(ctx context.Context) (int64, error) {
+	reporter, ok := q.next.(queue.DepthReporter)
+	if !ok {
+		return 0, queue.ErrDepthUnsupported
+	}
+	return reporter.Depth(ctx)
+}
+
+/**
+ * 元のキューがジョブの取り消しに対応している場合はそのまま委ねる。
+ */
+func (q *jobQueue) CancelFormat(ctx context.Context, postID post.DarkPostID) error {
+	canceller, ok := q.next.(queue.Canceller)
+	if !ok {
+		return queue.ErrCancelUnsupported
+	}
+	return canceller.CancelFormat(ctx, postID)
+}
+
+var (
+	_ queue.JobQueue      = (*jobQueue)(nil)
+	_ queue.DepthReporter = (*jobQueue)(nil)
+	_ queue.Canceller     = (*jobQueue)(nil)
+)
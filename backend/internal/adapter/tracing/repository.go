@@ -0,0 +1,323 @@
+package tracing
+
+import (
+	"context"
+
+	"backend/internal/domain/audit"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/domain/report"
+	"backend/internal/port/repository"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// firestoreSystem は Firestore を表す db.system.name の値。
+var firestoreSystem = semconv.DBSystemNameKey.String("gcp.firestore")
+
+/**
+ * Firestore 呼び出しのスパンを開始する。スパン名は「操作 コレクション」にする。
+ */
+func startFirestore(ctx context.Context, collection, operation string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, operation+" "+collection,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			firestoreSystem,
+			semconv.DBCollectionName(collection),
+			semconv.DBOperationName(operation),
+		),
+	)
+}
+
+// postRepository は投稿の読み書きをスパンとして記録するデコレーター。
+type postRepository struct {
+	next repository.PostRepository
+}
+
+/**
+ * 投稿リポジトリを包み、呼び出しごとにスパンを作る。
+ */
+func InstrumentPostRepository(next repository.PostRepository) repository.PostRepository {
+	return &postRepository{next: next}
+}
+
+func (r *postRepository) Create(ctx context.Context, p *post.Post) error {
+	ctx, span := startFirestore(ctx, "posts", "create")
+	defer span.End()
+	err := r.next.Create(ctx, p)
+	if err == nil && p != nil {
+		span.SetAttributes(attribute.String("app.post.status", string(p.Status())))
+	}
+	recordError(span, err)
+	return err
+}
+
+func (r *postRepository) Get(ctx context.Context, id post.DarkPostID) (*post.Post, error) {
+	ctx, span := startFirestore(ctx, "posts", "get")
+	defer span.End()
+	p, err := r.next.Get(ctx, id)
+	recordError(span, err)
+	return p, err
+}
+
+func (r *postRepository) ListReady(ctx context.Context, limit int) ([]*post.Post, error) {
+	ctx, span := startFirestore(ctx, "posts", "list_ready")
+	defer span.End()
+	posts, err := r.next.ListReady(ctx, limit)
+	recordError(span, err)
+	return posts, err
+}
+
+func (r *postRepository) Update(ctx context.Context, p *post.Post) error {
+	ctx, span := startFirestore(ctx, "posts", "update")
+	defer span.End()
+	err := r.next.Update(ctx, p)
+	recordError(span, err)
+	return err
+}
+
+func (r *postRepository) ListByStatus(ctx context.Context, status post.Status, after post.DarkPostID, limit int) ([]*post.Post, error) {
+	ctx, span := startFirestore(ctx, "posts", "list_by_status")
+	defer span.End()
+	span.SetAttributes(attribute.String("app.post.status", string(status)))
+	posts, err := r.next.ListByStatus(ctx, status, after, limit)
+	recordError(span, err)
+	return posts, err
+}
+
+func (r *postRepository) Delete(ctx context.Context, id post.DarkPostID) error {
+	ctx, span := startFirestore(ctx, "posts", "delete")
+	defer span.End()
+	err := r.next.Delete(ctx, id)
+	recordError(span, err)
+	return err
+}
+
+// drawRepository はおみくじ結果の読み書きをスパンとして記録するデコレーター。
+type drawRepository struct {
+	next repository.DrawRepository
+}
+
+/**
+ * おみくじ結果リポジトリを包み、呼び出しごとにスパンを作る。
+ */
+func InstrumentDrawRepository(next repository.DrawRepository) repository.DrawRepository {
+	return &drawRepository{next: next}
+}
+
+func (r *drawRepository) Create(ctx context.Context, d *drawdomain.Draw) error {
+	ctx, span := startFirestore(ctx, "draws", "create")
+	defer span.End()
+	err := r.next.Create(ctx, d)
+	recordError(span, err)
+	return err
+}
+
+func (r *drawRepository) GetByPostID(ctx context.Context, postID post.DarkPostID) (*drawdomain.Draw, error) {
+	ctx, span := startFirestore(ctx, "draws", "get_by_post_id")
+	defer span.End()
+	d, err := r.next.GetByPostID(ctx, postID)
+	recordError(span, err)
+	return d, err
+}
+
+func (r *drawRepository) GetByShareID(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	ctx, span := startFirestore(ctx, "draws", "get_by_share_id")
+	defer span.End()
+	d, err := r.next.GetByShareID(ctx, id)
+	recordError(span, err)
+	return d, err
+}
+
+func (r *drawRepository) ListReady(ctx context.Context) ([]*drawdomain.Draw, error) {
+	ctx, span := startFirestore(ctx, "draws", "list_ready")
+	defer span.End()
+	draws, err := r.next.ListReady(ctx)
+	recordError(span, err)
+	return draws, err
+}
+
+func (r *drawRepository) Update(ctx context.Context, d *drawdomain.Draw) error {
+	ctx, span := startFirestore(ctx, "draws", "update")
+	defer span.End()
+	err := r.next.Update(ctx, d)
+	recordError(span, err)
+	return err
+}
+
+func (r *drawRepository) Delete(ctx context.Context, postID post.DarkPostID) error {
+	ctx, span := startFirestore(ctx, "draws", "delete")
+	defer span.End()
+	err := r.next.Delete(ctx, postID)
+	recordError(span, err)
+	return err
+}
+
+// outcomeRepository は整形結果の記録をスパンとして残すデコレーター。
+type outcomeRepository struct {
+	next repository.OutcomeRepository
+}
+
+/**
+ * 整形結果リポジトリを包み、呼び出しごとにスパンを作る。
+ */
+func InstrumentOutcomeRepository(next repository.OutcomeRepository) repository.OutcomeRepository {
+	return &outcomeRepository{next: next}
+}
+
+func (r *outcomeRepository) Record(ctx context.Context, o *outcome.Outcome) error {
+	ctx, span := startFirestore(ctx, "format_outcomes", "record")
+	defer span.End()
+	err := r.next.Record(ctx, o)
+	recordError(span, err)
+	return err
+}
+
+func (r *outcomeRepository) List(ctx context.Context) ([]*outcome.Outcome, error) {
+	ctx, span := startFirestore(ctx, "format_outcomes", "list")
+	defer span.End()
+	outcomes, err := r.next.List(ctx)
+	recordError(span, err)
+	return outcomes, err
+}
+
+func (r *outcomeRepository) ListByPostID(ctx context.Context, postID post.DarkPostID) ([]*outcome.Outcome, error) {
+	ctx, span := startFirestore(ctx, "format_outcomes", "list_by_post_id")
+	defer span.End()
+	outcomes, err := r.next.ListByPostID(ctx, postID)
+	recordError(span, err)
+	return outcomes, err
+}
+
+func (r *outcomeRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	ctx, span := startFirestore(ctx, "format_outcomes", "delete_by_post_id")
+	defer span.End()
+	err := r.next.DeleteByPostID(ctx, postID)
+	recordError(span, err)
+	return err
+}
+
+// reactionRepository はおみくじへの反応の読み書きをスパンとして記録するデコレーター。
+type reactionRepository struct {
+	next repository.ReactionRepository
+}
+
+/**
+ * 反応リポジトリを包み、呼び出しごとにスパンを作る。
+ */
+func InstrumentReactionRepository(next repository.ReactionRepository) repository.ReactionRepository {
+	return &reactionRepository{next: next}
+}
+
+func (r *reactionRepository) Add(ctx context.Context, rc *reaction.Reaction) error {
+	ctx, span := startFirestore(ctx, "reactions", "add")
+	defer span.End()
+	err := r.next.Add(ctx, rc)
+	recordError(span, err)
+	return err
+}
+
+func (r *reactionRepository) CountByPostID(ctx context.Context, postID post.DarkPostID) (reaction.Counts, error) {
+	ctx, span := startFirestore(ctx, "reaction_counters", "count_by_post_id")
+	defer span.End()
+	counts, err := r.next.CountByPostID(ctx, postID)
+	recordError(span, err)
+	return counts, err
+}
+
+func (r *reactionRepository) CountByPostIDs(ctx context.Context, postIDs []post.DarkPostID) (map[post.DarkPostID]reaction.Counts, error) {
+	ctx, span := startFirestore(ctx, "reaction_counters", "count_by_post_ids")
+	defer span.End()
+	span.SetAttributes(attribute.Int("app.post_count", len(postIDs)))
+	counts, err := r.next.CountByPostIDs(ctx, postIDs)
+	recordError(span, err)
+	return counts, err
+}
+
+func (r *reactionRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	ctx, span := startFirestore(ctx, "reactions", "delete_by_post_id")
+	defer span.End()
+	err := r.next.DeleteByPostID(ctx, postID)
+	recordError(span, err)
+	return err
+}
+
+// reportRepository はおみくじへの通報の書き込みをスパンとして記録するデコレーター。
+type reportRepository struct {
+	next repository.ReportRepository
+}
+
+/**
+ * 通報リポジトリを包み、呼び出しごとにスパンを作る。
+ */
+func InstrumentReportRepository(next repository.ReportRepository) repository.ReportRepository {
+	return &reportRepository{next: next}
+}
+
+func (r *reportRepository) Add(ctx context.Context, rp *report.Report) (*report.Review, error) {
+	ctx, span := startFirestore(ctx, "reports", "add")
+	defer span.End()
+	review, err := r.next.Add(ctx, rp)
+	recordError(span, err)
+	return review, err
+}
+
+func (r *reportRepository) ListOpen(ctx context.Context, limit int) ([]*report.Review, error) {
+	ctx, span := startFirestore(ctx, "moderation_queue", "list_open")
+	defer span.End()
+	reviews, err := r.next.ListOpen(ctx, limit)
+	recordError(span, err)
+	return reviews, err
+}
+
+func (r *reportRepository) Resolve(ctx context.Context, postID post.DarkPostID) error {
+	ctx, span := startFirestore(ctx, "moderation_queue", "resolve")
+	defer span.End()
+	err := r.next.Resolve(ctx, postID)
+	recordError(span, err)
+	return err
+}
+
+func (r *reportRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	ctx, span := startFirestore(ctx, "reports", "delete_by_post_id")
+	defer span.End()
+	err := r.next.DeleteByPostID(ctx, postID)
+	recordError(span, err)
+	return err
+}
+
+// auditLogRepository は管理操作の記録の書き込みをスパンとして記録するデコレーター。
+type auditLogRepository struct {
+	next repository.AuditLogRepository
+}
+
+/**
+ * 監査ログリポジトリを包み、呼び出しごとにスパンを作る。
+ */
+func InstrumentAuditLogRepository(next repository.AuditLogRepository) repository.AuditLogRepository {
+	return &auditLogRepository{next: next}
+}
+
+func (r *auditLogRepository) Record(ctx context.Context, e *audit.Entry) error {
+	ctx, span := startFirestore(ctx, "admin_audit_logs", "record")
+	defer span.End()
+	if e != nil {
+		span.SetAttributes(attribute.String("app.admin.action", string(e.Action())))
+	}
+	err := r.next.Record(ctx, e)
+	recordError(span, err)
+	return err
+}
+
+var (
+	_ repository.PostRepository     = (*postRepository)(nil)
+	_ repository.DrawRepository     = (*drawRepository)(nil)
+	_ repository.OutcomeRepository  = (*outcomeRepository)(nil)
+	_ repository.ReactionRepository = (*reactionRepository)(nil)
+	_ repository.ReportRepository   = (*reportRepository)(nil)
+	_ repository.AuditLogRepository = (*auditLogRepository)(nil)
+)
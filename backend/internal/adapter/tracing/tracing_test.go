@@ -0,0 +1,186 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/port/llm"
+	"backend/internal/port/queue"
+	workertestutil "backend/internal/usecase/worker/testutil"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+/**
+ * テスト中だけスパンを記録するプロバイダーを既定にする。
+ */
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	origProvider, origPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(origProvider)
+		otel.SetTextMapPropagator(origPropagator)
+	})
+	return recorder
+}
+
+/**
+ * ジョブ登録時のトレースコンテキストを保存し、ワーカーのスパンが登録元のスパンへリンクすることを確認する。
+ */
+func TestStartJob_LinksToEnqueueSpan(t *testing.T) {
+	recorder := newRecorder(t)
+
+	var carrier map[string]string
+	q := InstrumentJobQueue(&capturingQueue{onEnqueue: func(ctx context.Context) { carrier = queue.TraceContextFromContext(ctx) }})
+	if err := q.EnqueueFormat(context.Background(), "p1"); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	_, span := StartJob(context.Background(), &queue.FormatJob{PostID: "p1", TraceContext: carrier})
+	EndJob(span, errors.New("boom"))
+
+	ended := recorder.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(ended))
+	}
+	send, process := ended[0], ended[1]
+	if process.Parent().IsValid() {
+		t.Fatalf("job span should start a new trace")
+	}
+	links := process.Links()
+	if len(links) != 1 || links[0].SpanContext.SpanID() != send.SpanContext().SpanID() {
+		t.Fatalf("job span should link to the enqueue span: %v", links)
+	}
+	if process.Status().Code != codes.Error {
+		t.Fatalf("job failure should be recorded: %v", process.Status())
+	}
+	for _, attr := range append(send.Attributes(), process.Attributes()...) {
+		if attr.Value.AsString() == "p1" {
+			t.Fatalf("raw post ID must not be recorded: %v", attr)
+		}
+	}
+}
+
+/**
+ * 保存済みのトレースコンテキストが無いジョブはリンク無しで処理されることを確認する。
+ */
+func TestStartJob_WithoutTraceContext(t *testing.T) {
+	recorder := newRecorder(t)
+
+	_, span := StartJob(context.Background(), &queue.FormatJob{PostID: "p1"})
+	EndJob(span, nil)
+
+	ended := recorder.Ended()
+	if len(ended) != 1 || len(ended[0].Links()) != 0 {
+		t.Fatalf("expected a single span without links, got %d spans", len(ended))
+	}
+	if ended[0].Status().Code == codes.Error {
+		t.Fatalf("successful job should not be marked as error")
+	}
+}
+
+/**
+ * LLM 呼び出しがプロバイダ名つきのスパンになり、本文や整形結果を載せないことを確認する。
+ */
+func TestInstrumentFormatter(t *testing.T) {
+	recorder := newRecorder(t)
+	stub := &workertestutil.StubFormatter{FormatResult: &llm.FormatResult{DarkPostID: "p1", FormattedContent: "secret fortune", PromptVersion: "v2"}}
+	f := InstrumentFormatter(stub, "openai")
+
+	if _, err := f.Format(context.Background(), &llm.FormatRequest{DarkPostID: "p1", DarkContent: "secret post"}); err != nil {
+		t.Fatalf("format: %v", err)
+	}
+
+	ended := recorder.Ended()
+	if len(ended) != 1 || ended[0].Name() != "chat openai" {
+		t.Fatalf("unexpected spans: %v", ended)
+	}
+	for _, attr := range ended[0].Attributes() {
+		if v := attr.Value.AsString(); v == "secret post" || v == "secret fortune" {
+			t.Fatalf("content must not be recorded: %v", attr)
+		}
+	}
+}
+
+/**
+ * おみくじの該当なしは失敗として扱わず、それ以外の失敗はスパンに残すことを確認する。
+ */
+func TestInstrumentFortune(t *testing.T) {
+	recorder := newRecorder(t)
+	for _, err := range []error{drawdomain.ErrEmptyResult, errors.New("boom")} {
+		_, _ = InstrumentFortune(stubFortune{err: err}).DrawFortune(context.Background())
+	}
+
+	ended := recorder.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(ended))
+	}
+	if ended[0].Status().Code == codes.Error {
+		t.Fatalf("empty result should not be marked as error")
+	}
+	if ended[1].Status().Code != codes.Error {
+		t.Fatalf("failure should be marked as error")
+	}
+}
+
+/**
+ * 受け取った traceparent の続きとしてサーバースパンを作り、/metrics は記録しないことを確認する。
+ */
+func TestGinMiddleware(t *testing.T) {
+	recorder := newRecorder(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(GinMiddleware("test"))
+	router.POST("/posts", func(c *gin.Context) { c.Status(http.StatusAccepted) })
+	router.GET("/metrics", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected only the /posts span, got %d", len(ended))
+	}
+	if got := ended[0].SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("span should continue the incoming trace, got %s", got)
+	}
+}
+
+// 登録時のコンテキストを覗けるキューのスタブ。
+type capturingQueue struct {
+	workertestutil.StubJobQueue
+	onEnqueue func(ctx context.Context)
+}
+
+func (q *capturingQueue) EnqueueFormat(ctx context.Context, id post.DarkPostID) error {
+	q.onEnqueue(ctx)
+	return nil
+}
+
+type stubFortune struct {
+	err error
+}
+
+func (s stubFortune) DrawFortune(ctx context.Context) (*drawdomain.Draw, error) {
+	return nil, s.err
+}
+
+func (s stubFortune) SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	return nil, s.err
+}
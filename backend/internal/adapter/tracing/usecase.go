@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/port/repository"
+	drawusecase "backend/internal/usecase/draw"
+	postusecase "backend/internal/usecase/post"
+)
+
+type fortuneDrawer struct {
+	next drawusecase.FortuneDrawer
+}
+
+/**
+ * おみくじを返す処理を包み、ユースケースのスパンを作る。該当なしは失敗として扱わない。
+ */
+func InstrumentFortune(next drawusecase.FortuneDrawer) drawusecase.FortuneDrawer {
+	return &fortuneDrawer{next: next}
+}
+
+func (f *fortuneDrawer) DrawFortune(ctx context.Context) (*drawdomain.Draw, error) {
+	ctx, span := start(ctx, "FortuneUsecase.DrawFortune")
+	defer span.End()
+	d, err := f.next.DrawFortune(ctx)
+	if !errors.Is(err, drawdomain.ErrEmptyResult) {
+		recordError(span, err)
+	}
+	return d, err
+}
+
+func (f *fortuneDrawer) SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	ctx, span := start(ctx, "FortuneUsecase.SharedDraw")
+	defer span.End()
+	d, err := f.next.SharedDraw(ctx, id)
+	if !errors.Is(err, repository.ErrDrawNotFound) {
+		recordError(span, err)
+	}
+	return d, err
+}
+
+// PostCreator は投稿を受け付ける処理（handler.CreatePostExecutor と同じ形）。
+type PostCreator interface {
+	Execute(ctx context.Context, in *postusecase.CreatePostInput) (*postusecase.CreatePostOutput, error)
+}
+
+type postCreator struct {
+	next PostCreator
+}
+
+/**
+ * 投稿の受け付けを包み、ユースケースのスパンを作る。
+ */
+func InstrumentCreatePost(next PostCreator) PostCreator {
+	return &postCreator{next: next}
+}
+
+func (p *postCreator) Execute(ctx context.Context, in *postusecase.CreatePostInput) (*postusecase.CreatePostOutput, error) {
+	ctx, span := start(ctx, "CreatePostUsecase.Execute")
+	defer span.End()
+	out, err := p.next.Execute(ctx, in)
+	recordError(span, err)
+	return out, err
+}
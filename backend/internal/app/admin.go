@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"backend/internal/adapter/adminauth"
+	"backend/internal/adapter/http/handler"
+	"backend/internal/adapter/metrics"
+	firestoreadapter "backend/internal/adapter/repository/firestore"
+	"backend/internal/adapter/tracing"
+	"backend/internal/config"
+	"backend/internal/port/queue"
+	"backend/internal/port/repository"
+	adminusecase "backend/internal/usecase/admin"
+)
+
+var errAdminFirestoreUnavailable = errors.New("admin audit log repository: Firestore クライアントが初期化されていません")
+
+// 管理操作の記録先を組み立てる。後から追えるよう API と同じ Firestore に残す
+var auditLogRepositoryFactory = newAuditLogRepository
+
+/**
+ * 環境変数に従って /admin 以下の管理 API を有効にするルーター設定を返す。ADMIN_AUTH が未設定なら nil。
+ * posts・draws・jobs・reports・reactions には公開 API と同じく計測・トレース済みのものを渡す。
+ * 投稿の削除では、reactions・reports・整形結果と cards が保存したカード画像も消す。
+ */
+func newAdminOption(infra *Infra, m *metrics.Metrics, posts repository.PostRepository, draws repository.DrawRepository, jobs queue.JobQueue, reports repository.ReportRepository, reactions repository.ReactionRepository, cards adminusecase.ShareCardForgetter) (handler.RouterOption, error) {
+	cfg, err := config.LoadAdminConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load admin config: %w", err)
+	}
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	verifier, err := newAdminVerifier(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("init admin verifier: %w", err)
+	}
+	auditLogs, err := auditLogRepositoryFactory(infra)
+	if err != nil {
+		return nil, fmt.Errorf("init audit log repository: %w", err)
+	}
+	// 却下理由は Worker が記録した整形結果から読む
+	outcomes, err := outcomeRepositoryFactory(context.Background(), infra)
+	if err != nil {
+		return nil, fmt.Errorf("init outcome repository: %w", err)
+	}
+	usecase := adminusecase.NewAdminUsecase(
+		posts,
+		draws,
+		tracing.InstrumentOutcomeRepository(outcomes),
+		jobs,
+		m.InstrumentAuditLogRepository(tracing.InstrumentAuditLogRepository(auditLogs)),
+	).
+		WithReports(reports).
+		WithReactions(reactions).
+		WithShareCards(cards)
+	return handler.WithAdmin(handler.NewAdminHandler(usecase), handler.AdminAuth(verifier)), nil
+}
+
+/**
+ * 認証方式に応じた管理トークンの検証器を返す。
+ */
+func newAdminVerifier(cfg *config.AdminConfig) (handler.AdminVerifier, error) {
+	switch cfg.Auth {
+	case config.AdminAuthToken:
+		return adminauth.NewTokenVerifier(cfg.Token)
+	case config.AdminAuthOIDC:
+		return adminauth.NewIDTokenVerifier(cfg.Audience, cfg.Emails)
+	default:
+		return nil, fmt.Errorf("unsupported admin auth: %q", cfg.Auth)
+	}
+}
+
+/**
+ * Firestore 固定の監査ログリポジトリを返す。
+ */
+func newAuditLogRepository(infra *Infra) (repository.AuditLogRepository, error) {
+	if infra == nil || infra.Firestore() == nil {
+		return nil, errAdminFirestoreUnavailable
+	}
+	return firestoreadapter.NewAuditLogRepository(infra.Firestore())
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"backend/internal/adapter/adminauth"
+	"backend/internal/config"
+)
+
+func TestNewAdminOption_DisabledWithoutAuth(t *testing.T) {
+	t.Setenv("ADMIN_AUTH", "")
+	option, err := newAdminOption(&Infra{}, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if option != nil {
+		t.Fatalf("admin API should not be registered without ADMIN_AUTH")
+	}
+}
+
+func TestNewAdminOption_RequiresFirestoreClient(t *testing.T) {
+	t.Setenv("ADMIN_AUTH", "token")
+	t.Setenv("ADMIN_TOKEN", "secret")
+	if _, err := newAdminOption(&Infra{}, nil, nil, nil, nil, nil, nil, nil); !errors.Is(err, errAdminFirestoreUnavailable) {
+		t.Fatalf("expected errAdminFirestoreUnavailable, got %v", err)
+	}
+}
+
+func TestNewAdminVerifier(t *testing.T) {
+	token, err := newAdminVerifier(&config.AdminConfig{Auth: config.AdminAuthToken, Token: "secret"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := token.(*adminauth.TokenVerifier); !ok {
+		t.Fatalf("expected token verifier, got %T", token)
+	}
+	oidc, err := newAdminVerifier(&config.AdminConfig{Auth: config.AdminAuthOIDC, Audience: "aud", Emails: []string{"a@example.com"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := oidc.(*adminauth.IDTokenVerifier); !ok {
+		t.Fatalf("expected id token verifier, got %T", oidc)
+	}
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"fmt"
+
+	"backend/internal/adapter/http/handler"
+	"backend/internal/config"
+)
+
+/**
+ * 環境変数に従って、接続元 IP を決めるときに信頼する中継元とヘッダーを設定するルーター設定を返す。
+ * 呼び出し上限は接続元 IP ごとに数えるため、クライアントが書き換えられる値を信頼しないようにする。
+ */
+func newClientIPOption() (handler.RouterOption, error) {
+	cfg, err := config.LoadClientIPConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load client ip config: %w", err)
+	}
+	return handler.WithTrustedProxies(cfg.TrustedProxies, cfg.PlatformHeader), nil
+}
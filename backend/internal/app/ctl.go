@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"backend/internal/port/queue"
+	"backend/internal/port/repository"
+	adminusecase "backend/internal/usecase/admin"
+	opsusecase "backend/internal/usecase/ops"
+	"backend/internal/usecase/worker"
+)
+
+// CtlContainer は運用コマンド（kirakujictl）で使う依存を保持する。
+type CtlContainer struct {
+	Infra    *Infra
+	Ops      *opsusecase.OpsUsecase
+	Admin    *adminusecase.AdminUsecase
+	posts    repository.PostRepository
+	draws    repository.DrawRepository
+	outcomes repository.OutcomeRepository
+	jobs     queue.JobQueue
+}
+
+/**
+ * 運用コマンド向けに、API・Worker と同じ Firestore（FIRESTORE_EMULATOR_HOST があればエミュレーター）を使う依存を組み立てる。
+ * おみくじの状態変更や積み直しは管理 API と同じユースケースを通し、監査ログに残す。
+ */
+func NewCtlContainer(ctx context.Context) (*CtlContainer, error) {
+	infra, err := infraFactory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("init infra: %w", err)
+	}
+	container, err := newCtlContainer(ctx, infra)
+	if err != nil {
+		_ = infra.Close()
+		return nil, err
+	}
+	return container, nil
+}
+
+func newCtlContainer(ctx context.Context, infra *Infra) (*CtlContainer, error) {
+	posts, err := postRepositoryFactory(ctx, infra)
+	if err != nil {
+		return nil, err
+	}
+	draws, err := drawRepositoryFactory(ctx, infra)
+	if err != nil {
+		return nil, fmt.Errorf("init draw repository: %w", err)
+	}
+	jobs, err := jobQueueFactory(infra)
+	if err != nil {
+		return nil, fmt.Errorf("init job queue: %w", err)
+	}
+	outcomes, err := outcomeRepositoryFactory(ctx, infra)
+	if err != nil {
+		return nil, fmt.Errorf("init outcome repository: %w", err)
+	}
+	auditLogs, err := auditLogRepositoryFactory(infra)
+	if err != nil {
+		return nil, fmt.Errorf("init audit log repository: %w", err)
+	}
+	return &CtlContainer{
+		Infra:    infra,
+		Ops:      opsusecase.NewOpsUsecase(posts, draws, jobs),
+		Admin:    adminusecase.NewAdminUsecase(posts, draws, outcomes, jobs, auditLogs),
+		posts:    posts,
+		draws:    draws,
+		outcomes: outcomes,
+		jobs:     jobs,
+	}, nil
+}
+
+/**
+ * 保存せずに整形・検証を試すためのユースケースを、Worker と同じ LLM・伏せ字の設定で組み立てる。
+ * LLM の設定は試すときだけ必要なので、コンテナの生成時ではなくここで読む。返すクローズ関数で整形器を閉じる。
+ */
+func (c *CtlContainer) NewFormatPreviewUsecase(ctx context.Context) (*worker.FormatPendingUsecase, func() error, error) {
+	redactor, err := redactorFactory()
+	if err != nil {
+		return nil, nil, fmt.Errorf("init redactor: %w", err)
+	}
+	formatter, closeFormatter, err := formatterFactory(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("init formatter: %w", err)
+	}
+	usecase := worker.NewFormatPendingUsecase(c.posts, c.draws, moderateOutput(formatter), c.jobs).WithRedactor(redactor)
+	return usecase, closeFormatter, nil
+}
+
+/**
+ * 生成時に開いたリソースを順に閉じる。
+ */
+func (c *CtlContainer) Close() error {
+	if c == nil {
+		return nil
+	}
+	var retErr error
+	if c.jobs != nil {
+		retErr = mergeCloseError(retErr, "job queue", c.jobs.Close)
+	}
+	return mergeCloseError(retErr, "infra", c.Infra.Close)
+}
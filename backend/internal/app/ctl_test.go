@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/adapter/repository/memory"
+	"backend/internal/port/llm"
+	"backend/internal/port/repository"
+	workertestutil "backend/internal/usecase/worker/testutil"
+)
+
+func TestNewCtlContainer(t *testing.T) {
+	defer stubCtlFactories(t)()
+
+	container, err := NewCtlContainer(context.Background())
+	if err != nil {
+		t.Fatalf("NewCtlContainer returned error: %v", err)
+	}
+	if container.Ops == nil || container.Admin == nil {
+		t.Fatalf("expected usecases to be built")
+	}
+
+	formatter := &stubFormatter{}
+	origFormatterFactory := formatterFactory
+	formatterFactory = func(ctx context.Context) (llm.Formatter, func() error, error) {
+		return formatter, formatter.Close, nil
+	}
+	defer func() { formatterFactory = origFormatterFactory }()
+
+	preview, closeFn, err := container.NewFormatPreviewUsecase(context.Background())
+	if err != nil || preview == nil {
+		t.Fatalf("NewFormatPreviewUsecase returned %v %v", preview, err)
+	}
+	if err := closeFn(); err != nil || !formatter.closed {
+		t.Fatalf("expected formatter to be closed, err=%v", err)
+	}
+	if err := container.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+}
+
+func TestNewCtlContainer_RequiresFirestore(t *testing.T) {
+	origInfra := infraFactory
+	infraFactory = func(ctx context.Context) (*Infra, error) {
+		return &Infra{}, nil
+	}
+	defer func() { infraFactory = origInfra }()
+
+	if _, err := NewCtlContainer(context.Background()); !errors.Is(err, errFirestoreClientUnavailable) {
+		t.Fatalf("expected firestore unavailable error, got %v", err)
+	}
+}
+
+// stubCtlFactories は運用コマンドの依存をすべてスタブに差し替え、元に戻す関数を返す。
+func stubCtlFactories(t *testing.T) func() {
+	t.Helper()
+	origInfra := infraFactory
+	infraFactory = func(ctx context.Context) (*Infra, error) {
+		return &Infra{}, nil
+	}
+	origPosts := postRepositoryFactory
+	postRepositoryFactory = func(ctx context.Context, infra *Infra) (repository.PostRepository, error) {
+		return memory.NewInMemoryPostRepository(), nil
+	}
+	origAudit := auditLogRepositoryFactory
+	auditLogRepositoryFactory = func(infra *Infra) (repository.AuditLogRepository, error) {
+		return memory.NewInMemoryAuditLogRepository(), nil
+	}
+	restoreDraws := stubDrawRepositoryFactory(t, &workertestutil.StubDrawRepository{}, nil)
+	restoreOutcomes := stubOutcomeRepositoryFactory(t, &workertestutil.StubOutcomeRepository{}, nil)
+	restoreJobs := stubJobQueueFactory(t)
+	return func() {
+		infraFactory = origInfra
+		postRepositoryFactory = origPosts
+		auditLogRepositoryFactory = origAudit
+		restoreDraws()
+		restoreOutcomes()
+		restoreJobs()
+	}
+}
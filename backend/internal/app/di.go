@@ -7,12 +7,17 @@ import (
 	"os"
 
 	"backend/internal/adapter/http/handler"
+	"backend/internal/adapter/metrics"
 	firestoreadapter "backend/internal/adapter/repository/firestore"
+	"backend/internal/adapter/tracing"
+	"backend/internal/config"
 	drawdomain "backend/internal/domain/draw"
 	"backend/internal/domain/post"
+	"backend/internal/health"
 	"backend/internal/port/repository"
 	drawusecase "backend/internal/usecase/draw"
 	postusecase "backend/internal/usecase/post"
+	reactionusecase "backend/internal/usecase/reaction"
 	"cloud.google.com/go/firestore"
 )
 
@@ -23,6 +28,8 @@ type Container struct {
 	DrawHandler        *handler.DrawHandler
 	CreatePostUsecase  *postusecase.CreatePostUsecase
 	PostHandler        *handler.PostHandler
+	Metrics            *metrics.Metrics
+	RouterOptions      []handler.RouterOption
 }
 
 // NewContainer は依存を初期化して返す。
@@ -31,14 +38,38 @@ func NewContainer(ctx context.Context) (*Container, error) {
 	if err != nil {
 		return nil, fmt.Errorf("init infra: %w", err)
 	}
+	// 本文をログに出さない方針と、任意のデバッグ用サンプルの書き込み先を設定する
+	if err := configureLogging(infra); err != nil {
+		return nil, err
+	}
+
+	// 業務コードへは持ち込まず、ポートを包むデコレーターで計測・トレースする
+	m := metricsFactory()
 
 	repo, err := provideDrawRepository(infra)
 	if err != nil {
 		return nil, fmt.Errorf("provide draw repository: %w", err)
 	}
 
-	usecase := drawusecase.NewFortuneUsecase(repo)
-	drawHandler := handler.NewDrawHandler(usecase)
+	// おみくじへの反応は閲覧時に数を添え、設定があれば抽選の重みにも使う
+	reactionConfig, err := config.LoadReactionConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load reaction config: %w", err)
+	}
+	reactionRepo, err := reactionRepositoryFactory(infra, reactionConfig)
+	if err != nil {
+		return nil, fmt.Errorf("init reaction repository: %w", err)
+	}
+	reactions := m.InstrumentReactionRepository(tracing.InstrumentReactionRepository(reactionRepo))
+
+	drawRepo := m.InstrumentDrawRepository(tracing.InstrumentDrawRepository(repo))
+	usecase := drawusecase.NewFortuneUsecase(drawRepo)
+	if reactionConfig.Weighting {
+		usecase.WithReactions(reactions).WithReactionCacheTTL(reactionConfig.WeightTTL)
+	}
+	fortune := m.InstrumentFortune(tracing.InstrumentFortune(usecase))
+	reactionUsecase := reactionusecase.NewReactionUsecase(fortune, reactions)
+	drawHandler := handler.NewDrawHandler(fortune).WithReactions(reactionUsecase)
 
 	// API では Firestore へ統一するため、メモリ実装へは切り替えない
 	postRepo, err := newAPIPostRepository(infra)
@@ -50,8 +81,78 @@ func NewContainer(ctx context.Context) (*Container, error) {
 	if err != nil {
 		return nil, fmt.Errorf("init job queue: %w", err)
 	}
-	createPostUsecase := postusecase.NewCreatePostUsecase(postRepo, jobQueue)
-	postHandler := handler.NewPostHandler(createPostUsecase)
+	// LLM へ渡す前に危機的な兆候などを判定する
+	moderator, err := moderatorFactory()
+	if err != nil {
+		return nil, fmt.Errorf("init moderator: %w", err)
+	}
+	// 外部の判定サービスへは個人情報を伏せた本文だけを渡す
+	redactor, err := redactorFactory()
+	if err != nil {
+		return nil, fmt.Errorf("init redactor: %w", err)
+	}
+	// 投稿がおみくじになるまでの進み具合を、Worker からの通知で SSE へ流す
+	postNotifier, err := notifierFactory(infra)
+	if err != nil {
+		return nil, fmt.Errorf("init notifier: %w", err)
+	}
+	posts := m.InstrumentPostRepository(tracing.InstrumentPostRepository(postRepo))
+	jobs := m.InstrumentJobQueue(tracing.InstrumentJobQueue(jobQueue))
+	createPostUsecase := postusecase.NewCreatePostUsecase(posts, jobs).
+		WithModerator(moderator).
+		WithRedactor(redactor).
+		WithNotifier(postNotifier)
+	postHandler := handler.NewPostHandler(tracing.InstrumentCreatePost(createPostUsecase))
+	watchPostUsecase := postusecase.NewWatchPostUsecase(posts, postNotifier)
+
+	// 接続元 IP は信頼する中継元が付けた値だけから決める
+	clientIPOption, err := newClientIPOption()
+	if err != nil {
+		return nil, err
+	}
+	// LLM を呼ぶ投稿と閲覧に、接続元ごとの呼び出し上限を設ける
+	rateLimitOption, err := newRateLimitOption(infra)
+	if err != nil {
+		return nil, err
+	}
+	// 共有リンクのプレビュー用カード画像は、共有ページと同じおみくじの取得を使う
+	shareCardOption, cards, err := newShareCardOption(fortune)
+	if err != nil {
+		return nil, err
+	}
+	// 通報は審査待ちの列に積み、閾値を超えたおみくじは抽選と共有リンクから外す。管理 API も同じ列を読んで審査を閉じる
+	reports, err := newInstrumentedReportRepository(infra, m)
+	if err != nil {
+		return nil, err
+	}
+	reportOption, err := newReportOption(fortune, drawRepo, reports)
+	if err != nil {
+		return nil, err
+	}
+
+	// 管理 API は ADMIN_AUTH を設定したときだけ公開する。投稿の削除では反応・通報・カード画像も消す
+	adminOption, err := newAdminOption(infra, m, posts, drawRepo, jobs, reports, reactions, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	routerOptions := []handler.RouterOption{
+		handler.WithTracing(tracing.GinMiddleware(APIServiceName)),
+		handler.WithMetrics(m.GinMiddleware()),
+		handler.WithHealth(
+			health.NewProbe(health.DefaultTimeout),
+			health.NewProbe(health.DefaultTimeout, apiReadinessChecks(infra)...),
+		),
+		clientIPOption,
+		rateLimitOption,
+		shareCardOption,
+		handler.WithPostEvents(handler.NewPostEventsHandler(watchPostUsecase)),
+		handler.WithReactions(handler.NewReactionHandler(reactionUsecase)),
+		reportOption,
+	}
+	if adminOption != nil {
+		routerOptions = append(routerOptions, adminOption)
+	}
 
 	return &Container{
 		Infra:              infra,
@@ -59,9 +160,22 @@ func NewContainer(ctx context.Context) (*Container, error) {
 		DrawHandler:        drawHandler,
 		CreatePostUsecase:  createPostUsecase,
 		PostHandler:        postHandler,
+		Metrics:            m,
+		RouterOptions:      routerOptions,
 	}, nil
 }
 
+/**
+ * API がリクエストを受けられる状態かの判定項目。Firestore へ問い合わせが届くかを確認する。
+ */
+func apiReadinessChecks(infra *Infra) []health.Check {
+	return []health.Check{
+		{Name: "firestore", Run: func(ctx context.Context) (string, error) {
+			return "", infra.PingFirestore(ctx)
+		}},
+	}
+}
+
 // Close は保持している外部リソースをクローズする。
 func (c *Container) Close() error {
 	if c == nil || c.Infra == nil {
@@ -128,6 +242,18 @@ func (f *failingDrawRepository) GetByPostID(ctx context.Context, postID post.Dar
 	return nil, f.err
 }
 
+func (f *failingDrawRepository) GetByShareID(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	return nil, f.err
+}
+
 func (f *failingDrawRepository) ListReady(ctx context.Context) ([]*drawdomain.Draw, error) {
 	return nil, f.err
 }
+
+func (f *failingDrawRepository) Update(ctx context.Context, d *drawdomain.Draw) error {
+	return f.err
+}
+
+func (f *failingDrawRepository) Delete(ctx context.Context, postID post.DarkPostID) error {
+	return f.err
+}
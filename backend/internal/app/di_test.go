@@ -67,3 +67,11 @@ func (stubPostRepository) ListReady(context.Context, int) ([]*post.Post, error)
 func (stubPostRepository) Update(context.Context, *post.Post) error {
 	return nil
 }
+
+func (stubPostRepository) ListByStatus(context.Context, post.Status, post.DarkPostID, int) ([]*post.Post, error) {
+	return nil, nil
+}
+
+func (stubPostRepository) Delete(context.Context, post.DarkPostID) error {
+	return nil
+}
@@ -8,11 +8,15 @@ import (
 	"strings"
 
 	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
 var errFirestoreProjectIDBlank = errors.New("firestore: GOOGLE_CLOUD_PROJECT is not set")
 
+// 疎通確認で読むコレクション。存在しなくてよく、読み取り権限だけで確認できる
+const firestorePingCollection = "health"
+
 // FirestoreConfig は Firestore クライアント初期化に必要な設定を保持する。
 type FirestoreConfig struct {
 	ProjectID       string
@@ -53,6 +57,20 @@ func (i *Infra) Firestore() *firestore.Client {
 	return i.firestoreClient
 }
 
+// PingFirestore は Firestore へ問い合わせが届くかを確認する。
+func (i *Infra) PingFirestore(ctx context.Context) error {
+	client := i.Firestore()
+	if client == nil {
+		return errFirestoreClientUnavailable
+	}
+	iter := client.Collection(firestorePingCollection).Limit(1).Documents(ctx)
+	defer iter.Stop()
+	if _, err := iter.Next(); err != nil && !errors.Is(err, iterator.Done) {
+		return fmt.Errorf("ping firestore: %w", err)
+	}
+	return nil
+}
+
 // Close は保持しているリソースを順次クローズする。
 func (i *Infra) Close() error {
 	if i == nil || i.firestoreClient == nil {
@@ -55,8 +55,8 @@ func (fakeJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostID) error
 	return nil
 }
 
-func (fakeJobQueue) DequeueFormat(ctx context.Context) (post.DarkPostID, error) {
-	return "", nil
+func (fakeJobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	return nil, nil
 }
 
 func (fakeJobQueue) Close() error {
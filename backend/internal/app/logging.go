@@ -0,0 +1,71 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	firestorelogsink "backend/internal/adapter/logsink/firestore"
+	memorylogsink "backend/internal/adapter/logsink/memory"
+	"backend/internal/config"
+	"backend/internal/logging"
+	"backend/internal/port/logsink"
+)
+
+var errLogSinkFirestoreUnavailable = errors.New("logging: Firestore クライアントが初期化されていないためデバッグ用サンプルを保存できません")
+
+/**
+ * LOG_LEVEL に従って Cloud Logging 互換の JSON ロガーを既定にする。
+ * 設定が不正な場合も info レベルで設定したうえでエラーを返す。
+ */
+func SetupLogger(w io.Writer) error {
+	cfg, err := config.LoadLoggingConfigFromEnv()
+	if err != nil {
+		logging.Setup(w, slog.LevelInfo)
+		return fmt.Errorf("load logging config: %w", err)
+	}
+	logging.Setup(w, cfg.Level)
+	return nil
+}
+
+// デバッグ用サンプルの書き込み先を組み立てる
+var logSinkFactory = newLogSink
+
+/**
+ * 環境変数に従ってログ出力方針（ハッシュのソルト、デバッグ用サンプル）を設定する。
+ */
+func configureLogging(infra *Infra) error {
+	cfg, err := config.LoadLoggingConfigFromEnv()
+	if err != nil {
+		return fmt.Errorf("load logging config: %w", err)
+	}
+	logging.SetHashSalt(cfg.HashSalt)
+	if !cfg.DebugSamples {
+		logging.DisableDebugSamples()
+		return nil
+	}
+	sink, err := logSinkFactory(cfg.DebugSink, infra)
+	if err != nil {
+		return fmt.Errorf("init debug log sink: %w", err)
+	}
+	logging.EnableDebugSamples(sink, cfg.DebugTTL)
+	slog.Warn("debug samples enabled", slog.String("sink", cfg.DebugSink), slog.Duration("ttl", cfg.DebugTTL))
+	return nil
+}
+
+/**
+ * 指定された種類のデバッグ用サンプルの書き込み先を返す。
+ */
+func newLogSink(kind string, infra *Infra) (logsink.Sink, error) {
+	switch kind {
+	case config.LogDebugSinkFirestore:
+		client := infra.Firestore()
+		if client == nil {
+			return nil, errLogSinkFirestoreUnavailable
+		}
+		return firestorelogsink.NewSink(client)
+	default:
+		return memorylogsink.NewSink(memorylogsink.DefaultCapacity), nil
+	}
+}
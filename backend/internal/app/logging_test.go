@@ -0,0 +1,46 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	memorylogsink "backend/internal/adapter/logsink/memory"
+	"backend/internal/logging"
+)
+
+func TestConfigureLogging_DebugDisabledByDefault(t *testing.T) {
+	t.Setenv("LOG_DEBUG_SAMPLES", "")
+
+	if err := configureLogging(&Infra{}); err != nil {
+		t.Fatalf("configureLogging returned error: %v", err)
+	}
+	if logging.DebugSamplesEnabled() {
+		t.Fatalf("expected debug samples to be disabled")
+	}
+}
+
+func TestConfigureLogging_EnablesMemorySink(t *testing.T) {
+	t.Setenv("LOG_DEBUG_SAMPLES", "true")
+	t.Setenv("LOG_DEBUG_SINK", "memory")
+	t.Cleanup(logging.DisableDebugSamples)
+
+	if err := configureLogging(&Infra{}); err != nil {
+		t.Fatalf("configureLogging returned error: %v", err)
+	}
+	if !logging.DebugSamplesEnabled() {
+		t.Fatalf("expected debug samples to be enabled")
+	}
+}
+
+func TestNewLogSink(t *testing.T) {
+	sink, err := newLogSink("memory", nil)
+	if err != nil {
+		t.Fatalf("newLogSink returned error: %v", err)
+	}
+	if _, ok := sink.(*memorylogsink.Sink); !ok {
+		t.Fatalf("expected memory sink, got %T", sink)
+	}
+	if _, err := newLogSink("firestore", &Infra{}); !errors.Is(err, errLogSinkFirestoreUnavailable) {
+		t.Fatalf("expected errLogSinkFirestoreUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+package app
+
+import (
+	"backend/internal/adapter/metrics"
+)
+
+// コンテナごとに専用のレジストリを持つメトリクスを作る（テストで差し替え可能）
+var metricsFactory = metrics.New
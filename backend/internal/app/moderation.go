@@ -0,0 +1,52 @@
+package app
+
+import (
+	"fmt"
+
+	"backend/internal/adapter/llm/fortune"
+	"backend/internal/adapter/moderation/chain"
+	"backend/internal/adapter/moderation/keyword"
+	openaimoderation "backend/internal/adapter/moderation/openai"
+	"backend/internal/config"
+	"backend/internal/port/llm"
+	"backend/internal/port/moderation"
+)
+
+// OpenAI Moderation API を使う判定器を作る
+var openaiModeratorFactory = func(apiKey, model string) (moderation.Moderator, error) {
+	return openaimoderation.NewModerator(apiKey, model, "")
+}
+
+// 投稿判定に使うモデレーターを組み立てる
+var moderatorFactory = newModerator
+
+// 整形結果の再確認に使うモデレーター。外部通信を増やさないよう、ローカルのキーワード判定だけを使う
+var outputModeratorFactory = func() moderation.Moderator {
+	return keyword.New()
+}
+
+/**
+ * どのプロバイダ・プロンプトの整形器でも、検証を通った整形結果を出力側のモデレーターで再確認させる。
+ */
+func moderateOutput(formatter llm.Formatter) llm.Formatter {
+	return fortune.WithModeration(formatter, outputModeratorFactory())
+}
+
+/**
+ * ローカルのキーワード判定を先頭に、設定があれば OpenAI Moderation を後ろにつないだ判定器を返す。
+ */
+func newModerator() (moderation.Moderator, error) {
+	cfg, err := config.LoadModerationConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load moderation config: %w", err)
+	}
+	local := keyword.New()
+	if !cfg.OpenAIEnabled {
+		return local, nil
+	}
+	remote, err := openaiModeratorFactory(cfg.OpenAIAPIKey, cfg.OpenAIModel)
+	if err != nil {
+		return nil, fmt.Errorf("new openai moderator: %w", err)
+	}
+	return chain.New(local, remote)
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/adapter/moderation/chain"
+	"backend/internal/adapter/moderation/keyword"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/port/llm"
+	"backend/internal/port/moderation"
+)
+
+type stubModerator struct{}
+
+func (stubModerator) Moderate(context.Context, string) (*moderation.Result, error) {
+	return &moderation.Result{}, nil
+}
+
+func TestNewModerator_LocalOnlyByDefault(t *testing.T) {
+	t.Setenv("MODERATION_OPENAI", "")
+
+	m, err := newModerator()
+	if err != nil {
+		t.Fatalf("newModerator returned error: %v", err)
+	}
+	if _, ok := m.(*keyword.Moderator); !ok {
+		t.Fatalf("expected keyword moderator, got %T", m)
+	}
+}
+
+func TestNewModerator_ChainsOpenAI(t *testing.T) {
+	t.Setenv("MODERATION_OPENAI", "true")
+	t.Setenv("OPENAI_API_KEY", "key")
+
+	called := false
+	orig := openaiModeratorFactory
+	openaiModeratorFactory = func(apiKey, model string) (moderation.Moderator, error) {
+		called = apiKey == "key"
+		return stubModerator{}, nil
+	}
+	defer func() { openaiModeratorFactory = orig }()
+
+	m, err := newModerator()
+	if err != nil {
+		t.Fatalf("newModerator returned error: %v", err)
+	}
+	if _, ok := m.(*chain.Moderator); !ok || !called {
+		t.Fatalf("expected chained moderator using openai, got %T (called=%v)", m, called)
+	}
+}
+
+func TestNewModerator_InvalidConfig(t *testing.T) {
+	t.Setenv("MODERATION_OPENAI", "true")
+	t.Setenv("OPENAI_API_KEY", "")
+
+	if _, err := newModerator(); err == nil {
+		t.Fatalf("expected error when openai moderation lacks api key")
+	}
+}
+
+func TestModerateOutput_RechecksVerifiedResults(t *testing.T) {
+	formatter := moderateOutput(&stubFormatter{})
+	result, err := formatter.Validate(context.Background(), &llm.FormatResult{
+		DarkPostID:       "post-1",
+		FormattedContent: "今日のきらくじ: 死にたい夜が続きます。",
+		Status:           drawdomain.StatusVerified,
+	})
+	if !errors.Is(err, llm.ErrContentRejected) || result.Status != drawdomain.StatusRejected {
+		t.Fatalf("expected output moderation to reject the result, got %+v %v", result, err)
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	firestorenotifier "backend/internal/adapter/notifier/firestore"
+	memorynotifier "backend/internal/adapter/notifier/memory"
+	"backend/internal/config"
+	"backend/internal/port/notifier"
+)
+
+var errNotifierFirestoreUnavailable = errors.New("notifier: Firestore クライアントが初期化されていないため進み具合を通知できません")
+
+// 投稿の進み具合の通知方式を組み立てる
+var notifierFactory = newNotifier
+
+/**
+ * 環境変数 NOTIFIER に従って投稿の進み具合の通知方式を返す。
+ * memory は同じプロセス内にしか届かないため、API と Worker を別々に動かす場合は firestore を使う。
+ */
+func newNotifier(infra *Infra) (notifier.Notifier, error) {
+	kind, err := config.LoadNotifierFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load notifier config: %w", err)
+	}
+	switch kind {
+	case config.NotifierMemory:
+		return memorynotifier.NewBroker(), nil
+	default:
+		if infra == nil || infra.Firestore() == nil {
+			return nil, errNotifierFirestoreUnavailable
+		}
+		return firestorenotifier.NewNotifier(infra.Firestore())
+	}
+}
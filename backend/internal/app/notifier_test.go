@@ -0,0 +1,29 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	memorynotifier "backend/internal/adapter/notifier/memory"
+	"backend/internal/config"
+)
+
+func TestNewNotifier_Memory(t *testing.T) {
+	t.Setenv("NOTIFIER", config.NotifierMemory)
+
+	n, err := newNotifier(&Infra{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := n.(*memorynotifier.Broker); !ok {
+		t.Fatalf("expected in-process broker, got %T", n)
+	}
+}
+
+func TestNewNotifier_FirestoreRequiresClient(t *testing.T) {
+	t.Setenv("NOTIFIER", "")
+
+	if _, err := newNotifier(&Infra{}); !errors.Is(err, errNotifierFirestoreUnavailable) {
+		t.Fatalf("expected errNotifierFirestoreUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"backend/internal/config"
+	experimentusecase "backend/internal/usecase/experiment"
+)
+
+/**
+ * プロンプト実験の集計（kirakujictl prompt-stats）向けに、コンテナの整形結果リポジトリで集計ユースケースを組み立てる。
+ * 反応を Firestore に保存している場合は、公開後の反応数もバージョンごとに集計する。
+ * 反応の設定は集計するときだけ必要なので、コンテナの生成時ではなくここで読む。
+ */
+func (c *CtlContainer) NewPromptStatsUsecase(ctx context.Context) (*experimentusecase.SummarizeUsecase, error) {
+	usecase := experimentusecase.NewSummarizeUsecase(c.outcomes)
+	// メモリの反応はこのプロセスに無いため、Firestore に保存しているときだけ読む
+	reactionConfig, err := config.LoadReactionConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load reaction config: %w", err)
+	}
+	if reactionConfig.Store == config.ReactionStoreFirestore {
+		reactions, err := reactionRepositoryFactory(c.Infra, reactionConfig)
+		if err != nil {
+			return nil, fmt.Errorf("init reaction repository: %w", err)
+		}
+		usecase.WithReactions(reactions)
+	}
+	return usecase, nil
+}
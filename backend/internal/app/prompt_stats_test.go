@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"backend/internal/adapter/repository/memory"
+	"backend/internal/config"
+	"backend/internal/port/repository"
+)
+
+func TestCtlContainer_NewPromptStatsUsecase(t *testing.T) {
+	// メモリの反応は集計に使わないため、反応の取得先は組み立てない
+	t.Setenv("REACTION_STORE", config.ReactionStoreMemory)
+	defer stubCtlFactories(t)()
+
+	container, err := NewCtlContainer(context.Background())
+	if err != nil {
+		t.Fatalf("NewCtlContainer returned error: %v", err)
+	}
+	usecase, err := container.NewPromptStatsUsecase(context.Background())
+	if err != nil {
+		t.Fatalf("NewPromptStatsUsecase returned error: %v", err)
+	}
+	summaries, err := usecase.Execute(context.Background())
+	if err != nil || len(summaries) != 0 {
+		t.Fatalf("expected empty summary, got %v %v", summaries, err)
+	}
+}
+
+func TestCtlContainer_NewPromptStatsUsecase_JoinsFirestoreReactions(t *testing.T) {
+	t.Setenv("REACTION_STORE", config.ReactionStoreFirestore)
+	defer stubCtlFactories(t)()
+
+	built := false
+	origReactions := reactionRepositoryFactory
+	reactionRepositoryFactory = func(infra *Infra, cfg *config.ReactionConfig) (repository.ReactionRepository, error) {
+		built = true
+		return memory.NewInMemoryReactionRepository(), nil
+	}
+	defer func() { reactionRepositoryFactory = origReactions }()
+
+	container, err := NewCtlContainer(context.Background())
+	if err != nil {
+		t.Fatalf("NewCtlContainer returned error: %v", err)
+	}
+	if built {
+		t.Fatalf("reaction repository should be built only when summarizing")
+	}
+	if _, err := container.NewPromptStatsUsecase(context.Background()); err != nil {
+		t.Fatalf("NewPromptStatsUsecase returned error: %v", err)
+	}
+	if !built {
+		t.Fatalf("expected reaction repository to be built")
+	}
+}
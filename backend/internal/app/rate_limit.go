@@ -0,0 +1,56 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"backend/internal/adapter/http/handler"
+	firestoreratelimit "backend/internal/adapter/ratelimit/firestore"
+	memoryratelimit "backend/internal/adapter/ratelimit/memory"
+	"backend/internal/config"
+	"backend/internal/port/ratelimit"
+)
+
+var errRateLimitFirestoreUnavailable = errors.New("rate limit: Firestore クライアントが初期化されていないため呼び出し回数を共有できません")
+
+// 呼び出し回数の保存先を組み立てる
+var rateLimitCounterFactory = newRateLimitCounter
+
+/**
+ * 環境変数に従ってルートごとの呼び出し上限を設定するルーター設定を返す。
+ * 上限は投稿・閲覧・共有カード・進み具合の購読・反応・通報で別々に数える。
+ */
+func newRateLimitOption(infra *Infra) (handler.RouterOption, error) {
+	cfg, err := config.LoadRateLimitConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load rate limit config: %w", err)
+	}
+	counter, err := rateLimitCounterFactory(cfg.Store, infra)
+	if err != nil {
+		return nil, fmt.Errorf("init rate limit counter: %w", err)
+	}
+	return handler.WithRateLimits(handler.RateLimits{
+		Posts:      handler.RateLimit(counter, handler.RateLimitPolicy{Name: "posts", PerMinute: cfg.PostsPerMinute, PerDay: cfg.PostsPerDay}),
+		Draws:      handler.RateLimit(counter, handler.RateLimitPolicy{Name: "draws", PerMinute: cfg.DrawsPerMinute, PerDay: cfg.DrawsPerDay}),
+		Cards:      handler.RateLimit(counter, handler.RateLimitPolicy{Name: "cards", PerMinute: cfg.CardsPerMinute, PerDay: cfg.CardsPerDay}),
+		PostEvents: handler.RateLimit(counter, handler.RateLimitPolicy{Name: "events", PerMinute: cfg.EventsPerMinute, PerDay: cfg.EventsPerDay}),
+		Reactions:  handler.RateLimit(counter, handler.RateLimitPolicy{Name: "reactions", PerMinute: cfg.ReactionsPerMinute, PerDay: cfg.ReactionsPerDay}),
+		Reports:    handler.RateLimit(counter, handler.RateLimitPolicy{Name: "reports", PerMinute: cfg.ReportsPerMinute, PerDay: cfg.ReportsPerDay}),
+	}), nil
+}
+
+/**
+ * 指定された種類の呼び出し回数の保存先を返す。
+ */
+func newRateLimitCounter(store string, infra *Infra) (ratelimit.Counter, error) {
+	switch store {
+	case config.RateLimitStoreFirestore:
+		client := infra.Firestore()
+		if client == nil {
+			return nil, errRateLimitFirestoreUnavailable
+		}
+		return firestoreratelimit.NewCounter(client)
+	default:
+		return memoryratelimit.NewCounter(), nil
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	firestoreadapter "backend/internal/adapter/repository/firestore"
+	"backend/internal/adapter/repository/memory"
+	"backend/internal/config"
+	"backend/internal/port/repository"
+)
+
+var errReactionFirestoreUnavailable = errors.New("reaction repository: Firestore クライアントが初期化されていません")
+
+// おみくじへの反応の保存先を組み立てる
+var reactionRepositoryFactory = newReactionRepository
+
+/**
+ * 指定された種類の反応の保存先を返す。
+ * memory はインスタンスごとに数えるため、複数台で動かす場合は firestore を使う。
+ */
+func newReactionRepository(infra *Infra, cfg *config.ReactionConfig) (repository.ReactionRepository, error) {
+	switch cfg.Store {
+	case config.ReactionStoreMemory:
+		return memory.NewInMemoryReactionRepository(), nil
+	default:
+		if infra == nil || infra.Firestore() == nil {
+			return nil, errReactionFirestoreUnavailable
+		}
+		repo, err := firestoreadapter.NewReactionRepository(infra.Firestore())
+		if err != nil {
+			return nil, fmt.Errorf("new firestore reaction repository: %w", err)
+		}
+		return repo, nil
+	}
+}
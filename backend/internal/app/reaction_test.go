@@ -0,0 +1,26 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"backend/internal/adapter/repository/memory"
+	"backend/internal/config"
+)
+
+func TestNewReactionRepository_Memory(t *testing.T) {
+	repo, err := newReactionRepository(&Infra{}, &config.ReactionConfig{Store: config.ReactionStoreMemory})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := repo.(*memory.InMemoryReactionRepository); !ok {
+		t.Fatalf("expected in-memory repository, got %T", repo)
+	}
+}
+
+func TestNewReactionRepository_FirestoreRequiresClient(t *testing.T) {
+	_, err := newReactionRepository(&Infra{}, &config.ReactionConfig{Store: config.ReactionStoreFirestore})
+	if !errors.Is(err, errReactionFirestoreUnavailable) {
+		t.Fatalf("expected errReactionFirestoreUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package app
+
+import (
+	redactionadapter "backend/internal/adapter/redaction"
+	"backend/internal/port/redaction"
+)
+
+// 外部サービスへ渡す前の伏せ字処理を組み立てる
+var redactorFactory = newRedactor
+
+/**
+ * 既定の検出器（電話番号・メール・URL・住所・学校名・人名・地名）で伏せ字処理を返す。
+ */
+func newRedactor() (redaction.Redactor, error) {
+	return redactionadapter.New(redactionadapter.DefaultDetectors()...)
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"backend/internal/adapter/http/handler"
+	"backend/internal/adapter/metrics"
+	firestoreadapter "backend/internal/adapter/repository/firestore"
+	"backend/internal/adapter/tracing"
+	"backend/internal/config"
+	"backend/internal/port/repository"
+	drawusecase "backend/internal/usecase/draw"
+	reportusecase "backend/internal/usecase/report"
+)
+
+var errReportFirestoreUnavailable = errors.New("report repository: Firestore クライアントが初期化されていません")
+
+// おみくじへの通報の保存先を組み立てる。審査に回すため API と同じ Firestore に置く
+var reportRepositoryFactory = newReportRepository
+
+/**
+ * 環境変数に従って POST /v1/draws/:id/reports を有効にするルーター設定を返す。
+ * draws には計測・トレース済みのおみくじユースケース、drawRepo には非公開にしたときの保存先、
+ * reports には管理 API と共有する計測・トレース済みの通報の保存先を渡す。
+ */
+func newReportOption(draws drawusecase.SharedDrawFinder, drawRepo repository.DrawRepository, reports repository.ReportRepository) (handler.RouterOption, error) {
+	cfg, err := config.LoadReportConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load report config: %w", err)
+	}
+	usecase := reportusecase.NewReportUsecase(draws, drawRepo, reports).WithHideThreshold(cfg.HideThreshold)
+	return handler.WithReports(handler.NewReportHandler(usecase)), nil
+}
+
+/**
+ * 通報の保存先を計測・トレースで包んで返す。
+ */
+func newInstrumentedReportRepository(infra *Infra, m *metrics.Metrics) (repository.ReportRepository, error) {
+	reports, err := reportRepositoryFactory(infra)
+	if err != nil {
+		return nil, fmt.Errorf("init report repository: %w", err)
+	}
+	return m.InstrumentReportRepository(tracing.InstrumentReportRepository(reports)), nil
+}
+
+/**
+ * Firestore 固定の通報リポジトリを返す。
+ */
+func newReportRepository(infra *Infra) (repository.ReportRepository, error) {
+	if infra == nil || infra.Firestore() == nil {
+		return nil, errReportFirestoreUnavailable
+	}
+	return firestoreadapter.NewReportRepository(infra.Firestore())
+}
@@ -0,0 +1,12 @@
+package app
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewReportRepository_RequiresFirestoreClient(t *testing.T) {
+	if _, err := newReportRepository(&Infra{}); !errors.Is(err, errReportFirestoreUnavailable) {
+		t.Fatalf("expected errReportFirestoreUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+
+	filesystemblob "backend/internal/adapter/blob/filesystem"
+	memoryblob "backend/internal/adapter/blob/memory"
+	"backend/internal/adapter/card"
+	"backend/internal/adapter/http/handler"
+	"backend/internal/config"
+	"backend/internal/port/blob"
+	cardusecase "backend/internal/usecase/card"
+	drawusecase "backend/internal/usecase/draw"
+)
+
+// 描いた共有カード画像の保存先を組み立てる
+var shareCardStoreFactory = newShareCardStore
+
+/**
+ * 環境変数に従って GET /v1/draws/:id/card.png を有効にするルーター設定と、カード画像のユースケースを返す。
+ * draws には計測・トレース済みのおみくじユースケースを渡す。ユースケースは投稿の削除時に保存済みの画像を消すのにも使う。
+ */
+func newShareCardOption(draws drawusecase.SharedDrawFinder) (handler.RouterOption, *cardusecase.ShareCardUsecase, error) {
+	cfg, err := config.LoadShareCardConfigFromEnv()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load share card config: %w", err)
+	}
+	store, err := shareCardStoreFactory(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("init share card store: %w", err)
+	}
+	renderer, err := card.NewRenderer()
+	if err != nil {
+		return nil, nil, fmt.Errorf("init share card renderer: %w", err)
+	}
+	cards := cardusecase.NewShareCardUsecase(draws, renderer, store)
+	return handler.WithShareCards(handler.NewCardHandler(cards)), cards, nil
+}
+
+/**
+ * 指定された種類の画像の保存先を返す。
+ */
+func newShareCardStore(cfg *config.ShareCardConfig) (blob.Store, error) {
+	switch cfg.Store {
+	case config.ShareCardStoreFilesystem:
+		return filesystemblob.NewStore(cfg.Dir)
+	default:
+		return memoryblob.NewStore(cfg.MemoryMaxBytes), nil
+	}
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"backend/internal/adapter/tracing"
+	"backend/internal/config"
+)
+
+// トレースに載せるサービス名（OTEL_SERVICE_NAME があればそちらが優先される）
+const (
+	APIServiceName    = "kiraku-ji-api"
+	WorkerServiceName = "kiraku-ji-worker"
+)
+
+/**
+ * OTEL_TRACES_EXPORTER に従ってトレースの送り先を設定し、終了時に未送信のスパンを送り切る関数を返す。
+ * 未設定なら何も送らず、トレースコンテキストの受け渡しだけを行う。
+ */
+func SetupTracing(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	cfg, err := config.LoadTracingConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load tracing config: %w", err)
+	}
+	shutdown, err := tracing.Setup(ctx, cfg.Exporter, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("setup tracing: %w", err)
+	}
+	return shutdown, nil
+}
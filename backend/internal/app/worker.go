@@ -4,14 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
+	anthropicFormatter "backend/internal/adapter/llm/anthropic"
+	"backend/internal/adapter/llm/circuit"
+	"backend/internal/adapter/llm/experiment"
 	"backend/internal/adapter/llm/gemini"
+	ollamaFormatter "backend/internal/adapter/llm/ollama"
 	openaiFormatter "backend/internal/adapter/llm/openai"
+	"backend/internal/adapter/llm/prompt"
 	repoFirestore "backend/internal/adapter/repository/firestore"
+	"backend/internal/adapter/tracing"
 	"backend/internal/config"
+	"backend/internal/health"
 	"backend/internal/port/llm"
 	"backend/internal/port/queue"
 	"backend/internal/port/repository"
@@ -25,7 +34,12 @@ type WorkerContainer struct {
 	DrawRepo             repository.DrawRepository
 	JobQueue             queue.JobQueue
 	Formatter            llm.Formatter
+	OutcomeRepo          repository.OutcomeRepository
 	FormatPendingUsecase *worker.FormatPendingUsecase
+	MetricsHandler       http.Handler
+	Heartbeat            *health.Heartbeat
+	llmCircuit           *circuit.Formatter
+	heartbeatMaxAge      time.Duration
 	closeFormatter       func() error
 	closeInfra           func() error
 }
@@ -33,30 +47,118 @@ type WorkerContainer struct {
 var formatterCtor = gemini.NewFormatter
 
 // OpenAI 用の整形器を作り、後片付け手順もあわせて返す
-var openaiFormatterFactory = func(apiKey, model, baseURL string) (llm.Formatter, func() error, error) {
+var openaiFormatterFactory = func(apiKey, model, baseURL string, tmpl *prompt.Template) (llm.Formatter, func() error, error) {
 	formatter, err := openaiFormatter.NewFormatter(apiKey, model, baseURL)
 	if err != nil {
 		return nil, nil, err
 	}
-	return formatter, formatter.Close, nil
+	return formatter.WithPrompt(tmpl), formatter.Close, nil
 }
 
-// 環境変数 LLM_PROVIDER に応じて利用する整形器を切り替える
+// Anthropic (Claude) 用の整形器を作り、後片付け手順もあわせて返す
+var anthropicFormatterFactory = func(apiKey, model string, tmpl *prompt.Template) (llm.Formatter, func() error, error) {
+	formatter, err := anthropicFormatter.NewFormatter(apiKey, model)
+	if err != nil {
+		return nil, nil, err
+	}
+	return formatter.WithPrompt(tmpl), formatter.Close, nil
+}
+
+// ローカル LLM（Ollama 互換）用の整形器を作り、後片付け手順もあわせて返す
+var ollamaFormatterFactory = func(host, model string, options map[string]any, timeout time.Duration, tmpl *prompt.Template) (llm.Formatter, func() error, error) {
+	formatter, err := ollamaFormatter.NewFormatter(host, model, options, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return formatter.WithPrompt(tmpl), formatter.Close, nil
+}
+
+// バージョンやファイルパスから整形に使うプロンプトテンプレートを読み込む
+var promptTemplateLoader = prompt.Load
+
+// 環境変数 LLM_PROVIDER に応じて利用する整形器を切り替え、
+// PROMPT_VARIANTS が指定されていればバリアントごとの整形器を束ねた A/B 実験にする
 var formatterFactory = func(ctx context.Context) (llm.Formatter, func() error, error) {
-	switch config.LoadLLMProvider() {
-	case "gemini":
-		return newGeminiFormatter(ctx)
-	case "openai":
-		fallthrough
-	default:
-		return newOpenAIFormatter()
+	cfg, err := config.LoadPromptConfigFromEnv()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load prompt config: %w", err)
+	}
+	provider := config.LoadLLMProvider()
+	if len(cfg.Variants) > 0 {
+		return newExperimentFormatter(ctx, provider, cfg.Variants)
 	}
+	// 指定が無ければ nil のまま渡し、各プロバイダが従来使っていたプロンプトを使わせる
+	var tmpl *prompt.Template
+	if cfg.Version != "" || cfg.Path != "" {
+		// プロンプトはプロバイダに依存しないので先に読み込み、不正な指定なら起動を止める
+		if tmpl, err = promptTemplateLoader(cfg.Version, cfg.Path); err != nil {
+			return nil, nil, fmt.Errorf("load prompt template: %w", err)
+		}
+	}
+	return newProviderFormatter(ctx, provider, tmpl)
 }
 var postRepositoryFactory = newPostRepository
 var drawRepositoryFactory = newDrawRepository
+var outcomeRepositoryFactory = newOutcomeRepository
 var infraFactory = NewInfra
 var errWorkerFirestoreEnvMissing = errors.New("worker: Firestore 環境変数が未設定です")
 
+/**
+ * 指定プロバイダの整形器を、渡されたプロンプトテンプレートで構築する。
+ */
+func newProviderFormatter(ctx context.Context, provider string, tmpl *prompt.Template) (llm.Formatter, func() error, error) {
+	switch provider {
+	case "gemini":
+		return newGeminiFormatter(ctx, tmpl)
+	case "ollama":
+		return newOllamaFormatter(tmpl)
+	case "anthropic":
+		return newAnthropicFormatter(tmpl)
+	case "openai":
+		fallthrough
+	default:
+		return newOpenAIFormatter(tmpl)
+	}
+}
+
+/**
+ * バリアントごとにプロンプトを差し替えた整形器を作り、投稿 ID で振り分ける実験用整形器にまとめる。
+ * 途中で失敗した場合は作成済みの整形器を閉じてから返す。
+ */
+func newExperimentFormatter(ctx context.Context, provider string, variants []config.PromptVariant) (llm.Formatter, func() error, error) {
+	var (
+		arms    []experiment.Variant
+		closers []func() error
+	)
+	closeAll := func() error {
+		var retErr error
+		for _, closeFn := range closers {
+			retErr = mergeCloseError(retErr, "experiment formatter", closeFn)
+		}
+		return retErr
+	}
+	for _, v := range variants {
+		tmpl, err := promptTemplateLoader(v.Version, "")
+		if err != nil {
+			_ = closeAll()
+			return nil, nil, fmt.Errorf("load prompt variant %s: %w", v.Version, err)
+		}
+		formatter, closeFn, err := newProviderFormatter(ctx, provider, tmpl)
+		if err != nil {
+			_ = closeAll()
+			return nil, nil, err
+		}
+		closers = append(closers, closeFn)
+		arms = append(arms, experiment.Variant{Version: tmpl.Version(), Weight: v.Weight, Formatter: formatter})
+	}
+	formatter, err := experiment.NewFormatter(arms)
+	if err != nil {
+		_ = closeAll()
+		return nil, nil, fmt.Errorf("new experiment formatter: %w", err)
+	}
+	return formatter, closeAll, nil
+}
+
 /**
  * ワーカー稼働に必要なインフラ、LLM、キューなどを整えて返す。
  */
@@ -71,6 +173,10 @@ func NewWorkerContainer(ctx context.Context) (*WorkerContainer, error) {
 	if err != nil {
 		return nil, fmt.Errorf("init infra: %w", err)
 	}
+	// 本文をログに出さない方針と、任意のデバッグ用サンプルの書き込み先を設定する
+	if err := configureLogging(infra); err != nil {
+		return nil, err
+	}
 
 	postRepo, err := postRepositoryFactory(ctx, infra)
 	if err != nil {
@@ -88,21 +194,72 @@ func NewWorkerContainer(ctx context.Context) (*WorkerContainer, error) {
 		return nil, fmt.Errorf("init job queue: %w", err)
 	}
 
+	// プロンプト実験の集計に使う整形結果の記録先
+	outcomeRepo, err := outcomeRepositoryFactory(ctx, infra)
+	if err != nil {
+		return nil, fmt.Errorf("init outcome repository: %w", err)
+	}
+
+	// LLM へは個人情報を伏せた本文だけを渡す
+	redactor, err := redactorFactory()
+	if err != nil {
+		return nil, fmt.Errorf("init redactor: %w", err)
+	}
+
+	// 死活判定の基準と、LLM への接続失敗が続いたときに呼び出しを止める条件
+	healthCfg, err := config.LoadWorkerHealthConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load worker health config: %w", err)
+	}
+
+	// 整形の進み具合を API の SSE へ知らせる
+	postNotifier, err := notifierFactory(infra)
+	if err != nil {
+		return nil, fmt.Errorf("init notifier: %w", err)
+	}
+
 	// どの LLM プロバイダを使うかは formatterFactory が環境変数から判断する
 	formatter, closeFormatter, err := formatterFactory(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("init formatter: %w", err)
 	}
+	llmCircuit := circuit.NewFormatter(moderateOutput(formatter), healthCfg.LLMCircuitThreshold, healthCfg.LLMCircuitCooldown)
+	formatter = llmCircuit
 
-	usecase := worker.NewFormatPendingUsecase(postRepo, drawRepo, formatter, jobQueue)
+	// 業務コードへは持ち込まず、ポートを包むデコレーターで計測・トレースする
+	// ジョブの取り出しはループ側で行うため、キューと整形器は計測済みのものをコンテナに残す
+	m := metricsFactory()
+	provider := config.LoadLLMProvider()
+	jobQueue = m.InstrumentJobQueue(tracing.InstrumentJobQueue(jobQueue))
+	formatter = m.InstrumentFormatter(tracing.InstrumentFormatter(formatter, provider), provider)
+	if reporter, ok := jobQueue.(queue.DepthReporter); ok {
+		if err := m.RegisterQueueDepth(reporter); err != nil {
+			return nil, fmt.Errorf("register queue depth metric: %w", err)
+		}
+	}
+
+	usecase := worker.NewFormatPendingUsecase(
+		m.InstrumentPostRepository(tracing.InstrumentPostRepository(postRepo)),
+		m.InstrumentDrawRepository(tracing.InstrumentDrawRepository(drawRepo)),
+		formatter,
+		jobQueue,
+	).
+		WithOutcomes(tracing.InstrumentOutcomeRepository(outcomeRepo)).
+		WithRedactor(redactor).
+		WithNotifier(postNotifier)
 
 	container := &WorkerContainer{
 		Infra:                infra,
 		PostRepo:             postRepo,
 		DrawRepo:             drawRepo,
 		JobQueue:             jobQueue,
+		OutcomeRepo:          outcomeRepo,
 		Formatter:            formatter,
 		FormatPendingUsecase: usecase,
+		MetricsHandler:       m.Handler(),
+		Heartbeat:            health.NewHeartbeat(),
+		llmCircuit:           llmCircuit,
+		heartbeatMaxAge:      healthCfg.HeartbeatMaxAge,
 		closeFormatter:       closeFormatter,
 	}
 	if infra != nil {
@@ -136,7 +293,7 @@ func mergeCloseError(current error, label string, fn func() error) error {
 	}
 	// 後片付けの失敗はログに残しつつ先頭エラーを優先
 	if err := fn(); err != nil {
-		log.Printf("%s close error: %v", label, err)
+		slog.Error("close error", slog.String("resource", label), slog.Any("error", err))
 		if current == nil {
 			return err
 		}
@@ -147,7 +304,7 @@ func mergeCloseError(current error, label string, fn func() error) error {
 /**
  * 環境変数から Gemini の鍵とモデルを読み込み、整形器とクローズ関数を返す。
  */
-func newGeminiFormatter(ctx context.Context) (llm.Formatter, func() error, error) {
+func newGeminiFormatter(ctx context.Context, tmpl *prompt.Template) (llm.Formatter, func() error, error) {
 	// 鍵とモデル指定に不足がないかを先に確かめる
 	cfg, err := config.LoadGeminiConfigFromEnv()
 	if err != nil {
@@ -158,26 +315,58 @@ func newGeminiFormatter(ctx context.Context) (llm.Formatter, func() error, error
 	if err != nil {
 		return nil, nil, fmt.Errorf("new gemini formatter: %w", err)
 	}
-	return formatter, formatter.Close, nil
+	return formatter.WithPrompt(tmpl), formatter.Close, nil
 }
 
 /**
  * OpenAI 用の設定を取り込み、API クライアントを包んだ整形器を作る。
  */
-func newOpenAIFormatter() (llm.Formatter, func() error, error) {
+func newOpenAIFormatter(tmpl *prompt.Template) (llm.Formatter, func() error, error) {
 	// OpenAI 側の鍵やモデル、任意 BaseURL を取得
 	cfg, err := config.LoadOpenAIConfigFromEnv()
 	if err != nil {
 		return nil, nil, fmt.Errorf("load openai config: %w", err)
 	}
 	// SDK から生成した整形器とクローズ処理を返す
-	formatter, closeFn, err := openaiFormatterFactory(cfg.APIKey, cfg.Model, cfg.BaseURL)
+	formatter, closeFn, err := openaiFormatterFactory(cfg.APIKey, cfg.Model, cfg.BaseURL, tmpl)
 	if err != nil {
 		return nil, nil, fmt.Errorf("new openai formatter: %w", err)
 	}
 	return formatter, closeFn, nil
 }
 
+/**
+ * 環境変数から Anthropic の鍵とモデルを読み込み、Messages API を包んだ整形器を作る。
+ */
+func newAnthropicFormatter(tmpl *prompt.Template) (llm.Formatter, func() error, error) {
+	// 鍵とモデル指定に不足がないかを先に確かめる
+	cfg, err := config.LoadAnthropicConfigFromEnv()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load anthropic config: %w", err)
+	}
+	formatter, closeFn, err := anthropicFormatterFactory(cfg.APIKey, cfg.Model, tmpl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new anthropic formatter: %w", err)
+	}
+	return formatter, closeFn, nil
+}
+
+/**
+ * ローカル LLM の接続先やモデルを取り込み、Ollama 互換サーバー向けの整形器を作る。
+ */
+func newOllamaFormatter(tmpl *prompt.Template) (llm.Formatter, func() error, error) {
+	// 接続先・モデル・生成オプション・タイムアウトを取得（未設定は既定値）
+	cfg, err := config.LoadOllamaConfigFromEnv()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load ollama config: %w", err)
+	}
+	formatter, closeFn, err := ollamaFormatterFactory(cfg.Host, cfg.Model, cfg.Options, cfg.Timeout, tmpl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new ollama formatter: %w", err)
+	}
+	return formatter, closeFn, nil
+}
+
 /**
  * Firestore 固定の投稿リポジトリを構築する。
  */
@@ -209,6 +398,20 @@ func newDrawRepository(ctx context.Context, infra *Infra) (repository.DrawReposi
 	return repo, nil
 }
 
+/**
+ * Firestore 固定の整形結果リポジトリを構築する。
+ */
+func newOutcomeRepository(ctx context.Context, infra *Infra) (repository.OutcomeRepository, error) {
+	if infra == nil || infra.Firestore() == nil {
+		return nil, errFirestoreClientUnavailable
+	}
+	repo, err := repoFirestore.NewOutcomeRepository(infra.Firestore())
+	if err != nil {
+		return nil, fmt.Errorf("new firestore outcome repository: %w", err)
+	}
+	return repo, nil
+}
+
 /**
  * Worker 起動に必須な Firestore 環境変数を検証する。
  */
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+
+	"backend/internal/adapter/llm/circuit"
+	"backend/internal/health"
+)
+
+/**
+ * ワーカーを再起動すべきかの判定項目。ジョブ取り出しのループが止まっていないかだけを見る。
+ * 外部サービスの不調で再起動を繰り返さないよう、Firestore や LLM は含めない。
+ */
+func (c *WorkerContainer) LivenessChecks() []health.Check {
+	return []health.Check{c.Heartbeat.Check("loop", c.heartbeatMaxAge)}
+}
+
+/**
+ * ワーカーがジョブを処理できる状態かの判定項目。
+ * ループの鼓動、Firestore への疎通、LLM 呼び出しを止めていないか、最後にジョブを処理し終えた時刻を返す。
+ */
+func (c *WorkerContainer) ReadinessChecks() []health.Check {
+	return []health.Check{
+		c.Heartbeat.Check("loop", c.heartbeatMaxAge),
+		{Name: "firestore", Run: func(ctx context.Context) (string, error) {
+			return "", c.Infra.PingFirestore(ctx)
+		}},
+		{Name: "llm_circuit", Run: func(context.Context) (string, error) {
+			state := c.llmCircuit.State()
+			if state == circuit.StateOpen {
+				return state, circuit.ErrCircuitOpen
+			}
+			return state, nil
+		}},
+		c.Heartbeat.LastSuccessCheck("last_success"),
+	}
+}
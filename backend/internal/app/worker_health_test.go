@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/adapter/llm/circuit"
+	"backend/internal/health"
+	"backend/internal/port/llm"
+	workertestutil "backend/internal/usecase/worker/testutil"
+)
+
+/**
+ * Firestore に届かない、または LLM 呼び出しを止めている間は準備未完了になることを確認する。
+ */
+func TestWorkerContainer_ReadinessChecks(t *testing.T) {
+	stub := &workertestutil.StubFormatter{FormatErr: llm.ErrFormatterUnavailable}
+	container := &WorkerContainer{
+		Infra:           &Infra{},
+		Heartbeat:       health.NewHeartbeat(),
+		llmCircuit:      circuit.NewFormatter(stub, 1, time.Minute),
+		heartbeatMaxAge: time.Minute,
+	}
+
+	report := health.NewProbe(time.Second, container.ReadinessChecks()...).Run(context.Background())
+	if report.Status != health.StatusFail {
+		t.Fatalf("expected readiness to fail without Firestore: %+v", report)
+	}
+	for name, want := range map[string]string{"loop": health.StatusOK, "firestore": health.StatusFail, "llm_circuit": health.StatusOK, "last_success": health.StatusOK} {
+		if got := report.Checks[name].Status; got != want {
+			t.Fatalf("%s = %s, want %s", name, got, want)
+		}
+	}
+
+	_, _ = container.llmCircuit.Format(context.Background(), &llm.FormatRequest{})
+	report = health.NewProbe(time.Second, container.ReadinessChecks()...).Run(context.Background())
+	if got := report.Checks["llm_circuit"]; got.Status != health.StatusFail || got.Detail != circuit.StateOpen {
+		t.Fatalf("expected open circuit to fail readiness: %+v", got)
+	}
+
+	// 再起動の判定には外部サービスの状態を含めない
+	live := health.NewProbe(time.Second, container.LivenessChecks()...).Run(context.Background())
+	if live.Status != health.StatusOK || len(live.Checks) != 1 {
+		t.Fatalf("unexpected liveness report: %+v", live)
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"google.golang.org/api/option"
 
+	"backend/internal/adapter/llm/experiment"
 	"backend/internal/adapter/llm/gemini"
+	"backend/internal/adapter/llm/prompt"
+	"backend/internal/config"
 	"backend/internal/domain/post"
 	"backend/internal/port/llm"
 	"backend/internal/port/queue"
@@ -18,6 +22,8 @@ import (
 
 func TestNewWorkerContainer_UsesFirestoreRepository(t *testing.T) {
 	setRequiredFirestoreEnv(t)
+	// テスト用の Infra は Firestore クライアントを持たないため、同じプロセス内の通知にする
+	t.Setenv("NOTIFIER", config.NotifierMemory)
 	defer stubJobQueueFactory(t)()
 
 	stubFormatter := &stubFormatter{}
@@ -36,6 +42,8 @@ func TestNewWorkerContainer_UsesFirestoreRepository(t *testing.T) {
 
 	stubDrawRepo := &workertestutil.StubDrawRepository{}
 	defer stubDrawRepositoryFactory(t, stubDrawRepo, nil)()
+	stubOutcomeRepo := &workertestutil.StubOutcomeRepository{}
+	defer stubOutcomeRepositoryFactory(t, stubOutcomeRepo, nil)()
 
 	origInfraFactory := infraFactory
 	infraFactory = func(ctx context.Context) (*Infra, error) {
@@ -53,6 +61,9 @@ func TestNewWorkerContainer_UsesFirestoreRepository(t *testing.T) {
 	if container.DrawRepo != stubDrawRepo {
 		t.Fatalf("expected draw repository stub to be used")
 	}
+	if container.OutcomeRepo != stubOutcomeRepo {
+		t.Fatalf("expected outcome repository stub to be used")
+	}
 	if err := container.Close(); err != nil {
 		t.Fatalf("close returned error: %v", err)
 	}
@@ -72,7 +83,7 @@ func TestFormatterFactory_UsesCtor(t *testing.T) {
 	t.Setenv("GEMINI_API_KEY", "key")
 	t.Setenv("GEMINI_MODEL", "model")
 
-	f, closer, err := newGeminiFormatter(context.Background())
+	f, closer, err := newGeminiFormatter(context.Background(), nil)
 	if err != nil {
 		t.Fatalf("newGeminiFormatter returned error: %v", err)
 	}
@@ -130,6 +141,7 @@ func TestNewWorkerContainer_FormatterFactoryError(t *testing.T) {
 	setRequiredFirestoreEnv(t)
 	defer stubJobQueueFactory(t)()
 	defer stubDrawRepositoryFactory(t, &workertestutil.StubDrawRepository{}, nil)()
+	defer stubOutcomeRepositoryFactory(t, &workertestutil.StubOutcomeRepository{}, nil)()
 
 	origInfra := infraFactory
 	infraFactory = func(ctx context.Context) (*Infra, error) {
@@ -310,7 +322,7 @@ func TestNewOpenAIFormatter_Success(t *testing.T) {
 
 	stub := &stubFormatter{}
 	origFactory := openaiFormatterFactory
-	openaiFormatterFactory = func(apiKey, model, baseURL string) (llm.Formatter, func() error, error) {
+	openaiFormatterFactory = func(apiKey, model, baseURL string, _ *prompt.Template) (llm.Formatter, func() error, error) {
 		if apiKey != "test-key" {
 			t.Fatalf("unexpected api key: %s", apiKey)
 		}
@@ -321,7 +333,7 @@ func TestNewOpenAIFormatter_Success(t *testing.T) {
 	}
 	defer func() { openaiFormatterFactory = origFactory }()
 
-	formatter, closer, err := newOpenAIFormatter()
+	formatter, closer, err := newOpenAIFormatter(nil)
 	if err != nil {
 		t.Fatalf("newOpenAIFormatter returned error: %v", err)
 	}
@@ -335,11 +347,251 @@ func TestNewOpenAIFormatter_Success(t *testing.T) {
 
 func TestNewOpenAIFormatter_MissingConfig(t *testing.T) {
 	t.Setenv("OPENAI_API_KEY", "")
-	if _, _, err := newOpenAIFormatter(); err == nil {
+	if _, _, err := newOpenAIFormatter(nil); err == nil {
 		t.Fatalf("expected error when OPENAI_API_KEY is missing")
 	}
 }
 
+func TestNewAnthropicFormatter_Success(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	t.Setenv("ANTHROPIC_MODEL", "")
+
+	stub := &stubFormatter{}
+	origFactory := anthropicFormatterFactory
+	anthropicFormatterFactory = func(apiKey, model string, _ *prompt.Template) (llm.Formatter, func() error, error) {
+		if apiKey != "test-key" {
+			t.Fatalf("unexpected api key: %s", apiKey)
+		}
+		if model != config.DefaultAnthropicModel {
+			t.Fatalf("expected default model, got %s", model)
+		}
+		return stub, stub.Close, nil
+	}
+	defer func() { anthropicFormatterFactory = origFactory }()
+
+	formatter, closer, err := newAnthropicFormatter(nil)
+	if err != nil {
+		t.Fatalf("newAnthropicFormatter returned error: %v", err)
+	}
+	if formatter != stub || closer == nil {
+		t.Fatalf("expected stub formatter with close function")
+	}
+}
+
+func TestNewAnthropicFormatter_MissingConfig(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	if _, _, err := newAnthropicFormatter(nil); err == nil {
+		t.Fatalf("expected error when ANTHROPIC_API_KEY is missing")
+	}
+}
+
+func TestFormatterFactory_SelectsAnthropic(t *testing.T) {
+	t.Setenv("LLM_PROVIDER", "anthropic")
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+
+	stub := &stubFormatter{}
+	origFactory := anthropicFormatterFactory
+	anthropicFormatterFactory = func(string, string, *prompt.Template) (llm.Formatter, func() error, error) {
+		return stub, stub.Close, nil
+	}
+	defer func() { anthropicFormatterFactory = origFactory }()
+
+	formatter, _, err := formatterFactory(context.Background())
+	if err != nil {
+		t.Fatalf("formatterFactory returned error: %v", err)
+	}
+	if formatter != stub {
+		t.Fatalf("expected anthropic formatter to be selected")
+	}
+}
+
+func TestNewOllamaFormatter_Success(t *testing.T) {
+	t.Setenv("OLLAMA_HOST", "http://ollama:11434")
+	t.Setenv("OLLAMA_MODEL", "")
+	t.Setenv("OLLAMA_OPTIONS", `{"temperature":0.1}`)
+	t.Setenv("OLLAMA_TIMEOUT", "15s")
+
+	stub := &stubFormatter{}
+	origFactory := ollamaFormatterFactory
+	ollamaFormatterFactory = func(host, model string, options map[string]any, timeout time.Duration, _ *prompt.Template) (llm.Formatter, func() error, error) {
+		if host != "http://ollama:11434" {
+			t.Fatalf("unexpected host: %s", host)
+		}
+		if model != config.DefaultOllamaModel {
+			t.Fatalf("expected default model, got %s", model)
+		}
+		if options["temperature"] != 0.1 {
+			t.Fatalf("unexpected options: %v", options)
+		}
+		if timeout != 15*time.Second {
+			t.Fatalf("unexpected timeout: %s", timeout)
+		}
+		return stub, stub.Close, nil
+	}
+	defer func() { ollamaFormatterFactory = origFactory }()
+
+	formatter, closer, err := newOllamaFormatter(nil)
+	if err != nil {
+		t.Fatalf("newOllamaFormatter returned error: %v", err)
+	}
+	if formatter != stub || closer == nil {
+		t.Fatalf("expected stub formatter with close function")
+	}
+}
+
+func TestNewOllamaFormatter_InvalidConfig(t *testing.T) {
+	t.Setenv("OLLAMA_TIMEOUT", "-1s")
+	if _, _, err := newOllamaFormatter(nil); err == nil {
+		t.Fatalf("expected error when OLLAMA_TIMEOUT is invalid")
+	}
+}
+
+func TestFormatterFactory_SelectsOllama(t *testing.T) {
+	t.Setenv("LLM_PROVIDER", "ollama")
+
+	stub := &stubFormatter{}
+	origFactory := ollamaFormatterFactory
+	ollamaFormatterFactory = func(string, string, map[string]any, time.Duration, *prompt.Template) (llm.Formatter, func() error, error) {
+		return stub, stub.Close, nil
+	}
+	defer func() { ollamaFormatterFactory = origFactory }()
+
+	formatter, _, err := formatterFactory(context.Background())
+	if err != nil {
+		t.Fatalf("formatterFactory returned error: %v", err)
+	}
+	if formatter != stub {
+		t.Fatalf("expected ollama formatter to be selected")
+	}
+}
+
+func TestFormatterFactory_PassesPromptTemplate(t *testing.T) {
+	t.Setenv("LLM_PROVIDER", "ollama")
+	t.Setenv("PROMPT_VERSION", "menhera-v1")
+	t.Setenv("PROMPT_TEMPLATE_PATH", "")
+
+	var got *prompt.Template
+	origFactory := ollamaFormatterFactory
+	ollamaFormatterFactory = func(_ string, _ string, _ map[string]any, _ time.Duration, tmpl *prompt.Template) (llm.Formatter, func() error, error) {
+		got = tmpl
+		return &stubFormatter{}, nil, nil
+	}
+	defer func() { ollamaFormatterFactory = origFactory }()
+
+	if _, _, err := formatterFactory(context.Background()); err != nil {
+		t.Fatalf("formatterFactory returned error: %v", err)
+	}
+	if got == nil || got.Version() != "menhera-v1" {
+		t.Fatalf("expected menhera-v1 template to be passed, got %v", got)
+	}
+}
+
+func TestFormatterFactory_KeepsProviderPromptByDefault(t *testing.T) {
+	t.Setenv("LLM_PROVIDER", "ollama")
+	t.Setenv("PROMPT_VERSION", "")
+	t.Setenv("PROMPT_TEMPLATE_PATH", "")
+	t.Setenv("PROMPT_VARIANTS", "")
+
+	got := prompt.Default()
+	origFactory := ollamaFormatterFactory
+	ollamaFormatterFactory = func(_ string, _ string, _ map[string]any, _ time.Duration, tmpl *prompt.Template) (llm.Formatter, func() error, error) {
+		got = tmpl
+		return &stubFormatter{}, nil, nil
+	}
+	defer func() { ollamaFormatterFactory = origFactory }()
+
+	if _, _, err := formatterFactory(context.Background()); err != nil {
+		t.Fatalf("formatterFactory returned error: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("expected no template so the provider keeps its own prompt, got %s", got.Version())
+	}
+}
+
+func TestFormatterFactory_UnknownPromptVersion(t *testing.T) {
+	t.Setenv("LLM_PROVIDER", "ollama")
+	t.Setenv("PROMPT_VERSION", "does-not-exist")
+	t.Setenv("PROMPT_TEMPLATE_PATH", "")
+
+	if _, _, err := formatterFactory(context.Background()); !errors.Is(err, prompt.ErrUnknownVersion) {
+		t.Fatalf("expected unknown version error, got %v", err)
+	}
+}
+
+func TestNewWorkerContainer_OutcomeRepoError(t *testing.T) {
+	setRequiredFirestoreEnv(t)
+	defer stubJobQueueFactory(t)()
+	defer stubDrawRepositoryFactory(t, &workertestutil.StubDrawRepository{}, nil)()
+
+	origInfra := infraFactory
+	infraFactory = func(ctx context.Context) (*Infra, error) {
+		return &Infra{}, nil
+	}
+	defer func() { infraFactory = origInfra }()
+
+	origRepoFactory := postRepositoryFactory
+	postRepositoryFactory = func(ctx context.Context, infra *Infra) (repository.PostRepository, error) {
+		return &workerStubPostRepository{}, nil
+	}
+	defer func() { postRepositoryFactory = origRepoFactory }()
+
+	outcomeErr := errors.New("outcome repo error")
+	defer stubOutcomeRepositoryFactory(t, nil, outcomeErr)()
+
+	if _, err := NewWorkerContainer(context.Background()); !errors.Is(err, outcomeErr) {
+		t.Fatalf("expected outcome repository error, got %v", err)
+	}
+}
+
+func TestFormatterFactory_BuildsExperimentFromVariants(t *testing.T) {
+	t.Setenv("LLM_PROVIDER", "ollama")
+	t.Setenv("PROMPT_VARIANTS", "fortune-v1:50,menhera-v1:50")
+
+	var (
+		versions []string
+		closed   int
+	)
+	origFactory := ollamaFormatterFactory
+	ollamaFormatterFactory = func(_ string, _ string, _ map[string]any, _ time.Duration, tmpl *prompt.Template) (llm.Formatter, func() error, error) {
+		versions = append(versions, tmpl.Version())
+		return &stubFormatter{}, func() error { closed++; return nil }, nil
+	}
+	defer func() { ollamaFormatterFactory = origFactory }()
+
+	formatter, closer, err := formatterFactory(context.Background())
+	if err != nil {
+		t.Fatalf("formatterFactory returned error: %v", err)
+	}
+	if _, ok := formatter.(*experiment.Formatter); !ok {
+		t.Fatalf("expected experiment formatter, got %T", formatter)
+	}
+	if len(versions) != 2 || versions[0] != "fortune-v1" || versions[1] != "menhera-v1" {
+		t.Fatalf("expected one formatter per variant, got %v", versions)
+	}
+	if err := closer(); err != nil || closed != 2 {
+		t.Fatalf("expected all variant formatters to be closed, closed=%d err=%v", closed, err)
+	}
+}
+
+func TestFormatterFactory_ExperimentUnknownVariant(t *testing.T) {
+	t.Setenv("LLM_PROVIDER", "ollama")
+	t.Setenv("PROMPT_VARIANTS", "fortune-v1:50,missing-v9:50")
+
+	closed := 0
+	origFactory := ollamaFormatterFactory
+	ollamaFormatterFactory = func(string, string, map[string]any, time.Duration, *prompt.Template) (llm.Formatter, func() error, error) {
+		return &stubFormatter{}, func() error { closed++; return nil }, nil
+	}
+	defer func() { ollamaFormatterFactory = origFactory }()
+
+	if _, _, err := formatterFactory(context.Background()); !errors.Is(err, prompt.ErrUnknownVersion) {
+		t.Fatalf("expected unknown version error, got %v", err)
+	}
+	if closed != 1 {
+		t.Fatalf("expected already built formatter to be closed, got %d", closed)
+	}
+}
+
 func setRequiredFirestoreEnv(t *testing.T) {
 	t.Helper()
 	t.Setenv("GOOGLE_CLOUD_PROJECT", "test-project")
@@ -367,6 +619,18 @@ func stubDrawRepositoryFactory(t *testing.T, repo repository.DrawRepository, ret
 	return func() { drawRepositoryFactory = orig }
 }
 
+func stubOutcomeRepositoryFactory(t *testing.T, repo repository.OutcomeRepository, retErr error) func() {
+	t.Helper()
+	orig := outcomeRepositoryFactory
+	outcomeRepositoryFactory = func(ctx context.Context, infra *Infra) (repository.OutcomeRepository, error) {
+		if retErr != nil {
+			return nil, retErr
+		}
+		return repo, nil
+	}
+	return func() { outcomeRepositoryFactory = orig }
+}
+
 type stubFormatter struct {
 	closeErr error
 	closed   bool
@@ -394,8 +658,8 @@ func (s *stubJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostID) er
 	return nil
 }
 
-func (s *stubJobQueue) DequeueFormat(ctx context.Context) (post.DarkPostID, error) {
-	return "", nil
+func (s *stubJobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	return nil, nil
 }
 
 func (s *stubJobQueue) Close() error {
@@ -412,8 +676,8 @@ func (noopJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostID) error
 	return nil
 }
 
-func (noopJobQueue) DequeueFormat(ctx context.Context) (post.DarkPostID, error) {
-	return "", nil
+func (noopJobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	return nil, nil
 }
 
 func (noopJobQueue) Close() error {
@@ -441,3 +705,11 @@ func (workerStubPostRepository) ListReady(ctx context.Context, limit int) ([]*po
 func (workerStubPostRepository) Update(ctx context.Context, p *post.Post) error {
 	return repository.ErrPostNotFound
 }
+
+func (workerStubPostRepository) ListByStatus(ctx context.Context, status post.Status, after post.DarkPostID, limit int) ([]*post.Post, error) {
+	return nil, nil
+}
+
+func (workerStubPostRepository) Delete(ctx context.Context, id post.DarkPostID) error {
+	return repository.ErrPostNotFound
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	AdminAuthDisabled = ""
+	AdminAuthToken    = "token"
+	AdminAuthOIDC     = "oidc"
+
+	envAdminAuth         = "ADMIN_AUTH"
+	envAdminToken        = "ADMIN_TOKEN"
+	envAdminOIDCAudience = "ADMIN_OIDC_AUDIENCE"
+	envAdminOIDCEmails   = "ADMIN_OIDC_EMAILS"
+)
+
+/**
+ * 管理 API（/admin）の認証設定。Auth が空なら管理 API を公開しない。
+ * @param Auth token（固定の Bearer トークン）/ oidc（Google の ID トークン）
+ * @param Token token のときの Bearer トークン
+ * @param Audience oidc のときに受け付ける ID トークンの宛先
+ * @param Emails oidc のときに管理者として通すメールアドレス
+ */
+type AdminConfig struct {
+	Auth     string
+	Token    string
+	Audience string
+	Emails   []string
+}
+
+// Enabled は管理 API を公開するかどうかを返す。
+func (c *AdminConfig) Enabled() bool {
+	return c.Auth != AdminAuthDisabled
+}
+
+/**
+ * 環境変数から管理 API の認証設定を読み込む。
+ * ADMIN_AUTH は未設定（管理 API を公開しない）/ token / oidc。
+ * token なら ADMIN_TOKEN、oidc なら ADMIN_OIDC_AUDIENCE と ADMIN_OIDC_EMAILS（カンマ区切り）が必須。
+ */
+func LoadAdminConfigFromEnv() (*AdminConfig, error) {
+	cfg := &AdminConfig{Auth: strings.ToLower(strings.TrimSpace(os.Getenv(envAdminAuth)))}
+
+	switch cfg.Auth {
+	case AdminAuthDisabled:
+	case AdminAuthToken:
+		cfg.Token = strings.TrimSpace(os.Getenv(envAdminToken))
+		if cfg.Token == "" {
+			return nil, fmt.Errorf("config: %s is required when %s=%s", envAdminToken, envAdminAuth, AdminAuthToken)
+		}
+	case AdminAuthOIDC:
+		cfg.Audience = strings.TrimSpace(os.Getenv(envAdminOIDCAudience))
+		if cfg.Audience == "" {
+			return nil, fmt.Errorf("config: %s is required when %s=%s", envAdminOIDCAudience, envAdminAuth, AdminAuthOIDC)
+		}
+		for _, email := range strings.Split(os.Getenv(envAdminOIDCEmails), ",") {
+			if email = strings.TrimSpace(email); email != "" {
+				cfg.Emails = append(cfg.Emails, email)
+			}
+		}
+		if len(cfg.Emails) == 0 {
+			return nil, fmt.Errorf("config: %s is required when %s=%s", envAdminOIDCEmails, envAdminAuth, AdminAuthOIDC)
+		}
+	default:
+		return nil, fmt.Errorf("config: %s must be %q or %q: %q", envAdminAuth, AdminAuthToken, AdminAuthOIDC, cfg.Auth)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadAdminConfigFromEnv(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Setenv(envAdminAuth, "")
+		cfg, err := LoadAdminConfigFromEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.Enabled() {
+			t.Fatalf("admin API should be disabled: %+v", cfg)
+		}
+	})
+	t.Run("token", func(t *testing.T) {
+		t.Setenv(envAdminAuth, " Token ")
+		t.Setenv(envAdminToken, "secret")
+		cfg, err := LoadAdminConfigFromEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !cfg.Enabled() || cfg.Auth != AdminAuthToken || cfg.Token != "secret" {
+			t.Fatalf("unexpected config: %+v", cfg)
+		}
+	})
+	t.Run("oidc", func(t *testing.T) {
+		t.Setenv(envAdminAuth, "oidc")
+		t.Setenv(envAdminOIDCAudience, "https://api.example.com")
+		t.Setenv(envAdminOIDCEmails, "a@example.com, ,b@example.com")
+		cfg, err := LoadAdminConfigFromEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.Audience != "https://api.example.com" || !reflect.DeepEqual(cfg.Emails, []string{"a@example.com", "b@example.com"}) {
+			t.Fatalf("unexpected config: %+v", cfg)
+		}
+	})
+}
+
+func TestLoadAdminConfigFromEnv_Invalid(t *testing.T) {
+	cases := map[string]map[string]string{
+		"unknown mode":     {envAdminAuth: "basic"},
+		"token missing":    {envAdminAuth: "token", envAdminToken: " "},
+		"audience missing": {envAdminAuth: "oidc", envAdminOIDCEmails: "a@example.com"},
+		"emails missing":   {envAdminAuth: "oidc", envAdminOIDCAudience: "aud", envAdminOIDCEmails: " , "},
+	}
+	for name, env := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{envAdminAuth, envAdminToken, envAdminOIDCAudience, envAdminOIDCEmails} {
+				t.Setenv(key, env[key])
+			}
+			if _, err := LoadAdminConfigFromEnv(); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import "testing"
+
+func TestLoadAnthropicConfigFromEnv_DefaultModel(t *testing.T) {
+	t.Setenv(envAnthropicAPIKey, "test-key")
+	t.Setenv(envAnthropicModel, "")
+
+	cfg, err := LoadAnthropicConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.APIKey != "test-key" {
+		t.Fatalf("unexpected api key: %s", cfg.APIKey)
+	}
+	if cfg.Model != DefaultAnthropicModel {
+		t.Fatalf("expected default model, got %s", cfg.Model)
+	}
+}
+
+func TestLoadAnthropicConfigFromEnv_CustomModel(t *testing.T) {
+	t.Setenv(envAnthropicAPIKey, "another-key")
+	t.Setenv(envAnthropicModel, "claude-custom")
+
+	cfg, err := LoadAnthropicConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Model != "claude-custom" {
+		t.Fatalf("unexpected model: %s", cfg.Model)
+	}
+}
+
+func TestLoadAnthropicConfigFromEnv_MissingKey(t *testing.T) {
+	t.Setenv(envAnthropicAPIKey, "")
+
+	if _, err := LoadAnthropicConfigFromEnv(); err == nil {
+		t.Fatal("expected error when api key is missing")
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	envTrustedProxies = "TRUSTED_PROXIES"
+	envClientIPHeader = "CLIENT_IP_HEADER"
+)
+
+// ClientIPConfig は接続元 IP を決めるときに信頼するプロキシとヘッダーの設定。
+type ClientIPConfig struct {
+	TrustedProxies []string
+	PlatformHeader string
+}
+
+/**
+ * 環境変数から接続元 IP の決め方を読み込む。どちらも未設定なら転送ヘッダーを信頼せず、接続してきた相手の IP を使う。
+ * TRUSTED_PROXIES は X-Forwarded-For を付け足してよい中継元の IP / CIDR（カンマ区切り）。
+ * CLIENT_IP_HEADER は前段のロードバランサーが必ず上書きする、接続元 IP 入りのヘッダー名。
+ */
+func LoadClientIPConfigFromEnv() (*ClientIPConfig, error) {
+	cfg := &ClientIPConfig{
+		PlatformHeader: strings.TrimSpace(os.Getenv(envClientIPHeader)),
+	}
+	if cfg.PlatformHeader != "" {
+		cfg.PlatformHeader = http.CanonicalHeaderKey(cfg.PlatformHeader)
+	}
+	for _, raw := range strings.Split(os.Getenv(envTrustedProxies), ",") {
+		proxy := strings.TrimSpace(raw)
+		if proxy == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, fmt.Errorf("config: %s must be a comma-separated list of IPs or CIDRs: %q", envTrustedProxies, proxy)
+		}
+		cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadClientIPConfigFromEnv(t *testing.T) {
+	t.Setenv(envTrustedProxies, "")
+	t.Setenv(envClientIPHeader, "")
+	cfg, err := LoadClientIPConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	// 既定では転送ヘッダーを信頼しない
+	if len(cfg.TrustedProxies) != 0 || cfg.PlatformHeader != "" {
+		t.Fatalf("expected nothing to be trusted by default, got %+v", cfg)
+	}
+
+	t.Setenv(envTrustedProxies, " 169.254.0.0/16, 10.0.0.1 ,")
+	t.Setenv(envClientIPHeader, "x-client-ip")
+	cfg, err = LoadClientIPConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(cfg.TrustedProxies, []string{"169.254.0.0/16", "10.0.0.1"}) {
+		t.Fatalf("unexpected proxies: %v", cfg.TrustedProxies)
+	}
+	if cfg.PlatformHeader != "X-Client-Ip" {
+		t.Fatalf("unexpected header: %q", cfg.PlatformHeader)
+	}
+}
+
+func TestLoadClientIPConfigFromEnv_Invalid(t *testing.T) {
+	for _, raw := range []string{"*", "10.0.0.0/33", "proxy.internal"} {
+		t.Run(raw, func(t *testing.T) {
+			t.Setenv(envTrustedProxies, raw)
+			if _, err := LoadClientIPConfigFromEnv(); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
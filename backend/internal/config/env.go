@@ -1,7 +1,7 @@
 package config
 
 import (
-	"log"
+	"log/slog"
 	"os"
 	"sync"
 
@@ -17,7 +17,7 @@ func LoadDotEnv() {
 			return
 		}
 		if err := godotenv.Load(); err != nil {
-			log.Printf("dotenv: failed to load .env: %v", err)
+			slog.Warn("failed to load .env", slog.Any("error", err))
 		}
 	})
 }
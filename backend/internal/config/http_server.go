@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultHTTPPort            = "8080"
+	DefaultMetricsPort         = "9090"
+	DefaultHTTPReadTimeout     = 10 * time.Second
+	DefaultHTTPWriteTimeout    = 30 * time.Second
+	DefaultHTTPIdleTimeout     = 120 * time.Second
+	DefaultHTTPShutdownTimeout = 10 * time.Second
+
+	envHTTPPort            = "PORT"
+	envMetricsPort         = "METRICS_PORT"
+	envHTTPReadTimeout     = "HTTP_READ_TIMEOUT"
+	envHTTPWriteTimeout    = "HTTP_WRITE_TIMEOUT"
+	envHTTPIdleTimeout     = "HTTP_IDLE_TIMEOUT"
+	envHTTPShutdownTimeout = "HTTP_SHUTDOWN_TIMEOUT"
+)
+
+// HTTPServerConfig は API サーバーの待ち受けポートとタイムアウトの設定。
+type HTTPServerConfig struct {
+	Port            string
+	MetricsPort     string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+/**
+ * 環境変数から API サーバーの設定を読み込む。未設定の項目は既定値で補う。
+ * ShutdownTimeout は停止指示を受けてから処理中のリクエストを待つ上限（Cloud Run の猶予 10 秒に合わせる）。
+ * METRICS_PORT は /metrics だけを配信する内部ポートで、公開する PORT とは分ける。
+ */
+func LoadHTTPServerConfigFromEnv() (*HTTPServerConfig, error) {
+	cfg := &HTTPServerConfig{
+		Port:            strings.TrimSpace(os.Getenv(envHTTPPort)),
+		MetricsPort:     strings.TrimSpace(os.Getenv(envMetricsPort)),
+		ReadTimeout:     DefaultHTTPReadTimeout,
+		WriteTimeout:    DefaultHTTPWriteTimeout,
+		IdleTimeout:     DefaultHTTPIdleTimeout,
+		ShutdownTimeout: DefaultHTTPShutdownTimeout,
+	}
+	if cfg.Port == "" {
+		cfg.Port = DefaultHTTPPort
+	}
+	if cfg.MetricsPort == "" {
+		cfg.MetricsPort = DefaultMetricsPort
+	}
+	if cfg.MetricsPort == cfg.Port {
+		return nil, fmt.Errorf("config: %s must differ from %s: %q", envMetricsPort, envHTTPPort, cfg.MetricsPort)
+	}
+
+	durations := []struct {
+		env    string
+		target *time.Duration
+	}{
+		{envHTTPReadTimeout, &cfg.ReadTimeout},
+		{envHTTPWriteTimeout, &cfg.WriteTimeout},
+		{envHTTPIdleTimeout, &cfg.IdleTimeout},
+		{envHTTPShutdownTimeout, &cfg.ShutdownTimeout},
+	}
+	for _, d := range durations {
+		raw := strings.TrimSpace(os.Getenv(d.env))
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("config: %s must be a positive duration: %q", d.env, raw)
+		}
+		*d.target = parsed
+	}
+	return cfg, nil
+}
+
+/**
+ * 待ち受けるアドレス（:PORT）を返す。
+ */
+func (c *HTTPServerConfig) Addr() string {
+	return ":" + c.Port
+}
+
+/**
+ * メトリクスを配信する内部アドレス（:METRICS_PORT）を返す。
+ */
+func (c *HTTPServerConfig) MetricsAddr() string {
+	return ":" + c.MetricsPort
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadHTTPServerConfigFromEnv_Defaults(t *testing.T) {
+	for _, key := range []string{envHTTPPort, envMetricsPort, envHTTPReadTimeout, envHTTPWriteTimeout, envHTTPIdleTimeout, envHTTPShutdownTimeout} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := LoadHTTPServerConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Addr() != ":8080" || cfg.MetricsAddr() != ":9090" {
+		t.Fatalf("unexpected addr: %s %s", cfg.Addr(), cfg.MetricsAddr())
+	}
+	if cfg.ReadTimeout != DefaultHTTPReadTimeout || cfg.WriteTimeout != DefaultHTTPWriteTimeout ||
+		cfg.IdleTimeout != DefaultHTTPIdleTimeout || cfg.ShutdownTimeout != DefaultHTTPShutdownTimeout {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestLoadHTTPServerConfigFromEnv_Overrides(t *testing.T) {
+	t.Setenv(envHTTPPort, "9090")
+	t.Setenv(envMetricsPort, "9100")
+	t.Setenv(envHTTPReadTimeout, "5s")
+	t.Setenv(envHTTPWriteTimeout, "1m")
+	t.Setenv(envHTTPIdleTimeout, "2m")
+	t.Setenv(envHTTPShutdownTimeout, "25s")
+
+	cfg, err := LoadHTTPServerConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Addr() != ":9090" || cfg.MetricsAddr() != ":9100" || cfg.ReadTimeout != 5*time.Second || cfg.WriteTimeout != time.Minute ||
+		cfg.IdleTimeout != 2*time.Minute || cfg.ShutdownTimeout != 25*time.Second {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadHTTPServerConfigFromEnv_InvalidDuration(t *testing.T) {
+	t.Setenv(envHTTPShutdownTimeout, "0s")
+	if _, err := LoadHTTPServerConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for non-positive shutdown timeout")
+	}
+}
+
+func TestLoadHTTPServerConfigFromEnv_MetricsPortMustDiffer(t *testing.T) {
+	t.Setenv(envHTTPPort, "8080")
+	t.Setenv(envMetricsPort, "8080")
+	if _, err := LoadHTTPServerConfigFromEnv(); err == nil {
+		t.Fatalf("expected error when metrics shares the public port")
+	}
+}
@@ -16,7 +16,7 @@ func LoadLLMProvider() string {
 		return "openai"
 	}
 	switch provider {
-	case "openai", "gemini":
+	case "openai", "gemini", "ollama", "anthropic":
 		return provider
 	default:
 		return "openai"
@@ -16,6 +16,16 @@ func TestLoadLLMProvider(t *testing.T) {
 		t.Fatalf("expected gemini, got %s", got)
 	}
 
+	t.Setenv(envLLMProvider, "ollama")
+	if got := LoadLLMProvider(); got != "ollama" {
+		t.Fatalf("expected ollama, got %s", got)
+	}
+
+	t.Setenv(envLLMProvider, "anthropic")
+	if got := LoadLLMProvider(); got != "anthropic" {
+		t.Fatalf("expected anthropic, got %s", got)
+	}
+
 	t.Setenv(envLLMProvider, "unknown")
 	if got := LoadLLMProvider(); got != "openai" {
 		t.Fatalf("default fallback failed, got %s", got)
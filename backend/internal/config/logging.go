@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LogDebugSinkMemory    = "memory"
+	LogDebugSinkFirestore = "firestore"
+
+	DefaultLogDebugTTL = 24 * time.Hour
+	// MaxLogDebugTTL を超える保存期間は指定できない（本文を長く残さないため）
+	MaxLogDebugTTL = 7 * 24 * time.Hour
+
+	envLogLevel        = "LOG_LEVEL"
+	envLogHashSalt     = "LOG_HASH_SALT"
+	envLogDebugSamples = "LOG_DEBUG_SAMPLES"
+	envLogDebugSink    = "LOG_DEBUG_SINK"
+	envLogDebugTTL     = "LOG_DEBUG_TTL"
+)
+
+// LoggingConfig はログ出力方針の設定。本文のサンプル採取は明示的に有効化した場合だけ行う。
+type LoggingConfig struct {
+	Level        slog.Level
+	HashSalt     string
+	DebugSamples bool
+	DebugSink    string
+	DebugTTL     time.Duration
+}
+
+/**
+ * 環境変数からログ出力方針を読み込む。
+ * LOG_LEVEL は debug / info / warn / error（未設定時は info）。
+ * LOG_DEBUG_SAMPLES=true のときだけ、LOG_DEBUG_SINK（memory / firestore）へ LOG_DEBUG_TTL の期限付きで本文を送る。
+ */
+func LoadLoggingConfigFromEnv() (*LoggingConfig, error) {
+	cfg := &LoggingConfig{
+		Level:     slog.LevelInfo,
+		HashSalt:  os.Getenv(envLogHashSalt),
+		DebugSink: LogDebugSinkMemory,
+		DebugTTL:  DefaultLogDebugTTL,
+	}
+
+	if raw := strings.TrimSpace(os.Getenv(envLogLevel)); raw != "" {
+		if err := cfg.Level.UnmarshalText([]byte(raw)); err != nil {
+			return nil, fmt.Errorf("config: %s must be one of debug, info, warn, error: %q", envLogLevel, raw)
+		}
+	}
+
+	if raw := strings.TrimSpace(os.Getenv(envLogDebugSamples)); raw != "" {
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("config: %s must be a boolean", envLogDebugSamples)
+		}
+		cfg.DebugSamples = enabled
+	}
+	if !cfg.DebugSamples {
+		return cfg, nil
+	}
+
+	if raw := strings.ToLower(strings.TrimSpace(os.Getenv(envLogDebugSink))); raw != "" {
+		if raw != LogDebugSinkMemory && raw != LogDebugSinkFirestore {
+			return nil, fmt.Errorf("config: %s must be %q or %q: %q", envLogDebugSink, LogDebugSinkMemory, LogDebugSinkFirestore, raw)
+		}
+		cfg.DebugSink = raw
+	}
+
+	if raw := strings.TrimSpace(os.Getenv(envLogDebugTTL)); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 || parsed > MaxLogDebugTTL {
+			return nil, fmt.Errorf("config: %s must be a positive duration up to %s: %q", envLogDebugTTL, MaxLogDebugTTL, raw)
+		}
+		cfg.DebugTTL = parsed
+	}
+	return cfg, nil
+}
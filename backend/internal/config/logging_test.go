@@ -0,0 +1,59 @@
+package config
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestLoadLoggingConfigFromEnv_DebugDisabledByDefault(t *testing.T) {
+	t.Setenv(envLogDebugSamples, "")
+	t.Setenv(envLogDebugSink, "firestore")
+	t.Setenv(envLogHashSalt, "salt")
+
+	cfg, err := LoadLoggingConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.DebugSamples || cfg.HashSalt != "salt" || cfg.Level != slog.LevelInfo {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadLoggingConfigFromEnv_DebugEnabled(t *testing.T) {
+	t.Setenv(envLogDebugSamples, "true")
+	t.Setenv(envLogDebugSink, "Firestore")
+	t.Setenv(envLogDebugTTL, "2h")
+	t.Setenv(envLogLevel, "DEBUG")
+
+	cfg, err := LoadLoggingConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !cfg.DebugSamples || cfg.DebugSink != LogDebugSinkFirestore || cfg.DebugTTL != 2*time.Hour || cfg.Level != slog.LevelDebug {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadLoggingConfigFromEnv_Invalid(t *testing.T) {
+	cases := map[string]map[string]string{
+		"level":   {envLogLevel: "verbose"},
+		"samples": {envLogDebugSamples: "maybe"},
+		"sink":    {envLogDebugSamples: "true", envLogDebugSink: "stdout"},
+		"ttl":     {envLogDebugSamples: "true", envLogDebugTTL: "720h"},
+	}
+	for name, env := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(envLogLevel, "")
+			t.Setenv(envLogDebugSamples, "")
+			t.Setenv(envLogDebugSink, "")
+			t.Setenv(envLogDebugTTL, "")
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := LoadLoggingConfigFromEnv(); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	envModerationOpenAI      = "MODERATION_OPENAI"
+	envModerationOpenAIModel = "MODERATION_OPENAI_MODEL"
+)
+
+// ModerationConfig は投稿判定の設定。ローカルのキーワード判定は常に有効で、OpenAI 判定は任意。
+type ModerationConfig struct {
+	OpenAIEnabled bool
+	OpenAIAPIKey  string
+	OpenAIModel   string
+}
+
+/**
+ * 環境変数から読み込んで投稿判定に使用
+ * MODERATION_OPENAI=true のときは OPENAI_API_KEY を必須にする
+ */
+func LoadModerationConfigFromEnv() (*ModerationConfig, error) {
+	cfg := &ModerationConfig{}
+	raw := strings.TrimSpace(os.Getenv(envModerationOpenAI))
+	if raw == "" {
+		return cfg, nil
+	}
+	enabled, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("config: %s must be a boolean", envModerationOpenAI)
+	}
+	if !enabled {
+		return cfg, nil
+	}
+
+	key := strings.TrimSpace(os.Getenv(envOpenAIAPIKey))
+	if key == "" {
+		return nil, fmt.Errorf("config: %s is not set", envOpenAIAPIKey)
+	}
+	cfg.OpenAIEnabled = true
+	cfg.OpenAIAPIKey = key
+	cfg.OpenAIModel = strings.TrimSpace(os.Getenv(envModerationOpenAIModel))
+	return cfg, nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import "testing"
+
+func TestLoadModerationConfigFromEnv_DisabledByDefault(t *testing.T) {
+	t.Setenv(envModerationOpenAI, "")
+
+	cfg, err := LoadModerationConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.OpenAIEnabled {
+		t.Fatalf("expected openai moderation to be disabled")
+	}
+}
+
+func TestLoadModerationConfigFromEnv_Enabled(t *testing.T) {
+	t.Setenv(envModerationOpenAI, "true")
+	t.Setenv(envOpenAIAPIKey, "key")
+	t.Setenv(envModerationOpenAIModel, "omni-moderation-latest")
+
+	cfg, err := LoadModerationConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !cfg.OpenAIEnabled || cfg.OpenAIAPIKey != "key" || cfg.OpenAIModel != "omni-moderation-latest" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadModerationConfigFromEnv_EnabledWithoutKey(t *testing.T) {
+	t.Setenv(envModerationOpenAI, "1")
+	t.Setenv(envOpenAIAPIKey, "")
+
+	if _, err := LoadModerationConfigFromEnv(); err == nil {
+		t.Fatal("expected error when api key is missing")
+	}
+}
+
+func TestLoadModerationConfigFromEnv_InvalidFlag(t *testing.T) {
+	t.Setenv(envModerationOpenAI, "maybe")
+
+	if _, err := LoadModerationConfigFromEnv(); err == nil {
+		t.Fatal("expected error for invalid boolean")
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	NotifierFirestore = "firestore"
+	NotifierMemory    = "memory"
+
+	envNotifier = "NOTIFIER"
+)
+
+/**
+ * 環境変数 NOTIFIER から投稿の進み具合の通知方式を読み込む。
+ * firestore（posts ドキュメントを介して API と Worker の間で届ける）/ memory（同じプロセス内だけ）。
+ * API と Worker は別プロセスで動かすため、未設定時は firestore。
+ */
+func LoadNotifierFromEnv() (string, error) {
+	raw := strings.ToLower(strings.TrimSpace(os.Getenv(envNotifier)))
+	switch raw {
+	case "":
+		return NotifierFirestore, nil
+	case NotifierFirestore, NotifierMemory:
+		return raw, nil
+	default:
+		return "", fmt.Errorf("config: %s must be %q or %q: %q", envNotifier, NotifierFirestore, NotifierMemory, raw)
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import "testing"
+
+func TestLoadNotifierFromEnv(t *testing.T) {
+	cases := map[string]string{
+		"":          NotifierFirestore,
+		"memory":    NotifierMemory,
+		" Memory ":  NotifierMemory,
+		"firestore": NotifierFirestore,
+	}
+	for raw, want := range cases {
+		t.Run(raw, func(t *testing.T) {
+			t.Setenv(envNotifier, raw)
+			got, err := LoadNotifierFromEnv()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got != want {
+				t.Fatalf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadNotifierFromEnv_Invalid(t *testing.T) {
+	t.Setenv(envNotifier, "pubsub")
+	if _, err := LoadNotifierFromEnv(); err == nil {
+		t.Fatal("expected error for unknown notifier")
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultOllamaHost    = "http://localhost:11434"
+	DefaultOllamaModel   = "qwen2.5:7b"
+	DefaultOllamaTimeout = 120 * time.Second
+
+	envOllamaHost    = "OLLAMA_HOST"
+	envOllamaModel   = "OLLAMA_MODEL"
+	envOllamaOptions = "OLLAMA_OPTIONS"
+	envOllamaTimeout = "OLLAMA_TIMEOUT"
+)
+
+type OllamaConfig struct {
+	Host    string
+	Model   string
+	Options map[string]any
+	Timeout time.Duration
+}
+
+/**
+ * 環境変数からローカル LLM（Ollama 互換サーバー）への接続設定を読み込む。
+ * API キーは不要なため、未設定の項目はすべて既定値で補う。
+ */
+func LoadOllamaConfigFromEnv() (*OllamaConfig, error) {
+	host := strings.TrimSpace(os.Getenv(envOllamaHost))
+	if host == "" {
+		host = DefaultOllamaHost
+	}
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "http://" + host
+	}
+
+	model := strings.TrimSpace(os.Getenv(envOllamaModel))
+	if model == "" {
+		model = DefaultOllamaModel
+	}
+
+	var options map[string]any
+	if raw := strings.TrimSpace(os.Getenv(envOllamaOptions)); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &options); err != nil {
+			return nil, fmt.Errorf("config: %s must be a JSON object: %w", envOllamaOptions, err)
+		}
+	}
+
+	timeout := DefaultOllamaTimeout
+	if raw := strings.TrimSpace(os.Getenv(envOllamaTimeout)); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("config: %s must be a positive duration: %q", envOllamaTimeout, raw)
+		}
+		timeout = parsed
+	}
+
+	return &OllamaConfig{
+		Host:    strings.TrimRight(host, "/"),
+		Model:   model,
+		Options: options,
+		Timeout: timeout,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadOllamaConfigFromEnv_Defaults(t *testing.T) {
+	t.Setenv(envOllamaHost, "")
+	t.Setenv(envOllamaModel, "")
+	t.Setenv(envOllamaOptions, "")
+	t.Setenv(envOllamaTimeout, "")
+
+	cfg, err := LoadOllamaConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != DefaultOllamaHost || cfg.Model != DefaultOllamaModel || cfg.Timeout != DefaultOllamaTimeout {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+	if cfg.Options != nil {
+		t.Fatalf("expected no options, got %v", cfg.Options)
+	}
+}
+
+func TestLoadOllamaConfigFromEnv_Custom(t *testing.T) {
+	t.Setenv(envOllamaHost, "127.0.0.1:8080/")
+	t.Setenv(envOllamaModel, "llama3.1")
+	t.Setenv(envOllamaOptions, `{"temperature":0.2,"num_predict":256}`)
+	t.Setenv(envOllamaTimeout, "30s")
+
+	cfg, err := LoadOllamaConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "http://127.0.0.1:8080" {
+		t.Fatalf("unexpected host: %s", cfg.Host)
+	}
+	if cfg.Model != "llama3.1" {
+		t.Fatalf("unexpected model: %s", cfg.Model)
+	}
+	if cfg.Options["temperature"] != 0.2 || cfg.Options["num_predict"] != float64(256) {
+		t.Fatalf("unexpected options: %v", cfg.Options)
+	}
+	if cfg.Timeout != 30*time.Second {
+		t.Fatalf("unexpected timeout: %s", cfg.Timeout)
+	}
+}
+
+func TestLoadOllamaConfigFromEnv_InvalidValues(t *testing.T) {
+	t.Setenv(envOllamaOptions, `not-json`)
+	if _, err := LoadOllamaConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for invalid options")
+	}
+
+	t.Setenv(envOllamaOptions, "")
+	t.Setenv(envOllamaTimeout, "soon")
+	if _, err := LoadOllamaConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for invalid timeout")
+	}
+}
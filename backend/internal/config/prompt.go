@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	envPromptVersion      = "PROMPT_VERSION"
+	envPromptTemplatePath = "PROMPT_TEMPLATE_PATH"
+	envPromptVariants     = "PROMPT_VARIANTS"
+)
+
+type PromptConfig struct {
+	Version  string
+	Path     string
+	Variants []PromptVariant
+}
+
+// PromptVariant は A/B 実験で配分するプロンプトのバージョンと重み。
+type PromptVariant struct {
+	Version string
+	Weight  int
+}
+
+/**
+ * 環境変数から読み込んで整形プロンプトの選択に使用（未設定なら組み込みの既定版）
+ * PROMPT_VARIANTS は "fortune-v1:80,menhera-v1:20" 形式で、指定時は A/B 実験として扱う
+ */
+func LoadPromptConfigFromEnv() (*PromptConfig, error) {
+	variants, err := parsePromptVariants(os.Getenv(envPromptVariants))
+	if err != nil {
+		return nil, err
+	}
+	return &PromptConfig{
+		Version:  strings.TrimSpace(os.Getenv(envPromptVersion)),
+		Path:     strings.TrimSpace(os.Getenv(envPromptTemplatePath)),
+		Variants: variants,
+	}, nil
+}
+
+func parsePromptVariants(raw string) ([]PromptVariant, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	seen := make(map[string]struct{})
+	var variants []PromptVariant
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		version, weightText, ok := strings.Cut(entry, ":")
+		version = strings.TrimSpace(version)
+		if !ok || version == "" {
+			return nil, fmt.Errorf("config: %s has invalid entry %q (want version:weight)", envPromptVariants, entry)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(weightText))
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("config: %s has invalid weight for %s", envPromptVariants, version)
+		}
+		if _, dup := seen[version]; dup {
+			return nil, fmt.Errorf("config: %s lists %s more than once", envPromptVariants, version)
+		}
+		seen[version] = struct{}{}
+		variants = append(variants, PromptVariant{Version: version, Weight: weight})
+	}
+	return variants, nil
+}
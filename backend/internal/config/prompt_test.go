@@ -0,0 +1,61 @@
+package config
+
+import "testing"
+
+func TestLoadPromptConfigFromEnv_Defaults(t *testing.T) {
+	t.Setenv(envPromptVersion, "")
+	t.Setenv(envPromptTemplatePath, "")
+	t.Setenv(envPromptVariants, "")
+
+	cfg, err := LoadPromptConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Version != "" || cfg.Path != "" || len(cfg.Variants) != 0 {
+		t.Fatalf("expected empty config, got %+v", cfg)
+	}
+}
+
+func TestLoadPromptConfigFromEnv_Trims(t *testing.T) {
+	t.Setenv(envPromptVersion, " menhera-v1 ")
+	t.Setenv(envPromptTemplatePath, " /etc/prompts/custom.tmpl ")
+	t.Setenv(envPromptVariants, "")
+
+	cfg, err := LoadPromptConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Version != "menhera-v1" {
+		t.Fatalf("unexpected version: %q", cfg.Version)
+	}
+	if cfg.Path != "/etc/prompts/custom.tmpl" {
+		t.Fatalf("unexpected path: %q", cfg.Path)
+	}
+}
+
+func TestLoadPromptConfigFromEnv_Variants(t *testing.T) {
+	t.Setenv(envPromptVariants, "fortune-v1:80, menhera-v1 : 20,")
+
+	cfg, err := LoadPromptConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []PromptVariant{{Version: "fortune-v1", Weight: 80}, {Version: "menhera-v1", Weight: 20}}
+	if len(cfg.Variants) != len(want) {
+		t.Fatalf("unexpected variants: %+v", cfg.Variants)
+	}
+	for i := range want {
+		if cfg.Variants[i] != want[i] {
+			t.Fatalf("variant %d: want %+v, got %+v", i, want[i], cfg.Variants[i])
+		}
+	}
+}
+
+func TestLoadPromptConfigFromEnv_InvalidVariants(t *testing.T) {
+	for _, raw := range []string{"fortune-v1", "fortune-v1:abc", "fortune-v1:-1", ":10", "a:1,a:2"} {
+		t.Setenv(envPromptVariants, raw)
+		if _, err := LoadPromptConfigFromEnv(); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
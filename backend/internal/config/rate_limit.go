@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	RateLimitStoreMemory    = "memory"
+	RateLimitStoreFirestore = "firestore"
+
+	DefaultPostsPerMinute     = 5
+	DefaultPostsPerDay        = 50
+	DefaultDrawsPerMinute     = 60
+	DefaultDrawsPerDay        = 2000
+	DefaultCardsPerMinute     = 20
+	DefaultCardsPerDay        = 500
+	DefaultEventsPerMinute    = 10
+	DefaultEventsPerDay       = 200
+	DefaultReactionsPerMinute = 10
+	DefaultReactionsPerDay    = 100
+	DefaultReportsPerMinute   = 3
+	DefaultReportsPerDay      = 20
+
+	envRateLimitStore              = "RATE_LIMIT_STORE"
+	envRateLimitPostsPerMinute     = "RATE_LIMIT_POSTS_PER_MINUTE"
+	envRateLimitPostsPerDay        = "RATE_LIMIT_POSTS_PER_DAY"
+	envRateLimitDrawsPerMinute     = "RATE_LIMIT_DRAWS_PER_MINUTE"
+	envRateLimitDrawsPerDay        = "RATE_LIMIT_DRAWS_PER_DAY"
+	envRateLimitCardsPerMinute     = "RATE_LIMIT_CARDS_PER_MINUTE"
+	envRateLimitCardsPerDay        = "RATE_LIMIT_CARDS_PER_DAY"
+	envRateLimitEventsPerMinute    = "RATE_LIMIT_EVENTS_PER_MINUTE"
+	envRateLimitEventsPerDay       = "RATE_LIMIT_EVENTS_PER_DAY"
+	envRateLimitReactionsPerMinute = "RATE_LIMIT_REACTIONS_PER_MINUTE"
+	envRateLimitReactionsPerDay    = "RATE_LIMIT_REACTIONS_PER_DAY"
+	envRateLimitReportsPerMinute   = "RATE_LIMIT_REPORTS_PER_MINUTE"
+	envRateLimitReportsPerDay      = "RATE_LIMIT_REPORTS_PER_DAY"
+)
+
+// RateLimitConfig は呼び出し上限の保存先とエンドポイントごとの上限。0 の上限は制限しない。
+type RateLimitConfig struct {
+	Store              string
+	PostsPerMinute     int
+	PostsPerDay        int
+	DrawsPerMinute     int
+	DrawsPerDay        int
+	CardsPerMinute     int
+	CardsPerDay        int
+	EventsPerMinute    int
+	EventsPerDay       int
+	ReactionsPerMinute int
+	ReactionsPerDay    int
+	ReportsPerMinute   int
+	ReportsPerDay      int
+}
+
+/**
+ * 環境変数から呼び出し上限の設定を読み込む。未設定の項目は既定値で補う。
+ * RATE_LIMIT_STORE は memory（インスタンスごと）/ firestore（インスタンス間で共有）。
+ */
+func LoadRateLimitConfigFromEnv() (*RateLimitConfig, error) {
+	cfg := &RateLimitConfig{
+		Store:              RateLimitStoreMemory,
+		PostsPerMinute:     DefaultPostsPerMinute,
+		PostsPerDay:        DefaultPostsPerDay,
+		DrawsPerMinute:     DefaultDrawsPerMinute,
+		DrawsPerDay:        DefaultDrawsPerDay,
+		CardsPerMinute:     DefaultCardsPerMinute,
+		CardsPerDay:        DefaultCardsPerDay,
+		EventsPerMinute:    DefaultEventsPerMinute,
+		EventsPerDay:       DefaultEventsPerDay,
+		ReactionsPerMinute: DefaultReactionsPerMinute,
+		ReactionsPerDay:    DefaultReactionsPerDay,
+		ReportsPerMinute:   DefaultReportsPerMinute,
+		ReportsPerDay:      DefaultReportsPerDay,
+	}
+
+	if raw := strings.ToLower(strings.TrimSpace(os.Getenv(envRateLimitStore))); raw != "" {
+		if raw != RateLimitStoreMemory && raw != RateLimitStoreFirestore {
+			return nil, fmt.Errorf("config: %s must be %q or %q: %q", envRateLimitStore, RateLimitStoreMemory, RateLimitStoreFirestore, raw)
+		}
+		cfg.Store = raw
+	}
+
+	limits := []struct {
+		env    string
+		target *int
+	}{
+		{envRateLimitPostsPerMinute, &cfg.PostsPerMinute},
+		{envRateLimitPostsPerDay, &cfg.PostsPerDay},
+		{envRateLimitDrawsPerMinute, &cfg.DrawsPerMinute},
+		{envRateLimitDrawsPerDay, &cfg.DrawsPerDay},
+		{envRateLimitCardsPerMinute, &cfg.CardsPerMinute},
+		{envRateLimitCardsPerDay, &cfg.CardsPerDay},
+		{envRateLimitEventsPerMinute, &cfg.EventsPerMinute},
+		{envRateLimitEventsPerDay, &cfg.EventsPerDay},
+		{envRateLimitReactionsPerMinute, &cfg.ReactionsPerMinute},
+		{envRateLimitReactionsPerDay, &cfg.ReactionsPerDay},
+		{envRateLimitReportsPerMinute, &cfg.ReportsPerMinute},
+		{envRateLimitReportsPerDay, &cfg.ReportsPerDay},
+	}
+	for _, l := range limits {
+		raw := strings.TrimSpace(os.Getenv(l.env))
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("config: %s must be a non-negative integer: %q", l.env, raw)
+		}
+		*l.target = parsed
+	}
+	return cfg, nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import "testing"
+
+func TestLoadRateLimitConfigFromEnv_Defaults(t *testing.T) {
+	for _, key := range []string{envRateLimitStore, envRateLimitPostsPerMinute, envRateLimitPostsPerDay, envRateLimitDrawsPerMinute, envRateLimitDrawsPerDay,
+		envRateLimitCardsPerMinute, envRateLimitCardsPerDay, envRateLimitEventsPerMinute, envRateLimitEventsPerDay,
+		envRateLimitReactionsPerMinute, envRateLimitReactionsPerDay, envRateLimitReportsPerMinute, envRateLimitReportsPerDay} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := LoadRateLimitConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := RateLimitConfig{
+		Store:              RateLimitStoreMemory,
+		PostsPerMinute:     DefaultPostsPerMinute,
+		PostsPerDay:        DefaultPostsPerDay,
+		DrawsPerMinute:     DefaultDrawsPerMinute,
+		DrawsPerDay:        DefaultDrawsPerDay,
+		CardsPerMinute:     DefaultCardsPerMinute,
+		CardsPerDay:        DefaultCardsPerDay,
+		EventsPerMinute:    DefaultEventsPerMinute,
+		EventsPerDay:       DefaultEventsPerDay,
+		ReactionsPerMinute: DefaultReactionsPerMinute,
+		ReactionsPerDay:    DefaultReactionsPerDay,
+		ReportsPerMinute:   DefaultReportsPerMinute,
+		ReportsPerDay:      DefaultReportsPerDay,
+	}
+	if *cfg != want {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestLoadRateLimitConfigFromEnv_Overrides(t *testing.T) {
+	t.Setenv(envRateLimitStore, "Firestore")
+	t.Setenv(envRateLimitPostsPerMinute, "2")
+	t.Setenv(envRateLimitPostsPerDay, "0")
+	t.Setenv(envRateLimitDrawsPerMinute, "10")
+	t.Setenv(envRateLimitDrawsPerDay, "100")
+	t.Setenv(envRateLimitCardsPerMinute, "3")
+	t.Setenv(envRateLimitCardsPerDay, "30")
+	t.Setenv(envRateLimitEventsPerMinute, "4")
+	t.Setenv(envRateLimitEventsPerDay, "40")
+	t.Setenv(envRateLimitReactionsPerMinute, "5")
+	t.Setenv(envRateLimitReactionsPerDay, "50")
+	t.Setenv(envRateLimitReportsPerMinute, "1")
+	t.Setenv(envRateLimitReportsPerDay, "6")
+
+	cfg, err := LoadRateLimitConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := RateLimitConfig{
+		Store:              RateLimitStoreFirestore,
+		PostsPerMinute:     2,
+		PostsPerDay:        0,
+		DrawsPerMinute:     10,
+		DrawsPerDay:        100,
+		CardsPerMinute:     3,
+		CardsPerDay:        30,
+		EventsPerMinute:    4,
+		EventsPerDay:       40,
+		ReactionsPerMinute: 5,
+		ReactionsPerDay:    50,
+		ReportsPerMinute:   1,
+		ReportsPerDay:      6,
+	}
+	if *cfg != want {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadRateLimitConfigFromEnv_Invalid(t *testing.T) {
+	cases := map[string]string{
+		envRateLimitStore:          "redis",
+		envRateLimitPostsPerMinute: "-1",
+		envRateLimitDrawsPerDay:    "many",
+		envRateLimitCardsPerMinute: "1.5",
+	}
+	for key, raw := range cases {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, raw)
+			if _, err := LoadRateLimitConfigFromEnv(); err == nil {
+				t.Fatalf("expected error for %s=%q", key, raw)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ReactionStoreFirestore = "firestore"
+	ReactionStoreMemory    = "memory"
+
+	envReactionStore         = "REACTION_STORE"
+	envDrawReactionWeighting = "DRAW_REACTION_WEIGHTING"
+	envDrawReactionWeightTTL = "DRAW_REACTION_WEIGHT_TTL"
+)
+
+// ReactionConfig はおみくじへの反応の保存先と、抽選への反映の有無。WeightTTL が 0 ならユースケースの既定を使う。
+type ReactionConfig struct {
+	Store     string
+	Weighting bool
+	WeightTTL time.Duration
+}
+
+/**
+ * 環境変数からおみくじへの反応の設定を読み込む。
+ * REACTION_STORE は firestore（既定）/ memory（インスタンスごと、再起動で消える）。
+ * DRAW_REACTION_WEIGHTING=true のときは、反応の多いおみくじほど GET /draws/random で選ばれやすくする。
+ * DRAW_REACTION_WEIGHT_TTL は重み付けに使う反応数を取り直すまでの間隔（未設定時は drawusecase.DefaultReactionCacheTTL）。
+ */
+func LoadReactionConfigFromEnv() (*ReactionConfig, error) {
+	cfg := &ReactionConfig{Store: ReactionStoreFirestore}
+
+	if raw := strings.ToLower(strings.TrimSpace(os.Getenv(envReactionStore))); raw != "" {
+		if raw != ReactionStoreFirestore && raw != ReactionStoreMemory {
+			return nil, fmt.Errorf("config: %s must be %q or %q: %q", envReactionStore, ReactionStoreFirestore, ReactionStoreMemory, raw)
+		}
+		cfg.Store = raw
+	}
+	if raw := strings.TrimSpace(os.Getenv(envDrawReactionWeighting)); raw != "" {
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("config: %s must be a boolean", envDrawReactionWeighting)
+		}
+		cfg.Weighting = enabled
+	}
+	if raw := strings.TrimSpace(os.Getenv(envDrawReactionWeightTTL)); raw != "" {
+		ttl, err := time.ParseDuration(raw)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("config: %s must be a positive duration: %q", envDrawReactionWeightTTL, raw)
+		}
+		cfg.WeightTTL = ttl
+	}
+	return cfg, nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadReactionConfigFromEnv(t *testing.T) {
+	t.Setenv(envReactionStore, "")
+	t.Setenv(envDrawReactionWeighting, "")
+	t.Setenv(envDrawReactionWeightTTL, "")
+	cfg, err := LoadReactionConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Store != ReactionStoreFirestore || cfg.Weighting || cfg.WeightTTL != 0 {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+
+	t.Setenv(envReactionStore, " Memory ")
+	t.Setenv(envDrawReactionWeighting, "true")
+	t.Setenv(envDrawReactionWeightTTL, "30s")
+	cfg, err = LoadReactionConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Store != ReactionStoreMemory || !cfg.Weighting || cfg.WeightTTL != 30*time.Second {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadReactionConfigFromEnv_Invalid(t *testing.T) {
+	cases := map[string][3]string{
+		"unknown store":     {"redis", "", ""},
+		"invalid weighting": {"", "sometimes", ""},
+		"invalid ttl":       {"", "", "soon"},
+		"non-positive ttl":  {"", "", "0s"},
+	}
+	for name, env := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(envReactionStore, env[0])
+			t.Setenv(envDrawReactionWeighting, env[1])
+			t.Setenv(envDrawReactionWeightTTL, env[2])
+			if _, err := LoadReactionConfigFromEnv(); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
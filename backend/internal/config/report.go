@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	envReportHideThreshold = "REPORT_HIDE_THRESHOLD"
+
+	defaultReportHideThreshold = 5
+)
+
+// ReportConfig はおみくじへの通報の設定。HideThreshold が 0 なら自動では非公開にしない。
+type ReportConfig struct {
+	HideThreshold int64
+}
+
+/**
+ * 環境変数からおみくじへの通報の設定を読み込む。
+ * REPORT_HIDE_THRESHOLD はおみくじを自動で非公開にする、別々の接続元 IP からの通報数（未設定時は 5、0 で自動の非公開を止める）。
+ */
+func LoadReportConfigFromEnv() (*ReportConfig, error) {
+	cfg := &ReportConfig{HideThreshold: defaultReportHideThreshold}
+
+	if raw := strings.TrimSpace(os.Getenv(envReportHideThreshold)); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("config: %s must be a non-negative integer: %q", envReportHideThreshold, raw)
+		}
+		cfg.HideThreshold = parsed
+	}
+	return cfg, nil
+}
@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestLoadReportConfigFromEnv(t *testing.T) {
+	cases := map[string]int64{
+		"":    defaultReportHideThreshold,
+		"3":   3,
+		" 0 ": 0,
+	}
+	for raw, want := range cases {
+		t.Run(raw, func(t *testing.T) {
+			t.Setenv(envReportHideThreshold, raw)
+			cfg, err := LoadReportConfigFromEnv()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if cfg.HideThreshold != want {
+				t.Fatalf("got %d, want %d", cfg.HideThreshold, want)
+			}
+		})
+	}
+}
+
+func TestLoadReportConfigFromEnv_Invalid(t *testing.T) {
+	for _, raw := range []string{"-1", "many"} {
+		t.Run(raw, func(t *testing.T) {
+			t.Setenv(envReportHideThreshold, raw)
+			if _, err := LoadReportConfigFromEnv(); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
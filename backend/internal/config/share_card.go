@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	ShareCardStoreMemory     = "memory"
+	ShareCardStoreFilesystem = "filesystem"
+	// DefaultShareCardMemoryMaxMB は memory のときに画像を保持する合計サイズの既定の上限（MB）
+	DefaultShareCardMemoryMaxMB = 64
+
+	envShareCardStore       = "SHARE_CARD_STORE"
+	envShareCardDir         = "SHARE_CARD_DIR"
+	envShareCardMemoryMaxMB = "SHARE_CARD_MEMORY_MAX_MB"
+)
+
+// ShareCardConfig は描いた共有カード画像の保存先。Dir は filesystem、MemoryMaxBytes は memory のときだけ使う。
+type ShareCardConfig struct {
+	Store          string
+	Dir            string
+	MemoryMaxBytes int64
+}
+
+/**
+ * 環境変数から共有カード画像の保存先を読み込む。
+ * SHARE_CARD_STORE は memory（インスタンスごと、再起動で消える）/ filesystem（SHARE_CARD_DIR 配下）。
+ * SHARE_CARD_DIR の既定は一時ディレクトリ配下の kiraku-ji-cards。
+ * SHARE_CARD_MEMORY_MAX_MB は memory で保持する合計サイズの上限で、超えたら長く使われていない画像から捨てる。
+ */
+func LoadShareCardConfigFromEnv() (*ShareCardConfig, error) {
+	cfg := &ShareCardConfig{
+		Store:          ShareCardStoreMemory,
+		Dir:            filepath.Join(os.TempDir(), "kiraku-ji-cards"),
+		MemoryMaxBytes: DefaultShareCardMemoryMaxMB << 20,
+	}
+
+	if raw := strings.ToLower(strings.TrimSpace(os.Getenv(envShareCardStore))); raw != "" {
+		if raw != ShareCardStoreMemory && raw != ShareCardStoreFilesystem {
+			return nil, fmt.Errorf("config: %s must be %q or %q: %q", envShareCardStore, ShareCardStoreMemory, ShareCardStoreFilesystem, raw)
+		}
+		cfg.Store = raw
+	}
+	if raw := strings.TrimSpace(os.Getenv(envShareCardDir)); raw != "" {
+		cfg.Dir = raw
+	}
+	if raw := strings.TrimSpace(os.Getenv(envShareCardMemoryMaxMB)); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("config: %s must be a positive integer: %q", envShareCardMemoryMaxMB, raw)
+		}
+		cfg.MemoryMaxBytes = int64(parsed) << 20
+	}
+	return cfg, nil
+}
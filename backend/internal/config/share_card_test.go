@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadShareCardConfigFromEnv_Defaults(t *testing.T) {
+	t.Setenv(envShareCardStore, "")
+	t.Setenv(envShareCardDir, "")
+	t.Setenv(envShareCardMemoryMaxMB, "")
+
+	cfg, err := LoadShareCardConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := ShareCardConfig{Store: ShareCardStoreMemory, Dir: filepath.Join(os.TempDir(), "kiraku-ji-cards"), MemoryMaxBytes: 64 << 20}
+	if *cfg != want {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestLoadShareCardConfigFromEnv_Overrides(t *testing.T) {
+	t.Setenv(envShareCardStore, "Filesystem")
+	t.Setenv(envShareCardDir, "/var/cache/cards")
+	t.Setenv(envShareCardMemoryMaxMB, "16")
+
+	cfg, err := LoadShareCardConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := ShareCardConfig{Store: ShareCardStoreFilesystem, Dir: "/var/cache/cards", MemoryMaxBytes: 16 << 20}
+	if *cfg != want {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadShareCardConfigFromEnv_Invalid(t *testing.T) {
+	t.Setenv(envShareCardStore, "gcs")
+	if _, err := LoadShareCardConfigFromEnv(); err == nil {
+		t.Fatal("expected error for unknown store")
+	}
+
+	t.Setenv(envShareCardStore, "")
+	t.Setenv(envShareCardMemoryMaxMB, "0")
+	if _, err := LoadShareCardConfigFromEnv(); err == nil {
+		t.Fatal("expected error for non-positive memory cap")
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	TraceExporterNone   = "none"
+	TraceExporterOTLP   = "otlp"
+	TraceExporterStdout = "stdout"
+
+	// OpenTelemetry の標準環境変数名に合わせる
+	envTracesExporter = "OTEL_TRACES_EXPORTER"
+)
+
+// TracingConfig はトレースの送り先の設定。既定では何も送らない。
+type TracingConfig struct {
+	Exporter string
+}
+
+/**
+ * 環境変数からトレースの送り先を読み込む。
+ * OTEL_TRACES_EXPORTER は otlp / stdout（console も可）/ none（未設定時は none）。
+ * otlp の接続先は OTEL_EXPORTER_OTLP_ENDPOINT など OpenTelemetry の標準環境変数で指定する。
+ */
+func LoadTracingConfigFromEnv() (*TracingConfig, error) {
+	raw := strings.ToLower(strings.TrimSpace(os.Getenv(envTracesExporter)))
+	switch raw {
+	case "", TraceExporterNone:
+		return &TracingConfig{Exporter: TraceExporterNone}, nil
+	case TraceExporterOTLP:
+		return &TracingConfig{Exporter: TraceExporterOTLP}, nil
+	case TraceExporterStdout, "console":
+		return &TracingConfig{Exporter: TraceExporterStdout}, nil
+	default:
+		return nil, fmt.Errorf("config: %s must be one of otlp, stdout, none: %q", envTracesExporter, raw)
+	}
+}
@@ -0,0 +1,30 @@
+package config
+
+import "testing"
+
+func TestLoadTracingConfigFromEnv(t *testing.T) {
+	cases := map[string]string{
+		"":        TraceExporterNone,
+		"none":    TraceExporterNone,
+		"OTLP":    TraceExporterOTLP,
+		"stdout":  TraceExporterStdout,
+		"console": TraceExporterStdout,
+	}
+	for raw, want := range cases {
+		t.Setenv(envTracesExporter, raw)
+		cfg, err := LoadTracingConfigFromEnv()
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", raw, err)
+		}
+		if cfg.Exporter != want {
+			t.Fatalf("%q: expected %s, got %s", raw, want, cfg.Exporter)
+		}
+	}
+}
+
+func TestLoadTracingConfigFromEnv_Invalid(t *testing.T) {
+	t.Setenv(envTracesExporter, "zipkin")
+	if _, err := LoadTracingConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for unsupported exporter")
+	}
+}
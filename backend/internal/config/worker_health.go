@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultWorkerHeartbeatMaxAge はジョブの取り出し間隔（最大 1 分）に余裕を持たせた値
+	DefaultWorkerHeartbeatMaxAge = 3 * time.Minute
+	DefaultLLMCircuitThreshold   = 5
+	DefaultLLMCircuitCooldown    = 30 * time.Second
+
+	envWorkerHeartbeatMaxAge = "WORKER_HEARTBEAT_MAX_AGE"
+	envLLMCircuitThreshold   = "LLM_CIRCUIT_THRESHOLD"
+	envLLMCircuitCooldown    = "LLM_CIRCUIT_COOLDOWN"
+)
+
+// WorkerHealthConfig はワーカーの死活判定と LLM 呼び出しを止める条件の設定。
+type WorkerHealthConfig struct {
+	HeartbeatMaxAge     time.Duration
+	LLMCircuitThreshold int
+	LLMCircuitCooldown  time.Duration
+}
+
+/**
+ * 環境変数からワーカーの死活判定の設定を読み込む。未設定の項目は既定値で補う。
+ * WORKER_HEARTBEAT_MAX_AGE はループが止まったとみなすまでの時間、
+ * LLM_CIRCUIT_THRESHOLD / LLM_CIRCUIT_COOLDOWN は LLM を止める連続失敗数と再度試すまでの待機時間。
+ */
+func LoadWorkerHealthConfigFromEnv() (*WorkerHealthConfig, error) {
+	cfg := &WorkerHealthConfig{
+		HeartbeatMaxAge:     DefaultWorkerHeartbeatMaxAge,
+		LLMCircuitThreshold: DefaultLLMCircuitThreshold,
+		LLMCircuitCooldown:  DefaultLLMCircuitCooldown,
+	}
+
+	if raw := strings.TrimSpace(os.Getenv(envWorkerHeartbeatMaxAge)); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("config: %s must be a positive duration: %q", envWorkerHeartbeatMaxAge, raw)
+		}
+		cfg.HeartbeatMaxAge = parsed
+	}
+
+	if raw := strings.TrimSpace(os.Getenv(envLLMCircuitThreshold)); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("config: %s must be a positive integer: %q", envLLMCircuitThreshold, raw)
+		}
+		cfg.LLMCircuitThreshold = parsed
+	}
+
+	if raw := strings.TrimSpace(os.Getenv(envLLMCircuitCooldown)); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("config: %s must be a positive duration: %q", envLLMCircuitCooldown, raw)
+		}
+		cfg.LLMCircuitCooldown = parsed
+	}
+	return cfg, nil
+}
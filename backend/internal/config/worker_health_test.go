@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadWorkerHealthConfigFromEnv_Defaults(t *testing.T) {
+	t.Setenv(envWorkerHeartbeatMaxAge, "")
+	t.Setenv(envLLMCircuitThreshold, "")
+	t.Setenv(envLLMCircuitCooldown, "")
+
+	cfg, err := LoadWorkerHealthConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.HeartbeatMaxAge != DefaultWorkerHeartbeatMaxAge || cfg.LLMCircuitThreshold != DefaultLLMCircuitThreshold || cfg.LLMCircuitCooldown != DefaultLLMCircuitCooldown {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestLoadWorkerHealthConfigFromEnv_Overrides(t *testing.T) {
+	t.Setenv(envWorkerHeartbeatMaxAge, "5m")
+	t.Setenv(envLLMCircuitThreshold, "3")
+	t.Setenv(envLLMCircuitCooldown, "1m")
+
+	cfg, err := LoadWorkerHealthConfigFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.HeartbeatMaxAge != 5*time.Minute || cfg.LLMCircuitThreshold != 3 || cfg.LLMCircuitCooldown != time.Minute {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadWorkerHealthConfigFromEnv_Invalid(t *testing.T) {
+	cases := map[string]string{
+		envWorkerHeartbeatMaxAge: "soon",
+		envLLMCircuitThreshold:   "0",
+		envLLMCircuitCooldown:    "-1s",
+	}
+	for key, raw := range cases {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, raw)
+			if _, err := LoadWorkerHealthConfigFromEnv(); err == nil {
+				t.Fatalf("expected error for %s=%q", key, raw)
+			}
+		})
+	}
+}
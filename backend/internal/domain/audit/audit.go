@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"errors"
+	"time"
+)
+
+// 管理操作の種類
+type Action string
+
+const (
+	// 状態ごとの投稿一覧を見た
+	ActionListPosts Action = "list_posts"
+	// 投稿とおみくじ・却下理由を見た
+	ActionViewPost Action = "view_post"
+	// おみくじを公開した（審査待ち・非公開から検証済みへ）
+	ActionApproveDraw Action = "approve_draw"
+	// 審査待ちのおみくじを却下した
+	ActionRejectDraw Action = "reject_draw"
+	// 公開中のおみくじを非公開にした
+	ActionHideDraw Action = "hide_draw"
+	// 公開中のおみくじの公開期間を終えた
+	ActionExpireDraw Action = "expire_draw"
+	// 投稿を整形ジョブへ積み直した
+	ActionRequeuePost Action = "requeue_post"
+	// 投稿とおみくじを削除した
+	ActionDeletePost Action = "delete_post"
+	// 通報の審査待ちの一覧を見た
+	ActionListReports Action = "list_reports"
+	// 通報の審査待ちの項目を閉じた
+	ActionResolveReports Action = "resolve_reports"
+)
+
+var (
+	// ErrEmptyActor は操作者が空の場合に返される。
+	ErrEmptyActor = errors.New("audit: actor is empty")
+	// ErrInvalidAction は定義されていない操作が指定された際に返される。
+	ErrInvalidAction = errors.New("audit: invalid action")
+)
+
+// Valid は定義済みの操作かどうかを返す。
+func (a Action) Valid() bool {
+	switch a {
+	case ActionListPosts, ActionViewPost, ActionApproveDraw, ActionRejectDraw, ActionHideDraw, ActionExpireDraw, ActionRequeuePost,
+		ActionDeletePost, ActionListReports, ActionResolveReports:
+		return true
+	}
+	return false
+}
+
+// Entry は管理者が行った操作 1 件の記録。失敗した操作も結果とともに残す。
+type Entry struct {
+	actor      string
+	action     Action
+	target     string
+	detail     map[string]string
+	err        string
+	occurredAt time.Time
+}
+
+/**
+ * New は操作の記録を生成する。target は操作した投稿の ID など（一覧のように対象が無ければ空）。
+ * detail には状態の絞り込みや却下の理由など、操作を読み返すための補足を入れる。
+ */
+func New(actor string, action Action, target string, detail map[string]string) (*Entry, error) {
+	if actor == "" {
+		return nil, ErrEmptyActor
+	}
+	if !action.Valid() {
+		return nil, ErrInvalidAction
+	}
+	clone := make(map[string]string, len(detail))
+	for k, v := range detail {
+		clone[k] = v
+	}
+	return &Entry{
+		actor:      actor,
+		action:     action,
+		target:     target,
+		detail:     clone,
+		occurredAt: time.Now(),
+	}, nil
+}
+
+// Actor は操作した管理者（トークン認証なら固定の名前、OIDC ならメールアドレス）を返す。
+func (e *Entry) Actor() string {
+	return e.actor
+}
+
+// Action は操作の種類を返す。
+func (e *Entry) Action() Action {
+	return e.action
+}
+
+// Target は操作の対象を返す。
+func (e *Entry) Target() string {
+	return e.target
+}
+
+// Detail は操作の補足を返す。
+func (e *Entry) Detail() map[string]string {
+	return e.detail
+}
+
+// Err は操作が失敗した場合のエラー文を返す（成功なら空）。
+func (e *Entry) Err() string {
+	return e.err
+}
+
+// Succeeded は操作が成功したかどうかを返す。
+func (e *Entry) Succeeded() bool {
+	return e.err == ""
+}
+
+// Fail は操作の失敗を記録する。
+func (e *Entry) Fail(err error) {
+	if err != nil {
+		e.err = err.Error()
+	}
+}
+
+// OccurredAt は操作した時刻を返す。
+func (e *Entry) OccurredAt() time.Time {
+	return e.occurredAt
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	detail := map[string]string{"reason": "spam"}
+	e, err := New("admin@example.com", ActionRejectDraw, "post-1", detail)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Actor() != "admin@example.com" || e.Action() != ActionRejectDraw || e.Target() != "post-1" || e.OccurredAt().IsZero() {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+	// 呼び出し側が後から書き換えても記録は変わらない
+	detail["reason"] = "other"
+	if e.Detail()["reason"] != "spam" {
+		t.Fatalf("detail should be copied, got %v", e.Detail())
+	}
+	if !e.Succeeded() {
+		t.Fatalf("new entry should be successful")
+	}
+	e.Fail(errors.New("boom"))
+	if e.Succeeded() || e.Err() != "boom" {
+		t.Fatalf("expected failed entry, got %q", e.Err())
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	if _, err := New("", ActionHideDraw, "post-1", nil); !errors.Is(err, ErrEmptyActor) {
+		t.Fatalf("expected ErrEmptyActor, got %v", err)
+	}
+	if _, err := New("admin", Action("drop_table"), "post-1", nil); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("expected ErrInvalidAction, got %v", err)
+	}
+}
@@ -17,6 +17,8 @@ var (
 	ErrNilPost = errors.New("draw: nil post supplied")
 	// ErrPostNotReady は ready でない Post から Draw を生成しようとした際に返される。
 	ErrPostNotReady = errors.New("draw: post is not ready")
+	// ErrInvalidStatusTransition は許可されていない状態遷移が要求された際に返される。
+	ErrInvalidStatusTransition = errors.New("draw: invalid status transition")
 )
 
 type (
@@ -29,13 +31,26 @@ const (
 	StatusPending  Status = "pending"
 	StatusVerified Status = "verified"
 	StatusRejected Status = "rejected"
+	// StatusHidden は通報などで公開を止めた状態。抽選にも共有リンクにも出さない。
+	StatusHidden Status = "hidden"
+	// StatusExpired は公開期間を終えた状態。抽選にも共有リンクにも出さず、公開にも戻さない。
+	StatusExpired Status = "expired"
 )
 
+// transitions は許可する状態遷移の表。ここに無い遷移は ErrInvalidStatusTransition になる。
+var transitions = map[Status][]Status{
+	StatusPending:  {StatusVerified, StatusRejected},
+	StatusVerified: {StatusHidden, StatusExpired},
+	StatusHidden:   {StatusVerified},
+}
+
 // Draw はおみくじ結果を表す。
 type Draw struct {
-	postID post.DarkPostID
-	result FormattedContent
-	status Status
+	postID        post.DarkPostID
+	result        FormattedContent
+	status        Status
+	promptVersion string
+	shareID       ShareID
 }
 
 // New は Post ID と結果から Draw を生成する。
@@ -48,9 +63,10 @@ func New(postID post.DarkPostID, result FormattedContent) (*Draw, error) {
 	}
 
 	return &Draw{
-		postID: postID,
-		result: result,
-		status: StatusPending,
+		postID:  postID,
+		result:  result,
+		status:  StatusPending,
+		shareID: NewShareID(),
 	}, nil
 }
 
@@ -100,11 +116,71 @@ func (d *Draw) Status() Status {
 	return d.status
 }
 
-// MarkVerified は結果を検証済み状態へ遷移させる。
-func (d *Draw) MarkVerified() {
-	d.status = StatusVerified
+// PromptVersion は整形に使ったプロンプトのバージョンを返す（不明な場合は空）。
+func (d *Draw) PromptVersion() string {
+	return d.promptVersion
+}
+
+// SetPromptVersion は整形に使ったプロンプトのバージョンを記録する。
+func (d *Draw) SetPromptVersion(version string) {
+	d.promptVersion = version
+}
+
+// ShareID は共有用の公開 ID を返す（共有 ID 導入前の結果では空）。
+func (d *Draw) ShareID() ShareID {
+	return d.shareID
+}
+
+// SetShareID は保存済みの共有 ID を復元する。
+func (d *Draw) SetShareID(id ShareID) {
+	d.shareID = id
+}
+
+// MarkVerified は pending -> verified と hidden -> verified の状態遷移のみを許可する。
+func (d *Draw) MarkVerified() error {
+	return d.transitionTo(StatusVerified)
+}
+
+// MarkHidden は verified -> hidden の状態遷移のみを許可する。
+func (d *Draw) MarkHidden() error {
+	return d.transitionTo(StatusHidden)
+}
+
+// MarkRejected は pending -> rejected の状態遷移のみを許可する。
+func (d *Draw) MarkRejected() error {
+	return d.transitionTo(StatusRejected)
+}
+
+// MarkExpired は verified -> expired の状態遷移のみを許可する。
+func (d *Draw) MarkExpired() error {
+	return d.transitionTo(StatusExpired)
+}
+
+// transitionTo は遷移表にある場合だけ状態を next へ変える。
+func (d *Draw) transitionTo(next Status) error {
+	if !d.status.CanTransitionTo(next) {
+		return ErrInvalidStatusTransition
+	}
+
+	d.status = next
+	return nil
+}
+
+// CanTransitionTo は現在の状態から next へ遷移できるかを返す。
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
 }
 
 func (s Status) isValid() bool {
-	return s == StatusPending || s == StatusVerified || s == StatusRejected
+	switch s {
+	case StatusPending, StatusVerified, StatusRejected, StatusHidden, StatusExpired:
+		return true
+	default:
+		return false
+	}
 }
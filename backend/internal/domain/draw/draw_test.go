@@ -23,6 +23,9 @@ func TestNew(t *testing.T) {
 	if draw.Status() != StatusPending {
 		t.Fatalf("expected status pending but got %s", draw.Status())
 	}
+	if !draw.ShareID().Valid() {
+		t.Fatalf("expected a share id to be issued, got %q", draw.ShareID())
+	}
 }
 
 func TestNew_EmptyResult(t *testing.T) {
@@ -74,8 +77,162 @@ func TestMarkVerified(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	draw.MarkVerified()
+	if err := draw.MarkVerified(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 	if draw.Status() != StatusVerified {
 		t.Fatalf("expected status verified but got %s", draw.Status())
 	}
 }
+
+func TestMarkHidden(t *testing.T) {
+	t.Parallel()
+
+	draw, err := New(post.DarkPostID("post-id"), FormattedContent("result"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 公開前のおみくじは非公開にできない
+	if err := draw.MarkHidden(); err != ErrInvalidStatusTransition {
+		t.Fatalf("expected ErrInvalidStatusTransition but got %v", err)
+	}
+	if err := draw.MarkVerified(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := draw.MarkHidden(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draw.Status() != StatusHidden {
+		t.Fatalf("expected status hidden but got %s", draw.Status())
+	}
+	if _, err := Restore(post.DarkPostID("post-id"), FormattedContent("result"), StatusHidden); err != nil {
+		t.Fatalf("expected hidden to be restorable, got %v", err)
+	}
+}
+
+func TestMarkRejected(t *testing.T) {
+	t.Parallel()
+
+	draw, err := New(post.DarkPostID("post-id"), FormattedContent("result"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := draw.MarkRejected(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draw.Status() != StatusRejected {
+		t.Fatalf("expected status rejected but got %s", draw.Status())
+	}
+	// 公開済みのおみくじは却下ではなく非公開にする
+	verified, _ := New(post.DarkPostID("post-id"), FormattedContent("result"))
+	if err := verified.MarkVerified(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := verified.MarkRejected(); err != ErrInvalidStatusTransition {
+		t.Fatalf("expected ErrInvalidStatusTransition but got %v", err)
+	}
+}
+
+func TestPromptVersion(t *testing.T) {
+	t.Parallel()
+
+	draw, err := New(post.DarkPostID("post-id"), FormattedContent("result"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draw.PromptVersion() != "" {
+		t.Fatalf("expected empty prompt version but got %s", draw.PromptVersion())
+	}
+	draw.SetPromptVersion("fortune-v1")
+	if draw.PromptVersion() != "fortune-v1" {
+		t.Fatalf("expected prompt version fortune-v1 but got %s", draw.PromptVersion())
+	}
+}
+
+func TestShareID(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewShareID(), NewShareID()
+	if a == b {
+		t.Fatalf("expected unique share ids, got %q twice", a)
+	}
+	cases := map[ShareID]bool{
+		a:                             true,
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ":  true,
+		"":                            false,
+		"ABCDEFGHIJKLMNOPQRSTUVWXY":   false,
+		"abcdefghijklmnopqrstuvwxyz":  false,
+		"ABCDEFGHIJKLMNOPQRSTUVWXY1":  false,
+		"post-id/../ABCDEFGHIJKLMNOP": false,
+	}
+	for id, want := range cases {
+		if got := id.Valid(); got != want {
+			t.Fatalf("ShareID(%q).Valid() = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestMarkExpired(t *testing.T) {
+	t.Parallel()
+
+	draw, err := New(post.DarkPostID("post-id"), FormattedContent("result"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 公開前のおみくじは期限切れにできない
+	if err := draw.MarkExpired(); err != ErrInvalidStatusTransition {
+		t.Fatalf("expected ErrInvalidStatusTransition but got %v", err)
+	}
+	if err := draw.MarkVerified(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := draw.MarkExpired(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draw.Status() != StatusExpired {
+		t.Fatalf("expected status expired but got %s", draw.Status())
+	}
+	if _, err := Restore(post.DarkPostID("post-id"), FormattedContent("result"), StatusExpired); err != nil {
+		t.Fatalf("expected expired to be restorable, got %v", err)
+	}
+}
+
+func TestStatusTransitions(t *testing.T) {
+	t.Parallel()
+
+	all := []Status{StatusPending, StatusVerified, StatusRejected, StatusHidden, StatusExpired}
+	allowed := map[Status]map[Status]bool{
+		StatusPending:  {StatusVerified: true, StatusRejected: true},
+		StatusVerified: {StatusHidden: true, StatusExpired: true},
+		StatusHidden:   {StatusVerified: true},
+	}
+	mark := map[Status]func(*Draw) error{
+		StatusVerified: (*Draw).MarkVerified,
+		StatusRejected: (*Draw).MarkRejected,
+		StatusHidden:   (*Draw).MarkHidden,
+		StatusExpired:  (*Draw).MarkExpired,
+	}
+	for _, from := range all {
+		for to, markFn := range mark {
+			want := allowed[from][to]
+			if got := from.CanTransitionTo(to); got != want {
+				t.Fatalf("CanTransitionTo(%s -> %s) = %v, want %v", from, to, got, want)
+			}
+			draw, err := Restore(post.DarkPostID("post-id"), FormattedContent("result"), from)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = markFn(draw)
+			switch {
+			case want && err != nil:
+				t.Fatalf("%s -> %s: unexpected error: %v", from, to, err)
+			case want && draw.Status() != to:
+				t.Fatalf("%s -> %s: expected status %s but got %s", from, to, to, draw.Status())
+			case !want && err != ErrInvalidStatusTransition:
+				t.Fatalf("%s -> %s: expected ErrInvalidStatusTransition but got %v", from, to, err)
+			case !want && draw.Status() != from:
+				t.Fatalf("%s -> %s: status must not change, got %s", from, to, draw.Status())
+			}
+		}
+	}
+}
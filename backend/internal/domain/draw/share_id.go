@@ -0,0 +1,25 @@
+package draw
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// shareIDLength は rand.Text が返す文字数（base32 で 128 ビット相当）。
+const shareIDLength = 26
+
+// ShareID はおみくじを共有するための公開 ID。推測できない乱数で、闇投稿の ID とは結びつかない。
+type ShareID string
+
+// NewShareID は新しい共有 ID を発行する。
+func NewShareID() ShareID {
+	return ShareID(rand.Text())
+}
+
+// Valid は共有 ID として妥当な形か（base32 の大文字と数字 2〜7 の 26 文字）を返す。
+func (id ShareID) Valid() bool {
+	if len(id) != shareIDLength {
+		return false
+	}
+	return strings.Trim(string(id), "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567") == ""
+}
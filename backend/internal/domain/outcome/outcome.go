@@ -0,0 +1,113 @@
+package outcome
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"backend/internal/domain/draw"
+	"backend/internal/domain/post"
+)
+
+var (
+	// ErrEmptyPostID は Post ID が空の場合に返される。
+	ErrEmptyPostID = errors.New("outcome: post id is empty")
+	// ErrInvalidStatus は検証済み・却下以外の状態を記録しようとした際に返される。
+	ErrInvalidStatus = errors.New("outcome: invalid status")
+)
+
+// Outcome は 1 回の整形・検証の結果を、使ったプロンプトのバージョンとともに表す。
+type Outcome struct {
+	postID        post.DarkPostID
+	promptVersion string
+	status        draw.Status
+	reason        string
+	length        int
+	redactions    map[string]int
+	recordedAt    time.Time
+}
+
+// New は検証結果から Outcome を生成する。長さは整形結果の文字数（rune 数）で記録する。
+func New(postID post.DarkPostID, promptVersion string, status draw.Status, reason string, content draw.FormattedContent) (*Outcome, error) {
+	return Restore(postID, promptVersion, status, reason, utf8.RuneCountInString(string(content)), time.Now())
+}
+
+// Restore は保存済みの Outcome を復元する。
+func Restore(postID post.DarkPostID, promptVersion string, status draw.Status, reason string, length int, recordedAt time.Time) (*Outcome, error) {
+	if postID == "" {
+		return nil, ErrEmptyPostID
+	}
+	if status != draw.StatusVerified && status != draw.StatusRejected {
+		return nil, ErrInvalidStatus
+	}
+	if length < 0 {
+		length = 0
+	}
+	return &Outcome{
+		postID:        postID,
+		promptVersion: promptVersion,
+		status:        status,
+		reason:        reason,
+		length:        length,
+		recordedAt:    recordedAt,
+	}, nil
+}
+
+// PostID は対象となった Post の ID を返す。
+func (o *Outcome) PostID() post.DarkPostID {
+	return o.postID
+}
+
+// PromptVersion は整形に使ったプロンプトのバージョンを返す。
+func (o *Outcome) PromptVersion() string {
+	return o.promptVersion
+}
+
+// Status は検証後の状態（verified / rejected）を返す。
+func (o *Outcome) Status() draw.Status {
+	return o.status
+}
+
+// Passed は検証を通過したかを返す。
+func (o *Outcome) Passed() bool {
+	return o.status == draw.StatusVerified
+}
+
+// Reason は却下理由を返す（通過時は空）。
+func (o *Outcome) Reason() string {
+	return o.reason
+}
+
+// Length は整形結果の文字数を返す。
+func (o *Outcome) Length() int {
+	return o.length
+}
+
+// RecordedAt は記録日時を返す。
+func (o *Outcome) RecordedAt() time.Time {
+	return o.recordedAt
+}
+
+// SetRedactions は整形前に伏せた個人情報の種類ごとの件数を記録する。本文そのものは持たない。
+func (o *Outcome) SetRedactions(counts map[string]int) {
+	if len(counts) == 0 {
+		o.redactions = nil
+		return
+	}
+	copied := make(map[string]int, len(counts))
+	for kind, n := range counts {
+		if n > 0 {
+			copied[kind] = n
+		}
+	}
+	o.redactions = copied
+}
+
+// Redactions は伏せた個人情報の種類ごとの件数を返す（伏せていなければ空）。
+func (o *Outcome) Redactions() map[string]int {
+	copied := make(map[string]int, len(o.redactions))
+	for kind, n := range o.redactions {
+		copied[kind] = n
+	}
+	return copied
+}
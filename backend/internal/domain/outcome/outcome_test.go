@@ -0,0 +1,70 @@
+package outcome
+
+import (
+	"errors"
+	"testing"
+
+	"backend/internal/domain/draw"
+	"backend/internal/domain/post"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	o, err := New(post.DarkPostID("post-1"), "fortune-v1", draw.StatusVerified, "", draw.FormattedContent("今日のきらくじ"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !o.Passed() || o.PromptVersion() != "fortune-v1" {
+		t.Fatalf("unexpected outcome: %+v", o)
+	}
+	// 文字数は rune 単位で数える
+	if o.Length() != 7 {
+		t.Fatalf("expected length 7, got %d", o.Length())
+	}
+	if o.RecordedAt().IsZero() {
+		t.Fatalf("expected recorded time to be set")
+	}
+}
+
+func TestNewRejected(t *testing.T) {
+	t.Parallel()
+
+	o, err := New(post.DarkPostID("post-1"), "menhera-v1", draw.StatusRejected, "禁止語を含む", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.Passed() || o.Reason() != "禁止語を含む" || o.Length() != 0 {
+		t.Fatalf("unexpected outcome: %+v", o)
+	}
+}
+
+func TestNewValidation(t *testing.T) {
+	t.Parallel()
+
+	if _, err := New("", "v", draw.StatusVerified, "", "x"); !errors.Is(err, ErrEmptyPostID) {
+		t.Fatalf("expected ErrEmptyPostID, got %v", err)
+	}
+	if _, err := New("p", "v", draw.StatusPending, "", "x"); !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("expected ErrInvalidStatus, got %v", err)
+	}
+}
+
+func TestSetRedactions(t *testing.T) {
+	t.Parallel()
+
+	o, err := New(post.DarkPostID("post-1"), "fortune-v1", draw.StatusVerified, "", "x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(o.Redactions()) != 0 {
+		t.Fatalf("expected no redactions, got %+v", o.Redactions())
+	}
+	counts := map[string]int{"phone": 1, "name": 2, "email": 0}
+	o.SetRedactions(counts)
+	counts["phone"] = 5
+	got := o.Redactions()
+	if len(got) != 2 || got["phone"] != 1 || got["name"] != 2 {
+		t.Fatalf("unexpected redactions: %+v", got)
+	}
+}
@@ -0,0 +1,113 @@
+package post
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxContentLength は本文の最大文字数（正規化後のルーン数）。LLM のトークン上限に収まるよう抑える。
+	MaxContentLength = 1000
+	// maxRepeatedRun を超えて同じ文字が続く本文は荒らしとみなす
+	maxRepeatedRun = 30
+	// maxRepeatUnit 文字以下の単位の繰り返しだけでできた本文は荒らしとみなす
+	maxRepeatUnit = 8
+	// minRepeatCount 回以上繰り返している場合だけ判定する（短い繰り返しは感情表現として許す）
+	minRepeatCount = 10
+)
+
+var (
+	// ErrContentTooLong は本文が MaxContentLength を超える場合に返される。
+	ErrContentTooLong = errors.New("post: content is too long")
+	// ErrInvalidCharacters は制御文字や不正な UTF-8 を含む場合に返される。
+	ErrInvalidCharacters = errors.New("post: content contains invalid characters")
+	// ErrRepetitiveContent は同じ文字や短い語の繰り返しだけでできた本文の場合に返される。
+	ErrRepetitiveContent = errors.New("post: content is repetitive")
+)
+
+/**
+ * 投稿本文を正規化して検証する。
+ * NFKC で全角英数などを揃え、改行を \n に統一し、前後の空白を取り除く。
+ * 空白だけの本文は ErrEmptyContent、長すぎる本文は ErrContentTooLong、
+ * 改行とタブ以外の制御文字や文字の向きを変える制御文字は ErrInvalidCharacters、
+ * 繰り返しだけの本文は ErrRepetitiveContent を返す。
+ */
+func NormalizeContent(raw DarkContent) (DarkContent, error) {
+	if !utf8.ValidString(string(raw)) {
+		return "", ErrInvalidCharacters
+	}
+	normalized := norm.NFKC.String(string(raw))
+	normalized = strings.ReplaceAll(normalized, "\r\n", "\n")
+	normalized = strings.TrimSpace(normalized)
+	if normalized == "" {
+		return "", ErrEmptyContent
+	}
+
+	runes := []rune(normalized)
+	if len(runes) > MaxContentLength {
+		return "", ErrContentTooLong
+	}
+	for _, r := range runes {
+		if !allowedRune(r) {
+			return "", ErrInvalidCharacters
+		}
+	}
+	if isRepetitive(runes) {
+		return "", ErrRepetitiveContent
+	}
+	return DarkContent(normalized), nil
+}
+
+/**
+ * 本文に使ってよい文字かを判定する。改行とタブ以外の制御文字と、双方向テキストの制御文字を拒む。
+ */
+func allowedRune(r rune) bool {
+	switch {
+	case r == '\n', r == '\t':
+		return true
+	case unicode.IsControl(r):
+		return false
+	case r >= '\u202A' && r <= '\u202E', r >= '\u2066' && r <= '\u2069':
+		return false
+	}
+	return true
+}
+
+/**
+ * 同じ文字が長く続く、または短い単位を何度も繰り返しただけの本文かを判定する。
+ */
+func isRepetitive(runes []rune) bool {
+	run := 1
+	for i := 1; i < len(runes); i++ {
+		if runes[i] != runes[i-1] {
+			run = 1
+			continue
+		}
+		run++
+		if run > maxRepeatedRun {
+			return true
+		}
+	}
+
+	for unit := 1; unit <= maxRepeatUnit; unit++ {
+		// 末尾の空白が取り除かれて最後の 1 回が欠けていても数えられるよう、端数も 1 回とみなす
+		if len(runes) <= unit*(minRepeatCount-1) {
+			break
+		}
+		periodic := true
+		for i := unit; i < len(runes); i++ {
+			if runes[i] != runes[i-unit] {
+				periodic = false
+				break
+			}
+		}
+		if periodic {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package post
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeContent(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		raw  DarkContent
+		want DarkContent
+	}{
+		"trims surrounding whitespace": {raw: "  \n闇がおおい\n ", want: "闇がおおい"},
+		"applies NFKC":                 {raw: "ＡＢＣ１２３ｶﾀｶﾅ", want: "ABC123カタカナ"},
+		"unifies line breaks":          {raw: "一行目\r\n二行目", want: "一行目\n二行目"},
+		"keeps tabs and emoji":         {raw: "疲れた\t👨‍👩‍👧", want: "疲れた\t👨‍👩‍👧"},
+		"keeps short emotional repeat": {raw: "つらいつらいつらい", want: "つらいつらいつらい"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeContent(tc.raw)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeContent_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		raw  DarkContent
+		want error
+	}{
+		"whitespace only":       {raw: " \n\t　", want: ErrEmptyContent},
+		"too long":              {raw: DarkContent(strings.Repeat("闇が深い。", MaxContentLength/5+1)), want: ErrContentTooLong},
+		"control character":     {raw: "闇\x00", want: ErrInvalidCharacters},
+		"bidi override":         {raw: "闇\u202eい深", want: ErrInvalidCharacters},
+		"invalid utf-8":         {raw: DarkContent([]byte{0xff, 0xfe}), want: ErrInvalidCharacters},
+		"long run of one rune":  {raw: DarkContent("あ" + strings.Repeat("あ", maxRepeatedRun)), want: ErrRepetitiveContent},
+		"repeated short phrase": {raw: DarkContent(strings.Repeat("死ね ", minRepeatCount)), want: ErrRepetitiveContent},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NormalizeContent(tc.raw); err != tc.want {
+				t.Fatalf("expected %v but got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestNew_NormalizesContent(t *testing.T) {
+	t.Parallel()
+
+	post, err := New(DarkPostID("post-id"), DarkContent("  ｔｅｓｔ  "))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if post.Content() != DarkContent("test") {
+		t.Fatalf("unexpected content: %q", post.Content())
+	}
+}
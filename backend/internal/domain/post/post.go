@@ -14,10 +14,12 @@ type (
 const (
 	StatusPending Status = "pending"
 	StatusReady   Status = "ready"
+	// 危機的な兆候などで整形対象から外した状態
+	StatusFlagged Status = "flagged"
 )
 
 var (
-	// ErrEmptyContent は投稿内容が空の場合に返される。
+	// ErrEmptyContent は投稿内容が空（空白だけの場合を含む）の場合に返される。
 	ErrEmptyContent = errors.New("post: content is empty")
 	// ErrInvalidStatus は不正な状態が指定された際に返される。
 	ErrInvalidStatus = errors.New("post: invalid status")
@@ -32,10 +34,11 @@ type Post struct {
 	status  Status
 }
 
-// New は新しい闇投稿を pending 状態で作成する。
+// New は本文を正規化・検証したうえで、新しい闇投稿を pending 状態で作成する。
 func New(id DarkPostID, content DarkContent) (*Post, error) {
-	if content == "" {
-		return nil, ErrEmptyContent
+	content, err := NormalizeContent(content)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Post{
@@ -45,7 +48,7 @@ func New(id DarkPostID, content DarkContent) (*Post, error) {
 	}, nil
 }
 
-// Restore は既存の投稿を再構築する。
+// Restore は既存の投稿を再構築する。保存済みの本文は作成時に検証済みのため、正規化し直さない。
 func Restore(id DarkPostID, content DarkContent, status Status) (*Post, error) {
 	if content == "" {
 		return nil, ErrEmptyContent
@@ -91,6 +94,26 @@ func (p *Post) MarkReady() error {
 	return nil
 }
 
+// IsFlagged は flagged 状態かどうかを返す。
+func (p *Post) IsFlagged() bool {
+	return p.status == StatusFlagged
+}
+
+// MarkFlagged は pending -> flagged の状態遷移のみを許可する。
+func (p *Post) MarkFlagged() error {
+	if p.status != StatusPending {
+		return ErrInvalidStatusTransition
+	}
+
+	p.status = StatusFlagged
+	return nil
+}
+
+// Valid は定義済みの状態かどうかを返す。
+func (s Status) Valid() bool {
+	return s.isValid()
+}
+
 func (s Status) isValid() bool {
-	return s == StatusPending || s == StatusReady
+	return s == StatusPending || s == StatusReady || s == StatusFlagged
 }
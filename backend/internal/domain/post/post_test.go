@@ -66,3 +66,36 @@ func TestRestore_InvalidStatus(t *testing.T) {
 		t.Fatalf("expected ErrInvalidStatus but got %v", err)
 	}
 }
+
+func TestMarkFlagged(t *testing.T) {
+	t.Parallel()
+
+	post, err := New(DarkPostID("id"), DarkContent("闇"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := post.MarkFlagged(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !post.IsFlagged() || post.Status() != StatusFlagged {
+		t.Fatalf("expected flagged but got %s", post.Status())
+	}
+	// flagged からは公開待ちへ進めない
+	if err := post.MarkReady(); err != ErrInvalidStatusTransition {
+		t.Fatalf("expected ErrInvalidStatusTransition but got %v", err)
+	}
+}
+
+func TestMarkFlagged_InvalidTransition(t *testing.T) {
+	t.Parallel()
+
+	post, err := Restore(DarkPostID("id"), DarkContent("闇"), StatusReady)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := post.MarkFlagged(); err != ErrInvalidStatusTransition {
+		t.Fatalf("expected ErrInvalidStatusTransition but got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package reaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"time"
+
+	"backend/internal/domain/post"
+)
+
+// おみくじへの反応の種類
+type Kind string
+
+const (
+	// 当たってる
+	KindAccurate Kind = "accurate"
+	// 救われた
+	KindSaved Kind = "saved"
+	// こわい
+	KindScary Kind = "scary"
+)
+
+var (
+	// ErrEmptyPostID は Post ID が空の場合に返される。
+	ErrEmptyPostID = errors.New("reaction: post id is empty")
+	// ErrEmptyVisitor は訪問者トークンが空の場合に返される。
+	ErrEmptyVisitor = errors.New("reaction: visitor token is empty")
+	// ErrInvalidKind は定義されていない種類が指定された際に返される。
+	ErrInvalidKind = errors.New("reaction: invalid kind")
+)
+
+// Kinds は反応の種類を表示順に返す。
+func Kinds() []Kind {
+	return []Kind{KindAccurate, KindSaved, KindScary}
+}
+
+// Valid は定義済みの種類かどうかを返す。
+func (k Kind) Valid() bool {
+	return k == KindAccurate || k == KindSaved || k == KindScary
+}
+
+// Positive はおみくじが届いたことを示す反応かどうかを返す。選ばれやすさの重み付けに使う。
+func (k Kind) Positive() bool {
+	return k == KindAccurate || k == KindSaved
+}
+
+// Reaction は 1 人の訪問者が 1 件のおみくじへ付けた反応。訪問者は 1 件のおみくじに 1 回だけ反応できる。
+type Reaction struct {
+	postID     post.DarkPostID
+	visitorKey string
+	kind       Kind
+	createdAt  time.Time
+}
+
+/**
+ * New は反応を生成する。訪問者トークンはそのまま持たず、ハッシュにした VisitorKey だけを残す。
+ */
+func New(postID post.DarkPostID, visitorToken string, kind Kind) (*Reaction, error) {
+	if postID == "" {
+		return nil, ErrEmptyPostID
+	}
+	if visitorToken == "" {
+		return nil, ErrEmptyVisitor
+	}
+	if !kind.Valid() {
+		return nil, ErrInvalidKind
+	}
+	sum := sha256.Sum256([]byte(visitorToken))
+	return &Reaction{
+		postID:     postID,
+		visitorKey: hex.EncodeToString(sum[:]),
+		kind:       kind,
+		createdAt:  time.Now(),
+	}, nil
+}
+
+// PostID は反応したおみくじの Post ID を返す。
+func (r *Reaction) PostID() post.DarkPostID {
+	return r.postID
+}
+
+// VisitorKey は訪問者トークンのハッシュを返す。1 人 1 回の判定に使う。
+func (r *Reaction) VisitorKey() string {
+	return r.visitorKey
+}
+
+// Kind は反応の種類を返す。
+func (r *Reaction) Kind() Kind {
+	return r.kind
+}
+
+// CreatedAt は反応した時刻を返す。
+func (r *Reaction) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// Counts は種類ごとの反応数。
+type Counts map[Kind]int64
+
+// Total は反応数の合計を返す。
+func (c Counts) Total() int64 {
+	var total int64
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+/**
+ * Weight はおみくじを選ぶときの重みを返す。反応が無ければ 1。
+ * 「当たってる」「救われた」が多いほど選ばれやすくするが、増え方は対数で緩め、新しいおみくじも選ばれるようにする。
+ * 「こわい」は重みに加えない。
+ */
+func (c Counts) Weight() float64 {
+	var positive int64
+	for kind, n := range c {
+		if kind.Positive() && n > 0 {
+			positive += n
+		}
+	}
+	return 1 + math.Log1p(float64(positive))
+}
@@ -0,0 +1,68 @@
+package reaction
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"backend/internal/domain/post"
+)
+
+func TestNew(t *testing.T) {
+	r, err := New("post-1", "visitor-token", KindSaved)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.PostID() != "post-1" || r.Kind() != KindSaved || r.CreatedAt().IsZero() {
+		t.Fatalf("unexpected reaction: %+v", r)
+	}
+	if r.VisitorKey() == "" || strings.Contains(r.VisitorKey(), "visitor-token") {
+		t.Fatalf("visitor key should be an opaque hash: %q", r.VisitorKey())
+	}
+	other, _ := New("post-2", "visitor-token", KindScary)
+	if other.VisitorKey() != r.VisitorKey() {
+		t.Fatal("same visitor should have the same key")
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	cases := []struct {
+		name    string
+		postID  string
+		visitor string
+		kind    Kind
+		want    error
+	}{
+		{"empty post", "", "v", KindSaved, ErrEmptyPostID},
+		{"empty visitor", "post-1", "", KindSaved, ErrEmptyVisitor},
+		{"unknown kind", "post-1", "v", Kind("like"), ErrInvalidKind},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := New(post.DarkPostID(tc.postID), tc.visitor, tc.kind); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestCounts_Weight(t *testing.T) {
+	if w := (Counts{}).Weight(); w != 1 {
+		t.Fatalf("expected weight 1 without reactions, got %v", w)
+	}
+	scary := Counts{KindScary: 100}
+	if w := scary.Weight(); w != 1 {
+		t.Fatalf("scary reactions should not add weight, got %v", w)
+	}
+	few := Counts{KindAccurate: 1, KindSaved: 1}
+	many := Counts{KindAccurate: 50, KindSaved: 50}
+	if !(few.Weight() > 1 && many.Weight() > few.Weight()) {
+		t.Fatalf("positive reactions should add weight: few=%v many=%v", few.Weight(), many.Weight())
+	}
+	if many.Weight() > 10 {
+		t.Fatalf("weight should grow slowly, got %v", many.Weight())
+	}
+	if total := (Counts{KindAccurate: 2, KindScary: 3}).Total(); total != 5 {
+		t.Fatalf("expected total 5, got %d", total)
+	}
+}
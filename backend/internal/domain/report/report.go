@@ -0,0 +1,144 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"time"
+
+	"backend/internal/domain/post"
+)
+
+// 通報の理由
+type Reason string
+
+const (
+	// 誹謗中傷・差別など、人を傷つける内容
+	ReasonOffensive Reason = "offensive"
+	// 名前や連絡先など、個人を特定できる内容
+	ReasonPersonalInfo Reason = "personal_info"
+	// 自傷や自殺をほのめかす内容
+	ReasonSelfHarm Reason = "self_harm"
+	// 宣伝や無意味な内容
+	ReasonSpam Reason = "spam"
+	// 上記に当てはまらないもの
+	ReasonOther Reason = "other"
+)
+
+var (
+	// ErrEmptyPostID は Post ID が空の場合に返される。
+	ErrEmptyPostID = errors.New("report: post id is empty")
+	// ErrEmptyVisitor は訪問者トークンが空の場合に返される。
+	ErrEmptyVisitor = errors.New("report: visitor token is empty")
+	// ErrEmptySource は接続元 IP が空の場合に返される。
+	ErrEmptySource = errors.New("report: client ip is empty")
+	// ErrInvalidReason は定義されていない理由が指定された際に返される。
+	ErrInvalidReason = errors.New("report: invalid reason")
+)
+
+// Reasons は通報の理由を表示順に返す。
+func Reasons() []Reason {
+	return []Reason{ReasonOffensive, ReasonPersonalInfo, ReasonSelfHarm, ReasonSpam, ReasonOther}
+}
+
+// Valid は定義済みの理由かどうかを返す。
+func (r Reason) Valid() bool {
+	switch r {
+	case ReasonOffensive, ReasonPersonalInfo, ReasonSelfHarm, ReasonSpam, ReasonOther:
+		return true
+	}
+	return false
+}
+
+/**
+ * Report は 1 人の訪問者が 1 件のおみくじへ送った通報。
+ * 訪問者トークンはクライアントが自由に作れるため、1 件のおみくじには訪問者ごと・接続元 IP ごとに 1 回だけ通報できる。
+ */
+type Report struct {
+	postID     post.DarkPostID
+	visitorKey string
+	sourceKey  string
+	reason     Reason
+	createdAt  time.Time
+}
+
+/**
+ * New は通報を生成する。訪問者トークンと接続元 IP はそのまま持たず、ハッシュにした VisitorKey と SourceKey だけを残す。
+ * clientIP は信頼する中継元を考慮して決めた値（gin の ClientIP）を渡す。
+ */
+func New(postID post.DarkPostID, visitorToken, clientIP string, reason Reason) (*Report, error) {
+	if postID == "" {
+		return nil, ErrEmptyPostID
+	}
+	if visitorToken == "" {
+		return nil, ErrEmptyVisitor
+	}
+	if clientIP == "" {
+		return nil, ErrEmptySource
+	}
+	if !reason.Valid() {
+		return nil, ErrInvalidReason
+	}
+	return &Report{
+		postID:     postID,
+		visitorKey: hashKey(visitorToken),
+		sourceKey:  hashKey(clientIP),
+		reason:     reason,
+		createdAt:  time.Now(),
+	}, nil
+}
+
+func hashKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// PostID は通報されたおみくじの Post ID を返す。
+func (r *Report) PostID() post.DarkPostID {
+	return r.postID
+}
+
+// VisitorKey は訪問者トークンのハッシュを返す。1 人 1 回の判定に使う。
+func (r *Report) VisitorKey() string {
+	return r.visitorKey
+}
+
+// SourceKey は接続元 IP のハッシュを返す。トークンを作り直しても同じ接続元からは 1 回だけにする判定に使う。
+func (r *Report) SourceKey() string {
+	return r.sourceKey
+}
+
+// Reason は通報の理由を返す。
+func (r *Report) Reason() Reason {
+	return r.reason
+}
+
+// CreatedAt は通報した時刻を返す。
+func (r *Report) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+/**
+ * Review はおみくじ 1 件ぶんの審査待ちの項目。通報が届くたびに数と理由を積み上げ、管理者が審査したら 0 に戻す。
+ * @param PostID 通報されたおみくじの Post ID
+ * @param Count 最後の審査以降の通報の数（訪問者・接続元 IP の重複を除くため、通報した接続元の数と等しい）
+ * @param Reasons 最後の審査以降の理由ごとの通報数
+ * @param LastReportedAt 最後に通報された時刻
+ */
+type Review struct {
+	PostID         post.DarkPostID
+	Count          int64
+	Reasons        map[Reason]int64
+	LastReportedAt time.Time
+}
+
+// SortReviews は審査待ちの項目を通報の多い順に、同数なら最後の通報が新しい順に並べる。
+func SortReviews(reviews []*Review) {
+	sort.SliceStable(reviews, func(i, j int) bool {
+		if reviews[i].Count != reviews[j].Count {
+			return reviews[i].Count > reviews[j].Count
+		}
+		return reviews[i].LastReportedAt.After(reviews[j].LastReportedAt)
+	})
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"backend/internal/domain/post"
+)
+
+func TestNew(t *testing.T) {
+	r, err := New("post-1", "visitor-token", "198.51.100.1", ReasonSpam)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.PostID() != "post-1" || r.Reason() != ReasonSpam || r.CreatedAt().IsZero() {
+		t.Fatalf("unexpected report: %+v", r)
+	}
+	if r.VisitorKey() == "" || strings.Contains(r.VisitorKey(), "visitor-token") {
+		t.Fatalf("visitor key should be an opaque hash: %q", r.VisitorKey())
+	}
+	if r.SourceKey() == "" || strings.Contains(r.SourceKey(), "198.51.100.1") || r.SourceKey() == r.VisitorKey() {
+		t.Fatalf("source key should be an opaque hash of the client ip: %q", r.SourceKey())
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	cases := []struct {
+		name    string
+		postID  string
+		visitor string
+		ip      string
+		reason  Reason
+		want    error
+	}{
+		{"empty post", "", "v", "198.51.100.1", ReasonSpam, ErrEmptyPostID},
+		{"empty visitor", "post-1", "", "198.51.100.1", ReasonSpam, ErrEmptyVisitor},
+		{"empty client ip", "post-1", "v", "", ReasonSpam, ErrEmptySource},
+		{"unknown reason", "post-1", "v", "198.51.100.1", Reason("boring"), ErrInvalidReason},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := New(post.DarkPostID(tc.postID), tc.visitor, tc.ip, tc.reason); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestReasons(t *testing.T) {
+	for _, r := range Reasons() {
+		if !r.Valid() {
+			t.Fatalf("listed reason %q should be valid", r)
+		}
+	}
+}
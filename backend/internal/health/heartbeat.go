@@ -0,0 +1,89 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+/**
+ * ループが動き続けていることを示す鼓動と、最後にジョブを処理し終えた時刻を記録する。
+ * 複数のゴルーチンから同時に呼んでよい。
+ */
+type Heartbeat struct {
+	now         func() time.Time
+	lastBeat    atomic.Int64
+	lastSuccess atomic.Int64
+}
+
+/**
+ * 作成時刻を最初の鼓動として記録した Heartbeat を返す。
+ */
+func NewHeartbeat() *Heartbeat {
+	h := &Heartbeat{now: time.Now}
+	h.Beat()
+	return h
+}
+
+/**
+ * ループが動いていることを記録する。
+ */
+func (h *Heartbeat) Beat() {
+	h.lastBeat.Store(h.now().UnixNano())
+}
+
+/**
+ * ジョブを処理し終えたことを記録する。鼓動も兼ねる。
+ */
+func (h *Heartbeat) RecordSuccess() {
+	now := h.now().UnixNano()
+	h.lastBeat.Store(now)
+	h.lastSuccess.Store(now)
+}
+
+/**
+ * 最後に鼓動してからの経過時間を返す。
+ */
+func (h *Heartbeat) Age() time.Duration {
+	return h.now().Sub(time.Unix(0, h.lastBeat.Load()))
+}
+
+/**
+ * 最後にジョブを処理し終えた時刻を返す。まだ無ければ false。
+ */
+func (h *Heartbeat) LastSuccess() (time.Time, bool) {
+	nanos := h.lastSuccess.Load()
+	if nanos == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+/**
+ * 最後の鼓動から maxAge を超えたら失敗にする判定項目を返す。
+ */
+func (h *Heartbeat) Check(name string, maxAge time.Duration) Check {
+	return Check{Name: name, Run: func(context.Context) (string, error) {
+		age := h.Age().Round(time.Millisecond)
+		detail := fmt.Sprintf("heartbeat age %s", age)
+		if age > maxAge {
+			return detail, fmt.Errorf("health: %s 以上鼓動がありません", maxAge)
+		}
+		return detail, nil
+	}}
+}
+
+/**
+ * 最後にジョブを処理し終えた時刻を補足として返す判定項目を返す。
+ * ジョブが無い間も正常なので、この項目は失敗にしない。
+ */
+func (h *Heartbeat) LastSuccessCheck(name string) Check {
+	return Check{Name: name, Run: func(context.Context) (string, error) {
+		last, ok := h.LastSuccess()
+		if !ok {
+			return "no job processed yet", nil
+		}
+		return last.UTC().Format(time.RFC3339), nil
+	}}
+}
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestHeartbeat(now *time.Time) *Heartbeat {
+	h := &Heartbeat{now: func() time.Time { return *now }}
+	h.Beat()
+	return h
+}
+
+/**
+ * 鼓動が途絶えて maxAge を超えたら失敗になり、鼓動すれば戻ることを確認する。
+ */
+func TestHeartbeat_Check(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newTestHeartbeat(&now)
+	check := h.Check("loop", time.Minute)
+
+	now = now.Add(30 * time.Second)
+	if _, err := check.Run(context.Background()); err != nil {
+		t.Fatalf("expected fresh heartbeat, got %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	detail, err := check.Run(context.Background())
+	if err == nil {
+		t.Fatalf("expected stale heartbeat to fail")
+	}
+	if detail != "heartbeat age 2m30s" {
+		t.Fatalf("unexpected detail: %q", detail)
+	}
+
+	h.Beat()
+	if _, err := check.Run(context.Background()); err != nil {
+		t.Fatalf("expected heartbeat to recover, got %v", err)
+	}
+}
+
+/**
+ * 最後にジョブを処理し終えた時刻を返し、まだ無くても失敗にしないことを確認する。
+ */
+func TestHeartbeat_LastSuccessCheck(t *testing.T) {
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	h := newTestHeartbeat(&now)
+	check := h.LastSuccessCheck("last_success")
+
+	if detail, err := check.Run(context.Background()); err != nil || detail != "no job processed yet" {
+		t.Fatalf("unexpected result before first job: %q, %v", detail, err)
+	}
+
+	h.RecordSuccess()
+	if detail, err := check.Run(context.Background()); err != nil || detail != "2026-01-01T09:00:00Z" {
+		t.Fatalf("unexpected result after job: %q, %v", detail, err)
+	}
+}
@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 判定結果の状態
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// DefaultTimeout は 1 回の判定全体に掛けてよい時間。
+const DefaultTimeout = 3 * time.Second
+
+var errInitializing = errors.New("health: 初期化中です")
+
+/**
+ * 死活判定の 1 項目。
+ * @param Name 結果の JSON に出す項目名
+ * @param Run 判定処理。問題が無ければ補足（空でもよい）を、問題があればエラーを返す
+ */
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) (string, error)
+}
+
+// CheckResult は 1 項目の判定結果。
+type CheckResult struct {
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report は判定結果全体。1 項目でも失敗すれば全体も失敗にする。
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+/**
+ * 判定項目をまとめて実行し、結果を JSON で返す HTTP ハンドラー。
+ * 全項目が通れば 200、1 つでも失敗すれば 503 を返す。
+ * 依存の初期化が終わる前から待ち受けられるよう、項目は後から差し替えられる。
+ */
+type Probe struct {
+	timeout time.Duration
+	mu      sync.RWMutex
+	checks  []Check
+}
+
+/**
+ * 判定項目を指定して Probe を作る。timeout が 0 以下なら DefaultTimeout を使う。
+ */
+func NewProbe(timeout time.Duration, checks ...Check) *Probe {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Probe{timeout: timeout, checks: checks}
+}
+
+/**
+ * 判定項目を差し替える。
+ */
+func (p *Probe) Set(checks ...Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checks = checks
+}
+
+/**
+ * 全項目を並行して実行し、結果をまとめる。
+ */
+func (p *Probe) Run(ctx context.Context) Report {
+	p.mu.RLock()
+	checks := p.checks
+	p.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+func (p *Probe) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := p.Run(r.Context())
+	code := http.StatusOK
+	if report.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+func runCheck(ctx context.Context, check Check) CheckResult {
+	detail, err := check.Run(ctx)
+	if err != nil {
+		return CheckResult{Status: StatusFail, Detail: detail, Error: err.Error()}
+	}
+	return CheckResult{Status: StatusOK, Detail: detail}
+}
+
+/**
+ * 依存の初期化が終わるまで失敗を返す項目。
+ */
+func Initializing(name string) Check {
+	return Check{Name: name, Run: func(context.Context) (string, error) {
+		return "", errInitializing
+	}}
+}
@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func okCheck(name, detail string) Check {
+	return Check{Name: name, Run: func(context.Context) (string, error) { return detail, nil }}
+}
+
+/**
+ * 全項目が通れば 200 と項目ごとの結果を JSON で返すことを確認する。
+ */
+func TestProbe_AllPassing(t *testing.T) {
+	probe := NewProbe(time.Second, okCheck("loop", "heartbeat age 1s"), okCheck("firestore", ""))
+
+	rec := httptest.NewRecorder()
+	probe.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var report Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if report.Status != StatusOK || report.Checks["loop"].Detail != "heartbeat age 1s" || report.Checks["firestore"].Status != StatusOK {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+/**
+ * 1 項目でも失敗すれば 503 になり、失敗した項目にエラーが残ることを確認する。
+ */
+func TestProbe_FailingCheck(t *testing.T) {
+	failing := Check{Name: "firestore", Run: func(context.Context) (string, error) { return "", errors.New("unreachable") }}
+	probe := NewProbe(time.Second, okCheck("loop", ""), failing)
+
+	rec := httptest.NewRecorder()
+	probe.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", rec.Code)
+	}
+	var report Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if report.Status != StatusFail || report.Checks["firestore"].Error != "unreachable" || report.Checks["loop"].Status != StatusOK {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+/**
+ * 判定が時間切れになれば失敗として扱うことを確認する。
+ */
+func TestProbe_Timeout(t *testing.T) {
+	slow := Check{Name: "firestore", Run: func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}}
+	report := NewProbe(10*time.Millisecond, slow).Run(context.Background())
+	if report.Status != StatusFail {
+		t.Fatalf("expected timeout to fail: %+v", report)
+	}
+}
+
+/**
+ * 初期化中の項目を後から差し替えられることを確認する。
+ */
+func TestProbe_Set(t *testing.T) {
+	probe := NewProbe(time.Second, Initializing("worker"))
+	if report := probe.Run(context.Background()); report.Status != StatusFail {
+		t.Fatalf("initializing probe should fail: %+v", report)
+	}
+	probe.Set(okCheck("loop", ""))
+	if report := probe.Run(context.Background()); report.Status != StatusOK {
+		t.Fatalf("probe should pass after Set: %+v", report)
+	}
+}
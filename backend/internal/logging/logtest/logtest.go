@@ -0,0 +1,62 @@
+package logtest
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"backend/internal/logging"
+)
+
+/**
+ * テスト中のログ出力（slog と標準の log）をバッファへ切り替え、終了時に元へ戻す。
+ */
+func Capture(t *testing.T) *Buffer {
+	t.Helper()
+	buf := &Buffer{}
+	origLogger := slog.Default()
+	origOutput := log.Writer()
+	origFlags := log.Flags()
+	logging.Setup(buf, slog.LevelDebug)
+	t.Cleanup(func() {
+		slog.SetDefault(origLogger)
+		// 既定のロガーへ戻しても log の出力先は戻らないため個別に戻す
+		log.SetOutput(origOutput)
+		log.SetFlags(origFlags)
+	})
+	return buf
+}
+
+// Buffer は並行して書き込まれても安全なログ用バッファ。
+type Buffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *Buffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+/**
+ * ログに指定した文字列が 1 つも含まれていないことを確かめる。
+ */
+func AssertNotContains(t *testing.T, buf *Buffer, forbidden ...string) {
+	t.Helper()
+	logs := buf.String()
+	for _, s := range forbidden {
+		if s != "" && strings.Contains(logs, s) {
+			t.Fatalf("log must not contain %q, got:\n%s", s, logs)
+		}
+	}
+}
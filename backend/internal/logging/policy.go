@@ -0,0 +1,134 @@
+package logging
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"backend/internal/port/logsink"
+)
+
+// ログに出すハッシュ ID の長さ（16 進文字数）。
+const hashIDLength = 12
+
+// DefaultDebugTTL はデバッグ用サンプルの既定の保存期間。
+const DefaultDebugTTL = 24 * time.Hour
+
+/**
+ * ログ出力の方針をまとめた層。
+ * - 投稿 ID はハッシュ化して出す
+ * - 本文・整形結果は長さだけを出す
+ * - 本文そのものは、明示的に有効化した場合だけ期限付きのデバッグ用書き込み先へ送る
+ */
+type policy struct {
+	mu       sync.RWMutex
+	salt     string
+	sink     logsink.Sink
+	debugTTL time.Duration
+	now      func() time.Time
+}
+
+var current = &policy{debugTTL: DefaultDebugTTL, now: time.Now}
+
+/**
+ * ハッシュ ID に混ぜるソルトを設定する。環境ごとに変えると ID の突き合わせを防げる。
+ */
+func SetHashSalt(salt string) {
+	current.mu.Lock()
+	defer current.mu.Unlock()
+	current.salt = salt
+}
+
+/**
+ * デバッグ用サンプルの採取を有効にする。sink が nil の場合は無効にする。
+ * ttl が 0 以下なら DefaultDebugTTL を使う。
+ */
+func EnableDebugSamples(sink logsink.Sink, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultDebugTTL
+	}
+	current.mu.Lock()
+	defer current.mu.Unlock()
+	current.sink = sink
+	current.debugTTL = ttl
+}
+
+/**
+ * デバッグ用サンプルの採取を止める。
+ */
+func DisableDebugSamples() {
+	EnableDebugSamples(nil, 0)
+}
+
+/**
+ * デバッグ用サンプルの採取が有効かを返す。
+ */
+func DebugSamplesEnabled() bool {
+	current.mu.RLock()
+	defer current.mu.RUnlock()
+	return current.sink != nil
+}
+
+/**
+ * 投稿 ID などの識別子をログ用にハッシュ化する。空文字は空文字のまま返す。
+ */
+func HashID(id string) string {
+	if id == "" {
+		return ""
+	}
+	current.mu.RLock()
+	salt := current.salt
+	current.mu.RUnlock()
+	sum := sha256.Sum256([]byte(salt + id))
+	return hex.EncodeToString(sum[:])[:hashIDLength]
+}
+
+/**
+ * 本文の代わりにログへ出す長さ（rune 数）を返す。
+ */
+func ContentLength(content string) int {
+	return utf8.RuneCountInString(content)
+}
+
+/**
+ * LLM の整形結果を受け取ったことを、ハッシュ ID と文字数だけでログに残す。
+ * デバッグ用サンプルが有効なら本文を期限付きの書き込み先へ送る。
+ */
+func Formatted(ctx context.Context, provider, postID, text string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	slog.InfoContext(ctx, "llm formatted", slog.String("provider", provider), PostAttr(postID), slog.Int("length", ContentLength(text)))
+	Sample(ctx, "formatted", provider, postID, text)
+}
+
+/**
+ * デバッグ用サンプルが有効な場合だけ本文を書き込み先へ送る。書き込み失敗は本文を含めずにログへ残す。
+ */
+func Sample(ctx context.Context, stage, provider, postID, content string) {
+	current.mu.RLock()
+	sink, ttl, now := current.sink, current.debugTTL, current.now
+	current.mu.RUnlock()
+	if sink == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	createdAt := now()
+	err := sink.Write(ctx, logsink.Sample{
+		Stage:      stage,
+		Provider:   provider,
+		PostIDHash: HashID(postID),
+		Content:    content,
+		CreatedAt:  createdAt,
+		ExpiresAt:  createdAt.Add(ttl),
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "debug sample write failed", slog.String("stage", stage), slog.String("provider", provider), slog.Any("error", err))
+	}
+}
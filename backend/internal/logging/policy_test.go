@@ -0,0 +1,122 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/port/logsink"
+)
+
+type recordingSink struct {
+	samples []logsink.Sample
+	err     error
+}
+
+func (s *recordingSink) Write(ctx context.Context, sample logsink.Sample) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.samples = append(s.samples, sample)
+	return nil
+}
+
+// logtest は本パッケージに依存するため、ここでは同等の処理を直接書く。
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	origLogger := slog.Default()
+	origOutput, origFlags := log.Writer(), log.Flags()
+	Setup(buf, slog.LevelDebug)
+	t.Cleanup(func() {
+		slog.SetDefault(origLogger)
+		log.SetOutput(origOutput)
+		log.SetFlags(origFlags)
+	})
+	return buf
+}
+
+func assertNotLogged(t *testing.T, buf *bytes.Buffer, forbidden ...string) {
+	t.Helper()
+	for _, s := range forbidden {
+		if strings.Contains(buf.String(), s) {
+			t.Fatalf("log must not contain %q, got:\n%s", s, buf.String())
+		}
+	}
+}
+
+func TestHashID(t *testing.T) {
+	t.Cleanup(func() { SetHashSalt("") })
+
+	if HashID("") != "" {
+		t.Fatalf("expected empty id to stay empty")
+	}
+	first := HashID("post-1")
+	if len(first) != hashIDLength || first == "post-1" || first != HashID("post-1") {
+		t.Fatalf("unexpected hash %q", first)
+	}
+	SetHashSalt("salt")
+	if HashID("post-1") == first {
+		t.Fatalf("expected salt to change hash")
+	}
+}
+
+func TestFormatted_LogsOnlyHashAndLength(t *testing.T) {
+	buf := captureLogs(t)
+	DisableDebugSamples()
+
+	Formatted(context.Background(), "openai", "post-secret", "本文そのもの")
+
+	logs := buf.String()
+	assertNotLogged(t, buf, "post-secret", "本文そのもの")
+	if !strings.Contains(logs, `"post":"`+HashID("post-secret")+`"`) || !strings.Contains(logs, `"length":6`) {
+		t.Fatalf("expected hash and length in log, got %q", logs)
+	}
+}
+
+func TestFormatted_WritesDebugSampleWhenEnabled(t *testing.T) {
+	buf := captureLogs(t)
+	sink := &recordingSink{}
+	EnableDebugSamples(sink, time.Hour)
+	t.Cleanup(DisableDebugSamples)
+
+	Formatted(context.Background(), "gemini", "post-secret", "本文そのもの")
+
+	if len(sink.samples) != 1 {
+		t.Fatalf("expected 1 sample, got %d", len(sink.samples))
+	}
+	got := sink.samples[0]
+	if got.Content != "本文そのもの" || got.PostIDHash != HashID("post-secret") || got.Provider != "gemini" {
+		t.Fatalf("unexpected sample: %+v", got)
+	}
+	if got.ExpiresAt.Sub(got.CreatedAt) != time.Hour {
+		t.Fatalf("expected ttl of 1h, got %s", got.ExpiresAt.Sub(got.CreatedAt))
+	}
+	// サンプルを書き込んでも通常ログには本文を出さない
+	assertNotLogged(t, buf, "post-secret", "本文そのもの")
+}
+
+func TestSample_WriteFailureDoesNotLogContent(t *testing.T) {
+	buf := captureLogs(t)
+	EnableDebugSamples(&recordingSink{err: errors.New("unavailable")}, 0)
+	t.Cleanup(DisableDebugSamples)
+
+	Sample(context.Background(), "formatted", "openai", "post-secret", "本文そのもの")
+
+	if !strings.Contains(buf.String(), "debug sample write failed") {
+		t.Fatalf("expected write failure to be logged, got %q", buf.String())
+	}
+	assertNotLogged(t, buf, "post-secret", "本文そのもの")
+}
+
+func TestSample_DisabledByDefault(t *testing.T) {
+	DisableDebugSamples()
+	if DebugSamplesEnabled() {
+		t.Fatalf("expected debug samples to be disabled")
+	}
+}
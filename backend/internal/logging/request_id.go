@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// 外部から受け取るリクエスト ID の最大長。
+const maxRequestIDLength = 128
+
+type requestIDContextKey struct{}
+
+/**
+ * リクエスト ID をコンテキストに載せる。空の場合はそのまま返す。
+ */
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+/**
+ * コンテキストに載ったリクエスト ID を返す。無ければ空文字。
+ */
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+/**
+ * 新しいリクエスト ID（16 バイトの乱数の 16 進表記）を作る。
+ */
+func NewRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
+
+/**
+ * 外部から受け取ったリクエスト ID をそのまま使ってよいかを判定する。
+ * ログの改ざんを避けるため、英数字と - _ . : / だけで構成された短い値に限る。
+ */
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':', r == '/':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Cloud Logging が解釈する構造化ログのキー。
+const (
+	severityKey  = "severity"
+	messageKey   = "message"
+	requestIDKey = "request_id"
+	traceIDKey   = "trace_id"
+	spanIDKey    = "span_id"
+)
+
+/**
+ * Cloud Logging 互換の JSON を出力するハンドラーを作る。
+ * level は severity（DEBUG / INFO / WARNING / ERROR）、msg は message として出力し、
+ * コンテキストにリクエスト ID があれば request_id を、記録中のスパンがあれば trace_id / span_id を添える。
+ */
+func NewHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	return &contextHandler{
+		Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{
+			Level:       level,
+			ReplaceAttr: cloudLoggingAttr,
+		}),
+	}
+}
+
+/**
+ * Cloud Logging 互換のロガーを既定にする。標準の log パッケージの出力も同じ形式になる。
+ */
+func Setup(w io.Writer, level slog.Leveler) *slog.Logger {
+	logger := slog.New(NewHandler(w, level))
+	slog.SetDefault(logger)
+	return logger
+}
+
+/**
+ * 投稿 ID をハッシュ化した属性を返す。ログの属性には生の ID ではなくこちらを使う。
+ */
+func PostAttr(postID string) slog.Attr {
+	return slog.String("post", HashID(postID))
+}
+
+/**
+ * slog の標準キーを Cloud Logging のキーと重大度へ読み替える。
+ */
+func cloudLoggingAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.LevelKey:
+		level, _ := a.Value.Any().(slog.Level)
+		return slog.String(severityKey, severity(level))
+	case slog.MessageKey:
+		a.Key = messageKey
+	}
+	return a
+}
+
+func severity(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARNING"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+// contextHandler はコンテキストに載った相関 ID をログへ添える。
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String(requestIDKey, id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String(traceIDKey, sc.TraceID().String()), slog.String(spanIDKey, sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestNewHandler_CloudLoggingFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, slog.LevelDebug))
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	logger.WarnContext(ctx, "something happened", slog.String("provider", "openai"))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected JSON log, got %q: %v", buf.String(), err)
+	}
+	if entry["severity"] != "WARNING" || entry["message"] != "something happened" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if entry["request_id"] != "req-1" || entry["provider"] != "openai" {
+		t.Fatalf("expected request id and attrs, got %+v", entry)
+	}
+	if _, ok := entry["level"]; ok {
+		t.Fatalf("level should be replaced by severity: %+v", entry)
+	}
+}
+
+func TestNewHandler_KeepsRequestIDWithAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, slog.LevelInfo)).With(slog.String("component", "worker"))
+
+	logger.DebugContext(context.Background(), "hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("expected debug log to be filtered, got %q", buf.String())
+	}
+	logger.ErrorContext(WithRequestID(context.Background(), "req-2"), "failed")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected JSON log: %v", err)
+	}
+	if entry["severity"] != "ERROR" || entry["request_id"] != "req-2" || entry["component"] != "worker" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	if RequestIDFromContext(context.Background()) != "" {
+		t.Fatalf("expected empty request id")
+	}
+	if WithRequestID(context.Background(), "") != context.Background() {
+		t.Fatalf("expected empty id to keep context")
+	}
+	id := NewRequestID()
+	if len(id) != 32 || !ValidRequestID(id) {
+		t.Fatalf("unexpected generated id %q", id)
+	}
+	for _, invalid := range []string{"", "has space", "改行\n", string(make([]byte, maxRequestIDLength+1))} {
+		if ValidRequestID(invalid) {
+			t.Fatalf("expected %q to be invalid", invalid)
+		}
+	}
+	if !ValidRequestID("abc-123_DEF.4:5/6") {
+		t.Fatalf("expected id to be valid")
+	}
+}
@@ -0,0 +1,50 @@
+package blob
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNotFound   = errors.New("blob: 指定したキーのデータがありません")
+	ErrInvalidKey = errors.New("blob: キーの形式が不正です")
+)
+
+/**
+ * 生成済みの画像などのバイト列をキーで出し入れする保存先。
+ * キーは英数字と - _ . / からなる相対パス形式（例: cards/v1/ABC.png）とする。
+ * Put は同じキーを上書きする。Get は未保存なら ErrNotFound を返す。Delete は未保存でも成功とする。
+ */
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) error
+	Delete(ctx context.Context, key string) error
+}
+
+/**
+ * キーが保存先で扱える形かを判定する。空の区切りや .. を含むもの、先頭が / のものは不可。
+ */
+func ValidKey(key string) bool {
+	if key == "" || len(key) > 512 {
+		return false
+	}
+	segmentStart := 0
+	for i := 0; i <= len(key); i++ {
+		if i < len(key) && key[i] != '/' {
+			c := key[i]
+			switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+			case c == '-', c == '_', c == '.':
+			default:
+				return false
+			}
+			continue
+		}
+		segment := key[segmentStart:i]
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+		segmentStart = i + 1
+	}
+	return true
+}
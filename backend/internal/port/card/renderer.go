@@ -0,0 +1,21 @@
+package card
+
+/**
+ * 共有カード 1 枚に描く内容
+ * @param Heading 上部の見出し（おみくじの段や種類）
+ * @param Body おみくじ本文
+ * @param Brand 下部に添えるサービス名
+ */
+type Card struct {
+	Heading string
+	Body    string
+	Brand   string
+}
+
+/**
+ * 共有カードを PNG に描く契約。SNS のリンクプレビュー（OGP）に使う。
+ * 同じ内容からは同じ画像を返す。
+ */
+type Renderer interface {
+	Render(c Card) ([]byte, error)
+}
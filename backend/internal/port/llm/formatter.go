@@ -30,12 +30,14 @@ type FormatRequest struct {
  * @param FormattedContent 整形後の本文
  * @param Status 整形結果の状態
  * @param ValidationReason 検証理由（Status が Rejected の場合にセットされる）
+ * @param PromptVersion 整形に使ったプロンプトテンプレートのバージョン
  */
 type FormatResult struct {
 	DarkPostID       post.DarkPostID
 	FormattedContent draw.FormattedContent
 	Status           draw.Status
 	ValidationReason string
+	PromptVersion    string
 }
 
 /**
@@ -0,0 +1,34 @@
+package logsink
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrSinkUnavailable = errors.New("logsink: デバッグ用サンプルの書き込み先を利用できません")
+
+/**
+ * 通常ログには出さない本文を、調査用に一時保存するためのサンプル
+ * @param Stage どの処理で採取したか（例: formatted）
+ * @param Provider 採取元のプロバイダ名
+ * @param PostIDHash ハッシュ化した投稿 ID（生の ID は保存しない）
+ * @param Content 本文
+ * @param CreatedAt 採取日時
+ * @param ExpiresAt 破棄期限。書き込み先はこれを過ぎたサンプルを返さず、削除してよい
+ */
+type Sample struct {
+	Stage      string
+	Provider   string
+	PostIDHash string
+	Content    string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+/**
+ * デバッグ用サンプルの書き込み先。通常ログとは別の、保存期限付きの場所に置く
+ */
+type Sink interface {
+	Write(ctx context.Context, sample Sample) error
+}
@@ -0,0 +1,38 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrModeratorUnavailable = errors.New("moderation: 判定サービスに接続できません")
+
+// 判定カテゴリ
+const (
+	// 自傷・自殺など、本人の安全に関わる兆候
+	CategoryCrisis = "crisis"
+	// 他者への加害予告などの暴力的な表現
+	CategoryViolence = "violence"
+)
+
+/**
+ * 投稿本文の判定結果
+ * @param Flagged いずれかのカテゴリに該当したか
+ * @param Crisis 本人の安全に関わる兆候があるか（整形せず相談窓口を案内する）
+ * @param Categories 該当したカテゴリ
+ * @param Matches 判定根拠となった語句やラベル（ログには本文を残さない前提で扱う）
+ */
+type Result struct {
+	Flagged    bool
+	Crisis     bool
+	Categories []string
+	Matches    []string
+}
+
+/**
+ * LLM へ渡す前に投稿本文を判定するモデレーターの契約
+ * Moderate: 本文を判定して結果を返す。判定サービス停止時は ErrModeratorUnavailable
+ */
+type Moderator interface {
+	Moderate(ctx context.Context, content string) (*Result, error)
+}
@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"backend/internal/domain/post"
+	"backend/internal/logging"
+)
+
+// 投稿がおみくじになるまでの進み具合
+type Status string
+
+const (
+	// 整形ジョブに載って順番を待っている
+	StatusQueued Status = "queued"
+	// ワーカーが LLM で整形している
+	StatusFormatting Status = "formatting"
+	// おみくじとして公開された
+	StatusReady Status = "ready"
+	// 判定や検証で公開しないと決まった
+	StatusRejected Status = "rejected"
+)
+
+// Terminal はこれ以上進まない状態かどうかを返す。
+func (s Status) Terminal() bool {
+	return s == StatusReady || s == StatusRejected
+}
+
+/**
+ * 投稿の状態が変わったことの通知
+ * @param PostID 対象の投稿 ID
+ * @param Status 変わった後の状態
+ * @param At 状態が変わった時刻
+ */
+type Event struct {
+	PostID post.DarkPostID
+	Status Status
+	At     time.Time
+}
+
+/**
+ * 投稿の状態変化を知らせる側の契約。通知は補助的なもので、失敗しても投稿の処理は止めない。
+ */
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+/**
+ * 投稿の状態変化を受け取る側の契約。
+ * Subscribe は ctx が終わるか終端の状態を届けたところでチャネルを閉じる。
+ * 直近の状態が分かる実装は、購読の開始時にそれを最初に届ける。
+ */
+type Subscriber interface {
+	Subscribe(ctx context.Context, postID post.DarkPostID) (<-chan Event, error)
+}
+
+// Notifier は通知と購読の両方を担う実装の契約。
+type Notifier interface {
+	Publisher
+	Subscriber
+}
+
+/**
+ * 投稿の状態変化を publisher へ知らせる。publisher が nil なら何もしない。
+ * 通知は補助的なものなので、失敗はログに残すだけにして呼び出し元へは返さない。
+ */
+func Notify(ctx context.Context, publisher Publisher, postID post.DarkPostID, status Status) {
+	if publisher == nil {
+		return
+	}
+	if err := publisher.Publish(ctx, Event{PostID: postID, Status: status}); err != nil {
+		slog.WarnContext(ctx, "post progress notification failed", logging.PostAttr(string(postID)), slog.String("status", string(status)), slog.Any("error", err))
+	}
+}
@@ -3,6 +3,7 @@ package queue
 import (
 	"context"
 	"errors"
+	"time"
 
 	"backend/internal/domain/post"
 )
@@ -11,13 +12,63 @@ var (
 	ErrJobAlreadyScheduled = errors.New("queue: 同一 ID のジョブがすでに存在します")
 	ErrQueueClosed         = errors.New("queue: ジョブキューが停止しました")
 	ErrContextClosed       = errors.New("queue: コンテキストが終了しました")
+	ErrDepthUnsupported    = errors.New("queue: キューが滞留数の取得に対応していません")
+	ErrCancelUnsupported   = errors.New("queue: キューがジョブの取り消しに対応していません")
 )
 
+/**
+ * 取り出した整形ジョブ
+ * @param PostID 整形対象の投稿 ID
+ * @param RequestID ジョブを登録した API リクエストの ID（ログの突き合わせ用。無ければ空）
+ * @param TraceContext ジョブを登録したリクエストのトレースコンテキスト（W3C traceparent など。無ければ nil）
+ */
+type FormatJob struct {
+	PostID       post.DarkPostID
+	RequestID    string
+	TraceContext map[string]string
+}
+
 /**
  * 闇投稿の整形ジョブを溜めたり取り出したりする契約。
+ * EnqueueFormat はコンテキストに載ったリクエスト ID とトレースコンテキスト（WithTraceContext）もジョブに記録する。
  */
 type JobQueue interface {
 	EnqueueFormat(ctx context.Context, postID post.DarkPostID) error
-	DequeueFormat(ctx context.Context) (post.DarkPostID, error)
+	DequeueFormat(ctx context.Context) (*FormatJob, error)
 	Close() error
 }
+
+/**
+ * 整形待ちジョブの件数を返せるキュー。監視や運用ツール向けの任意の契約。
+ */
+type DepthReporter interface {
+	Depth(ctx context.Context) (int64, error)
+}
+
+/**
+ * 整形待ちのジョブを取り消せるキュー。投稿を削除するときに使う任意の契約。
+ * 取り出し済みなどでジョブが無い場合も成功として扱う。
+ */
+type Canceller interface {
+	CancelFormat(ctx context.Context, postID post.DarkPostID) error
+}
+
+/**
+ * キューに残っている整形ジョブ
+ * @param PostID 整形対象の投稿 ID
+ * @param RequestID ジョブを登録した API リクエストの ID（無ければ空）
+ * @param QueuedAt ジョブを登録した時刻
+ */
+type QueuedJob struct {
+	PostID    post.DarkPostID
+	RequestID string
+	QueuedAt  time.Time
+}
+
+/**
+ * 取り出さずにキューの中身を覗けるキュー。運用ツール向けの任意の契約。
+ * Oldest は登録の古い順に最大 limit 件を返す。
+ */
+type Inspector interface {
+	Oldest(ctx context.Context, limit int) ([]QueuedJob, error)
+}
@@ -0,0 +1,28 @@
+package queue
+
+import "context"
+
+type pollObserverContextKey struct{}
+
+/**
+ * ジョブ待ちのポーリングごとに呼ばれる関数をコンテキストに載せる。
+ * ジョブが来るまで DequeueFormat から戻らない間も、呼び出し側が待機の継続を確認できるようにする。
+ */
+func WithPollObserver(ctx context.Context, observe func()) context.Context {
+	if observe == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, pollObserverContextKey{}, observe)
+}
+
+/**
+ * コンテキストに載ったポーリングの通知先を呼ぶ。DequeueFormat の実装が待機のたびに呼び出す。
+ */
+func NotifyPoll(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+	if observe, ok := ctx.Value(pollObserverContextKey{}).(func()); ok {
+		observe()
+	}
+}
@@ -0,0 +1,27 @@
+package queue
+
+import "context"
+
+type traceContextKey struct{}
+
+/**
+ * ジョブに記録するトレースコンテキストをコンテキストに載せる。
+ * トレースの仕組みはキューの外のデコレーターが受け持ち、キューの実装は載っていれば保存するだけにする。
+ */
+func WithTraceContext(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, traceContextKey{}, carrier)
+}
+
+/**
+ * WithTraceContext で載せたトレースコンテキストを返す。無ければ nil。
+ */
+func TraceContextFromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	carrier, _ := ctx.Value(traceContextKey{}).(map[string]string)
+	return carrier
+}
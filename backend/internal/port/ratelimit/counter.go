@@ -0,0 +1,27 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrCounterUnavailable = errors.New("ratelimit: 呼び出し回数の保存先を利用できません")
+
+/**
+ * 固定幅の時間枠 1 つ分の呼び出し回数
+ * @param Count 今回の呼び出しを含めた、枠内の呼び出し回数
+ * @param ResetAt 枠が切り替わって回数が 0 に戻る時刻
+ */
+type Window struct {
+	Count   int64
+	ResetAt time.Time
+}
+
+/**
+ * 呼び出し元ごとの呼び出し回数を、固定幅の時間枠単位で数える保存先。
+ * 複数インスタンスで上限を共有する場合は、インスタンス間で共有される保存先を使う。
+ */
+type Counter interface {
+	Increment(ctx context.Context, key string, window time.Duration) (Window, error)
+}
@@ -0,0 +1,37 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrRedactionFailed = errors.New("redaction: 個人情報の伏せ字処理に失敗しました")
+
+// 検出する個人情報の種類
+const (
+	KindPhone   = "phone"
+	KindEmail   = "email"
+	KindURL     = "url"
+	KindName    = "name"
+	KindPlace   = "place"
+	KindSchool  = "school"
+	KindAddress = "address"
+)
+
+/**
+ * 伏せ字処理の結果
+ * @param Text 伏せ字に置き換えた本文（外部へ送ってよいのはこちらだけ）
+ * @param Counts 種類ごとの置換件数（本文そのものは含めない）
+ */
+type Result struct {
+	Text   string
+	Counts map[string]int
+}
+
+/**
+ * 本文が外部サービスへ出る前に個人情報を伏せ字へ置き換える契約
+ * Redact: 置換後の本文と件数を返す。処理できない場合は ErrRedactionFailed
+ */
+type Redactor interface {
+	Redact(ctx context.Context, text string) (*Result, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"backend/internal/domain/audit"
+)
+
+/**
+ * 管理操作の記録（監査ログ）を扱うリポジトリの契約
+ * Record: 操作 1 件を追記する（書き換え・削除はしない）
+ */
+type AuditLogRepository interface {
+	Record(ctx context.Context, e *audit.Entry) error
+}
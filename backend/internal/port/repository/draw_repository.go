@@ -17,10 +17,16 @@ var (
  * おみくじ結果を扱うリポジトリの契約
  * Create: 新規保存（重複時は ErrDrawAlreadyExists）
  * GetByPostID: 闇投稿 ID から結果を取得（postID が空の場合、未存在時は ErrDrawNotFound）
- * ListReady: 公開可能（verified）なおみくじ結果一覧を返す。
+ * GetByShareID: 共有 ID から結果を取得（状態は問わない。id が空の場合、未存在時は ErrDrawNotFound）
+ * ListReady: 公開可能（verified）なおみくじ結果一覧を返す。非公開（hidden）などは含めない。
+ * Update: 状態を保存する（未存在時は ErrDrawNotFound）
+ * Delete: 削除する（未存在時は ErrDrawNotFound）
  */
 type DrawRepository interface {
 	Create(ctx context.Context, d *draw.Draw) error
 	GetByPostID(ctx context.Context, postID post.DarkPostID) (*draw.Draw, error)
+	GetByShareID(ctx context.Context, id draw.ShareID) (*draw.Draw, error)
 	ListReady(ctx context.Context) ([]*draw.Draw, error)
+	Update(ctx context.Context, d *draw.Draw) error
+	Delete(ctx context.Context, postID post.DarkPostID) error
 }
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"backend/internal/domain/outcome"
+	"backend/internal/domain/post"
+)
+
+/**
+ * 整形・検証結果（プロンプト実験の成果）を扱うリポジトリの契約
+ * Record: 1 回分の結果を追記する（同じ投稿の再整形も別件として残す。通過はおみくじを保存できた回だけ記録する）
+ * List: 記録済みの結果をすべて返す
+ * ListByPostID: 指定投稿の結果を記録順に返す
+ * DeleteByPostID: 指定投稿の結果をすべて消す（投稿の削除用。無くても成功）
+ */
+type OutcomeRepository interface {
+	Record(ctx context.Context, o *outcome.Outcome) error
+	List(ctx context.Context) ([]*outcome.Outcome, error)
+	ListByPostID(ctx context.Context, postID post.DarkPostID) ([]*outcome.Outcome, error)
+	DeleteByPostID(ctx context.Context, postID post.DarkPostID) error
+}
@@ -17,11 +17,15 @@ var (
  * Create: 新規保存、重複時は ErrPostAlreadyExists
  * Get: ID 取得、未存在時は ErrPostNotFound
  * ListReady: ready 投稿を最大 limit 件返す
+ * ListByStatus: 指定状態の投稿を ID 順に、after より後ろから最大 limit 件返す（after が空なら先頭から）
  * Update: 更新、対象欠如時は ErrPostNotFound
+ * Delete: 削除、対象欠如時は ErrPostNotFound
  */
 type PostRepository interface {
 	Create(ctx context.Context, p *post.Post) error
 	Get(ctx context.Context, id post.DarkPostID) (*post.Post, error)
 	ListReady(ctx context.Context, limit int) ([]*post.Post, error)
+	ListByStatus(ctx context.Context, status post.Status, after post.DarkPostID, limit int) ([]*post.Post, error)
 	Update(ctx context.Context, p *post.Post) error
+	Delete(ctx context.Context, id post.DarkPostID) error
 }
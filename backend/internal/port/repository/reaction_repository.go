@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+)
+
+var ErrReactionAlreadyExists = errors.New("repository: このおみくじにはすでに反応しています")
+
+/**
+ * おみくじへの反応を扱うリポジトリの契約
+ * Add: 反応を保存して種類ごとの数に足す（同じ訪問者が同じおみくじへ 2 回目なら ErrReactionAlreadyExists）
+ * CountByPostID: 指定おみくじの種類ごとの反応数を返す（反応が無ければ空）
+ * CountByPostIDs: 複数のおみくじの反応数をまとめて返す（反応の無いおみくじは含めない）
+ * DeleteByPostID: 指定おみくじの反応と数をすべて消す（反応が無くても成功）
+ */
+type ReactionRepository interface {
+	Add(ctx context.Context, r *reaction.Reaction) error
+	CountByPostID(ctx context.Context, postID post.DarkPostID) (reaction.Counts, error)
+	CountByPostIDs(ctx context.Context, postIDs []post.DarkPostID) (map[post.DarkPostID]reaction.Counts, error)
+	DeleteByPostID(ctx context.Context, postID post.DarkPostID) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"backend/internal/domain/post"
+	"backend/internal/domain/report"
+)
+
+var ErrReportAlreadyExists = errors.New("repository: このおみくじはすでに通報されています")
+
+/**
+ * おみくじへの通報と、その審査待ちの列（モデレーションキュー）を扱うリポジトリの契約
+ * Add: 通報を保存し、おみくじごとの審査待ちの項目へ積み上げて、積み上げた後の項目を返す
+ *      （同じ訪問者、または同じ接続元 IP から同じおみくじへ 2 回目なら ErrReportAlreadyExists）
+ * ListOpen: 審査待ちの項目を通報の多い順に最大 limit 件返す
+ * Resolve: 審査待ちの項目を閉じ、数と理由を 0 に戻す（項目が無ければ何もしない）。
+ *          通報済みの訪問者・接続元 IP の記録は残すため、同じ相手からの再通報は数えない
+ * DeleteByPostID: 指定おみくじの通報・接続元 IP の記録・審査待ちの項目をすべて消す（投稿の削除用。無くても成功）
+ */
+type ReportRepository interface {
+	Add(ctx context.Context, r *report.Report) (*report.Review, error)
+	ListOpen(ctx context.Context, limit int) ([]*report.Review, error)
+	Resolve(ctx context.Context, postID post.DarkPostID) error
+	DeleteByPostID(ctx context.Context, postID post.DarkPostID) error
+}
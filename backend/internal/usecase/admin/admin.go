@@ -0,0 +1,389 @@
+package admin
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"backend/internal/domain/audit"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
+	"backend/internal/domain/post"
+	"backend/internal/domain/report"
+	"backend/internal/logging"
+	"backend/internal/port/queue"
+	"backend/internal/port/repository"
+)
+
+const (
+	// DefaultPageSize は件数の指定が無いときの 1 ページの件数。
+	DefaultPageSize = 20
+	// MaxPageSize は 1 ページに載せる件数の上限。
+	MaxPageSize = 100
+)
+
+var (
+	ErrInvalidCursor       = errors.New("admin: カーソルが不正です")
+	ErrInvalidStatus       = errors.New("admin: 投稿の状態が不正です")
+	ErrPostNotPending      = errors.New("admin: 整形待ちの投稿ではありません")
+	ErrJobAlreadyScheduled = errors.New("admin: 整形ジョブがすでに登録済みです")
+)
+
+/**
+ * 状態ごとの投稿一覧の 1 ページ
+ * @param Posts 投稿 ID 順の投稿
+ * @param NextCursor 続きを取得するためのカーソル（最後のページなら空）
+ */
+type PostPage struct {
+	Posts      []*post.Post
+	NextCursor string
+}
+
+/**
+ * 投稿 1 件の詳細
+ * @param Post 投稿
+ * @param Draw 投稿から作ったおみくじ（まだ無ければ nil）
+ * @param Outcomes 整形・検証の結果（却下理由を含む）を記録順に
+ */
+type PostDetail struct {
+	Post     *post.Post
+	Draw     *drawdomain.Draw
+	Outcomes []*outcome.Outcome
+}
+
+/**
+ * 管理者が投稿とおみくじを確認・操作するユースケース
+ * posts: 投稿の保存先
+ * draws: おみくじの保存先
+ * outcomes: 整形・検証の結果（却下理由の表示に使う）
+ * jobs: 整形ジョブキュー（積み直しと、削除時の取り消しに使う）
+ * audit: 操作の記録先。成功・失敗にかかわらず、すべての操作を残す
+ * reports: 通報の審査待ちの列（未設定なら通報の確認・審査の完了は行わない）
+ * reactions: おみくじへの反応（投稿の削除時に消す）
+ * cards: 保存済みの共有カード画像（投稿の削除時に消す）
+ */
+type AdminUsecase struct {
+	posts     repository.PostRepository
+	draws     repository.DrawRepository
+	outcomes  repository.OutcomeRepository
+	jobs      queue.JobQueue
+	audit     repository.AuditLogRepository
+	reports   repository.ReportRepository
+	reactions repository.ReactionRepository
+	cards     ShareCardForgetter
+}
+
+// ShareCardForgetter は保存済みの共有カード画像を消す。
+type ShareCardForgetter interface {
+	Forget(ctx context.Context, d *drawdomain.Draw) error
+}
+
+// NewAdminUsecase は AdminUsecase を生成する。
+func NewAdminUsecase(posts repository.PostRepository, draws repository.DrawRepository, outcomes repository.OutcomeRepository, jobs queue.JobQueue, audit repository.AuditLogRepository) *AdminUsecase {
+	return &AdminUsecase{posts: posts, draws: draws, outcomes: outcomes, jobs: jobs, audit: audit}
+}
+
+// WithReports は通報の審査待ちの列を設定する。おみくじを審査したら、その項目も閉じる。
+func (u *AdminUsecase) WithReports(reports repository.ReportRepository) *AdminUsecase {
+	u.reports = reports
+	return u
+}
+
+// WithReactions はおみくじへの反応の保存先を設定する。投稿を消すときに反応も消す。
+func (u *AdminUsecase) WithReactions(reactions repository.ReactionRepository) *AdminUsecase {
+	u.reactions = reactions
+	return u
+}
+
+// WithShareCards は共有カード画像の保存先を設定する。投稿を消すときに保存済みの画像も消す。
+func (u *AdminUsecase) WithShareCards(cards ShareCardForgetter) *AdminUsecase {
+	u.cards = cards
+	return u
+}
+
+/**
+ * 指定状態の投稿を投稿 ID 順に 1 ページ分返す。cursor には前のページの NextCursor を渡す（先頭なら空）。
+ */
+func (u *AdminUsecase) ListPosts(ctx context.Context, actor string, status post.Status, cursor string, limit int) (page *PostPage, err error) {
+	defer u.record(ctx, actor, audit.ActionListPosts, "", map[string]string{"status": string(status), "cursor": cursor}, &err)
+
+	if !status.Valid() {
+		return nil, ErrInvalidStatus
+	}
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	// 1 件多く読み、続きがあるかを判定する
+	posts, err := u.posts.ListByStatus(ctx, status, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page = &PostPage{Posts: posts}
+	if len(posts) > limit {
+		page.Posts = posts[:limit]
+		page.NextCursor = encodeCursor(page.Posts[limit-1].ID())
+	}
+	return page, nil
+}
+
+/**
+ * 投稿と、そのおみくじ・整形結果を返す。おみくじや整形結果が無くても投稿があれば返す。
+ */
+func (u *AdminUsecase) GetPost(ctx context.Context, actor string, id post.DarkPostID) (detail *PostDetail, err error) {
+	defer u.record(ctx, actor, audit.ActionViewPost, string(id), nil, &err)
+
+	p, err := u.posts.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	detail = &PostDetail{Post: p}
+	d, err := u.draws.GetByPostID(ctx, id)
+	if err != nil && !errors.Is(err, repository.ErrDrawNotFound) {
+		return nil, err
+	}
+	detail.Draw = d
+	if u.outcomes != nil {
+		if detail.Outcomes, err = u.outcomes.ListByPostID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return detail, nil
+}
+
+/**
+ * 審査待ち・非公開のおみくじを公開する。公開中・却下済みのおみくじは ErrInvalidStatusTransition になる。
+ */
+func (u *AdminUsecase) ApproveDraw(ctx context.Context, actor string, id post.DarkPostID) (*drawdomain.Draw, error) {
+	return u.transition(ctx, actor, audit.ActionApproveDraw, id, nil, (*drawdomain.Draw).MarkVerified)
+}
+
+/**
+ * 審査待ちのおみくじを却下する。reason は監査ログに残す。
+ */
+func (u *AdminUsecase) RejectDraw(ctx context.Context, actor string, id post.DarkPostID, reason string) (*drawdomain.Draw, error) {
+	return u.transition(ctx, actor, audit.ActionRejectDraw, id, map[string]string{"reason": reason}, (*drawdomain.Draw).MarkRejected)
+}
+
+/**
+ * 公開中のおみくじを非公開にし、抽選と共有リンクから外す。
+ */
+func (u *AdminUsecase) HideDraw(ctx context.Context, actor string, id post.DarkPostID) (*drawdomain.Draw, error) {
+	return u.transition(ctx, actor, audit.ActionHideDraw, id, nil, (*drawdomain.Draw).MarkHidden)
+}
+
+/**
+ * 公開中のおみくじの公開期間を終え、抽選と共有リンクから外す。非公開と違い、公開には戻せない。
+ */
+func (u *AdminUsecase) ExpireDraw(ctx context.Context, actor string, id post.DarkPostID) (*drawdomain.Draw, error) {
+	return u.transition(ctx, actor, audit.ActionExpireDraw, id, nil, (*drawdomain.Draw).MarkExpired)
+}
+
+/**
+ * 通報の審査待ちの項目を通報の多い順に返す。limit の既定は 20（最大 100）。
+ */
+func (u *AdminUsecase) ListReports(ctx context.Context, actor string, limit int) (reviews []*report.Review, err error) {
+	defer u.record(ctx, actor, audit.ActionListReports, "", nil, &err)
+
+	if u.reports == nil {
+		return []*report.Review{}, nil
+	}
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	return u.reports.ListOpen(ctx, min(limit, MaxPageSize))
+}
+
+/**
+ * おみくじの状態は変えずに、通報の審査待ちの項目だけを閉じる（問題なしと判断した場合など）。
+ * 閉じた後の通報は 0 から数え直す。
+ */
+func (u *AdminUsecase) ResolveReports(ctx context.Context, actor string, id post.DarkPostID) (err error) {
+	defer u.record(ctx, actor, audit.ActionResolveReports, string(id), nil, &err)
+
+	if _, err := u.draws.GetByPostID(ctx, id); err != nil {
+		return err
+	}
+	if u.reports == nil {
+		return nil
+	}
+	return u.reports.Resolve(ctx, id)
+}
+
+/**
+ * 整形待ちの投稿を整形ジョブへ積み直す。整形済み・対象外の投稿は ErrPostNotPending になる。
+ */
+func (u *AdminUsecase) RequeuePost(ctx context.Context, actor string, id post.DarkPostID) (err error) {
+	defer u.record(ctx, actor, audit.ActionRequeuePost, string(id), nil, &err)
+
+	p, err := u.posts.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if p.Status() != post.StatusPending {
+		return ErrPostNotPending
+	}
+	if err := u.jobs.EnqueueFormat(ctx, id); err != nil {
+		if errors.Is(err, queue.ErrJobAlreadyScheduled) {
+			return ErrJobAlreadyScheduled
+		}
+		return err
+	}
+	return nil
+}
+
+/**
+ * 整形待ちの投稿をまとめて積み直した結果
+ * @param Enqueued 積み直した投稿 ID
+ * @param AlreadyScheduled ジョブが残っていたため積まなかった投稿 ID
+ */
+type RequeueResult struct {
+	Enqueued         []post.DarkPostID
+	AlreadyScheduled []post.DarkPostID
+}
+
+/**
+ * 整形待ちの投稿をすべて整形ジョブへ積み直す。1 件ずつ RequeuePost と同じく監査ログに残す。
+ * ジョブが残っている投稿は飛ばし、それ以外の失敗で止める（それまでに積んだ分は結果に含める）。
+ */
+func (u *AdminUsecase) RequeuePending(ctx context.Context, actor string) (*RequeueResult, error) {
+	result := &RequeueResult{}
+	var after post.DarkPostID
+	for {
+		posts, err := u.posts.ListByStatus(ctx, post.StatusPending, after, MaxPageSize)
+		if err != nil {
+			return result, err
+		}
+		for _, p := range posts {
+			err := u.RequeuePost(ctx, actor, p.ID())
+			switch {
+			case err == nil:
+				result.Enqueued = append(result.Enqueued, p.ID())
+			case errors.Is(err, ErrJobAlreadyScheduled):
+				result.AlreadyScheduled = append(result.AlreadyScheduled, p.ID())
+			default:
+				return result, fmt.Errorf("requeue %s: %w", p.ID(), err)
+			}
+		}
+		if len(posts) < MaxPageSize {
+			return result, nil
+		}
+		after = posts[len(posts)-1].ID()
+	}
+}
+
+/**
+ * 投稿を、それに付くデータごと削除する。
+ * 消している間に Worker がおみくじを作り直さないよう、整形待ちのジョブを先に取り消してから、
+ * 共有カード画像・反応・通報と審査待ちの項目・整形結果・おみくじの順に消す。
+ * 途中で失敗しても再実行で続きから消せるよう、投稿は最後に消す。監査ログは操作の記録なので残す。
+ */
+func (u *AdminUsecase) DeletePost(ctx context.Context, actor string, id post.DarkPostID) (err error) {
+	defer u.record(ctx, actor, audit.ActionDeletePost, string(id), nil, &err)
+
+	if _, err := u.posts.Get(ctx, id); err != nil {
+		return err
+	}
+	// 計測・トレースのデコレーターは常に Canceller を満たすため、包んだキューが未対応なら取り消しを省く
+	if canceller, ok := u.jobs.(queue.Canceller); ok {
+		if err := canceller.CancelFormat(ctx, id); err != nil && !errors.Is(err, queue.ErrCancelUnsupported) {
+			return fmt.Errorf("cancel format job: %w", err)
+		}
+	}
+	d, err := u.draws.GetByPostID(ctx, id)
+	if err != nil && !errors.Is(err, repository.ErrDrawNotFound) {
+		return fmt.Errorf("get draw: %w", err)
+	}
+	// 画像のキーはおみくじの共有 ID と本文から決まるため、おみくじより先に消す
+	if d != nil && u.cards != nil {
+		if err := u.cards.Forget(ctx, d); err != nil {
+			return err
+		}
+	}
+	if u.reactions != nil {
+		if err := u.reactions.DeleteByPostID(ctx, id); err != nil {
+			return fmt.Errorf("delete reactions: %w", err)
+		}
+	}
+	if u.reports != nil {
+		if err := u.reports.DeleteByPostID(ctx, id); err != nil {
+			return fmt.Errorf("delete reports: %w", err)
+		}
+	}
+	if u.outcomes != nil {
+		if err := u.outcomes.DeleteByPostID(ctx, id); err != nil {
+			return fmt.Errorf("delete outcomes: %w", err)
+		}
+	}
+	if err := u.draws.Delete(ctx, id); err != nil && !errors.Is(err, repository.ErrDrawNotFound) {
+		return fmt.Errorf("delete draw: %w", err)
+	}
+	return u.posts.Delete(ctx, id)
+}
+
+/**
+ * おみくじの状態を change で変えて保存する。遷移前の状態は監査ログに残す。
+ * 管理者が審査したので、通報の審査待ちの項目も閉じ、以降の通報は 0 から数え直す。
+ */
+func (u *AdminUsecase) transition(ctx context.Context, actor string, action audit.Action, id post.DarkPostID, detail map[string]string, change func(*drawdomain.Draw) error) (d *drawdomain.Draw, err error) {
+	if detail == nil {
+		detail = make(map[string]string)
+	}
+	defer u.record(ctx, actor, action, string(id), detail, &err)
+
+	d, err = u.draws.GetByPostID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	detail["from"] = string(d.Status())
+	if err := change(d); err != nil {
+		return nil, err
+	}
+	if err := u.draws.Update(ctx, d); err != nil {
+		return nil, err
+	}
+	if u.reports != nil {
+		if err := u.reports.Resolve(ctx, id); err != nil {
+			return nil, fmt.Errorf("resolve reports: %w", err)
+		}
+	}
+	return d, nil
+}
+
+/**
+ * 操作を監査ログへ残す。操作の結果は変えずに、記録の失敗はログに残すだけにする。
+ */
+func (u *AdminUsecase) record(ctx context.Context, actor string, action audit.Action, target string, detail map[string]string, errp *error) {
+	entry, err := audit.New(actor, action, target, detail)
+	if err == nil {
+		entry.Fail(*errp)
+		err = u.audit.Record(ctx, entry)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to record admin action", slog.String("action", string(action)), logging.PostAttr(target), slog.Any("error", err))
+	}
+}
+
+// encodeCursor は続きの起点となる投稿 ID を URL にそのまま載せられる形にする。
+func encodeCursor(id post.DarkPostID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+// decodeCursor はカーソルから起点の投稿 ID を取り出す。空なら先頭から。
+func decodeCursor(cursor string) (post.DarkPostID, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) == 0 {
+		return "", ErrInvalidCursor
+	}
+	return post.DarkPostID(raw), nil
+}
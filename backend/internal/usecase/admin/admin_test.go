@@ -0,0 +1,430 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/adapter/repository/memory"
+	"backend/internal/domain/audit"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/domain/report"
+	"backend/internal/port/queue"
+	"backend/internal/port/repository"
+)
+
+type fixture struct {
+	posts     *memory.InMemoryPostRepository
+	draws     *memory.InMemoryDrawRepository
+	outcomes  *memory.InMemoryOutcomeRepository
+	jobs      *stubJobQueue
+	audit     *memory.InMemoryAuditLogRepository
+	reports   *memory.InMemoryReportRepository
+	reactions *memory.InMemoryReactionRepository
+	cards     *stubShareCards
+	uc        *AdminUsecase
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		posts:     memory.NewInMemoryPostRepository(),
+		draws:     memory.NewInMemoryDrawRepository(),
+		outcomes:  memory.NewInMemoryOutcomeRepository(),
+		jobs:      &stubJobQueue{scheduled: map[post.DarkPostID]bool{}},
+		audit:     memory.NewInMemoryAuditLogRepository(),
+		reports:   memory.NewInMemoryReportRepository(),
+		reactions: memory.NewInMemoryReactionRepository(),
+		cards:     &stubShareCards{},
+	}
+	f.uc = NewAdminUsecase(f.posts, f.draws, f.outcomes, f.jobs, f.audit).
+		WithReports(f.reports).
+		WithReactions(f.reactions).
+		WithShareCards(f.cards)
+	return f
+}
+
+func (f *fixture) addPost(t *testing.T, id post.DarkPostID, ready bool) {
+	t.Helper()
+	p, err := post.New(id, "content")
+	if err != nil {
+		t.Fatalf("post.New() error = %v", err)
+	}
+	if ready {
+		_ = p.MarkReady()
+	}
+	if err := f.posts.Create(context.Background(), p); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+}
+
+func (f *fixture) addDraw(t *testing.T, id post.DarkPostID, status drawdomain.Status) {
+	t.Helper()
+	d, err := drawdomain.Restore(id, "fortune", status)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if err := f.draws.Create(context.Background(), d); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+}
+
+func (f *fixture) addReport(t *testing.T, id post.DarkPostID, clientIP string) {
+	t.Helper()
+	r, err := report.New(id, "visitor-"+clientIP, clientIP, report.ReasonSpam)
+	if err != nil {
+		t.Fatalf("report.New() error = %v", err)
+	}
+	if _, err := f.reports.Add(context.Background(), r); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+}
+
+// lastEntry は最後に記録された操作を返す。
+func (f *fixture) lastEntry(t *testing.T) *audit.Entry {
+	t.Helper()
+	entries := f.audit.Entries()
+	if len(entries) == 0 {
+		t.Fatalf("expected audit entries")
+	}
+	return entries[len(entries)-1]
+}
+
+func TestAdminUsecase_ListPostsPaginates(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	for _, id := range []post.DarkPostID{"post-c", "post-a", "post-b"} {
+		f.addPost(t, id, false)
+	}
+	f.addPost(t, "post-ready", true)
+
+	first, err := f.uc.ListPosts(ctx, "admin", post.StatusPending, "", 2)
+	if err != nil {
+		t.Fatalf("ListPosts() error = %v", err)
+	}
+	if len(first.Posts) != 2 || first.Posts[0].ID() != "post-a" || first.NextCursor == "" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	second, err := f.uc.ListPosts(ctx, "admin", post.StatusPending, first.NextCursor, 2)
+	if err != nil {
+		t.Fatalf("ListPosts() error = %v", err)
+	}
+	if len(second.Posts) != 1 || second.Posts[0].ID() != "post-c" || second.NextCursor != "" {
+		t.Fatalf("unexpected second page: %+v", second)
+	}
+	if e := f.lastEntry(t); e.Action() != audit.ActionListPosts || e.Actor() != "admin" || e.Detail()["status"] != "pending" {
+		t.Fatalf("unexpected audit entry: %+v", e)
+	}
+}
+
+func TestAdminUsecase_ListPostsRejectsInvalidInput(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+
+	if _, err := f.uc.ListPosts(ctx, "admin", post.Status("deleted"), "", 0); !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("expected ErrInvalidStatus, got %v", err)
+	}
+	if _, err := f.uc.ListPosts(ctx, "admin", post.StatusPending, "%%%", 0); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+	// 失敗した操作も残す
+	if e := f.lastEntry(t); e.Succeeded() {
+		t.Fatalf("expected failed audit entry, got %+v", e)
+	}
+}
+
+func TestAdminUsecase_GetPost(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	f.addPost(t, "post-1", false)
+	rejected, _ := outcome.New("post-1", "fortune-v1", drawdomain.StatusRejected, "禁止語を含む", "x")
+	_ = f.outcomes.Record(ctx, rejected)
+
+	detail, err := f.uc.GetPost(ctx, "admin", "post-1")
+	if err != nil {
+		t.Fatalf("GetPost() error = %v", err)
+	}
+	if detail.Post.ID() != "post-1" || detail.Draw != nil || len(detail.Outcomes) != 1 || detail.Outcomes[0].Reason() != "禁止語を含む" {
+		t.Fatalf("unexpected detail: %+v", detail)
+	}
+
+	f.addDraw(t, "post-1", drawdomain.StatusVerified)
+	if detail, err = f.uc.GetPost(ctx, "admin", "post-1"); err != nil || detail.Draw == nil {
+		t.Fatalf("expected draw, got %+v %v", detail, err)
+	}
+	if _, err := f.uc.GetPost(ctx, "admin", "missing"); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Fatalf("expected ErrPostNotFound, got %v", err)
+	}
+}
+
+func TestAdminUsecase_DrawTransitions(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	f.addDraw(t, "post-pending", drawdomain.StatusPending)
+	f.addDraw(t, "post-verified", drawdomain.StatusVerified)
+
+	if _, err := f.uc.RejectDraw(ctx, "admin", "post-pending", "spam"); err != nil {
+		t.Fatalf("RejectDraw() error = %v", err)
+	}
+	if e := f.lastEntry(t); e.Action() != audit.ActionRejectDraw || e.Detail()["reason"] != "spam" || e.Detail()["from"] != "pending" {
+		t.Fatalf("unexpected audit entry: %+v", e)
+	}
+	if _, err := f.uc.ApproveDraw(ctx, "admin", "post-pending"); !errors.Is(err, drawdomain.ErrInvalidStatusTransition) {
+		t.Fatalf("rejected draw should not be approved, got %v", err)
+	}
+
+	hidden, err := f.uc.HideDraw(ctx, "admin", "post-verified")
+	if err != nil || hidden.Status() != drawdomain.StatusHidden {
+		t.Fatalf("HideDraw() = %v, %v", hidden, err)
+	}
+	if ready, _ := f.draws.ListReady(ctx); len(ready) != 0 {
+		t.Fatalf("hidden draw should not be drawable, got %d", len(ready))
+	}
+	approved, err := f.uc.ApproveDraw(ctx, "admin", "post-verified")
+	if err != nil || approved.Status() != drawdomain.StatusVerified {
+		t.Fatalf("ApproveDraw() = %v, %v", approved, err)
+	}
+	if _, err := f.uc.HideDraw(ctx, "admin", "missing"); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound, got %v", err)
+	}
+
+	expired, err := f.uc.ExpireDraw(ctx, "admin", "post-verified")
+	if err != nil || expired.Status() != drawdomain.StatusExpired {
+		t.Fatalf("ExpireDraw() = %v, %v", expired, err)
+	}
+	if e := f.lastEntry(t); e.Action() != audit.ActionExpireDraw || e.Detail()["from"] != "verified" {
+		t.Fatalf("unexpected audit entry: %+v", e)
+	}
+	if ready, _ := f.draws.ListReady(ctx); len(ready) != 0 {
+		t.Fatalf("expired draw should not be drawable, got %d", len(ready))
+	}
+	// 公開期間を終えたおみくじは公開に戻さない
+	if _, err := f.uc.ApproveDraw(ctx, "admin", "post-verified"); !errors.Is(err, drawdomain.ErrInvalidStatusTransition) {
+		t.Fatalf("expired draw should not be approved, got %v", err)
+	}
+	if _, err := f.uc.ExpireDraw(ctx, "admin", "post-pending"); !errors.Is(err, drawdomain.ErrInvalidStatusTransition) {
+		t.Fatalf("rejected draw should not expire, got %v", err)
+	}
+}
+
+func TestAdminUsecase_RequeuePost(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	f.addPost(t, "post-pending", false)
+	f.addPost(t, "post-ready", true)
+
+	if err := f.uc.RequeuePost(ctx, "admin", "post-pending"); err != nil {
+		t.Fatalf("RequeuePost() error = %v", err)
+	}
+	if !f.jobs.scheduled["post-pending"] {
+		t.Fatalf("expected job to be enqueued")
+	}
+	if err := f.uc.RequeuePost(ctx, "admin", "post-pending"); !errors.Is(err, ErrJobAlreadyScheduled) {
+		t.Fatalf("expected ErrJobAlreadyScheduled, got %v", err)
+	}
+	if err := f.uc.RequeuePost(ctx, "admin", "post-ready"); !errors.Is(err, ErrPostNotPending) {
+		t.Fatalf("expected ErrPostNotPending, got %v", err)
+	}
+}
+
+func TestAdminUsecase_RequeuePending(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	f.addPost(t, "post-a", false)
+	f.addPost(t, "post-b", false)
+	f.addPost(t, "post-ready", true)
+	f.jobs.scheduled["post-b"] = true
+
+	result, err := f.uc.RequeuePending(ctx, "cli")
+	if err != nil {
+		t.Fatalf("RequeuePending() error = %v", err)
+	}
+	if len(result.Enqueued) != 1 || result.Enqueued[0] != "post-a" {
+		t.Fatalf("unexpected enqueued: %v", result.Enqueued)
+	}
+	if len(result.AlreadyScheduled) != 1 || result.AlreadyScheduled[0] != "post-b" {
+		t.Fatalf("unexpected already scheduled: %v", result.AlreadyScheduled)
+	}
+	if f.jobs.scheduled["post-ready"] {
+		t.Fatalf("ready post must not be requeued")
+	}
+	// 1 件ずつ監査ログに残る
+	if got := len(f.audit.Entries()); got != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", got)
+	}
+}
+
+func TestAdminUsecase_ListAndResolveReports(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	f.addDraw(t, "post-1", drawdomain.StatusVerified)
+	f.addReport(t, "post-1", "198.51.100.1")
+	f.addReport(t, "post-1", "198.51.100.2")
+
+	reviews, err := f.uc.ListReports(ctx, "admin", 0)
+	if err != nil {
+		t.Fatalf("ListReports() error = %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].PostID != "post-1" || reviews[0].Count != 2 {
+		t.Fatalf("unexpected reviews: %+v", reviews)
+	}
+	if e := f.lastEntry(t); e.Action() != audit.ActionListReports || !e.Succeeded() {
+		t.Fatalf("unexpected audit entry: %+v", e)
+	}
+
+	if err := f.uc.ResolveReports(ctx, "admin", "post-1"); err != nil {
+		t.Fatalf("ResolveReports() error = %v", err)
+	}
+	if reviews, _ := f.uc.ListReports(ctx, "admin", 0); len(reviews) != 0 {
+		t.Fatalf("resolved reports should leave the list: %+v", reviews)
+	}
+	if err := f.uc.ResolveReports(ctx, "admin", "missing"); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound, got %v", err)
+	}
+}
+
+func TestAdminUsecase_ReviewRestartsReportCount(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	f.addDraw(t, "post-1", drawdomain.StatusHidden)
+	f.addReport(t, "post-1", "198.51.100.1")
+	f.addReport(t, "post-1", "198.51.100.2")
+
+	// 自動で非公開になったおみくじを承認したら、それまでの通報は数え直しにする
+	if _, err := f.uc.ApproveDraw(ctx, "admin", "post-1"); err != nil {
+		t.Fatalf("ApproveDraw() error = %v", err)
+	}
+	if reviews, _ := f.reports.ListOpen(ctx, 0); len(reviews) != 0 {
+		t.Fatalf("approval should resolve the review: %+v", reviews)
+	}
+	r, _ := report.New("post-1", "visitor-new", "198.51.100.3", report.ReasonSpam)
+	review, err := f.reports.Add(ctx, r)
+	if err != nil || review.Count != 1 {
+		t.Fatalf("expected the count to restart after review, got %+v %v", review, err)
+	}
+}
+
+func TestAdminUsecase_DeletePost(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	f.addPost(t, "post-1", false)
+	f.addDraw(t, "post-1", drawdomain.StatusVerified)
+	f.addReport(t, "post-1", "198.51.100.1")
+	f.jobs.scheduled["post-1"] = true
+	rc, _ := reaction.New("post-1", "visitor-1", reaction.KindAccurate)
+	_ = f.reactions.Add(ctx, rc)
+	o, _ := outcome.New("post-1", "fortune-v1", drawdomain.StatusVerified, "", "fortune")
+	_ = f.outcomes.Record(ctx, o)
+	// 他の投稿のデータは消さない
+	f.addPost(t, "post-2", false)
+	f.addReport(t, "post-2", "198.51.100.2")
+	// 削除中に Worker がおみくじを作り直さないよう、ジョブはおみくじより先に取り消す
+	f.jobs.onCancel = func(id post.DarkPostID) {
+		if _, err := f.draws.GetByPostID(ctx, id); err != nil {
+			t.Errorf("job should be cancelled before the draw is deleted, got %v", err)
+		}
+	}
+
+	if err := f.uc.DeletePost(ctx, "admin", "post-1"); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+	if _, err := f.posts.Get(ctx, "post-1"); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Fatalf("post should be deleted, got %v", err)
+	}
+	if _, err := f.draws.GetByPostID(ctx, "post-1"); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("draw should be deleted, got %v", err)
+	}
+	if f.jobs.scheduled["post-1"] {
+		t.Fatalf("job should be cancelled")
+	}
+	if len(f.cards.forgotten) != 1 || f.cards.forgotten[0] != "post-1" {
+		t.Fatalf("share card should be deleted, got %v", f.cards.forgotten)
+	}
+	if counts, _ := f.reactions.CountByPostID(ctx, "post-1"); counts.Total() != 0 {
+		t.Fatalf("reactions should be deleted, got %v", counts)
+	}
+	if outcomes, _ := f.outcomes.ListByPostID(ctx, "post-1"); len(outcomes) != 0 {
+		t.Fatalf("outcomes should be deleted, got %d", len(outcomes))
+	}
+	reviews, _ := f.reports.ListOpen(ctx, 0)
+	if len(reviews) != 1 || reviews[0].PostID != "post-2" {
+		t.Fatalf("only the reports of post-1 should be deleted, got %+v", reviews)
+	}
+	// 通報の記録も消えているため、同じ訪問者からの通報も新しい投稿と同じく数える
+	again, _ := report.New("post-1", "visitor-198.51.100.1", "198.51.100.1", report.ReasonSpam)
+	if _, err := f.reports.Add(ctx, again); err != nil {
+		t.Fatalf("report records should be deleted, got %v", err)
+	}
+	if err := f.uc.DeletePost(ctx, "admin", "post-1"); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Fatalf("expected ErrPostNotFound, got %v", err)
+	}
+	if e := f.lastEntry(t); e.Action() != audit.ActionDeletePost || e.Succeeded() {
+		t.Fatalf("unexpected audit entry: %+v", e)
+	}
+}
+
+func TestAdminUsecase_DeletePostSkipsUnsupportedCancel(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	f.uc = NewAdminUsecase(f.posts, f.draws, f.outcomes, unsupportedCancelQueue{f.jobs}, f.audit)
+	f.addPost(t, "post-1", false)
+
+	// 包んだキューが取り消しに対応していなくても、投稿の削除は続ける
+	if err := f.uc.DeletePost(ctx, "admin", "post-1"); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+	if _, err := f.posts.Get(ctx, "post-1"); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Fatalf("post should be deleted, got %v", err)
+	}
+}
+
+// unsupportedCancelQueue は取り消しに対応しないキューを包んだデコレーターの振る舞いを真似る。
+type unsupportedCancelQueue struct {
+	*stubJobQueue
+}
+
+func (unsupportedCancelQueue) CancelFormat(context.Context, post.DarkPostID) error {
+	return queue.ErrCancelUnsupported
+}
+
+// stubJobQueue は積まれたジョブを覚える整形キュー。取り消しにも対応する。
+type stubJobQueue struct {
+	scheduled map[post.DarkPostID]bool
+	onCancel  func(post.DarkPostID)
+}
+
+func (q *stubJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostID) error {
+	if q.scheduled[id] {
+		return queue.ErrJobAlreadyScheduled
+	}
+	q.scheduled[id] = true
+	return nil
+}
+
+func (q *stubJobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	return nil, queue.ErrQueueClosed
+}
+
+func (q *stubJobQueue) Close() error {
+	return nil
+}
+
+func (q *stubJobQueue) CancelFormat(ctx context.Context, id post.DarkPostID) error {
+	if q.onCancel != nil {
+		q.onCancel(id)
+	}
+	delete(q.scheduled, id)
+	return nil
+}
+
+// stubShareCards は消した共有カード画像のおみくじを覚える。
+type stubShareCards struct {
+	forgotten []post.DarkPostID
+}
+
+func (s *stubShareCards) Forget(ctx context.Context, d *drawdomain.Draw) error {
+	s.forgotten = append(s.forgotten, d.PostID())
+	return nil
+}
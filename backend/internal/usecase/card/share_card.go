@@ -0,0 +1,120 @@
+package card
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/port/blob"
+	portcard "backend/internal/port/card"
+	drawusecase "backend/internal/usecase/draw"
+)
+
+var ErrRenderFailed = errors.New("share_card: 共有カードを描けませんでした")
+
+// layoutVersion はカードの見た目のバージョン。描き方を変えたら上げ、保存済みの古い画像を使わないようにする。
+const layoutVersion = "v1"
+
+// カードに描く固定の文言。おみくじ本文の冒頭に付く見出しは本文から外して見出し欄へ置く。
+const (
+	cardHeading = "今日のきらくじ"
+	cardBrand   = "きらくじ"
+)
+
+/**
+ * 共有カード画像
+ * @param PNG 画像本体
+ * @param ETag 内容とレイアウトから決まる ETag（引用符付き）
+ */
+type Image struct {
+	PNG  []byte
+	ETag string
+}
+
+/**
+ * 共有リンク用のカード画像を返すユースケース
+ * draws: 共有できるおみくじの取得
+ * renderer: カードの描画
+ * store: 描いた画像の保存先（キャッシュ）
+ */
+type ShareCardUsecase struct {
+	draws    drawusecase.SharedDrawFinder
+	renderer portcard.Renderer
+	store    blob.Store
+}
+
+// NewShareCardUsecase は ShareCardUsecase を生成する。
+func NewShareCardUsecase(draws drawusecase.SharedDrawFinder, renderer portcard.Renderer, store blob.Store) *ShareCardUsecase {
+	return &ShareCardUsecase{draws: draws, renderer: renderer, store: store}
+}
+
+/**
+ * 共有 ID に対応するおみくじのカード画像を返す。検証済みでなければ SharedDraw のエラーをそのまま返す。
+ * 保存先にあればそれを返し、無ければ描いて保存する。保存先の不調は描き直しで補い、失敗にはしない。
+ */
+func (u *ShareCardUsecase) Card(ctx context.Context, id drawdomain.ShareID) (*Image, error) {
+	d, err := u.draws.SharedDraw(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := cardDigest(d)
+	key := cardKey(d.ShareID(), digest)
+	etag := `"` + hex.EncodeToString(digest[:16]) + `"`
+
+	data, err := u.store.Get(ctx, key)
+	if err == nil {
+		return &Image{PNG: data, ETag: etag}, nil
+	}
+	if !errors.Is(err, blob.ErrNotFound) {
+		slog.WarnContext(ctx, "share card cache unavailable", slog.Any("error", err))
+	}
+
+	data, err = u.renderer.Render(newCard(d.Result()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRenderFailed, err)
+	}
+	if err := u.store.Put(ctx, key, data); err != nil {
+		slog.WarnContext(ctx, "share card cache write failed", slog.Any("error", err))
+	}
+	return &Image{PNG: data, ETag: etag}, nil
+}
+
+/**
+ * おみくじのカード画像を保存先から消す。投稿を消すときに使い、まだ描いていなければ何もしない。
+ */
+func (u *ShareCardUsecase) Forget(ctx context.Context, d *drawdomain.Draw) error {
+	if d == nil {
+		return nil
+	}
+	if err := u.store.Delete(ctx, cardKey(d.ShareID(), cardDigest(d))); err != nil {
+		return fmt.Errorf("delete share card: %w", err)
+	}
+	return nil
+}
+
+// cardDigest はレイアウトと共有 ID・本文から決まる値。保存先のキーと ETag に使う。
+func cardDigest(d *drawdomain.Draw) [sha256.Size]byte {
+	return sha256.Sum256([]byte(layoutVersion + "\x00" + string(d.ShareID()) + "\x00" + string(d.Result())))
+}
+
+// cardKey はカード画像の保存先のキーを返す。
+func cardKey(id drawdomain.ShareID, digest [sha256.Size]byte) string {
+	return "cards/" + layoutVersion + "/" + string(id) + "-" + hex.EncodeToString(digest[:8]) + ".png"
+}
+
+/**
+ * おみくじ本文からカードの内容を組み立てる。冒頭の「今日のきらくじ:」は見出しと重なるため外す。
+ */
+func newCard(result drawdomain.FormattedContent) portcard.Card {
+	body := strings.TrimSpace(string(result))
+	if rest, ok := strings.CutPrefix(body, cardHeading); ok {
+		body = strings.TrimSpace(strings.TrimLeft(rest, ":："))
+	}
+	return portcard.Card{Heading: cardHeading, Body: body, Brand: cardBrand}
+}
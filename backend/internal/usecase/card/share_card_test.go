@@ -0,0 +1,167 @@
+package card
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/port/blob"
+	portcard "backend/internal/port/card"
+	"backend/internal/port/repository"
+)
+
+func TestShareCardUsecase_RendersOnceAndCaches(t *testing.T) {
+	d := newVerifiedDraw(t, "今日のきらくじ: 小さな勝ちを拾えます。")
+	renderer := &stubRenderer{}
+	store := newStubStore()
+	u := NewShareCardUsecase(stubFinder{draw: d}, renderer, store)
+
+	first, err := u.Card(context.Background(), d.ShareID())
+	if err != nil {
+		t.Fatalf("Card: %v", err)
+	}
+	second, err := u.Card(context.Background(), d.ShareID())
+	if err != nil {
+		t.Fatalf("Card again: %v", err)
+	}
+
+	if renderer.calls != 1 {
+		t.Fatalf("expected one render, got %d", renderer.calls)
+	}
+	if string(first.PNG) != "png" || string(second.PNG) != "png" || first.ETag == "" || first.ETag != second.ETag {
+		t.Fatalf("unexpected images: %+v %+v", first, second)
+	}
+	// 見出しは本文から外して見出し欄へ置く
+	want := portcard.Card{Heading: "今日のきらくじ", Body: "小さな勝ちを拾えます。", Brand: "きらくじ"}
+	if renderer.last != want {
+		t.Fatalf("unexpected card: %+v", renderer.last)
+	}
+}
+
+func TestShareCardUsecase_StoreFailureFallsBackToRendering(t *testing.T) {
+	d := newVerifiedDraw(t, "小さな勝ちを拾えます。")
+	renderer := &stubRenderer{}
+	store := newStubStore()
+	store.err = errors.New("disk full")
+	u := NewShareCardUsecase(stubFinder{draw: d}, renderer, store)
+
+	img, err := u.Card(context.Background(), d.ShareID())
+	if err != nil {
+		t.Fatalf("Card: %v", err)
+	}
+	if string(img.PNG) != "png" {
+		t.Fatalf("unexpected image: %q", img.PNG)
+	}
+}
+
+func TestShareCardUsecase_Errors(t *testing.T) {
+	d := newVerifiedDraw(t, "小さな勝ちを拾えます。")
+
+	u := NewShareCardUsecase(stubFinder{err: repository.ErrDrawNotFound}, &stubRenderer{}, newStubStore())
+	if _, err := u.Card(context.Background(), d.ShareID()); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound, got %v", err)
+	}
+
+	u = NewShareCardUsecase(stubFinder{draw: d}, &stubRenderer{err: errors.New("bad font")}, newStubStore())
+	if _, err := u.Card(context.Background(), d.ShareID()); !errors.Is(err, ErrRenderFailed) {
+		t.Fatalf("expected ErrRenderFailed, got %v", err)
+	}
+}
+
+func TestShareCardUsecase_ForgetDeletesCachedCard(t *testing.T) {
+	d := newVerifiedDraw(t, "小さな勝ちを拾えます。")
+	renderer := &stubRenderer{}
+	store := newStubStore()
+	u := NewShareCardUsecase(stubFinder{draw: d}, renderer, store)
+
+	if _, err := u.Card(context.Background(), d.ShareID()); err != nil {
+		t.Fatalf("Card: %v", err)
+	}
+	if err := u.Forget(context.Background(), d); err != nil {
+		t.Fatalf("Forget: %v", err)
+	}
+	if len(store.blobs) != 0 {
+		t.Fatalf("expected the cached card to be deleted, got %v", store.blobs)
+	}
+	// 描いていないカードを消しても失敗にしない
+	if err := u.Forget(context.Background(), d); err != nil {
+		t.Fatalf("Forget again: %v", err)
+	}
+}
+
+func newVerifiedDraw(t *testing.T, result string) *drawdomain.Draw {
+	t.Helper()
+	d, err := drawdomain.New(post.DarkPostID("post-1"), drawdomain.FormattedContent(result))
+	if err != nil {
+		t.Fatalf("drawdomain.New() error = %v", err)
+	}
+	if err := d.MarkVerified(); err != nil {
+		t.Fatalf("MarkVerified() error = %v", err)
+	}
+	return d
+}
+
+type stubFinder struct {
+	draw *drawdomain.Draw
+	err  error
+}
+
+func (s stubFinder) SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	return s.draw, s.err
+}
+
+type stubRenderer struct {
+	calls int
+	last  portcard.Card
+	err   error
+}
+
+func (s *stubRenderer) Render(c portcard.Card) ([]byte, error) {
+	s.calls++
+	s.last = c
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []byte("png"), nil
+}
+
+type stubStore struct {
+	blobs map[string][]byte
+	err   error
+}
+
+func newStubStore() *stubStore {
+	return &stubStore{blobs: make(map[string][]byte)}
+}
+
+func (s *stubStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return data, nil
+}
+
+func (s *stubStore) Put(ctx context.Context, key string, data []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	if !blob.ValidKey(key) {
+		return blob.ErrInvalidKey
+	}
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *stubStore) Delete(ctx context.Context, key string) error {
+	if s.err != nil {
+		return s.err
+	}
+	delete(s.blobs, key)
+	return nil
+}
@@ -2,25 +2,70 @@ package draw
 
 import (
 	"context"
+	"log/slog"
 	"math/rand"
+	"sync"
 	"time"
 
 	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
 	"backend/internal/port/repository"
 )
 
+// DefaultReactionCacheTTL は重み付けに使う反応数を取り直すまでの既定の間隔。
+const DefaultReactionCacheTTL = time.Minute
+
+// FortuneDrawer はおみくじを返す処理。計測やトレースのデコレーターはこの形で FortuneUsecase を包む。
+type FortuneDrawer interface {
+	DrawFortune(ctx context.Context) (*drawdomain.Draw, error)
+	SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error)
+}
+
+// SharedDrawFinder は共有 ID から検証済みのおみくじを返す処理。カード・反応・通報のユースケースが使う。
+type SharedDrawFinder interface {
+	SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error)
+}
+
 // FortuneUsecase は検証済みのおみくじを 1 件返すユースケース。
 type FortuneUsecase struct {
-	repo repository.DrawRepository
-	rand *rand.Rand
+	repo      repository.DrawRepository
+	reactions repository.ReactionRepository
+	now       func() time.Time
+
+	// *rand.Rand は並行に使えないため、randMu を取ってから使う
+	randMu sync.Mutex
+	rand   *rand.Rand
+
+	// 抽選のたびに全件の反応数を数えないよう、反応数を cacheTTL の間だけ使い回す
+	cacheMu       sync.Mutex
+	cacheTTL      time.Duration
+	cachedCounts  map[post.DarkPostID]reaction.Counts
+	cacheExpireAt time.Time
 }
 
 // NewFortuneUsecase は FortuneUsecase を生成する。
 func NewFortuneUsecase(repo repository.DrawRepository) *FortuneUsecase {
 	return &FortuneUsecase{
-		repo: repo,
-		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+		repo:     repo,
+		now:      time.Now,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		cacheTTL: DefaultReactionCacheTTL,
+	}
+}
+
+// WithReactions は反応数による重み付けに使う反応の取得先を設定する。nil なら一様に選ぶ。
+func (u *FortuneUsecase) WithReactions(reactions repository.ReactionRepository) *FortuneUsecase {
+	u.reactions = reactions
+	return u
+}
+
+// WithReactionCacheTTL は反応数を取り直すまでの間隔を設定する。0 以下なら既定のままにする。
+func (u *FortuneUsecase) WithReactionCacheTTL(ttl time.Duration) *FortuneUsecase {
+	if ttl > 0 {
+		u.cacheTTL = ttl
 	}
+	return u
 }
 
 // DrawFortune は Verified 状態のおみくじから 1 件をランダムに返す。
@@ -48,6 +93,115 @@ func (u *FortuneUsecase) DrawFortune(ctx context.Context) (*drawdomain.Draw, err
 		return verified[0], nil
 	}
 
+	if weights := u.weights(ctx, verified); weights != nil {
+		return verified[u.pickWeighted(weights)], nil
+	}
+	u.randMu.Lock()
 	index := u.rand.Intn(len(verified))
+	u.randMu.Unlock()
 	return verified[index], nil
 }
+
+/**
+ * おみくじごとの選ばれやすさを反応数から求める。反応数は cacheTTL の間だけ使い回す。
+ * 取得先が未設定、または取得に失敗した場合は nil を返し、呼び出し側は一様に選ぶ。
+ */
+func (u *FortuneUsecase) weights(ctx context.Context, draws []*drawdomain.Draw) []float64 {
+	if u.reactions == nil {
+		return nil
+	}
+	ids := make([]post.DarkPostID, len(draws))
+	for i, d := range draws {
+		ids[i] = d.PostID()
+	}
+	counts, err := u.reactionCounts(ctx, ids)
+	if err != nil {
+		// 重み付けは補助的なものなので、引けなくなるより一様に選ぶほうを優先する
+		slog.WarnContext(ctx, "reaction counts unavailable, drawing uniformly", slog.Any("error", err))
+		return nil
+	}
+	weights := make([]float64, len(draws))
+	for i, id := range ids {
+		weights[i] = counts[id].Weight()
+	}
+	return weights
+}
+
+/**
+ * 反応数を返す。期限内に数えたものは使い回し、まだ数えていないおみくじ（新しく公開されたものなど）だけを数え足す。
+ * 返す map は書き換えず、数え足すときは作り直すので、ロックを外した後も読める。
+ */
+func (u *FortuneUsecase) reactionCounts(ctx context.Context, ids []post.DarkPostID) (map[post.DarkPostID]reaction.Counts, error) {
+	u.cacheMu.Lock()
+	var cached map[post.DarkPostID]reaction.Counts
+	if u.now().Before(u.cacheExpireAt) {
+		cached = u.cachedCounts
+	}
+	u.cacheMu.Unlock()
+
+	var missing []post.DarkPostID
+	for _, id := range ids {
+		if _, ok := cached[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return cached, nil
+	}
+	fetched, err := u.reactions.CountByPostIDs(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	u.cacheMu.Lock()
+	defer u.cacheMu.Unlock()
+	base := u.cachedCounts
+	if now := u.now(); !now.Before(u.cacheExpireAt) {
+		base = nil
+		u.cacheExpireAt = now.Add(u.cacheTTL)
+	}
+	merged := make(map[post.DarkPostID]reaction.Counts, len(base)+len(missing))
+	for id, c := range base {
+		merged[id] = c
+	}
+	// 反応の無いおみくじも数え終えたものとして覚え、期限まで数え直さない
+	for _, id := range missing {
+		merged[id] = fetched[id]
+	}
+	u.cachedCounts = merged
+	return merged, nil
+}
+
+// 重みに比例した確率で添字を 1 つ選ぶ。
+func (u *FortuneUsecase) pickWeighted(weights []float64) int {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	u.randMu.Lock()
+	r := u.rand.Float64() * total
+	u.randMu.Unlock()
+	for i, w := range weights {
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+	return len(weights) - 1
+}
+
+// SharedDraw は共有 ID に対応する検証済みのおみくじを返す。
+// 形が不正な ID や検証済みでない結果は、存在を明かさないよう repository.ErrDrawNotFound として扱う。
+func (u *FortuneUsecase) SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	if !id.Valid() {
+		return nil, repository.ErrDrawNotFound
+	}
+	d, err := u.repo.GetByShareID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status() != drawdomain.StatusVerified {
+		return nil, repository.ErrDrawNotFound
+	}
+	return d, nil
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"sync"
 	"testing"
+	"time"
 
 	drawdomain "backend/internal/domain/draw"
 	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
 	"backend/internal/port/repository"
 )
 
@@ -38,6 +41,20 @@ func TestDrawFortune_Success(t *testing.T) {
 	}
 }
 
+func TestDrawFortune_SkipsHidden(t *testing.T) {
+	t.Parallel()
+
+	hidden := newVerifiedDraw(t, "post-hidden", "hidden")
+	if err := hidden.MarkHidden(); err != nil {
+		t.Fatalf("MarkHidden() error = %v", err)
+	}
+	// リポジトリが非公開のおみくじを返しても抽選には出さない
+	usecase := NewFortuneUsecase(&fakeDrawRepository{draws: []*drawdomain.Draw{hidden}})
+	if _, err := usecase.DrawFortune(context.Background()); !errors.Is(err, drawdomain.ErrEmptyResult) {
+		t.Fatalf("expected ErrEmptyResult, got %v", err)
+	}
+}
+
 func TestDrawFortune_EmptyResults(t *testing.T) {
 	t.Parallel()
 
@@ -60,6 +77,151 @@ func TestDrawFortune_RepositoryError(t *testing.T) {
 	}
 }
 
+func TestDrawFortune_WeightedByReactions(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeDrawRepository{
+		draws: []*drawdomain.Draw{
+			newVerifiedDraw(t, "post-1", "fortune-1"),
+			newVerifiedDraw(t, "post-2", "fortune-2"),
+		},
+	}
+	reactions := &fakeReactionRepository{counts: map[post.DarkPostID]reaction.Counts{
+		"post-1": {reaction.KindAccurate: 1000, reaction.KindSaved: 1000},
+	}}
+	usecase := NewFortuneUsecase(repo).WithReactions(reactions)
+	usecase.rand = rand.New(rand.NewSource(1))
+
+	picked := map[post.DarkPostID]int{}
+	for range 1000 {
+		got, err := usecase.DrawFortune(context.Background())
+		if err != nil {
+			t.Fatalf("DrawFortune() error = %v", err)
+		}
+		picked[got.PostID()]++
+	}
+	// 重みは 1+log1p(2000)≈8.6 対 1 なので、反応の多いほうが大きく偏る
+	if picked["post-1"] < 800 || picked["post-2"] == 0 {
+		t.Fatalf("unexpected distribution: %v", picked)
+	}
+}
+
+func TestDrawFortune_ReactionErrorFallsBackToUniform(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeDrawRepository{
+		draws: []*drawdomain.Draw{
+			newVerifiedDraw(t, "post-1", "fortune-1"),
+			newVerifiedDraw(t, "post-2", "fortune-2"),
+		},
+	}
+	usecase := NewFortuneUsecase(repo).WithReactions(&fakeReactionRepository{err: errors.New("unavailable")})
+
+	if _, err := usecase.DrawFortune(context.Background()); err != nil {
+		t.Fatalf("DrawFortune() should ignore reaction errors, got %v", err)
+	}
+}
+
+func TestDrawFortune_CachesReactionCounts(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeDrawRepository{
+		draws: []*drawdomain.Draw{
+			newVerifiedDraw(t, "post-1", "fortune-1"),
+			newVerifiedDraw(t, "post-2", "fortune-2"),
+		},
+	}
+	reactions := &fakeReactionRepository{counts: map[post.DarkPostID]reaction.Counts{
+		"post-1": {reaction.KindSaved: 3},
+	}}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	usecase := NewFortuneUsecase(repo).WithReactions(reactions).WithReactionCacheTTL(time.Minute)
+	usecase.now = func() time.Time { return now }
+
+	draw := func() {
+		t.Helper()
+		if _, err := usecase.DrawFortune(context.Background()); err != nil {
+			t.Fatalf("DrawFortune() error = %v", err)
+		}
+	}
+
+	draw()
+	draw()
+	if len(reactions.calls) != 1 {
+		t.Fatalf("expected counts to be reused within the TTL, got %d calls", len(reactions.calls))
+	}
+
+	// 新しく公開されたおみくじだけを数え足す
+	repo.draws = append(repo.draws, newVerifiedDraw(t, "post-3", "fortune-3"))
+	draw()
+	if len(reactions.calls) != 2 || len(reactions.calls[1]) != 1 || reactions.calls[1][0] != "post-3" {
+		t.Fatalf("expected only the new draw to be counted, got %v", reactions.calls)
+	}
+
+	now = now.Add(time.Minute)
+	draw()
+	if len(reactions.calls) != 3 || len(reactions.calls[2]) != 3 {
+		t.Fatalf("expected all counts to be refreshed after the TTL, got %v", reactions.calls)
+	}
+}
+
+func TestDrawFortune_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeDrawRepository{
+		draws: []*drawdomain.Draw{
+			newVerifiedDraw(t, "post-1", "fortune-1"),
+			newVerifiedDraw(t, "post-2", "fortune-2"),
+		},
+	}
+	reactions := &fakeReactionRepository{counts: map[post.DarkPostID]reaction.Counts{}}
+	usecases := []*FortuneUsecase{NewFortuneUsecase(repo), NewFortuneUsecase(repo).WithReactions(reactions)}
+
+	// 乱数と反応数の使い回しを複数のリクエストから同時に使っても壊れない（-race で確かめる）
+	var wg sync.WaitGroup
+	for _, usecase := range usecases {
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 50 {
+					if _, err := usecase.DrawFortune(context.Background()); err != nil {
+						t.Errorf("DrawFortune() error = %v", err)
+						return
+					}
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func TestSharedDraw(t *testing.T) {
+	t.Parallel()
+
+	verified := newVerifiedDraw(t, "post-1", "fortune-1")
+	pending, err := drawdomain.New(post.DarkPostID("post-2"), drawdomain.FormattedContent("fortune-2"))
+	if err != nil {
+		t.Fatalf("drawdomain.New() error = %v", err)
+	}
+	usecase := NewFortuneUsecase(&fakeDrawRepository{draws: []*drawdomain.Draw{verified, pending}})
+
+	got, err := usecase.SharedDraw(context.Background(), verified.ShareID())
+	if err != nil {
+		t.Fatalf("SharedDraw() error = %v", err)
+	}
+	if got.PostID() != verified.PostID() {
+		t.Fatalf("unexpected draw: %s", got.PostID())
+	}
+
+	// 未検証・未存在・形の不正な ID はどれも見つからない扱い
+	for _, id := range []drawdomain.ShareID{pending.ShareID(), drawdomain.NewShareID(), "post-1"} {
+		if _, err := usecase.SharedDraw(context.Background(), id); !errors.Is(err, repository.ErrDrawNotFound) {
+			t.Fatalf("SharedDraw(%q) error = %v, want ErrDrawNotFound", id, err)
+		}
+	}
+}
+
 type fakeDrawRepository struct {
 	repository.DrawRepository
 
@@ -75,6 +237,15 @@ func (f *fakeDrawRepository) GetByPostID(ctx context.Context, postID post.DarkPo
 	return nil, repository.ErrDrawNotFound
 }
 
+func (f *fakeDrawRepository) GetByShareID(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	for _, d := range f.draws {
+		if d.ShareID() == id {
+			return d, nil
+		}
+	}
+	return nil, repository.ErrDrawNotFound
+}
+
 func (f *fakeDrawRepository) ListReady(ctx context.Context) ([]*drawdomain.Draw, error) {
 	if f.listErr != nil {
 		return nil, f.listErr
@@ -82,6 +253,26 @@ func (f *fakeDrawRepository) ListReady(ctx context.Context) ([]*drawdomain.Draw,
 	return f.draws, nil
 }
 
+type fakeReactionRepository struct {
+	repository.ReactionRepository
+
+	counts map[post.DarkPostID]reaction.Counts
+	err    error
+
+	mu    sync.Mutex
+	calls [][]post.DarkPostID
+}
+
+func (f *fakeReactionRepository) CountByPostIDs(ctx context.Context, postIDs []post.DarkPostID) (map[post.DarkPostID]reaction.Counts, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, postIDs)
+	f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.counts, nil
+}
+
 func newVerifiedDraw(t *testing.T, postID, result string) *drawdomain.Draw {
 	t.Helper()
 
@@ -89,6 +280,8 @@ func newVerifiedDraw(t *testing.T, postID, result string) *drawdomain.Draw {
 	if err != nil {
 		t.Fatalf("drawdomain.New() error = %v", err)
 	}
-	d.MarkVerified()
+	if err := d.MarkVerified(); err != nil {
+		t.Fatalf("MarkVerified() error = %v", err)
+	}
 	return d
 }
@@ -0,0 +1,138 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/port/repository"
+)
+
+// UnknownVersion はプロンプトのバージョンが記録されていない結果の集計名。
+const UnknownVersion = "unknown"
+
+var ErrNilUsecase = errors.New("experiment: ユースケースが初期化されていません")
+
+// VariantSummary はプロンプトのバージョンごとの集計結果。
+type VariantSummary struct {
+	PromptVersion    string         `json:"prompt_version"`
+	Total            int            `json:"total"`
+	Passed           int            `json:"passed"`
+	Rejected         int            `json:"rejected"`
+	PassRate         float64        `json:"pass_rate"`
+	AverageLength    float64        `json:"average_length"`
+	RejectionReasons map[string]int `json:"rejection_reasons"`
+	// Reactions は通過して公開したおみくじへの反応数の種類ごとの合計（反応の集計先が未設定なら nil）。
+	Reactions reaction.Counts `json:"reactions,omitempty"`
+	// ReactionsPerDraw は通過したおみくじ 1 件あたりの反応数。
+	ReactionsPerDraw float64 `json:"reactions_per_draw"`
+}
+
+// SummarizeUsecase は記録済みの整形結果をバリアント別に集計するユースケース。
+type SummarizeUsecase struct {
+	outcomes  repository.OutcomeRepository
+	reactions repository.ReactionRepository
+}
+
+// NewSummarizeUsecase は SummarizeUsecase を生成する。
+func NewSummarizeUsecase(outcomes repository.OutcomeRepository) *SummarizeUsecase {
+	return &SummarizeUsecase{outcomes: outcomes}
+}
+
+// WithReactions は公開後の反応数の取得先を設定する。nil なら反応は集計しない。
+func (u *SummarizeUsecase) WithReactions(reactions repository.ReactionRepository) *SummarizeUsecase {
+	u.reactions = reactions
+	return u
+}
+
+// Execute は通過率・平均文字数・却下理由の内訳（反応の取得先があれば反応数も）をバージョン名の昇順で返す。
+func (u *SummarizeUsecase) Execute(ctx context.Context) ([]VariantSummary, error) {
+	if u == nil || u.outcomes == nil {
+		return nil, ErrNilUsecase
+	}
+	outcomes, err := u.outcomes.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[string]*VariantSummary)
+	totalLength := make(map[string]int)
+	// おみくじは通過した投稿と同じ ID で作られるため、通過した結果の投稿 ID でバージョンと反応を結び付ける
+	drawVersions := make(map[post.DarkPostID]string)
+	for _, o := range outcomes {
+		if o == nil {
+			continue
+		}
+		version := o.PromptVersion()
+		if version == "" {
+			version = UnknownVersion
+		}
+		s, ok := byVersion[version]
+		if !ok {
+			s = &VariantSummary{PromptVersion: version, RejectionReasons: map[string]int{}}
+			byVersion[version] = s
+		}
+		s.Total++
+		totalLength[version] += o.Length()
+		if o.Passed() {
+			s.Passed++
+			drawVersions[o.PostID()] = version
+			continue
+		}
+		s.Rejected++
+		s.RejectionReasons[o.Reason()]++
+	}
+
+	if err := u.addReactions(ctx, byVersion, drawVersions); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]VariantSummary, 0, len(byVersion))
+	for version, s := range byVersion {
+		s.PassRate = float64(s.Passed) / float64(s.Total)
+		s.AverageLength = float64(totalLength[version]) / float64(s.Total)
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].PromptVersion < summaries[j].PromptVersion
+	})
+	return summaries, nil
+}
+
+// addReactions はおみくじごとの反応数を、そのおみくじを生成したバージョンの集計へ足す。
+func (u *SummarizeUsecase) addReactions(ctx context.Context, byVersion map[string]*VariantSummary, drawVersions map[post.DarkPostID]string) error {
+	if u.reactions == nil {
+		return nil
+	}
+	for _, s := range byVersion {
+		s.Reactions = reaction.Counts{}
+	}
+	if len(drawVersions) == 0 {
+		return nil
+	}
+	ids := make([]post.DarkPostID, 0, len(drawVersions))
+	for id := range drawVersions {
+		ids = append(ids, id)
+	}
+	counts, err := u.reactions.CountByPostIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for id, c := range counts {
+		s := byVersion[drawVersions[id]]
+		if s == nil {
+			continue
+		}
+		for kind, n := range c {
+			s.Reactions[kind] += n
+		}
+	}
+	for _, s := range byVersion {
+		if s.Passed > 0 {
+			s.ReactionsPerDraw = float64(s.Reactions.Total()) / float64(s.Passed)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/adapter/repository/memory"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+)
+
+type stubOutcomeRepository struct {
+	outcomes []*outcome.Outcome
+	err      error
+}
+
+func (s *stubOutcomeRepository) Record(_ context.Context, o *outcome.Outcome) error {
+	s.outcomes = append(s.outcomes, o)
+	return nil
+}
+
+func (s *stubOutcomeRepository) List(context.Context) ([]*outcome.Outcome, error) {
+	return s.outcomes, s.err
+}
+
+func (s *stubOutcomeRepository) ListByPostID(context.Context, post.DarkPostID) ([]*outcome.Outcome, error) {
+	return nil, nil
+}
+
+func (s *stubOutcomeRepository) DeleteByPostID(context.Context, post.DarkPostID) error {
+	return nil
+}
+
+func TestSummarizeUsecase_Execute(t *testing.T) {
+	repo := &stubOutcomeRepository{}
+	ctx := context.Background()
+	record := func(version string, status drawdomain.Status, reason string, content string) {
+		t.Helper()
+		o, err := outcome.New(post.DarkPostID("post"), version, status, reason, drawdomain.FormattedContent(content))
+		if err != nil {
+			t.Fatalf("outcome.New() error = %v", err)
+		}
+		if err := repo.Record(ctx, o); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	record("fortune-v1", drawdomain.StatusVerified, "", "1234")
+	record("fortune-v1", drawdomain.StatusVerified, "", "12")
+	record("fortune-v1", drawdomain.StatusRejected, "禁止語を含む", "123456")
+	record("menhera-v1", drawdomain.StatusRejected, "文数が不正", "12")
+	record("menhera-v1", drawdomain.StatusRejected, "文数が不正", "12")
+	record("", drawdomain.StatusVerified, "", "1")
+
+	summaries, err := NewSummarizeUsecase(repo).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(summaries) != 3 {
+		t.Fatalf("expected 3 summaries, got %d", len(summaries))
+	}
+
+	fortune := summaries[0]
+	if fortune.PromptVersion != "fortune-v1" || fortune.Total != 3 || fortune.Passed != 2 || fortune.Rejected != 1 {
+		t.Fatalf("unexpected fortune summary: %+v", fortune)
+	}
+	if fortune.AverageLength != 4 {
+		t.Fatalf("expected average length 4, got %v", fortune.AverageLength)
+	}
+	if fortune.RejectionReasons["禁止語を含む"] != 1 {
+		t.Fatalf("unexpected rejection reasons: %v", fortune.RejectionReasons)
+	}
+
+	menhera := summaries[1]
+	if menhera.PassRate != 0 || menhera.RejectionReasons["文数が不正"] != 2 {
+		t.Fatalf("unexpected menhera summary: %+v", menhera)
+	}
+
+	// バージョン未記録の結果は unknown にまとめる
+	if summaries[2].PromptVersion != UnknownVersion || summaries[2].PassRate != 1 {
+		t.Fatalf("unexpected unknown summary: %+v", summaries[2])
+	}
+}
+
+func TestSummarizeUsecase_JoinsReactionsByPromptVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := &stubOutcomeRepository{}
+	record := func(id post.DarkPostID, version string, status drawdomain.Status) {
+		t.Helper()
+		o, err := outcome.New(id, version, status, "", "本文")
+		if err != nil {
+			t.Fatalf("outcome.New() error = %v", err)
+		}
+		_ = repo.Record(ctx, o)
+	}
+	record("post-1", "fortune-v1", drawdomain.StatusVerified)
+	record("post-2", "fortune-v1", drawdomain.StatusVerified)
+	record("post-3", "fortune-v2", drawdomain.StatusVerified)
+	record("post-4", "fortune-v2", drawdomain.StatusRejected)
+
+	reactions := memory.NewInMemoryReactionRepository()
+	react := func(id post.DarkPostID, visitor string, kind reaction.Kind) {
+		t.Helper()
+		r, err := reaction.New(id, visitor, kind)
+		if err != nil {
+			t.Fatalf("reaction.New() error = %v", err)
+		}
+		if err := reactions.Add(ctx, r); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	react("post-1", "visitor-a", reaction.KindAccurate)
+	react("post-2", "visitor-a", reaction.KindSaved)
+	react("post-2", "visitor-b", reaction.KindAccurate)
+	react("post-3", "visitor-a", reaction.KindScary)
+
+	summaries, err := NewSummarizeUsecase(repo).WithReactions(reactions).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	v1, v2 := summaries[0], summaries[1]
+	if v1.Reactions[reaction.KindAccurate] != 2 || v1.Reactions[reaction.KindSaved] != 1 || v1.ReactionsPerDraw != 1.5 {
+		t.Fatalf("unexpected fortune-v1 reactions: %+v", v1)
+	}
+	// 却下された投稿はおみくじにならないので 1 件あたりの分母に含めない
+	if v2.Reactions[reaction.KindScary] != 1 || v2.ReactionsPerDraw != 1 {
+		t.Fatalf("unexpected fortune-v2 reactions: %+v", v2)
+	}
+
+	plain, err := NewSummarizeUsecase(repo).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if plain[0].Reactions != nil {
+		t.Fatalf("expected no reactions without a reaction repository, got %v", plain[0].Reactions)
+	}
+}
+
+func TestSummarizeUsecase_ListError(t *testing.T) {
+	listErr := errors.New("boom")
+	if _, err := NewSummarizeUsecase(&stubOutcomeRepository{err: listErr}).Execute(context.Background()); !errors.Is(err, listErr) {
+		t.Fatalf("expected list error, got %v", err)
+	}
+}
+
+func TestSummarizeUsecase_NilRepository(t *testing.T) {
+	if _, err := NewSummarizeUsecase(nil).Execute(context.Background()); !errors.Is(err, ErrNilUsecase) {
+		t.Fatalf("expected ErrNilUsecase, got %v", err)
+	}
+}
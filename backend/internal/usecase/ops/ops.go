@@ -0,0 +1,112 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/port/queue"
+	"backend/internal/port/repository"
+)
+
+// exportPageSize は書き出し時に一度に読む投稿の件数。
+const exportPageSize = 100
+
+// ErrQueueInspectUnsupported はキューが件数や中身の確認に対応していない場合に返される。
+var ErrQueueInspectUnsupported = errors.New("ops: ジョブキューが中身の確認に対応していません")
+
+// exportStatuses は書き出す投稿の状態。投稿 ID 順に状態ごとにまとめて書き出す。
+var exportStatuses = []post.Status{post.StatusPending, post.StatusReady, post.StatusFlagged}
+
+/**
+ * 整形キューの状況
+ * @param Depth 残っているジョブの件数
+ * @param Oldest 登録の古いジョブ
+ */
+type QueueStatus struct {
+	Depth  int64
+	Oldest []queue.QueuedJob
+}
+
+/**
+ * 運用コマンドから投稿・おみくじ・整形キューを確認するユースケース
+ * posts: 投稿の保存先
+ * draws: おみくじの保存先
+ * jobs: 整形ジョブキュー（件数と中身を確認できること）
+ */
+type OpsUsecase struct {
+	posts repository.PostRepository
+	draws repository.DrawRepository
+	jobs  queue.JobQueue
+}
+
+// NewOpsUsecase は OpsUsecase を生成する。
+func NewOpsUsecase(posts repository.PostRepository, draws repository.DrawRepository, jobs queue.JobQueue) *OpsUsecase {
+	return &OpsUsecase{posts: posts, draws: draws, jobs: jobs}
+}
+
+/**
+ * 整形キューに残っているジョブの件数と、登録の古いジョブを最大 limit 件返す。
+ */
+func (u *OpsUsecase) QueueStatus(ctx context.Context, limit int) (*QueueStatus, error) {
+	reporter, ok := u.jobs.(queue.DepthReporter)
+	if !ok {
+		return nil, ErrQueueInspectUnsupported
+	}
+	inspector, ok := u.jobs.(queue.Inspector)
+	if !ok {
+		return nil, ErrQueueInspectUnsupported
+	}
+	depth, err := reporter.Depth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("queue depth: %w", err)
+	}
+	oldest, err := inspector.Oldest(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("oldest jobs: %w", err)
+	}
+	return &QueueStatus{Depth: depth, Oldest: oldest}, nil
+}
+
+/**
+ * すべての投稿を状態ごとに投稿 ID 順で fn へ渡す。fn がエラーを返した時点で止める。
+ */
+func (u *OpsUsecase) ExportPosts(ctx context.Context, fn func(*post.Post) error) error {
+	for _, status := range exportStatuses {
+		var after post.DarkPostID
+		for {
+			posts, err := u.posts.ListByStatus(ctx, status, after, exportPageSize)
+			if err != nil {
+				return fmt.Errorf("list %s posts: %w", status, err)
+			}
+			for _, p := range posts {
+				if err := fn(p); err != nil {
+					return err
+				}
+			}
+			if len(posts) < exportPageSize {
+				break
+			}
+			after = posts[len(posts)-1].ID()
+		}
+	}
+	return nil
+}
+
+/**
+ * すべてのおみくじを、元の投稿の並び（ExportPosts と同じ順）で fn へ渡す。おみくじの無い投稿は飛ばす。
+ */
+func (u *OpsUsecase) ExportDraws(ctx context.Context, fn func(*drawdomain.Draw) error) error {
+	return u.ExportPosts(ctx, func(p *post.Post) error {
+		d, err := u.draws.GetByPostID(ctx, p.ID())
+		if errors.Is(err, repository.ErrDrawNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get draw %s: %w", p.ID(), err)
+		}
+		return fn(d)
+	})
+}
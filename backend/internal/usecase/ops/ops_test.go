@@ -0,0 +1,105 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend/internal/adapter/repository/memory"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/port/queue"
+)
+
+func TestOpsUsecase_QueueStatus(t *testing.T) {
+	queued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobs := &stubInspectableQueue{depth: 3, oldest: []queue.QueuedJob{{PostID: "post-1", QueuedAt: queued}}}
+	uc := NewOpsUsecase(memory.NewInMemoryPostRepository(), memory.NewInMemoryDrawRepository(), jobs)
+
+	status, err := uc.QueueStatus(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("QueueStatus() error = %v", err)
+	}
+	if status.Depth != 3 || len(status.Oldest) != 1 || status.Oldest[0].PostID != "post-1" {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if jobs.limit != 5 {
+		t.Fatalf("expected limit to be passed, got %d", jobs.limit)
+	}
+}
+
+func TestOpsUsecase_QueueStatusUnsupported(t *testing.T) {
+	uc := NewOpsUsecase(memory.NewInMemoryPostRepository(), memory.NewInMemoryDrawRepository(), plainQueue{})
+	if _, err := uc.QueueStatus(context.Background(), 5); !errors.Is(err, ErrQueueInspectUnsupported) {
+		t.Fatalf("expected ErrQueueInspectUnsupported, got %v", err)
+	}
+}
+
+func TestOpsUsecase_Export(t *testing.T) {
+	ctx := context.Background()
+	posts := memory.NewInMemoryPostRepository()
+	draws := memory.NewInMemoryDrawRepository()
+	// 1 ページに収まらない件数で、ページをまたいでも漏れないことを確かめる
+	for i := range exportPageSize + 2 {
+		p, err := post.New(post.DarkPostID(fmt.Sprintf("post-%03d", i)), "content")
+		if err != nil {
+			t.Fatalf("post.New() error = %v", err)
+		}
+		if i%2 == 0 {
+			_ = p.MarkReady()
+			d, err := drawdomain.New(p.ID(), "fortune")
+			if err != nil {
+				t.Fatalf("draw.New() error = %v", err)
+			}
+			if err := draws.Create(ctx, d); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+		if err := posts.Create(ctx, p); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	uc := NewOpsUsecase(posts, draws, plainQueue{})
+
+	var exportedPosts, exportedDraws int
+	if err := uc.ExportPosts(ctx, func(*post.Post) error { exportedPosts++; return nil }); err != nil {
+		t.Fatalf("ExportPosts() error = %v", err)
+	}
+	if err := uc.ExportDraws(ctx, func(*drawdomain.Draw) error { exportedDraws++; return nil }); err != nil {
+		t.Fatalf("ExportDraws() error = %v", err)
+	}
+	if exportedPosts != exportPageSize+2 || exportedDraws != exportPageSize/2+1 {
+		t.Fatalf("unexpected export counts: posts=%d draws=%d", exportedPosts, exportedDraws)
+	}
+
+	stop := errors.New("stop")
+	if err := uc.ExportPosts(ctx, func(*post.Post) error { return stop }); !errors.Is(err, stop) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+}
+
+// plainQueue は件数や中身の確認に対応しない整形キュー。
+type plainQueue struct{}
+
+func (plainQueue) EnqueueFormat(ctx context.Context, id post.DarkPostID) error { return nil }
+func (plainQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	return nil, queue.ErrQueueClosed
+}
+func (plainQueue) Close() error { return nil }
+
+// stubInspectableQueue は決まった件数と中身を返す整形キュー。
+type stubInspectableQueue struct {
+	plainQueue
+	depth  int64
+	oldest []queue.QueuedJob
+	limit  int
+}
+
+func (q *stubInspectableQueue) Depth(ctx context.Context) (int64, error) { return q.depth, nil }
+
+func (q *stubInspectableQueue) Oldest(ctx context.Context, limit int) ([]queue.QueuedJob, error) {
+	q.limit = limit
+	return q.oldest, nil
+}
@@ -3,9 +3,15 @@ package post
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 
 	"backend/internal/domain/post"
+	"backend/internal/logging"
+	"backend/internal/port/moderation"
+	"backend/internal/port/notifier"
 	"backend/internal/port/queue"
+	"backend/internal/port/redaction"
 	"backend/internal/port/repository"
 )
 
@@ -13,6 +19,9 @@ var (
 	ErrNilInput            = errors.New("create_post: 入力が指定されていません")
 	ErrPostAlreadyExists   = errors.New("create_post: 投稿がすでに存在します")
 	ErrJobAlreadyScheduled = errors.New("create_post: 整形ジョブがすでに登録済みです")
+	ErrModerationFailed    = errors.New("create_post: 投稿内容の判定に失敗しました")
+	ErrRedactionFailed     = errors.New("create_post: 個人情報の伏せ字処理に失敗しました")
+	ErrPostFlagged         = errors.New("create_post: 投稿内容が判定に該当したため受け付けられません")
 )
 
 // 闇投稿作成の入力値
@@ -22,18 +31,27 @@ type CreatePostInput struct {
 }
 
 // 闇投稿作成後に呼び出し側へ返す値
+// Flagged の投稿は整形ジョブに載せない。受け付けるのは Crisis の場合だけで、呼び出し側で相談窓口を案内する
 type CreatePostOutput struct {
 	DarkPostID string
+	Flagged    bool
+	Crisis     bool
 }
 
 /**
  * 闇投稿作成のユースケース
  * postRepo: 投稿リポジトリ
  * jobQueue: 整形ジョブキュー
+ * moderator: 整形前の判定
+ * redactor: 判定サービスへ渡す前の伏せ字処理
+ * notifier: 投稿の進み具合の通知先
  */
 type CreatePostUsecase struct {
-	postRepo repository.PostRepository
-	jobQueue queue.JobQueue
+	postRepo  repository.PostRepository
+	jobQueue  queue.JobQueue
+	moderator moderation.Moderator
+	redactor  redaction.Redactor
+	notifier  notifier.Publisher
 }
 
 /**
@@ -46,6 +64,30 @@ func NewCreatePostUsecase(postRepo repository.PostRepository, jobQueue queue.Job
 	}
 }
 
+/**
+ * LLM へ渡す前の判定に使うモデレーターを設定する。nil なら判定しない。
+ */
+func (u *CreatePostUsecase) WithModerator(moderator moderation.Moderator) *CreatePostUsecase {
+	u.moderator = moderator
+	return u
+}
+
+/**
+ * 判定サービスへ渡す前に本文の個人情報を伏せる処理を設定する。nil なら伏せずに渡す。
+ */
+func (u *CreatePostUsecase) WithRedactor(redactor redaction.Redactor) *CreatePostUsecase {
+	u.redactor = redactor
+	return u
+}
+
+/**
+ * 投稿の進み具合（整形待ち・公開しない）の通知先を設定する。nil なら通知しない。
+ */
+func (u *CreatePostUsecase) WithNotifier(publisher notifier.Publisher) *CreatePostUsecase {
+	u.notifier = publisher
+	return u
+}
+
 /**
  * 闇投稿作成の実行
  */
@@ -61,6 +103,25 @@ func (u *CreatePostUsecase) Execute(ctx context.Context, in *CreatePostInput) (*
 		return nil, err
 	}
 
+	// LLM へ渡す前に本文を判定し、該当した投稿は整形対象から外す
+	verdict, err := u.moderate(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if verdict.Flagged {
+		// 危機的な兆候の無い該当投稿は保存せずに断る。保存してから断ると、再送が重複扱いになってしまう
+		if !verdict.Crisis {
+			slog.InfoContext(ctx, "post rejected by moderation",
+				logging.PostAttr(string(p.ID())),
+				slog.Any("categories", verdict.Categories),
+			)
+			return nil, ErrPostFlagged
+		}
+		if err := p.MarkFlagged(); err != nil {
+			return nil, err
+		}
+	}
+
 	// 投稿の保存
 	if err := u.postRepo.Create(ctx, p); err != nil {
 		// 重複時はエラー
@@ -71,6 +132,17 @@ func (u *CreatePostUsecase) Execute(ctx context.Context, in *CreatePostInput) (*
 		return nil, err
 	}
 
+	// 危機的な兆候のある投稿はおみくじにしないため、整形ジョブも登録しない
+	if p.IsFlagged() {
+		slog.InfoContext(ctx, "post flagged by moderation",
+			logging.PostAttr(string(p.ID())),
+			slog.Bool("crisis", verdict.Crisis),
+			slog.Any("categories", verdict.Categories),
+		)
+		notifier.Notify(ctx, u.notifier, p.ID(), notifier.StatusRejected)
+		return &CreatePostOutput{DarkPostID: string(p.ID()), Flagged: true, Crisis: verdict.Crisis}, nil
+	}
+
 	// 整形ジョブの登録
 	if err := u.jobQueue.EnqueueFormat(ctx, p.ID()); err != nil {
 		// 重複時はエラー
@@ -81,5 +153,46 @@ func (u *CreatePostUsecase) Execute(ctx context.Context, in *CreatePostInput) (*
 		return nil, err
 	}
 
+	notifier.Notify(ctx, u.notifier, p.ID(), notifier.StatusQueued)
+	slog.InfoContext(ctx, "post accepted", logging.PostAttr(string(p.ID())), slog.Int("length", logging.ContentLength(string(p.Content()))))
 	return &CreatePostOutput{DarkPostID: string(p.ID())}, nil
 }
+
+/**
+ * モデレーター未設定なら該当なしとして扱う。
+ * 外部の判定サービスにも伏せ字済みの本文だけを渡し、伏せられない場合は判定せずに止める。
+ */
+func (u *CreatePostUsecase) moderate(ctx context.Context, p *post.Post) (*moderation.Result, error) {
+	if u.moderator == nil {
+		return &moderation.Result{}, nil
+	}
+	content, err := u.redact(ctx, string(p.Content()))
+	if err != nil {
+		return nil, err
+	}
+	verdict, err := u.moderator.Moderate(ctx, content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrModerationFailed, err)
+	}
+	if verdict == nil {
+		return &moderation.Result{}, nil
+	}
+	return verdict, nil
+}
+
+/**
+ * 伏せ字処理が未設定なら本文をそのまま返す。
+ */
+func (u *CreatePostUsecase) redact(ctx context.Context, content string) (string, error) {
+	if u.redactor == nil {
+		return content, nil
+	}
+	result, err := u.redactor.Redact(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRedactionFailed, err)
+	}
+	if result == nil {
+		return "", ErrRedactionFailed
+	}
+	return result.Text, nil
+}
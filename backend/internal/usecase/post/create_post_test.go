@@ -3,10 +3,16 @@ package post
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"backend/internal/domain/post"
+	"backend/internal/logging"
+	"backend/internal/logging/logtest"
+	"backend/internal/port/moderation"
+	"backend/internal/port/notifier"
 	"backend/internal/port/queue"
+	"backend/internal/port/redaction"
 	"backend/internal/port/repository"
 )
 
@@ -190,9 +196,217 @@ func TestCreatePostUsecase_Execute(t *testing.T) {
 	}
 }
 
+func TestCreatePostUsecase_ModerationCrisis(t *testing.T) {
+	t.Parallel()
+
+	var saved *post.Post
+	repo := &stubPostRepository{createFunc: func(ctx context.Context, p *post.Post) error {
+		saved = p
+		return nil
+	}}
+	q := &stubJobQueue{enqueueFunc: func(ctx context.Context, id post.DarkPostID) error {
+		t.Fatalf("危機判定の投稿をジョブ投入してはいけない: %s", id)
+		return nil
+	}}
+	moderator := &stubModerator{result: &moderation.Result{Flagged: true, Crisis: true, Categories: []string{moderation.CategoryCrisis}}}
+
+	uc := NewCreatePostUsecase(repo, q).WithModerator(moderator)
+	got, err := uc.Execute(context.Background(), &CreatePostInput{DarkPostID: "abc123", Content: "もう消えたい"})
+	if err != nil {
+		t.Fatalf("想定外のエラー: %v", err)
+	}
+	if !got.Flagged || !got.Crisis {
+		t.Fatalf("危機判定を期待したが %+v", got)
+	}
+	if saved == nil || saved.Status() != post.StatusFlagged {
+		t.Fatalf("flagged 状態で保存されることを期待したが %+v", saved)
+	}
+	if moderator.received != "もう消えたい" {
+		t.Fatalf("本文がモデレーターに渡っていない: %q", moderator.received)
+	}
+}
+
+func TestCreatePostUsecase_ModerationFlaggedWithoutCrisis(t *testing.T) {
+	t.Parallel()
+
+	enqueued := false
+	q := &stubJobQueue{enqueueFunc: func(ctx context.Context, id post.DarkPostID) error {
+		enqueued = true
+		return nil
+	}}
+	saved := false
+	repo := &stubPostRepository{createFunc: func(ctx context.Context, p *post.Post) error {
+		saved = true
+		return nil
+	}}
+	moderator := &stubModerator{result: &moderation.Result{Flagged: true, Categories: []string{moderation.CategoryViolence}}}
+
+	_, err := NewCreatePostUsecase(repo, q).WithModerator(moderator).
+		Execute(context.Background(), &CreatePostInput{DarkPostID: "abc123", Content: "闇"})
+	if !errors.Is(err, ErrPostFlagged) {
+		t.Fatalf("ErrPostFlagged を期待したが %v", err)
+	}
+	// 断った投稿を保存すると、再送が重複扱いになる
+	if saved || enqueued {
+		t.Fatalf("保存もジョブ投入もしないことを期待したが saved=%v enqueued=%v", saved, enqueued)
+	}
+}
+
+func TestCreatePostUsecase_ModerationClean(t *testing.T) {
+	t.Parallel()
+
+	enqueued := false
+	q := &stubJobQueue{enqueueFunc: func(ctx context.Context, id post.DarkPostID) error {
+		enqueued = true
+		return nil
+	}}
+	got, err := NewCreatePostUsecase(&stubPostRepository{}, q).WithModerator(&stubModerator{result: &moderation.Result{}}).
+		Execute(context.Background(), &CreatePostInput{DarkPostID: "abc123", Content: "闇"})
+	if err != nil {
+		t.Fatalf("想定外のエラー: %v", err)
+	}
+	if got.Flagged || !enqueued {
+		t.Fatalf("通常どおりジョブ投入されることを期待したが %+v enqueued=%v", got, enqueued)
+	}
+}
+
+func TestCreatePostUsecase_ModerationError(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubPostRepository{createFunc: func(ctx context.Context, p *post.Post) error {
+		t.Fatalf("判定失敗時に保存してはいけない")
+		return nil
+	}}
+	_, err := NewCreatePostUsecase(repo, &stubJobQueue{}).WithModerator(&stubModerator{err: errors.New("boom")}).
+		Execute(context.Background(), &CreatePostInput{DarkPostID: "abc123", Content: "闇"})
+	if !errors.Is(err, ErrModerationFailed) {
+		t.Fatalf("ErrModerationFailed を期待したが %v", err)
+	}
+}
+
+func TestCreatePostUsecase_ModeratesRedactedContent(t *testing.T) {
+	t.Parallel()
+
+	var saved *post.Post
+	repo := &stubPostRepository{createFunc: func(ctx context.Context, p *post.Post) error {
+		saved = p
+		return nil
+	}}
+	moderator := &stubModerator{result: &moderation.Result{}}
+	redactor := &stubRedactor{result: &redaction.Result{Text: "[人名]がひどい", Counts: map[string]int{redaction.KindName: 1}}}
+	_, err := NewCreatePostUsecase(repo, &stubJobQueue{}).WithModerator(moderator).WithRedactor(redactor).
+		Execute(context.Background(), &CreatePostInput{DarkPostID: "abc123", Content: "田中さんがひどい"})
+	if err != nil {
+		t.Fatalf("想定外のエラー: %v", err)
+	}
+	if moderator.received != "[人名]がひどい" {
+		t.Fatalf("伏せ字済みの本文で判定することを期待したが %q", moderator.received)
+	}
+	// 保存する本文は元のまま（外部へ出すときだけ伏せる）
+	if saved == nil || saved.Content() != "田中さんがひどい" {
+		t.Fatalf("元の本文で保存することを期待したが %+v", saved)
+	}
+}
+
+func TestCreatePostUsecase_RedactionError(t *testing.T) {
+	t.Parallel()
+
+	moderator := &stubModerator{result: &moderation.Result{}}
+	repo := &stubPostRepository{createFunc: func(ctx context.Context, p *post.Post) error {
+		t.Fatalf("伏せ字処理の失敗時に保存してはいけない")
+		return nil
+	}}
+	_, err := NewCreatePostUsecase(repo, &stubJobQueue{}).WithModerator(moderator).
+		WithRedactor(&stubRedactor{err: errors.New("boom")}).
+		Execute(context.Background(), &CreatePostInput{DarkPostID: "abc123", Content: "闇"})
+	if !errors.Is(err, ErrRedactionFailed) {
+		t.Fatalf("ErrRedactionFailed を期待したが %v", err)
+	}
+	if moderator.received != "" {
+		t.Fatalf("伏せられなかった本文を判定に渡してはいけない")
+	}
+}
+
+func TestCreatePostUsecase_LogsWithoutContent(t *testing.T) {
+	// ログ出力先を差し替えるため並列実行しない
+	buf := logtest.Capture(t)
+	moderator := &stubModerator{result: &moderation.Result{Flagged: true, Crisis: true, Categories: []string{moderation.CategoryCrisis}}}
+
+	_, err := NewCreatePostUsecase(&stubPostRepository{}, &stubJobQueue{}).WithModerator(moderator).
+		Execute(logging.WithRequestID(context.Background(), "req-1"), &CreatePostInput{DarkPostID: "secret-id", Content: "もう消えたい"})
+	if err != nil {
+		t.Fatalf("想定外のエラー: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"request_id":"req-1"`) {
+		t.Fatalf("リクエスト ID 付きのログを期待したが %s", buf.String())
+	}
+	logtest.AssertNotContains(t, buf, "secret-id", "もう消えたい")
+}
+
+// stubRedactor は Redactor の簡易モック。
+func TestCreatePostUsecase_NotifiesProgress(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		verdict *moderation.Result
+		want    notifier.Status
+	}{
+		{"queued", &moderation.Result{}, notifier.StatusQueued},
+		{"crisis", &moderation.Result{Flagged: true, Crisis: true}, notifier.StatusRejected},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &recordingNotifier{err: errors.New("notifier down")}
+			_, err := NewCreatePostUsecase(&stubPostRepository{}, &stubJobQueue{}).
+				WithModerator(&stubModerator{result: tc.verdict}).
+				WithNotifier(n).
+				Execute(context.Background(), &CreatePostInput{DarkPostID: "abc123", Content: "闇"})
+			if err != nil {
+				t.Fatalf("通知の失敗で投稿が失敗してはいけない: %v", err)
+			}
+			if len(n.events) != 1 || n.events[0].Status != tc.want || n.events[0].PostID != "abc123" {
+				t.Fatalf("%s の通知を期待したが %+v", tc.want, n.events)
+			}
+		})
+	}
+}
+
+type recordingNotifier struct {
+	events []notifier.Event
+	err    error
+}
+
+func (n *recordingNotifier) Publish(ctx context.Context, event notifier.Event) error {
+	n.events = append(n.events, event)
+	return n.err
+}
+
+type stubRedactor struct {
+	result *redaction.Result
+	err    error
+}
+
+func (s *stubRedactor) Redact(ctx context.Context, text string) (*redaction.Result, error) {
+	return s.result, s.err
+}
+
+// stubModerator は Moderator の簡易モック。
+type stubModerator struct {
+	result   *moderation.Result
+	err      error
+	received string
+}
+
+func (s *stubModerator) Moderate(ctx context.Context, content string) (*moderation.Result, error) {
+	s.received = content
+	return s.result, s.err
+}
+
 // stubPostRepository は PostRepository の簡易モック。
 type stubPostRepository struct {
 	createFunc func(context.Context, *post.Post) error
+	getFunc    func(context.Context, post.DarkPostID) (*post.Post, error)
 }
 
 func (s *stubPostRepository) Create(ctx context.Context, p *post.Post) error {
@@ -202,7 +416,10 @@ func (s *stubPostRepository) Create(ctx context.Context, p *post.Post) error {
 	return nil
 }
 
-func (*stubPostRepository) Get(context.Context, post.DarkPostID) (*post.Post, error) {
+func (s *stubPostRepository) Get(ctx context.Context, id post.DarkPostID) (*post.Post, error) {
+	if s.getFunc != nil {
+		return s.getFunc(ctx, id)
+	}
 	panic("not implemented")
 }
 
@@ -214,6 +431,14 @@ func (*stubPostRepository) Update(context.Context, *post.Post) error {
 	panic("not implemented")
 }
 
+func (*stubPostRepository) ListByStatus(context.Context, post.Status, post.DarkPostID, int) ([]*post.Post, error) {
+	panic("not implemented")
+}
+
+func (*stubPostRepository) Delete(context.Context, post.DarkPostID) error {
+	panic("not implemented")
+}
+
 // stubJobQueue は JobQueue の簡易モック。
 type stubJobQueue struct {
 	enqueueFunc func(context.Context, post.DarkPostID) error
@@ -226,8 +451,8 @@ func (s *stubJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostID) er
 	return nil
 }
 
-func (s *stubJobQueue) DequeueFormat(ctx context.Context) (post.DarkPostID, error) {
-	return "", queue.ErrQueueClosed
+func (s *stubJobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	return nil, queue.ErrQueueClosed
 }
 
 func (s *stubJobQueue) Close() error {
@@ -0,0 +1,85 @@
+package post
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain/post"
+	"backend/internal/port/notifier"
+	"backend/internal/port/repository"
+)
+
+/**
+ * 投稿がおみくじになるまでの進み具合を見守るユースケース
+ * postRepo: 投稿リポジトリ（存在確認と現在の状態）
+ * subscriber: 進み具合の通知の購読先
+ */
+type WatchPostUsecase struct {
+	postRepo   repository.PostRepository
+	subscriber notifier.Subscriber
+	now        func() time.Time
+}
+
+/**
+ * ユースケース毎に初期化
+ */
+func NewWatchPostUsecase(postRepo repository.PostRepository, subscriber notifier.Subscriber) *WatchPostUsecase {
+	return &WatchPostUsecase{postRepo: postRepo, subscriber: subscriber, now: time.Now}
+}
+
+/**
+ * 投稿の進み具合を順に届けるチャネルを返す。投稿が無ければ repository.ErrPostNotFound。
+ * 最初に保存済みの状態を届け、以降は変わったときだけ届ける。
+ * 終端の状態（ready / rejected）を届けるか ctx が終わったところでチャネルを閉じる。
+ */
+func (u *WatchPostUsecase) Watch(ctx context.Context, id post.DarkPostID) (<-chan notifier.Event, error) {
+	p, err := u.postRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	initial := notifier.Event{PostID: p.ID(), Status: statusOf(p), At: u.now()}
+	out := make(chan notifier.Event, 1)
+	out <- initial
+	if initial.Status.Terminal() {
+		close(out)
+		return out, nil
+	}
+
+	events, err := u.subscriber.Subscribe(ctx, p.ID())
+	if err != nil {
+		close(out)
+		return nil, err
+	}
+	go func() {
+		defer close(out)
+		last := initial.Status
+		for event := range events {
+			if event.Status == last {
+				continue
+			}
+			last = event.Status
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+			if event.Status.Terminal() {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// 保存済みの投稿の状態を進み具合へ読み替える。pending は整形中かどうかまでは分からないため queued とする。
+func statusOf(p *post.Post) notifier.Status {
+	switch {
+	case p.IsReady():
+		return notifier.StatusReady
+	case p.IsFlagged():
+		return notifier.StatusRejected
+	default:
+		return notifier.StatusQueued
+	}
+}
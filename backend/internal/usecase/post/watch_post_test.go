@@ -0,0 +1,107 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/internal/domain/post"
+	"backend/internal/port/notifier"
+	"backend/internal/port/repository"
+)
+
+func TestWatchPostUsecase_Watch(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		status    post.Status
+		published []notifier.Status
+		want      []notifier.Status
+	}{
+		{
+			name:      "整形待ちから公開まで届ける",
+			status:    post.StatusPending,
+			published: []notifier.Status{notifier.StatusQueued, notifier.StatusFormatting, notifier.StatusReady, notifier.StatusFormatting},
+			want:      []notifier.Status{notifier.StatusQueued, notifier.StatusFormatting, notifier.StatusReady},
+		},
+		{
+			name:      "公開しないと決まったら終わる",
+			status:    post.StatusPending,
+			published: []notifier.Status{notifier.StatusRejected},
+			want:      []notifier.Status{notifier.StatusQueued, notifier.StatusRejected},
+		},
+		{
+			name:   "公開済みなら購読せずに終わる",
+			status: post.StatusReady,
+			want:   []notifier.Status{notifier.StatusReady},
+		},
+		{
+			name:   "判定に該当した投稿は rejected",
+			status: post.StatusFlagged,
+			want:   []notifier.Status{notifier.StatusRejected},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := post.Restore("abc123", "闇", tc.status)
+			if err != nil {
+				t.Fatalf("restore: %v", err)
+			}
+			repo := &stubPostRepository{getFunc: func(context.Context, post.DarkPostID) (*post.Post, error) { return p, nil }}
+			sub := &stubSubscriber{statuses: tc.published}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			events, err := NewWatchPostUsecase(repo, sub).Watch(ctx, "abc123")
+			if err != nil {
+				t.Fatalf("想定外のエラー: %v", err)
+			}
+			var got []notifier.Status
+			for event := range events {
+				got = append(got, event.Status)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("%v を期待したが %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("%v を期待したが %v", tc.want, got)
+				}
+			}
+			if tc.status != post.StatusPending && sub.called {
+				t.Fatal("終端の投稿を購読してはいけない")
+			}
+		})
+	}
+}
+
+func TestWatchPostUsecase_PostNotFound(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubPostRepository{getFunc: func(context.Context, post.DarkPostID) (*post.Post, error) {
+		return nil, repository.ErrPostNotFound
+	}}
+	_, err := NewWatchPostUsecase(repo, &stubSubscriber{}).Watch(context.Background(), "missing")
+	if !errors.Is(err, repository.ErrPostNotFound) {
+		t.Fatalf("ErrPostNotFound を期待したが %v", err)
+	}
+}
+
+// stubSubscriber は購読されたら用意した状態を順に流して閉じる。
+type stubSubscriber struct {
+	statuses []notifier.Status
+	called   bool
+}
+
+func (s *stubSubscriber) Subscribe(ctx context.Context, postID post.DarkPostID) (<-chan notifier.Event, error) {
+	s.called = true
+	ch := make(chan notifier.Event, len(s.statuses))
+	for _, status := range s.statuses {
+		ch <- notifier.Event{PostID: postID, Status: status}
+	}
+	close(ch)
+	return ch, nil
+}
@@ -0,0 +1,53 @@
+package reaction
+
+import (
+	"context"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/domain/reaction"
+	"backend/internal/port/repository"
+	drawusecase "backend/internal/usecase/draw"
+)
+
+/**
+ * おみくじへの反応を受け付け、数を返すユースケース
+ * draws: 反応できるおみくじの取得
+ * repo: 反応の保存先
+ */
+type ReactionUsecase struct {
+	draws drawusecase.SharedDrawFinder
+	repo  repository.ReactionRepository
+}
+
+// NewReactionUsecase は ReactionUsecase を生成する。
+func NewReactionUsecase(draws drawusecase.SharedDrawFinder, repo repository.ReactionRepository) *ReactionUsecase {
+	return &ReactionUsecase{draws: draws, repo: repo}
+}
+
+/**
+ * 共有 ID のおみくじへ訪問者の反応を 1 つ付け、付けた後の種類ごとの数を返す。
+ * 検証済みでなければ SharedDraw のエラー、同じ訪問者の 2 回目は repository.ErrReactionAlreadyExists を返す。
+ */
+func (u *ReactionUsecase) React(ctx context.Context, id drawdomain.ShareID, visitorToken string, kind reaction.Kind) (reaction.Counts, error) {
+	if !kind.Valid() {
+		return nil, reaction.ErrInvalidKind
+	}
+	d, err := u.draws.SharedDraw(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r, err := reaction.New(d.PostID(), visitorToken, kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.Add(ctx, r); err != nil {
+		return nil, err
+	}
+	return u.repo.CountByPostID(ctx, d.PostID())
+}
+
+// Counts はおみくじの種類ごとの反応数を返す。
+func (u *ReactionUsecase) Counts(ctx context.Context, postID post.DarkPostID) (reaction.Counts, error) {
+	return u.repo.CountByPostID(ctx, postID)
+}
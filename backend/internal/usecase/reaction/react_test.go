@@ -0,0 +1,78 @@
+package reaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/adapter/repository/memory"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/reaction"
+	"backend/internal/port/repository"
+)
+
+func TestReactionUsecase_React(t *testing.T) {
+	d, err := drawdomain.New("post-1", "大吉")
+	if err != nil {
+		t.Fatalf("draw.New() error = %v", err)
+	}
+	if err := d.MarkVerified(); err != nil {
+		t.Fatalf("MarkVerified() error = %v", err)
+	}
+	uc := NewReactionUsecase(&stubDraws{draw: d}, memory.NewInMemoryReactionRepository())
+	ctx := context.Background()
+
+	counts, err := uc.React(ctx, d.ShareID(), "visitor-a", reaction.KindSaved)
+	if err != nil {
+		t.Fatalf("React() error = %v", err)
+	}
+	if counts[reaction.KindSaved] != 1 {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+	if _, err := uc.React(ctx, d.ShareID(), "visitor-a", reaction.KindScary); !errors.Is(err, repository.ErrReactionAlreadyExists) {
+		t.Fatalf("expected ErrReactionAlreadyExists, got %v", err)
+	}
+	if counts, _ := uc.Counts(ctx, d.PostID()); counts.Total() != 1 {
+		t.Fatalf("duplicate reaction should not be counted: %v", counts)
+	}
+}
+
+func TestReactionUsecase_ReactErrors(t *testing.T) {
+	d, _ := drawdomain.New("post-1", "大吉")
+	if err := d.MarkVerified(); err != nil {
+		t.Fatalf("MarkVerified() error = %v", err)
+	}
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		draws   *stubDraws
+		visitor string
+		kind    reaction.Kind
+		want    error
+	}{
+		{"unknown kind", &stubDraws{draw: d}, "visitor-a", reaction.Kind("like"), reaction.ErrInvalidKind},
+		{"no visitor", &stubDraws{draw: d}, "", reaction.KindSaved, reaction.ErrEmptyVisitor},
+		{"draw not found", &stubDraws{err: repository.ErrDrawNotFound}, "visitor-a", reaction.KindSaved, repository.ErrDrawNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := NewReactionUsecase(tc.draws, memory.NewInMemoryReactionRepository())
+			if _, err := uc.React(ctx, d.ShareID(), tc.visitor, tc.kind); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+type stubDraws struct {
+	draw *drawdomain.Draw
+	err  error
+}
+
+func (s *stubDraws) SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.draw, nil
+}
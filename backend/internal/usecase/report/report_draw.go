@@ -0,0 +1,87 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/report"
+	"backend/internal/logging"
+	"backend/internal/port/repository"
+	drawusecase "backend/internal/usecase/draw"
+)
+
+/**
+ * おみくじへの通報を受け付け、審査待ちの列へ積むユースケース
+ * draws: 通報できるおみくじの取得
+ * drawRepo: 自動で非公開にしたときの保存先
+ * reports: 通報と審査待ちの項目の保存先
+ * hideThreshold: この数の接続元 IP から通報が集まったら自動で非公開にする（0 なら自動では非公開にしない）
+ */
+type ReportUsecase struct {
+	draws         drawusecase.SharedDrawFinder
+	drawRepo      repository.DrawRepository
+	reports       repository.ReportRepository
+	hideThreshold int64
+}
+
+// NewReportUsecase は ReportUsecase を生成する。自動の非公開は WithHideThreshold で有効にする。
+func NewReportUsecase(draws drawusecase.SharedDrawFinder, drawRepo repository.DrawRepository, reports repository.ReportRepository) *ReportUsecase {
+	return &ReportUsecase{draws: draws, drawRepo: drawRepo, reports: reports}
+}
+
+// WithHideThreshold は自動で非公開にする通報元の数を設定する。0 以下なら自動では非公開にしない。
+func (u *ReportUsecase) WithHideThreshold(threshold int64) *ReportUsecase {
+	u.hideThreshold = threshold
+	return u
+}
+
+/**
+ * 共有 ID のおみくじへの通報を受け付ける。
+ * 同じ訪問者・同じ接続元 IP の 2 回目は数えずに受け付けた扱いにする（通報の有無を明かさず、連打で閾値を超えさせない）。
+ * 訪問者トークンはクライアントが作れるため、閾値は信頼する中継元を考慮して決めた接続元 IP の数で数える。
+ * 別々の接続元からの通報が閾値に達したおみくじは hidden にし、抽選と共有リンクから外す。
+ */
+func (u *ReportUsecase) Report(ctx context.Context, id drawdomain.ShareID, visitorToken, clientIP string, reason report.Reason) error {
+	if !reason.Valid() {
+		return report.ErrInvalidReason
+	}
+	d, err := u.draws.SharedDraw(ctx, id)
+	if err != nil {
+		return err
+	}
+	r, err := report.New(d.PostID(), visitorToken, clientIP, reason)
+	if err != nil {
+		return err
+	}
+	review, err := u.reports.Add(ctx, r)
+	if errors.Is(err, repository.ErrReportAlreadyExists) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if u.hideThreshold <= 0 || review.Count < u.hideThreshold {
+		return nil
+	}
+	u.hide(ctx, d, review.Count)
+	return nil
+}
+
+/**
+ * おみくじを非公開にする。通報自体は審査待ちの列に残っているため、失敗はログに残すだけにする。
+ * 次の通報でも閾値を超えたままなので、そのときに再び試みる。
+ */
+func (u *ReportUsecase) hide(ctx context.Context, d *drawdomain.Draw, count int64) {
+	if err := d.MarkHidden(); err != nil {
+		slog.WarnContext(ctx, "reported draw could not be hidden", logging.PostAttr(string(d.PostID())), slog.Any("error", err))
+		return
+	}
+	if err := u.drawRepo.Update(ctx, d); err != nil {
+		slog.ErrorContext(ctx, "failed to hide reported draw", logging.PostAttr(string(d.PostID())), slog.Any("error", err))
+		return
+	}
+	slog.InfoContext(ctx, "draw hidden by reports", logging.PostAttr(string(d.PostID())), slog.Int64("report_count", count))
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"backend/internal/adapter/repository/memory"
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/domain/report"
+	"backend/internal/port/repository"
+)
+
+func TestReportUsecase_HidesAtThreshold(t *testing.T) {
+	ctx := context.Background()
+	drawRepo := memory.NewInMemoryDrawRepository()
+	d := newVerifiedDraw(t, "post-1")
+	if err := drawRepo.Create(ctx, d); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	uc := NewReportUsecase(&repoFinder{repo: drawRepo}, drawRepo, memory.NewInMemoryReportRepository()).WithHideThreshold(3)
+
+	for i := range 2 {
+		if err := uc.Report(ctx, d.ShareID(), fmt.Sprintf("visitor-%d", i), fmt.Sprintf("198.51.100.%d", i), report.ReasonSpam); err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+	}
+	// 同じ訪問者の 2 回目は受け付けるが数えない
+	if err := uc.Report(ctx, d.ShareID(), "visitor-0", "198.51.100.9", report.ReasonSpam); err != nil {
+		t.Fatalf("duplicate Report() error = %v", err)
+	}
+	// トークンを作り直しても、同じ接続元 IP からの通報は数えない
+	for i := range 5 {
+		if err := uc.Report(ctx, d.ShareID(), fmt.Sprintf("forged-%d", i), "198.51.100.0", report.ReasonSpam); err != nil {
+			t.Fatalf("Report() from the same client ip error = %v", err)
+		}
+	}
+	if got, _ := drawRepo.GetByPostID(ctx, d.PostID()); got.Status() != drawdomain.StatusVerified {
+		t.Fatalf("draw should stay verified below the threshold, got %s", got.Status())
+	}
+
+	if err := uc.Report(ctx, d.ShareID(), "visitor-2", "198.51.100.2", report.ReasonOffensive); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if got, _ := drawRepo.GetByPostID(ctx, d.PostID()); got.Status() != drawdomain.StatusHidden {
+		t.Fatalf("draw should be hidden at the threshold, got %s", got.Status())
+	}
+	if ready, _ := drawRepo.ListReady(ctx); len(ready) != 0 {
+		t.Fatalf("hidden draw should not be drawable, got %d", len(ready))
+	}
+	// 非公開になったおみくじは通報先としても見つからない
+	if err := uc.Report(ctx, d.ShareID(), "visitor-3", "198.51.100.3", report.ReasonSpam); !errors.Is(err, repository.ErrDrawNotFound) {
+		t.Fatalf("expected ErrDrawNotFound, got %v", err)
+	}
+}
+
+func TestReportUsecase_NoThresholdKeepsDraw(t *testing.T) {
+	ctx := context.Background()
+	drawRepo := memory.NewInMemoryDrawRepository()
+	d := newVerifiedDraw(t, "post-1")
+	_ = drawRepo.Create(ctx, d)
+	uc := NewReportUsecase(&repoFinder{repo: drawRepo}, drawRepo, memory.NewInMemoryReportRepository())
+
+	for i := range 5 {
+		if err := uc.Report(ctx, d.ShareID(), fmt.Sprintf("visitor-%d", i), fmt.Sprintf("198.51.100.%d", i), report.ReasonSpam); err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+	}
+	if got, _ := drawRepo.GetByPostID(ctx, d.PostID()); got.Status() != drawdomain.StatusVerified {
+		t.Fatalf("draw should not be hidden without a threshold, got %s", got.Status())
+	}
+}
+
+func TestReportUsecase_Errors(t *testing.T) {
+	ctx := context.Background()
+	drawRepo := memory.NewInMemoryDrawRepository()
+	d := newVerifiedDraw(t, "post-1")
+	_ = drawRepo.Create(ctx, d)
+	uc := NewReportUsecase(&repoFinder{repo: drawRepo}, drawRepo, memory.NewInMemoryReportRepository())
+
+	cases := []struct {
+		name    string
+		id      drawdomain.ShareID
+		visitor string
+		ip      string
+		reason  report.Reason
+		want    error
+	}{
+		{"unknown reason", d.ShareID(), "visitor-a", "198.51.100.1", report.Reason("boring"), report.ErrInvalidReason},
+		{"no visitor", d.ShareID(), "", "198.51.100.1", report.ReasonSpam, report.ErrEmptyVisitor},
+		{"no client ip", d.ShareID(), "visitor-a", "", report.ReasonSpam, report.ErrEmptySource},
+		{"draw not found", drawdomain.NewShareID(), "visitor-a", "198.51.100.1", report.ReasonSpam, repository.ErrDrawNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := uc.Report(ctx, tc.id, tc.visitor, tc.ip, tc.reason); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+// repoFinder はリポジトリから検証済みのおみくじだけを返す（FortuneUsecase.SharedDraw の代わり）。
+type repoFinder struct {
+	repo repository.DrawRepository
+}
+
+func (f *repoFinder) SharedDraw(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	d, err := f.repo.GetByShareID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status() != drawdomain.StatusVerified {
+		return nil, repository.ErrDrawNotFound
+	}
+	return d, nil
+}
+
+func newVerifiedDraw(t *testing.T, postID string) *drawdomain.Draw {
+	t.Helper()
+	d, err := drawdomain.New(post.DarkPostID(postID), "大吉")
+	if err != nil {
+		t.Fatalf("draw.New() error = %v", err)
+	}
+	if err := d.MarkVerified(); err != nil {
+		t.Fatalf("MarkVerified() error = %v", err)
+	}
+	return d
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
 	"backend/internal/domain/post"
+	"backend/internal/logging"
 	"backend/internal/port/llm"
+	"backend/internal/port/notifier"
 	"backend/internal/port/queue"
+	"backend/internal/port/redaction"
 	"backend/internal/port/repository"
 )
 
@@ -23,6 +28,8 @@ var (
 	ErrRequeueFailed        = errors.New("format_pending: おみくじ結果保存失敗後の再キューに失敗しました")
 	ErrNilUsecase           = errors.New("format_pending: ユースケースが初期化されていません")
 	ErrNilContext           = errors.New("format_pending: コンテキストが指定されていません")
+	ErrOutcomeRecordFailed  = errors.New("format_pending: 整形結果の記録に失敗しました")
+	ErrRedactionFailed      = errors.New("format_pending: 個人情報の伏せ字処理に失敗しました")
 )
 
 const maxDrawResultLength = 400
@@ -33,6 +40,9 @@ type FormatPendingUsecase struct {
 	drawRepo repository.DrawRepository
 	llm      llm.Formatter
 	jobQueue queue.JobQueue
+	outcomes repository.OutcomeRepository
+	redactor redaction.Redactor
+	notifier notifier.Publisher
 }
 
 // 依存をまとめて整形用ユースケースを組み立てる。
@@ -50,6 +60,24 @@ func NewFormatPendingUsecase(
 	}
 }
 
+// WithOutcomes は検証結果（プロンプト実験の成果）の記録先を設定する。nil なら記録しない。
+func (u *FormatPendingUsecase) WithOutcomes(outcomes repository.OutcomeRepository) *FormatPendingUsecase {
+	u.outcomes = outcomes
+	return u
+}
+
+// WithRedactor は LLM へ渡す前に本文の個人情報を伏せる処理を設定する。nil なら伏せずに渡す。
+func (u *FormatPendingUsecase) WithRedactor(redactor redaction.Redactor) *FormatPendingUsecase {
+	u.redactor = redactor
+	return u
+}
+
+// WithNotifier は投稿の進み具合の通知先を設定する。nil なら通知しない。
+func (u *FormatPendingUsecase) WithNotifier(publisher notifier.Publisher) *FormatPendingUsecase {
+	u.notifier = publisher
+	return u
+}
+
 // LLM で整えて検証を通過した投稿を公開待ちに進める。
 // 投稿欠如、LLM 停止など例外もエラーとして伝える。
 func (u *FormatPendingUsecase) Execute(ctx context.Context, postID string) error {
@@ -76,9 +104,20 @@ func (u *FormatPendingUsecase) Execute(ctx context.Context, postID string) error
 		return ErrPostNotPending
 	}
 
+	notifier.Notify(ctx, u.notifier, p.ID(), notifier.StatusFormatting)
+
+	// 外部の LLM には伏せ字済みの本文だけを渡す。伏せられない場合は送らずに止める
+	content, redactions, err := u.redact(ctx, p.Content())
+	if err != nil {
+		return err
+	}
+	if len(redactions) > 0 {
+		slog.DebugContext(ctx, "personal information redacted", logging.PostAttr(postID), slog.Any("redactions", redactions))
+	}
+
 	formatResult, err := u.llm.Format(ctx, &llm.FormatRequest{
 		DarkPostID:  p.ID(),
-		DarkContent: p.Content(),
+		DarkContent: content,
 	})
 	if err != nil {
 		if errors.Is(err, llm.ErrFormatterUnavailable) {
@@ -88,16 +127,27 @@ func (u *FormatPendingUsecase) Execute(ctx context.Context, postID string) error
 	}
 
 	validated, err := u.llm.Validate(ctx, formatResult)
+	if validated != nil && validated.Status == drawdomain.StatusRejected {
+		slog.InfoContext(ctx, "formatted content rejected",
+			logging.PostAttr(postID),
+			slog.String("prompt_version", validated.PromptVersion),
+			slog.String("reason", validated.ValidationReason),
+		)
+	}
 	if err != nil {
+		// 却下もバリアントごとの通過率集計に使うため記録する
+		recordErr := u.recordOutcome(ctx, p.ID(), validated, redactions)
 		if errors.Is(err, llm.ErrContentRejected) {
-			return ErrContentRejected
+			notifier.Notify(ctx, u.notifier, p.ID(), notifier.StatusRejected)
+			return joinRecordErr(ErrContentRejected, recordErr)
 		}
-		return err
+		return joinRecordErr(err, recordErr)
 	}
 
 	// 検証で公開不可となった場合はここで終了
 	if validated.Status != drawdomain.StatusVerified {
-		return nil
+		notifier.Notify(ctx, u.notifier, p.ID(), notifier.StatusRejected)
+		return u.recordOutcome(ctx, p.ID(), validated, redactions)
 	}
 
 	drawContent := normalizeDrawContent(validated.FormattedContent)
@@ -105,26 +155,76 @@ func (u *FormatPendingUsecase) Execute(ctx context.Context, postID string) error
 	if err != nil {
 		return err
 	}
-	drawEntity.MarkVerified()
+	if err := drawEntity.MarkVerified(); err != nil {
+		return err
+	}
+	// どのプロンプトで生成したかを後から追えるように記録する
+	drawEntity.SetPromptVersion(validated.PromptVersion)
 	if err := u.drawRepo.Create(ctx, drawEntity); err != nil {
 		if err := u.requeueFormatJob(ctx, p.ID()); err != nil {
 			return fmt.Errorf("%w: %v", ErrRequeueFailed, err)
 		}
 		return fmt.Errorf("%w: %v", ErrDrawCreationFailed, err)
 	}
+	// 通過は保存できてから記録する。保存に失敗して積み直した整形を再試行で二重に数えないため
+	recordErr := u.recordOutcome(ctx, p.ID(), validated, redactions)
 
 	// 公開待ちへの状態遷移に失敗した場合は元エラーも保持しつつ整形待ちではないとみなす
 	if err := p.MarkReady(); err != nil {
-		return fmt.Errorf("%w: %v", ErrPostNotPending, err)
+		return joinRecordErr(fmt.Errorf("%w: %v", ErrPostNotPending, err), recordErr)
 	}
 
 	if err := u.postRepo.Update(ctx, p); err != nil {
-		return err
+		return joinRecordErr(err, recordErr)
 	}
+	notifier.Notify(ctx, u.notifier, p.ID(), notifier.StatusReady)
 
+	// 記録だけが失敗した場合は投稿自体は公開待ちへ進めたうえで知らせる
+	return recordErr
+}
+
+// 検証結果を記録する。記録先が未設定、または結果が確定していない場合は何もしない。
+func (u *FormatPendingUsecase) recordOutcome(ctx context.Context, postID post.DarkPostID, validated *llm.FormatResult, redactions map[string]int) error {
+	if u.outcomes == nil || validated == nil {
+		return nil
+	}
+	if validated.Status != drawdomain.StatusVerified && validated.Status != drawdomain.StatusRejected {
+		return nil
+	}
+	o, err := outcome.New(postID, validated.PromptVersion, validated.Status, validated.ValidationReason, validated.FormattedContent)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOutcomeRecordFailed, err)
+	}
+	o.SetRedactions(redactions)
+	if err := u.outcomes.Record(ctx, o); err != nil {
+		return fmt.Errorf("%w: %v", ErrOutcomeRecordFailed, err)
+	}
 	return nil
 }
 
+// 本文の個人情報を伏せ、伏せ字済みの本文と種類ごとの件数を返す。伏せ字処理が未設定なら本文をそのまま返す。
+func (u *FormatPendingUsecase) redact(ctx context.Context, content post.DarkContent) (post.DarkContent, map[string]int, error) {
+	if u.redactor == nil {
+		return content, nil, nil
+	}
+	result, err := u.redactor.Redact(ctx, string(content))
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrRedactionFailed, err)
+	}
+	if result == nil || strings.TrimSpace(result.Text) == "" {
+		return "", nil, ErrRedactionFailed
+	}
+	return post.DarkContent(result.Text), result.Counts, nil
+}
+
+// 本来のエラーを優先しつつ、記録失敗があれば併せて返す。
+func joinRecordErr(err, recordErr error) error {
+	if recordErr == nil {
+		return err
+	}
+	return errors.Join(err, recordErr)
+}
+
 func normalizeDrawContent(content drawdomain.FormattedContent) drawdomain.FormattedContent {
 	trimmed := strings.TrimSpace(string(content))
 	runes := []rune(trimmed)
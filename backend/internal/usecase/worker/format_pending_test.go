@@ -9,7 +9,9 @@ import (
 	drawdomain "backend/internal/domain/draw"
 	"backend/internal/domain/post"
 	"backend/internal/port/llm"
+	"backend/internal/port/notifier"
 	"backend/internal/port/queue"
+	"backend/internal/port/redaction"
 	"backend/internal/usecase/worker/testutil"
 )
 
@@ -30,6 +32,7 @@ func TestFormatPendingUsecase_Success(t *testing.T) {
 			DarkPostID:       p.ID(),
 			Status:           drawdomain.StatusVerified,
 			FormattedContent: "formatted",
+			PromptVersion:    "fortune-v1",
 		},
 	}
 	usecase := NewFormatPendingUsecase(repo, drawRepo, formatter, testutil.StubJobQueue{})
@@ -56,6 +59,9 @@ func TestFormatPendingUsecase_Success(t *testing.T) {
 	if created.Status() != drawdomain.StatusVerified {
 		t.Fatalf("expected verified draw, got %s", created.Status())
 	}
+	if created.PromptVersion() != "fortune-v1" {
+		t.Fatalf("expected prompt version to be stored, got %q", created.PromptVersion())
+	}
 }
 
 func TestFormatPendingUsecase_PostNotFound(t *testing.T) {
@@ -327,8 +333,8 @@ func (q *recordingJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostI
 	return nil
 }
 
-func (*recordingJobQueue) DequeueFormat(ctx context.Context) (post.DarkPostID, error) {
-	return "", queue.ErrQueueClosed
+func (*recordingJobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	return nil, queue.ErrQueueClosed
 }
 
 func (*recordingJobQueue) Close() error {
@@ -364,3 +370,218 @@ func TestFormatPendingUsecase_DrawCreateFailed_RequeueError(t *testing.T) {
 		t.Fatalf("requeue should not record success when enqueue fails")
 	}
 }
+
+func TestFormatPendingUsecase_RecordsOutcome(t *testing.T) {
+	p, _ := post.New(post.DarkPostID("post-1"), post.DarkContent("test"))
+	repo := testutil.NewStubPostRepository(p)
+	outcomes := &testutil.StubOutcomeRepository{}
+	usecase := NewFormatPendingUsecase(repo, &testutil.StubDrawRepository{}, &testutil.StubFormatter{
+		FormatResult: &llm.FormatResult{DarkPostID: p.ID()},
+		ValidateResult: &llm.FormatResult{
+			DarkPostID:       p.ID(),
+			Status:           drawdomain.StatusVerified,
+			FormattedContent: "整形済み",
+			PromptVersion:    "menhera-v1",
+		},
+	}, testutil.StubJobQueue{}).WithOutcomes(outcomes)
+
+	if err := usecase.Execute(context.Background(), "post-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(outcomes.Recorded) != 1 {
+		t.Fatalf("expected 1 outcome, got %d", len(outcomes.Recorded))
+	}
+	got := outcomes.Recorded[0]
+	if !got.Passed() || got.PromptVersion() != "menhera-v1" || got.Length() != 4 {
+		t.Fatalf("unexpected outcome: %+v", got)
+	}
+}
+
+func TestFormatPendingUsecase_DrawCreateFailedDoesNotRecordOutcome(t *testing.T) {
+	p, _ := post.New(post.DarkPostID("post-1"), post.DarkContent("test"))
+	repo := testutil.NewStubPostRepository(p)
+	drawRepo := &testutil.StubDrawRepository{CreateErr: errors.New("draw create failed")}
+	outcomes := &testutil.StubOutcomeRepository{}
+	usecase := NewFormatPendingUsecase(repo, drawRepo, &testutil.StubFormatter{
+		FormatResult: &llm.FormatResult{DarkPostID: p.ID()},
+		ValidateResult: &llm.FormatResult{
+			DarkPostID:       p.ID(),
+			Status:           drawdomain.StatusVerified,
+			FormattedContent: "整形済み",
+			PromptVersion:    "fortune-v1",
+		},
+	}, &recordingJobQueue{}).WithOutcomes(outcomes)
+
+	if err := usecase.Execute(context.Background(), "post-1"); !errors.Is(err, ErrDrawCreationFailed) {
+		t.Fatalf("expected ErrDrawCreationFailed, got %v", err)
+	}
+	// 積み直した整形の再試行で記録するため、失敗した回は数えない
+	if len(outcomes.Recorded) != 0 {
+		t.Fatalf("outcome should not be recorded before the draw is saved: %+v", outcomes.Recorded)
+	}
+
+	drawRepo.CreateErr = nil
+	if err := usecase.Execute(context.Background(), "post-1"); err != nil {
+		t.Fatalf("retry returned error: %v", err)
+	}
+	if len(outcomes.Recorded) != 1 {
+		t.Fatalf("expected the retry to record exactly 1 outcome, got %d", len(outcomes.Recorded))
+	}
+}
+
+func TestFormatPendingUsecase_RecordsRejectedOutcome(t *testing.T) {
+	p, _ := post.New(post.DarkPostID("post-1"), post.DarkContent("test"))
+	repo := testutil.NewStubPostRepository(p)
+	outcomes := &testutil.StubOutcomeRepository{}
+	usecase := NewFormatPendingUsecase(repo, &testutil.StubDrawRepository{}, &testutil.StubFormatter{
+		FormatResult: &llm.FormatResult{DarkPostID: p.ID()},
+		ValidateResult: &llm.FormatResult{
+			DarkPostID:       p.ID(),
+			Status:           drawdomain.StatusRejected,
+			ValidationReason: "禁止語を含む",
+			PromptVersion:    "fortune-v1",
+		},
+		ValidateErr: llm.ErrContentRejected,
+	}, testutil.StubJobQueue{}).WithOutcomes(outcomes)
+
+	if err := usecase.Execute(context.Background(), "post-1"); !errors.Is(err, ErrContentRejected) {
+		t.Fatalf("expected ErrContentRejected, got %v", err)
+	}
+	if len(outcomes.Recorded) != 1 || outcomes.Recorded[0].Passed() || outcomes.Recorded[0].Reason() != "禁止語を含む" {
+		t.Fatalf("expected rejected outcome to be recorded: %+v", outcomes.Recorded)
+	}
+}
+
+func TestFormatPendingUsecase_OutcomeRecordFailure(t *testing.T) {
+	p, _ := post.New(post.DarkPostID("post-1"), post.DarkContent("test"))
+	repo := testutil.NewStubPostRepository(p)
+	drawRepo := &testutil.StubDrawRepository{}
+	usecase := NewFormatPendingUsecase(repo, drawRepo, &testutil.StubFormatter{
+		FormatResult: &llm.FormatResult{DarkPostID: p.ID()},
+		ValidateResult: &llm.FormatResult{
+			DarkPostID:       p.ID(),
+			Status:           drawdomain.StatusVerified,
+			FormattedContent: "整形済み",
+		},
+	}, testutil.StubJobQueue{}).WithOutcomes(&testutil.StubOutcomeRepository{RecordErr: errors.New("write failed")})
+
+	err := usecase.Execute(context.Background(), "post-1")
+	if !errors.Is(err, ErrOutcomeRecordFailed) {
+		t.Fatalf("expected ErrOutcomeRecordFailed, got %v", err)
+	}
+	// 記録に失敗しても公開準備は進める
+	if len(drawRepo.Created) != 1 || repo.Updated == nil {
+		t.Fatalf("expected draw to be created and post updated despite record failure")
+	}
+}
+
+func TestFormatPendingUsecase_RedactsBeforeFormatting(t *testing.T) {
+	p, _ := post.New(post.DarkPostID("post-1"), post.DarkContent("田中さんに090-1234-5678で呼び出された"))
+	repo := testutil.NewStubPostRepository(p)
+	outcomes := &testutil.StubOutcomeRepository{}
+	formatter := &testutil.StubFormatter{
+		FormatResult: &llm.FormatResult{DarkPostID: p.ID()},
+		ValidateResult: &llm.FormatResult{
+			DarkPostID:       p.ID(),
+			Status:           drawdomain.StatusVerified,
+			FormattedContent: "整形済み",
+		},
+	}
+	redactor := &testutil.StubRedactor{Result: &redaction.Result{
+		Text:   "[人名]に[電話番号]で呼び出された",
+		Counts: map[string]int{redaction.KindName: 1, redaction.KindPhone: 1},
+	}}
+	usecase := NewFormatPendingUsecase(repo, &testutil.StubDrawRepository{}, formatter, testutil.StubJobQueue{}).
+		WithOutcomes(outcomes).
+		WithRedactor(redactor)
+
+	if err := usecase.Execute(context.Background(), "post-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if formatter.LastRequest == nil || formatter.LastRequest.DarkContent != "[人名]に[電話番号]で呼び出された" {
+		t.Fatalf("expected redacted content to be sent, got %+v", formatter.LastRequest)
+	}
+	if len(outcomes.Recorded) != 1 {
+		t.Fatalf("expected 1 outcome, got %d", len(outcomes.Recorded))
+	}
+	got := outcomes.Recorded[0].Redactions()
+	if got[redaction.KindName] != 1 || got[redaction.KindPhone] != 1 {
+		t.Fatalf("unexpected redaction counts: %+v", got)
+	}
+}
+
+func TestFormatPendingUsecase_RedactionFailureStopsFormatting(t *testing.T) {
+	p, _ := post.New(post.DarkPostID("post-1"), post.DarkContent("test"))
+	repo := testutil.NewStubPostRepository(p)
+	formatter := &testutil.StubFormatter{}
+	usecase := NewFormatPendingUsecase(repo, &testutil.StubDrawRepository{}, formatter, testutil.StubJobQueue{}).
+		WithRedactor(&testutil.StubRedactor{Err: redaction.ErrRedactionFailed})
+
+	if err := usecase.Execute(context.Background(), "post-1"); !errors.Is(err, ErrRedactionFailed) {
+		t.Fatalf("expected ErrRedactionFailed, got %v", err)
+	}
+	// 伏せられなかった本文は外部へ送らない
+	if formatter.FormatCalls != 0 {
+		t.Fatalf("expected formatter not to be called, got %d calls", formatter.FormatCalls)
+	}
+	if repo.Updated != nil {
+		t.Fatalf("expected post to stay pending")
+	}
+}
+
+func TestFormatPendingUsecase_NotifiesProgress(t *testing.T) {
+	verified := &llm.FormatResult{Status: drawdomain.StatusVerified, FormattedContent: "formatted"}
+	cases := []struct {
+		name      string
+		formatter *testutil.StubFormatter
+		succeeds  bool
+		want      []notifier.Status
+	}{
+		{
+			name:      "ready",
+			formatter: &testutil.StubFormatter{FormatResult: &llm.FormatResult{}, ValidateResult: verified},
+			succeeds:  true,
+			want:      []notifier.Status{notifier.StatusFormatting, notifier.StatusReady},
+		},
+		{
+			name:      "rejected by validation",
+			formatter: &testutil.StubFormatter{FormatResult: &llm.FormatResult{}, ValidateErr: llm.ErrContentRejected},
+			want:      []notifier.Status{notifier.StatusFormatting, notifier.StatusRejected},
+		},
+		{
+			name:      "not verified",
+			formatter: &testutil.StubFormatter{FormatResult: &llm.FormatResult{}, ValidateResult: &llm.FormatResult{Status: drawdomain.StatusRejected}},
+			want:      []notifier.Status{notifier.StatusFormatting, notifier.StatusRejected},
+		},
+		{
+			name:      "formatter unavailable keeps formatting",
+			formatter: &testutil.StubFormatter{FormatErr: llm.ErrFormatterUnavailable},
+			want:      []notifier.Status{notifier.StatusFormatting},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := post.New(post.DarkPostID("post-1"), post.DarkContent("test"))
+			// 通知の失敗は整形の結果に影響しない
+			n := &testutil.RecordingNotifier{Err: errors.New("notifier down")}
+			usecase := NewFormatPendingUsecase(testutil.NewStubPostRepository(p), &testutil.StubDrawRepository{}, tc.formatter, testutil.StubJobQueue{}).
+				WithNotifier(n)
+
+			err := usecase.Execute(context.Background(), "post-1")
+			if tc.succeeds && err != nil {
+				t.Fatalf("execute returned error: %v", err)
+			}
+			if strings.Join(statusStrings(n.Statuses), ",") != strings.Join(statusStrings(tc.want), ",") {
+				t.Fatalf("notified %v, want %v", n.Statuses, tc.want)
+			}
+		})
+	}
+}
+
+func statusStrings(statuses []notifier.Status) []string {
+	out := make([]string, len(statuses))
+	for i, s := range statuses {
+		out[i] = string(s)
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"errors"
+
+	"backend/internal/domain/post"
+	"backend/internal/port/llm"
+	"backend/internal/port/repository"
+)
+
+/**
+ * 保存せずに整形・検証だけを試した結果
+ * @param PostID 対象の投稿 ID
+ * @param PostStatus 試した時点の投稿の状態
+ * @param Redactions LLM へ渡す前に伏せた個人情報の種類ごとの件数
+ * @param Formatted LLM が整形した結果（検証前）
+ * @param Validated 検証後の結果（検証が結果を返さなかった場合は nil）
+ * @param ValidationErr 検証が返したエラー（却下・形式不正など。通過なら nil）
+ */
+type Preview struct {
+	PostID        post.DarkPostID
+	PostStatus    post.Status
+	Redactions    map[string]int
+	Formatted     *llm.FormatResult
+	Validated     *llm.FormatResult
+	ValidationErr error
+}
+
+// Preview は投稿を Execute と同じ手順で整形・検証し、おみくじや投稿の状態を変えずに結果だけを返す。
+// 運用時の確認用のため、投稿の状態は問わない。整形結果の記録や進み具合の通知もしない。
+func (u *FormatPendingUsecase) Preview(ctx context.Context, postID string) (*Preview, error) {
+	if u == nil {
+		return nil, ErrNilUsecase
+	}
+	if ctx == nil {
+		return nil, ErrNilContext
+	}
+	if postID == "" {
+		return nil, ErrEmptyPostID
+	}
+
+	p, err := u.postRepo.Get(ctx, post.DarkPostID(postID))
+	if err != nil {
+		if errors.Is(err, repository.ErrPostNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+
+	content, redactions, err := u.redact(ctx, p.Content())
+	if err != nil {
+		return nil, err
+	}
+	formatted, err := u.llm.Format(ctx, &llm.FormatRequest{
+		DarkPostID:  p.ID(),
+		DarkContent: content,
+	})
+	if err != nil {
+		if errors.Is(err, llm.ErrFormatterUnavailable) {
+			return nil, ErrFormatterUnavailable
+		}
+		return nil, err
+	}
+	// 検証で却下されても結果として返し、呼び出し側で理由を確認できるようにする
+	validated, validationErr := u.llm.Validate(ctx, formatted)
+	if validated != nil {
+		normalized := *validated
+		normalized.FormattedContent = normalizeDrawContent(validated.FormattedContent)
+		validated = &normalized
+	}
+	return &Preview{
+		PostID:        p.ID(),
+		PostStatus:    p.Status(),
+		Redactions:    redactions,
+		Formatted:     formatted,
+		Validated:     validated,
+		ValidationErr: validationErr,
+	}, nil
+}
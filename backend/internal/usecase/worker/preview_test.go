@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/post"
+	"backend/internal/port/llm"
+	"backend/internal/port/redaction"
+	"backend/internal/usecase/worker/testutil"
+)
+
+func TestFormatPendingUsecase_PreviewDoesNotPersist(t *testing.T) {
+	p, err := post.Restore(post.DarkPostID("post-1"), post.DarkContent("電話は 090-0000-0000"), post.StatusReady)
+	if err != nil {
+		t.Fatalf("failed to restore post: %v", err)
+	}
+	repo := testutil.NewStubPostRepository(p)
+	drawRepo := &testutil.StubDrawRepository{}
+	outcomes := &testutil.StubOutcomeRepository{}
+	formatter := &testutil.StubFormatter{
+		FormatResult: &llm.FormatResult{DarkPostID: p.ID(), Status: drawdomain.StatusPending, FormattedContent: "  formatted  "},
+		ValidateResult: &llm.FormatResult{
+			DarkPostID:       p.ID(),
+			Status:           drawdomain.StatusVerified,
+			FormattedContent: "  formatted  ",
+			PromptVersion:    "fortune-v1",
+		},
+	}
+	redactor := &testutil.StubRedactor{Result: &redaction.Result{Text: "電話は [電話番号]", Counts: map[string]int{"phone": 1}}}
+	usecase := NewFormatPendingUsecase(repo, drawRepo, formatter, testutil.StubJobQueue{}).
+		WithOutcomes(outcomes).
+		WithRedactor(redactor)
+
+	preview, err := usecase.Preview(context.Background(), "post-1")
+	if err != nil {
+		t.Fatalf("preview returned error: %v", err)
+	}
+	if preview.PostStatus != post.StatusReady || preview.Redactions["phone"] != 1 {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+	if formatter.LastRequest.DarkContent != "電話は [電話番号]" {
+		t.Fatalf("expected redacted content to be sent, got %q", formatter.LastRequest.DarkContent)
+	}
+	if preview.Validated.FormattedContent != "formatted" || preview.ValidationErr != nil {
+		t.Fatalf("unexpected validated result: %+v %v", preview.Validated, preview.ValidationErr)
+	}
+	if len(drawRepo.Created) != 0 || repo.Updated != nil || len(outcomes.Recorded) != 0 {
+		t.Fatalf("preview must not persist anything")
+	}
+}
+
+func TestFormatPendingUsecase_PreviewReturnsRejection(t *testing.T) {
+	p, _ := post.New(post.DarkPostID("post-1"), post.DarkContent("test"))
+	formatter := &testutil.StubFormatter{
+		FormatResult: &llm.FormatResult{DarkPostID: p.ID(), Status: drawdomain.StatusPending, FormattedContent: "formatted"},
+		ValidateResult: &llm.FormatResult{
+			DarkPostID:       p.ID(),
+			Status:           drawdomain.StatusRejected,
+			FormattedContent: "formatted",
+			ValidationReason: "禁止語を含む",
+		},
+		ValidateErr: llm.ErrContentRejected,
+	}
+	usecase := NewFormatPendingUsecase(testutil.NewStubPostRepository(p), &testutil.StubDrawRepository{}, formatter, testutil.StubJobQueue{})
+
+	preview, err := usecase.Preview(context.Background(), "post-1")
+	if err != nil {
+		t.Fatalf("preview returned error: %v", err)
+	}
+	if !errors.Is(preview.ValidationErr, llm.ErrContentRejected) || preview.Validated.ValidationReason != "禁止語を含む" {
+		t.Fatalf("expected rejection to be reported, got %+v", preview)
+	}
+}
+
+func TestFormatPendingUsecase_PreviewErrors(t *testing.T) {
+	usecase := NewFormatPendingUsecase(testutil.NewStubPostRepository(nil), &testutil.StubDrawRepository{}, &testutil.StubFormatter{}, testutil.StubJobQueue{})
+	if _, err := usecase.Preview(context.Background(), "unknown"); !errors.Is(err, ErrPostNotFound) {
+		t.Fatalf("expected ErrPostNotFound, got %v", err)
+	}
+
+	p, _ := post.New(post.DarkPostID("post-1"), post.DarkContent("test"))
+	formatter := &testutil.StubFormatter{FormatErr: llm.ErrFormatterUnavailable}
+	usecase = NewFormatPendingUsecase(testutil.NewStubPostRepository(p), &testutil.StubDrawRepository{}, formatter, testutil.StubJobQueue{})
+	if _, err := usecase.Preview(context.Background(), "post-1"); !errors.Is(err, ErrFormatterUnavailable) {
+		t.Fatalf("expected ErrFormatterUnavailable, got %v", err)
+	}
+}
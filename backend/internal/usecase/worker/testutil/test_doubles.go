@@ -4,9 +4,12 @@ import (
 	"context"
 
 	drawdomain "backend/internal/domain/draw"
+	"backend/internal/domain/outcome"
 	"backend/internal/domain/post"
 	"backend/internal/port/llm"
+	"backend/internal/port/notifier"
 	"backend/internal/port/queue"
+	"backend/internal/port/redaction"
 	"backend/internal/port/repository"
 )
 
@@ -68,6 +71,24 @@ func (r *StubPostRepository) Update(ctx context.Context, p *post.Post) error {
 	return nil
 }
 
+/**
+ * ListByStatus は使用しないため nil を返す。
+ */
+func (r *StubPostRepository) ListByStatus(ctx context.Context, status post.Status, after post.DarkPostID, limit int) ([]*post.Post, error) {
+	return nil, nil
+}
+
+/**
+ * 保持している投稿を取り除く。
+ */
+func (r *StubPostRepository) Delete(ctx context.Context, id post.DarkPostID) error {
+	if _, ok := r.Store[id]; !ok {
+		return repository.ErrPostNotFound
+	}
+	delete(r.Store, id)
+	return nil
+}
+
 var _ repository.PostRepository = (*StubPostRepository)(nil)
 
 // DrawRepository を埋めるだけの簡易モック。
@@ -94,6 +115,13 @@ func (StubDrawRepository) GetByPostID(ctx context.Context, postID post.DarkPostI
 	return nil, repository.ErrDrawNotFound
 }
 
+/**
+ * GetByShareID は既定で見つからない扱いにする。
+ */
+func (StubDrawRepository) GetByShareID(ctx context.Context, id drawdomain.ShareID) (*drawdomain.Draw, error) {
+	return nil, repository.ErrDrawNotFound
+}
+
 /**
  * ListReady は空を返す。
  */
@@ -101,6 +129,20 @@ func (StubDrawRepository) ListReady(ctx context.Context) ([]*drawdomain.Draw, er
 	return nil, nil
 }
 
+/**
+ * Update は何もせず成功する。
+ */
+func (StubDrawRepository) Update(ctx context.Context, d *drawdomain.Draw) error {
+	return nil
+}
+
+/**
+ * Delete は既定で見つからない扱いにする。
+ */
+func (StubDrawRepository) Delete(ctx context.Context, postID post.DarkPostID) error {
+	return repository.ErrDrawNotFound
+}
+
 var _ repository.DrawRepository = (*StubDrawRepository)(nil)
 
 // 整形と検証の結果を切り替えられるテスト用スタブ。
@@ -111,6 +153,7 @@ type StubFormatter struct {
 	ValidateErr    error
 	FormatCalls    int
 	ValidateCalls  int
+	LastRequest    *llm.FormatRequest
 }
 
 /**
@@ -118,6 +161,7 @@ type StubFormatter struct {
  */
 func (f *StubFormatter) Format(ctx context.Context, req *llm.FormatRequest) (*llm.FormatResult, error) {
 	f.FormatCalls++
+	f.LastRequest = req
 	if f.FormatErr != nil {
 		return nil, f.FormatErr
 	}
@@ -125,14 +169,12 @@ func (f *StubFormatter) Format(ctx context.Context, req *llm.FormatRequest) (*ll
 }
 
 /**
- * 設定された結果かエラーを返す。
+ * 設定された結果とエラーをそのまま返す。
  */
 func (f *StubFormatter) Validate(ctx context.Context, result *llm.FormatResult) (*llm.FormatResult, error) {
 	f.ValidateCalls++
-	if f.ValidateErr != nil {
-		return nil, f.ValidateErr
-	}
-	return f.ValidateResult, nil
+	// 実装と同じく、却下時も結果とエラーを両方返せるようにする
+	return f.ValidateResult, f.ValidateErr
 }
 
 var _ llm.Formatter = (*StubFormatter)(nil)
@@ -150,8 +192,8 @@ func (StubJobQueue) EnqueueFormat(ctx context.Context, id post.DarkPostID) error
 /**
  * 閉鎖エラーを返す。
  */
-func (StubJobQueue) DequeueFormat(ctx context.Context) (post.DarkPostID, error) {
-	return "", queue.ErrQueueClosed
+func (StubJobQueue) DequeueFormat(ctx context.Context) (*queue.FormatJob, error) {
+	return nil, queue.ErrQueueClosed
 }
 
 /**
@@ -162,3 +204,95 @@ func (StubJobQueue) Close() error {
 }
 
 var _ queue.JobQueue = (*StubJobQueue)(nil)
+
+// 整形結果の記録を覚えておくスタブ。
+type StubOutcomeRepository struct {
+	Recorded  []*outcome.Outcome
+	RecordErr error
+}
+
+/**
+ * 記録内容を覚えて、必要ならエラーを返す。
+ */
+func (s *StubOutcomeRepository) Record(ctx context.Context, o *outcome.Outcome) error {
+	if s.RecordErr != nil {
+		return s.RecordErr
+	}
+	s.Recorded = append(s.Recorded, o)
+	return nil
+}
+
+/**
+ * 記録済みの結果を返す。
+ */
+func (s *StubOutcomeRepository) List(ctx context.Context) ([]*outcome.Outcome, error) {
+	return s.Recorded, nil
+}
+
+/**
+ * 指定投稿の記録済み結果を返す。
+ */
+func (s *StubOutcomeRepository) ListByPostID(ctx context.Context, postID post.DarkPostID) ([]*outcome.Outcome, error) {
+	var result []*outcome.Outcome
+	for _, o := range s.Recorded {
+		if o.PostID() == postID {
+			result = append(result, o)
+		}
+	}
+	return result, nil
+}
+
+/**
+ * 指定投稿の記録済み結果を消す。
+ */
+func (s *StubOutcomeRepository) DeleteByPostID(ctx context.Context, postID post.DarkPostID) error {
+	kept := s.Recorded[:0]
+	for _, o := range s.Recorded {
+		if o.PostID() != postID {
+			kept = append(kept, o)
+		}
+	}
+	s.Recorded = kept
+	return nil
+}
+
+var _ repository.OutcomeRepository = (*StubOutcomeRepository)(nil)
+
+// 伏せ字処理の結果を切り替えられるスタブ。Result が nil なら本文をそのまま返す。
+type StubRedactor struct {
+	Result *redaction.Result
+	Err    error
+	Calls  int
+}
+
+/**
+ * 設定された結果かエラーを返す。
+ */
+func (s *StubRedactor) Redact(ctx context.Context, text string) (*redaction.Result, error) {
+	s.Calls++
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if s.Result != nil {
+		return s.Result, nil
+	}
+	return &redaction.Result{Text: text}, nil
+}
+
+var _ redaction.Redactor = (*StubRedactor)(nil)
+
+// 通知された状態を順に覚えておくスタブ。
+type RecordingNotifier struct {
+	Statuses []notifier.Status
+	Err      error
+}
+
+/**
+ * 状態を覚えて、必要ならエラーを返す。
+ */
+func (n *RecordingNotifier) Publish(ctx context.Context, event notifier.Event) error {
+	n.Statuses = append(n.Statuses, event.Status)
+	return n.Err
+}
+
+var _ notifier.Publisher = (*RecordingNotifier)(nil)
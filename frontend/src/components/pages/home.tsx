@@ -14,8 +14,9 @@ import KirakujiTransitionOverlay, {
 } from "@/components/ui/kirakuji-transition-overlay";
 import { fetchRandomDraw } from "@/lib/draws";
 import { createPost } from "@/lib/posts";
+import type { CreatePostResponse, SupportResponse } from "@/types/api";
 
-type Step = "input" | "loading" | "ready" | "error";
+type Step = "input" | "loading" | "ready" | "support" | "error";
 
 /** 表示文を指定の長さで2行に分割する。 */
 const splitMessage = (message: string, maxChars: number) => {
@@ -58,6 +59,7 @@ export default function HomePage() {
   );
   const [content, setContent] = useState("");
   const [errorMessage, setErrorMessage] = useState("");
+  const [support, setSupport] = useState<SupportResponse | null>(null);
   const [isTransitioning, setIsTransitioning] = useState(false);
   const transitionStartedAtRef = useRef<number | null>(null);
   const transitionPromiseRef = useRef<Promise<void> | null>(null);
@@ -133,6 +135,7 @@ export default function HomePage() {
     }
     setLoadingOrigin(null);
     setErrorMessage("");
+    setSupport(null);
     setCurrentStep("input");
   }, []);
 
@@ -143,8 +146,9 @@ export default function HomePage() {
     setLoadingOrigin("input");
     setCurrentStep("loading");
     setErrorMessage("");
+    let response: CreatePostResponse;
     try {
-      await createPost(content.trim());
+      response = await createPost(content.trim());
     } catch (error) {
       setErrorMessage(
         error instanceof Error ? error.message : defaultPostError,
@@ -155,6 +159,12 @@ export default function HomePage() {
     }
 
     setLoadingOrigin(null);
+    // 危機的な兆候がある投稿はおみくじにせず、相談窓口を案内する
+    if (response.support) {
+      setSupport(response.support);
+      setCurrentStep("support");
+      return;
+    }
     setCurrentStep("ready");
   };
 
@@ -394,6 +404,52 @@ export default function HomePage() {
               </section>
             )}
 
+            {currentStep === "support" && support && (
+              <section className="flex flex-col gap-4 text-left">
+                <p className="font-medium text-base text-zinc-900">
+                  {support.message}
+                </p>
+                <ul className="flex flex-col gap-2">
+                  {support.resources.map((resource) => (
+                    <li
+                      key={resource.name}
+                      className="rounded-md bg-white px-4 py-3 text-sm text-zinc-900"
+                    >
+                      <p className="font-semibold">{resource.name}</p>
+                      {resource.contact && (
+                        <a
+                          className="underline"
+                          href={`tel:${resource.contact.replaceAll("-", "")}`}
+                        >
+                          {resource.contact}
+                        </a>
+                      )}
+                      {resource.url && (
+                        <a
+                          className="break-all underline"
+                          href={resource.url}
+                          target="_blank"
+                          rel="noopener noreferrer"
+                        >
+                          {resource.url}
+                        </a>
+                      )}
+                    </li>
+                  ))}
+                </ul>
+                <button
+                  className="mx-auto rounded-md bg-white px-6 py-2 font-semibold text-sm text-zinc-900"
+                  type="button"
+                  onClick={() => {
+                    setIsModalOpen(false);
+                    handleRetry({ clearContent: true });
+                  }}
+                >
+                  閉じる
+                </button>
+              </section>
+            )}
+
             {currentStep === "error" && (
               <section className="flex flex-col gap-4 text-center">
                 <p className="font-medium text-base text-red-600">
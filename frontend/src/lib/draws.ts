@@ -1,4 +1,11 @@
-import type { DrawResponse } from "@/types/api";
+import type {
+  CreateReactionRequest,
+  CreateReactionResponse,
+  CreateReportRequest,
+  DrawResponse,
+  ReactionKind,
+  ReportReason,
+} from "@/types/api";
 import { getApiErrorMessageFromResponse } from "@/utils/api";
 import { normalizeApiBaseUrl } from "./api";
 
@@ -6,7 +13,7 @@ import { normalizeApiBaseUrl } from "./api";
  * 検証済みのおみくじをランダムに取得する。
  */
 export const fetchRandomDraw = async (): Promise<DrawResponse> => {
-  const response = await fetch(`${normalizeApiBaseUrl()}/draws/random`);
+  const response = await fetch(`${normalizeApiBaseUrl()}/v1/draws/random`);
 
   if (!response.ok) {
     const errorMessage = await getApiErrorMessageFromResponse(
@@ -18,3 +25,65 @@ export const fetchRandomDraw = async (): Promise<DrawResponse> => {
 
   return (await response.json()) as DrawResponse;
 };
+
+/**
+ * おみくじへ反応を付け、付けた後の反応数を返す。訪問者ごとに 1 回までで、2 回目はエラーになる。
+ */
+export const reactToDraw = async (
+  shareId: string,
+  kind: ReactionKind,
+  visitorToken: string,
+): Promise<CreateReactionResponse> => {
+  const payload: CreateReactionRequest = { kind };
+  const response = await fetch(
+    `${normalizeApiBaseUrl()}/v1/draws/${encodeURIComponent(shareId)}/reactions`,
+    {
+      method: "POST",
+      headers: {
+        "Content-Type": "application/json",
+        "X-Visitor-Token": visitorToken,
+      },
+      body: JSON.stringify(payload),
+    },
+  );
+
+  if (!response.ok) {
+    const errorMessage = await getApiErrorMessageFromResponse(
+      response,
+      "反応を送れませんでした",
+    );
+    throw new Error(errorMessage);
+  }
+
+  return (await response.json()) as CreateReactionResponse;
+};
+
+/**
+ * おみくじを通報する。同じ訪問者の 2 回目も成功として扱われる。
+ */
+export const reportDraw = async (
+  shareId: string,
+  reason: ReportReason,
+  visitorToken: string,
+): Promise<void> => {
+  const payload: CreateReportRequest = { reason };
+  const response = await fetch(
+    `${normalizeApiBaseUrl()}/v1/draws/${encodeURIComponent(shareId)}/reports`,
+    {
+      method: "POST",
+      headers: {
+        "Content-Type": "application/json",
+        "X-Visitor-Token": visitorToken,
+      },
+      body: JSON.stringify(payload),
+    },
+  );
+
+  if (!response.ok) {
+    const errorMessage = await getApiErrorMessageFromResponse(
+      response,
+      "通報を送れませんでした",
+    );
+    throw new Error(errorMessage);
+  }
+};
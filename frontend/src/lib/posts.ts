@@ -1,4 +1,8 @@
-import type { CreatePostRequest, CreatePostResponse } from "@/types/api";
+import type {
+  CreatePostRequest,
+  CreatePostResponse,
+  PostEvent,
+} from "@/types/api";
 import { getApiErrorMessage } from "@/utils/api";
 import { normalizeApiBaseUrl } from "./api";
 
@@ -15,7 +19,7 @@ export const createPost = async (content: string) => {
   const controller = new AbortController();
   const timeoutId = window.setTimeout(() => controller.abort(), 10_000);
 
-  const response = await fetch(`${normalizeApiBaseUrl()}/posts`, {
+  const response = await fetch(`${normalizeApiBaseUrl()}/v1/posts`, {
     method: "POST",
     headers: {
       "Content-Type": "application/json",
@@ -36,3 +40,34 @@ export const createPost = async (content: string) => {
 
   return (await response.json()) as CreatePostResponse;
 };
+
+/**
+ * 投稿がおみくじになるまでの進み具合を購読する。
+ * ready / rejected を受け取るか、サーバーが timeout で閉じたら購読をやめる。戻り値で途中でも止められる。
+ */
+export const subscribePostEvents = (
+  postId: string,
+  onEvent: (event: PostEvent) => void,
+  onEnd?: () => void,
+) => {
+  const source = new EventSource(
+    `${normalizeApiBaseUrl()}/v1/posts/${encodeURIComponent(postId)}/events`,
+  );
+  const close = () => {
+    source.close();
+    onEnd?.();
+  };
+
+  source.addEventListener("status", (message) => {
+    const event = JSON.parse((message as MessageEvent<string>).data) as PostEvent;
+    onEvent(event);
+    if (event.status === "ready" || event.status === "rejected") {
+      close();
+    }
+  });
+  source.addEventListener("timeout", close);
+  // 404 などで開けなかった場合も自動で接続し直さずに止める
+  source.onerror = close;
+
+  return () => source.close();
+};
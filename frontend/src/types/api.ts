@@ -1,5 +1,8 @@
 export type ApiErrorResponse = {
+  code?: string;
   message?: string;
+  details?: Record<string, unknown>;
+  request_id?: string;
 };
 
 export type CreatePostRequest = {
@@ -7,12 +10,57 @@ export type CreatePostRequest = {
   content: string;
 };
 
+export type SupportResource = {
+  name: string;
+  contact?: string;
+  url?: string;
+};
+
+export type SupportResponse = {
+  message: string;
+  resources: SupportResource[];
+};
+
 export type CreatePostResponse = {
   post_id: string;
+  status?: "flagged";
+  support?: SupportResponse;
 };
 
+export type ReactionKind = "accurate" | "saved" | "scary";
+
+export type ReactionCounts = Record<ReactionKind, number>;
+
 export type DrawResponse = {
-  post_id: string;
+  share_id?: string;
   result: string;
   status: string;
+  reactions?: ReactionCounts;
+};
+
+export type CreateReactionRequest = {
+  kind: ReactionKind;
+};
+
+export type CreateReactionResponse = {
+  reactions: ReactionCounts;
+};
+
+export type ReportReason =
+  | "offensive"
+  | "personal_info"
+  | "self_harm"
+  | "spam"
+  | "other";
+
+export type CreateReportRequest = {
+  reason: ReportReason;
+};
+
+export type PostProgressStatus = "queued" | "formatting" | "ready" | "rejected";
+
+export type PostEvent = {
+  post_id: string;
+  status: PostProgressStatus;
+  at: string;
 };